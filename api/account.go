@@ -2,6 +2,7 @@ package api
 
 import (
 	"database/sql"
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -9,76 +10,59 @@ import (
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
-	"github.com/lib/pq"
 )
 
-type createAccountErrorResponse struct {
-	Error string `json:"error"`
-}
+var (
+	errAccountNotOwned = errors.New("account doesn't belong to the authenticated user")
+	errSameAccount     = errors.New("cannot sweep an account to itself")
+)
 
 type createAccountSuccessResponse struct {
 	Data db.Account `json:"data"`
 }
 
 type createAccountRequest struct {
-	OwnerID  uuid.UUID `json:"owner_id" binding:"required"`
-	Currency string    `json:"currency" binding:"required,oneof=USD EUR IDR"`
-	Balance  int64     `json:"balance" binding:"requied"`
+	OwnerID     uuid.UUID `json:"owner_id" binding:"required"`
+	Currency    string    `json:"currency" binding:"required,oneof=USD EUR IDR"`
+	AccountType string    `json:"account_type" binding:"omitempty,oneof=current savings business"`
 }
 
 func (r createAccountRequest) Validate() error {
 	return validation.ValidateStruct(&r,
 		validation.Field(&r.OwnerID, validation.Required),
-		validation.Field(&r.Balance, validation.Required),
 		validation.Field(&r.Currency, validation.Required, validation.In("USD", "EUR", "IDR")),
+		validation.Field(&r.AccountType, validation.In(db.AccountTypeCurrent, db.AccountTypeSavings, db.AccountTypeBusiness)),
 	)
 }
 
 func (s *Server) CreateAccount(c echo.Context) error {
 	req := new(createAccountRequest)
 	if err := c.Bind(req); err != nil {
-		return c.JSON(
-			http.StatusBadRequest,
-			&createAccountErrorResponse{
-				Error: err.Error(),
-			},
-		)
+		return err
 	}
 
 	if err := req.Validate(); err != nil {
-		return c.JSON(
-			http.StatusBadRequest,
-			&createAccountErrorResponse{
-				Error: err.Error(),
-			},
-		)
+		return err
+	}
+
+	payload := authPayload(c)
+	if !db.CanOpenAccountFor(payload.UserID, payload.Role, req.OwnerID) {
+		return errAccountNotOwned
+	}
+
+	if req.AccountType == "" {
+		req.AccountType = db.AccountTypeCurrent
 	}
 
 	arg := db.CreateAccountParams{
-		OwnerID:  req.OwnerID,
-		Currency: req.Currency,
-		Balance:  req.Balance,
+		OwnerID:     req.OwnerID,
+		Currency:    req.Currency,
+		AccountType: req.AccountType,
 	}
 
 	account, err := s.store.CreateAccount(c.Request().Context(), arg)
 	if err != nil {
-		if pgErr, ok := err.(*pq.Error); ok {
-			switch pgErr.Code.Name() {
-			case "foreign_key_violation", "unique_violation":
-				return c.JSON(
-					http.StatusForbidden,
-					&createAccountErrorResponse{
-						Error: err.Error(),
-					},
-				)
-			}
-		}
-		return c.JSON(
-			http.StatusInternalServerError,
-			&createAccountErrorResponse{
-				Error: err.Error(),
-			},
-		)
+		return err
 	}
 
 	return c.JSON(
@@ -89,10 +73,6 @@ func (s *Server) CreateAccount(c echo.Context) error {
 	)
 }
 
-type getAccountErrorResponse struct {
-	Error string `json:"error"`
-}
-
 type getAccountSuccessResponse struct {
 	Data db.Account `json:"data"`
 }
@@ -100,40 +80,21 @@ type getAccountSuccessResponse struct {
 func (s *Server) GetAccount(c echo.Context) error {
 	paramId := c.Param("id")
 	id, err := strconv.ParseInt(paramId, 10, 64)
-	if err != nil {
-		return c.JSON(
-			http.StatusBadRequest,
-			&getAccountErrorResponse{
-				Error: err.Error(),
-			},
-		)
+	if err != nil || id == 0 {
+		return invalidParam("id", err)
 	}
 
-	if id == 0 {
-		return c.JSON(
-			http.StatusBadRequest,
-			&getAccountErrorResponse{
-				Error: "ID cannot be 0",
-			},
-		)
+	account, err := s.store.GetAccount(c.Request().Context(), id)
+	if err != nil {
+		return notFound(CodeAccountNotFound, err)
 	}
 
-	account, err := s.store.GetAccount(c.Request().Context(), id)
+	access, err := s.accountAccess(c, account)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return c.JSON(
-				http.StatusNotFound,
-				&getAccountErrorResponse{
-					Error: err.Error(),
-				},
-			)
-		}
-		return c.JSON(
-			http.StatusInternalServerError,
-			&getAccountErrorResponse{
-				Error: err.Error(),
-			},
-		)
+		return err
+	}
+	if !access.Can(db.PermView) {
+		return errAccountNotOwned
 	}
 
 	return c.JSON(
@@ -144,18 +105,14 @@ func (s *Server) GetAccount(c echo.Context) error {
 	)
 }
 
-type fetchAccountErrorResponse struct {
-	Error string `json:"error"`
-}
-
 type fetchAccountSuccessResponse struct {
 	Data []db.Account `json:"data"`
 	Meta Meta         `json:"meta"`
 }
 
 type fetchAccountRequest struct {
-	PageID int32 `form:"page" binding:"required"`
-	Limit  int32 `form:"limit" binding:"required"`
+	PageID int32 `query:"page" form:"page" binding:"required"`
+	Limit  int32 `query:"limit" form:"limit" binding:"required"`
 }
 
 func (r fetchAccountRequest) Validate() error {
@@ -168,53 +125,26 @@ func (r fetchAccountRequest) Validate() error {
 func (s *Server) FetchAccount(c echo.Context) error {
 	req := new(fetchAccountRequest)
 	if err := c.Bind(req); err != nil {
-		return c.JSON(
-			http.StatusBadRequest,
-			&fetchAccountErrorResponse{
-				Error: err.Error(),
-			},
-		)
+		return err
 	}
 
 	if err := req.Validate(); err != nil {
-		return c.JSON(
-			http.StatusBadRequest,
-			&fetchAccountErrorResponse{
-				Error: err.Error(),
-			},
-		)
+		return err
 	}
 
-	arg := db.FetchAccountsParams{
-		Limit:  req.Limit,
-		Offset: (req.PageID - 1) * req.Limit,
+	arg := db.FetchAccountsByOwnerParams{
+		OwnerID: authPayload(c).UserID,
+		Limit:   req.Limit,
+		Offset:  (req.PageID - 1) * req.Limit,
 	}
 
-	account, err := s.store.FetchAccounts(c.Request().Context(), arg)
+	account, err := s.store.FetchAccountsByOwner(c.Request().Context(), arg)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return c.JSON(
-				http.StatusNotFound,
-				&fetchAccountErrorResponse{
-					Error: err.Error(),
-				},
-			)
-		}
-		return c.JSON(
-			http.StatusInternalServerError,
-			&fetchAccountErrorResponse{
-				Error: err.Error(),
-			},
-		)
+		return notFound(CodeAccountNotFound, err)
 	}
 
 	if len(account) == 0 {
-		return c.JSON(
-			http.StatusNotFound,
-			&fetchAccountErrorResponse{
-				Error: "record for accounts not found",
-			},
-		)
+		return notFound(CodeAccountNotFound, sql.ErrNoRows)
 	}
 
 	return c.JSON(
@@ -228,3 +158,79 @@ func (s *Server) FetchAccount(c echo.Context) error {
 		},
 	)
 }
+
+type closeAccountSuccessResponse struct {
+	Data db.CloseAccountTxResult `json:"data"`
+}
+
+type closeAccountRequest struct {
+	SweepToAccountID int64 `json:"sweep_to_account_id" binding:"min=0"`
+}
+
+func (r closeAccountRequest) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.SweepToAccountID, validation.Min(0)),
+	)
+}
+
+// CloseAccount closes an account, sweeping what is left on it to another
+// account of the same owner and currency. The account is kept for history.
+func (s *Server) CloseAccount(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return invalidParam("id", err)
+	}
+
+	req := new(closeAccountRequest)
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	if req.SweepToAccountID == id {
+		return errSameAccount
+	}
+
+	account, err := s.store.GetAccount(c.Request().Context(), id)
+	if err != nil {
+		return notFound(CodeAccountNotFound, err)
+	}
+
+	access, err := s.accountAccess(c, account)
+	if err != nil {
+		return err
+	}
+	if !access.Can(db.PermManage) {
+		return errAccountNotOwned
+	}
+
+	if req.SweepToAccountID != 0 {
+		sweepTo, err := s.validAccount(c, req.SweepToAccountID, account.Currency)
+		if err != nil {
+			return err
+		}
+		// the sweep is neither limited nor charged, so it may only move
+		// money between accounts of the same owner
+		if sweepTo.OwnerID != account.OwnerID {
+			return errAccountNotOwned
+		}
+	}
+
+	result, err := s.store.CloseAccountTx(c.Request().Context(), db.CloseAccountTxParams{
+		AccountID:        id,
+		SweepToAccountID: req.SweepToAccountID,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(
+		http.StatusOK,
+		&closeAccountSuccessResponse{
+			Data: result,
+		},
+	)
+}
@@ -0,0 +1,126 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	db "github.com/flukis/simplebank/db/sqlc"
+	"github.com/labstack/echo/v4"
+)
+
+// AccountNumbers lets clients name accounts by their account number wherever
+// an account id is accepted: in the route params listed and in the top-level
+// JSON fields ending in account_id. Numbers have their check digits verified
+// before they are looked up, and are replaced with the id of their account.
+// It must be registered after AuthMiddleware.
+func (s *Server) AccountNumbers(params ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			values := c.ParamValues()
+			for i, name := range c.ParamNames() {
+				if i >= len(values) || !containsString(params, name) || isAccountID(values[i]) {
+					continue
+				}
+
+				id, err := s.accountIDByNumber(c, values[i])
+				if err != nil {
+					return err
+				}
+				values[i] = strconv.FormatInt(id, 10)
+			}
+			c.SetParamValues(values...)
+
+			if err := s.replaceAccountNumbers(c); err != nil {
+				return err
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// replaceAccountNumbers rewrites the account numbers in a JSON request body
+func (s *Server) replaceAccountNumbers(c echo.Context) error {
+	req := c.Request()
+	if req.Body == nil || !strings.HasPrefix(req.Header.Get(echo.HeaderContentType), echo.MIMEApplicationJSON) {
+		return nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return newError(http.StatusBadRequest, CodeBadRequest, err)
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	// anything but an object is left for the handler to reject
+	var fields map[string]json.RawMessage
+	if json.Unmarshal(body, &fields) != nil {
+		return nil
+	}
+
+	// in a fixed order, so a bad number is always reported before looking
+	// up the others
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	replaced := false
+	for _, key := range keys {
+		raw := fields[key]
+		if !strings.HasSuffix(key, "account_id") || len(raw) == 0 || raw[0] != '"' {
+			continue
+		}
+		var number string
+		if json.Unmarshal(raw, &number) != nil {
+			continue
+		}
+
+		id, err := s.accountIDByNumber(c, number)
+		if err != nil {
+			return err
+		}
+		fields[key] = json.RawMessage(strconv.FormatInt(id, 10))
+		replaced = true
+	}
+	if !replaced {
+		return nil
+	}
+
+	body, err = json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.ContentLength = int64(len(body))
+	return nil
+}
+
+// accountIDByNumber returns the id of the account with number
+func (s *Server) accountIDByNumber(c echo.Context, number string) (int64, error) {
+	account, err := db.GetAccountByCheckedNumber(c.Request().Context(), s.store, number)
+	if err != nil {
+		return 0, notFound(CodeAccountNotFound, err)
+	}
+	return account.ID, nil
+}
+
+func isAccountID(value string) bool {
+	_, err := strconv.ParseInt(value, 10, 64)
+	return err == nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
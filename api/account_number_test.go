@@ -0,0 +1,189 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mocks "github.com/flukis/simplebank/db/mock"
+	db "github.com/flukis/simplebank/db/sqlc"
+	"github.com/flukis/simplebank/util"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountNumbersMiddleware(t *testing.T) {
+	from := randomAccount()
+	from.Number = randomAccountNumber(t)
+	to := randomAccount()
+	to.Currency = from.Currency
+	to.Number = randomAccountNumber(t)
+	transfer := generateTransferResult(from, to, 100)
+
+	// a single digit off, so the check digits no longer match
+	mistyped := from.Number[:len(from.Number)-1] + string('0'+(from.Number[len(from.Number)-1]-'0'+1)%10)
+
+	testCases := []struct {
+		name   string
+		method string
+		url    string
+		body   string
+		build  func(store *mocks.Store)
+		check  func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "GetAccountByNumber",
+			method: http.MethodGet,
+			url:    "/account/" + from.Number,
+			build: func(store *mocks.Store) {
+				store.On("GetAccountByNumber", mock.Anything, from.Number).
+					Return(from, nil).
+					Once()
+				store.On("GetAccount", mock.Anything, from.ID).
+					Return(from, nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+			},
+		},
+		{
+			name:   "GetAccountByPrintedNumber",
+			method: http.MethodGet,
+			url:    "/account/" + from.Number[:4] + "%20" + from.Number[4:8] + "%20" + from.Number[8:],
+			build: func(store *mocks.Store) {
+				store.On("GetAccountByNumber", mock.Anything, from.Number).
+					Return(from, nil).
+					Once()
+				store.On("GetAccount", mock.Anything, from.ID).
+					Return(from, nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+			},
+		},
+		{
+			name:   "GetAccountMistypedNumber",
+			method: http.MethodGet,
+			url:    "/account/" + mistyped,
+			build:  func(store *mocks.Store) {},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			name:   "GetAccountUnknownNumber",
+			method: http.MethodGet,
+			url:    "/account/" + from.Number,
+			build: func(store *mocks.Store) {
+				store.On("GetAccountByNumber", mock.Anything, from.Number).
+					Return(db.Account{}, sql.ErrNoRows).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, rec.Code)
+			},
+		},
+		{
+			name:   "TransferByNumbers",
+			method: http.MethodPost,
+			url:    "/account/transfer",
+			body:   fmt.Sprintf(`{"from_account_id":%q,"to_account_id":%q,"currency":%q,"amount":100}`, from.Number, to.Number, from.Currency),
+			build: func(store *mocks.Store) {
+				store.On("GetAccountByNumber", mock.Anything, from.Number).
+					Return(from, nil).
+					Once()
+				store.On("GetAccountByNumber", mock.Anything, to.Number).
+					Return(to, nil).
+					Once()
+				store.On("GetAccount", mock.Anything, from.ID).
+					Return(from, nil).
+					Once()
+				store.On("GetAccount", mock.Anything, to.ID).
+					Return(to, nil).
+					Once()
+				store.On("TransferTx", mock.Anything, db.TransferTxParams{
+					FromAccountID: from.ID,
+					ToAccountID:   to.ID,
+					Amount:        100,
+				}).
+					Return(transfer, nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+			},
+		},
+		{
+			name:   "TransferMixingIDAndNumber",
+			method: http.MethodPost,
+			url:    "/account/transfer",
+			body:   fmt.Sprintf(`{"from_account_id":%d,"to_account_id":%q,"currency":%q,"amount":100}`, from.ID, to.Number, from.Currency),
+			build: func(store *mocks.Store) {
+				store.On("GetAccountByNumber", mock.Anything, to.Number).
+					Return(to, nil).
+					Once()
+				store.On("GetAccount", mock.Anything, from.ID).
+					Return(from, nil).
+					Once()
+				store.On("GetAccount", mock.Anything, to.ID).
+					Return(to, nil).
+					Once()
+				store.On("TransferTx", mock.Anything, mock.Anything).
+					Return(transfer, nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+			},
+		},
+		{
+			name:   "TransferMistypedNumber",
+			method: http.MethodPost,
+			url:    "/account/transfer",
+			body:   fmt.Sprintf(`{"from_account_id":%q,"to_account_id":%q,"currency":%q,"amount":100}`, mistyped, to.Number, from.Currency),
+			build:  func(store *mocks.Store) {},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		ts := testCases[i]
+
+		t.Run(ts.name, func(t *testing.T) {
+			store := &mocks.Store{}
+			ts.build(store)
+
+			server, err := NewServer(store, util.Config{
+				TokenSymetricKey:    "12345678901234567890123456789012",
+				AccessTokenDuration: time.Minute,
+			})
+			require.NoError(t, err)
+			rec := httptest.NewRecorder()
+
+			req, err := http.NewRequest(ts.method, ts.url, bytes.NewReader([]byte(ts.body)))
+			require.NoError(t, err)
+			req.Header = http.Header{
+				"Content-Type": {"application/json"},
+			}
+			addAuthorization(t, req, server.tokenMaker, "Bearer", from.OwnerID, util.RoleCustomer, time.Minute)
+
+			server.router.ServeHTTP(rec, req)
+			ts.check(t, rec)
+			store.AssertExpectations(t)
+		})
+	}
+}
+
+func randomAccountNumber(t *testing.T) string {
+	number, err := util.NewAccountNumber(fmt.Sprintf("%010d", util.GenRandomNum(0, 9999999999)))
+	require.NoError(t, err)
+	return number
+}
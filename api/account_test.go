@@ -22,9 +22,11 @@ import (
 func TestFetchAccountAPI(t *testing.T) {
 	n := 5
 
+	ownerID := uuid.New()
 	account := make([]db.Account, n)
 	for i := 0; i < n; i++ {
 		account[i] = randomAccount()
+		account[i].OwnerID = ownerID
 	}
 
 	type falseFetchAccountRequest struct {
@@ -45,11 +47,12 @@ func TestFetchAccountAPI(t *testing.T) {
 				Limit:  int32(n),
 			},
 			build: func(store *mocks.Store) {
-				arg := db.FetchAccountsParams{
-					Limit:  int32(n),
-					Offset: 0,
+				arg := db.FetchAccountsByOwnerParams{
+					OwnerID: ownerID,
+					Limit:   int32(n),
+					Offset:  0,
 				}
-				store.On("FetchAccounts", mock.Anything, arg).
+				store.On("FetchAccountsByOwner", mock.Anything, arg).
 					Return(account, nil).
 					Once()
 			},
@@ -71,11 +74,12 @@ func TestFetchAccountAPI(t *testing.T) {
 				Limit:  int32(n),
 			},
 			build: func(store *mocks.Store) {
-				arg := db.FetchAccountsParams{
-					Limit:  int32(n),
-					Offset: 0,
+				arg := db.FetchAccountsByOwnerParams{
+					OwnerID: ownerID,
+					Limit:   int32(n),
+					Offset:  0,
 				}
-				store.On("FetchAccounts", mock.Anything, arg).
+				store.On("FetchAccountsByOwner", mock.Anything, arg).
 					Return(account, nil)
 			},
 			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
@@ -89,11 +93,12 @@ func TestFetchAccountAPI(t *testing.T) {
 				Limit:  "w",
 			},
 			build: func(store *mocks.Store) {
-				arg := db.FetchAccountsParams{
-					Limit:  int32(n),
-					Offset: 0,
+				arg := db.FetchAccountsByOwnerParams{
+					OwnerID: ownerID,
+					Limit:   int32(n),
+					Offset:  0,
 				}
-				store.On("FetchAccounts", mock.Anything, arg).
+				store.On("FetchAccountsByOwner", mock.Anything, arg).
 					Return(account, nil)
 			},
 			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
@@ -107,11 +112,12 @@ func TestFetchAccountAPI(t *testing.T) {
 				Limit:  int32(n),
 			},
 			build: func(store *mocks.Store) {
-				arg := db.FetchAccountsParams{
-					Limit:  int32(n),
-					Offset: 0,
+				arg := db.FetchAccountsByOwnerParams{
+					OwnerID: ownerID,
+					Limit:   int32(n),
+					Offset:  0,
 				}
-				store.On("FetchAccounts", mock.Anything, arg).
+				store.On("FetchAccountsByOwner", mock.Anything, arg).
 					Return(account, sql.ErrConnDone)
 			},
 			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
@@ -125,11 +131,12 @@ func TestFetchAccountAPI(t *testing.T) {
 				Limit:  int32(n),
 			},
 			build: func(store *mocks.Store) {
-				arg := db.FetchAccountsParams{
-					Limit:  int32(n),
-					Offset: 0,
+				arg := db.FetchAccountsByOwnerParams{
+					OwnerID: ownerID,
+					Limit:   int32(n),
+					Offset:  0,
 				}
-				store.On("FetchAccounts", mock.Anything, arg).
+				store.On("FetchAccountsByOwner", mock.Anything, arg).
 					Return(make([]db.Account, 5), sql.ErrNoRows)
 			},
 			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
@@ -143,11 +150,12 @@ func TestFetchAccountAPI(t *testing.T) {
 				Limit:  int32(5),
 			},
 			build: func(store *mocks.Store) {
-				arg := db.FetchAccountsParams{
-					Limit:  int32(n),
-					Offset: 0,
+				arg := db.FetchAccountsByOwnerParams{
+					OwnerID: ownerID,
+					Limit:   int32(n),
+					Offset:  0,
 				}
-				store.On("FetchAccounts", mock.Anything, arg).
+				store.On("FetchAccountsByOwner", mock.Anything, arg).
 					Return(make([]db.Account, 0), nil)
 			},
 			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
@@ -174,11 +182,12 @@ func TestFetchAccountAPI(t *testing.T) {
 			data, err := json.Marshal(ts.body)
 			require.NoError(t, err)
 
-			req, err := http.NewRequest(http.MethodGet, "/account", bytes.NewReader(data))
+			req, err := http.NewRequest(http.MethodGet, "/account/", bytes.NewReader(data))
 			require.NoError(t, err)
 			req.Header = http.Header{
 				"Content-Type": {"application/json"},
 			}
+			addAuthorization(t, req, server.tokenMaker, "Bearer", ownerID, util.RoleCustomer, time.Minute)
 
 			server.router.ServeHTTP(rec, req)
 			ts.check(t, rec)
@@ -191,8 +200,7 @@ func TestCreateAccountAPI(t *testing.T) {
 
 	type falseCreateAccountRequest struct {
 		OwnerID  uuid.UUID `json:"owner_id" binding:"required"`
-		Currency string    `json:"currency" binding:"required,oneof=USD EUR"`
-		Balance  string    `json:"balance" binding:"requied"`
+		Currency int       `json:"currency" binding:"required,oneof=USD EUR"`
 	}
 
 	testCases := []struct {
@@ -206,13 +214,12 @@ func TestCreateAccountAPI(t *testing.T) {
 			body: createAccountRequest{
 				OwnerID:  account.OwnerID,
 				Currency: account.Currency,
-				Balance:  account.Balance,
 			},
 			build: func(store *mocks.Store) {
 				arg := db.CreateAccountParams{
-					OwnerID:  account.OwnerID,
-					Currency: account.Currency,
-					Balance:  account.Balance,
+					OwnerID:     account.OwnerID,
+					Currency:    account.Currency,
+					AccountType: db.AccountTypeCurrent,
 				}
 				store.On("CreateAccount", mock.Anything, arg).
 					Return(account, nil).
@@ -223,18 +230,71 @@ func TestCreateAccountAPI(t *testing.T) {
 				requireBodyMatchAccount(t, rec.Body, createAccountSuccessResponse{Data: account})
 			},
 		},
+		{
+			name: "StatusOKSavings",
+			body: createAccountRequest{
+				OwnerID:     account.OwnerID,
+				Currency:    account.Currency,
+				AccountType: db.AccountTypeSavings,
+			},
+			build: func(store *mocks.Store) {
+				arg := db.CreateAccountParams{
+					OwnerID:     account.OwnerID,
+					Currency:    account.Currency,
+					AccountType: db.AccountTypeSavings,
+				}
+				store.On("CreateAccount", mock.Anything, arg).
+					Return(account, nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+			},
+		},
+		{
+			name: "StatusOKBusiness",
+			body: createAccountRequest{
+				OwnerID:     account.OwnerID,
+				Currency:    account.Currency,
+				AccountType: db.AccountTypeBusiness,
+			},
+			build: func(store *mocks.Store) {
+				arg := db.CreateAccountParams{
+					OwnerID:     account.OwnerID,
+					Currency:    account.Currency,
+					AccountType: db.AccountTypeBusiness,
+				}
+				store.On("CreateAccount", mock.Anything, arg).
+					Return(account, nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+			},
+		},
+		{
+			name: "StatusBadRequestHouseAccountType",
+			body: createAccountRequest{
+				OwnerID:     account.OwnerID,
+				Currency:    account.Currency,
+				AccountType: db.AccountTypeHouseRevenue,
+			},
+			build: func(store *mocks.Store) {},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
 		{
 			name: "StatusBadRequestNotValidParams",
 			body: createAccountRequest{
 				OwnerID:  account.OwnerID,
 				Currency: "PESO",
-				Balance:  account.Balance,
 			},
 			build: func(store *mocks.Store) {
 				arg := db.CreateAccountParams{
-					OwnerID:  account.OwnerID,
-					Currency: account.Currency,
-					Balance:  account.Balance,
+					OwnerID:     account.OwnerID,
+					Currency:    account.Currency,
+					AccountType: db.AccountTypeCurrent,
 				}
 				store.On("CreateAccount", mock.Anything, arg).
 					Return(db.Account{}, mock.Anything)
@@ -247,14 +307,13 @@ func TestCreateAccountAPI(t *testing.T) {
 			name: "StatusBadRequestWrongParams",
 			body: falseCreateAccountRequest{
 				OwnerID:  account.OwnerID,
-				Currency: account.Currency,
-				Balance:  "9899",
+				Currency: 840,
 			},
 			build: func(store *mocks.Store) {
 				arg := db.CreateAccountParams{
-					OwnerID:  account.OwnerID,
-					Currency: account.Currency,
-					Balance:  account.Balance,
+					OwnerID:     account.OwnerID,
+					Currency:    account.Currency,
+					AccountType: db.AccountTypeCurrent,
 				}
 				store.On("CreateAccount", mock.Anything, arg).
 					Return(db.Account{}, mock.Anything)
@@ -263,18 +322,28 @@ func TestCreateAccountAPI(t *testing.T) {
 				require.Equal(t, http.StatusBadRequest, rec.Code)
 			},
 		},
+		{
+			name: "StatusForbiddenOtherOwner",
+			body: createAccountRequest{
+				OwnerID:  uuid.New(),
+				Currency: account.Currency,
+			},
+			build: func(store *mocks.Store) {},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, rec.Code)
+			},
+		},
 		{
 			name: "StatusInternalServerError",
 			body: createAccountRequest{
 				OwnerID:  account.OwnerID,
 				Currency: account.Currency,
-				Balance:  account.Balance,
 			},
 			build: func(store *mocks.Store) {
 				arg := db.CreateAccountParams{
-					OwnerID:  account.OwnerID,
-					Currency: account.Currency,
-					Balance:  account.Balance,
+					OwnerID:     account.OwnerID,
+					Currency:    account.Currency,
+					AccountType: db.AccountTypeCurrent,
 				}
 				store.On("CreateAccount", mock.Anything, arg).
 					Return(account, sql.ErrConnDone)
@@ -303,11 +372,12 @@ func TestCreateAccountAPI(t *testing.T) {
 			data, err := json.Marshal(ts.body)
 			require.NoError(t, err)
 
-			req, err := http.NewRequest(http.MethodPost, "/account", bytes.NewReader(data))
+			req, err := http.NewRequest(http.MethodPost, "/account/", bytes.NewReader(data))
 			require.NoError(t, err)
 			req.Header = http.Header{
 				"Content-Type": {"application/json"},
 			}
+			addAuthorization(t, req, server.tokenMaker, "Bearer", account.OwnerID, util.RoleCustomer, time.Minute)
 
 			server.router.ServeHTTP(rec, req)
 			ts.check(t, rec)
@@ -317,6 +387,7 @@ func TestCreateAccountAPI(t *testing.T) {
 
 func TestGetAccountAPI(t *testing.T) {
 	account := randomAccount()
+	otherAccount := randomAccount()
 
 	testCases := []struct {
 		name  string
@@ -359,6 +430,23 @@ func TestGetAccountAPI(t *testing.T) {
 				require.Equal(t, http.StatusBadRequest, rec.Code)
 			},
 		},
+		{
+			name: "StatusForbiddenOtherOwner",
+			url:  fmt.Sprintf("/account/%d", otherAccount.ID),
+			build: func(store *mocks.Store) {
+				store.On("GetAccount", mock.Anything, otherAccount.ID).
+					Return(otherAccount, nil).
+					Once()
+				store.On("GetAccountHolder", mock.Anything, db.GetAccountHolderParams{
+					AccountID: otherAccount.ID,
+					UserID:    account.OwnerID,
+				}).
+					Return(db.AccountHolder{}, sql.ErrNoRows)
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, rec.Code)
+			},
+		},
 		{
 			name: "StatusNotFound",
 			url:  fmt.Sprintf("/account/%d", account.ID),
@@ -400,9 +488,180 @@ func TestGetAccountAPI(t *testing.T) {
 
 			req, err := http.NewRequest(http.MethodGet, ts.url, nil)
 			require.NoError(t, err)
+			addAuthorization(t, req, server.tokenMaker, "Bearer", account.OwnerID, util.RoleCustomer, time.Minute)
+
+			server.router.ServeHTTP(rec, req)
+			ts.check(t, rec)
+		})
+	}
+}
+
+func TestGetAccountAPIStaff(t *testing.T) {
+	account := randomAccount()
+
+	for _, role := range []string{util.RoleTeller, util.RoleAdmin, util.RoleAuditor} {
+		t.Run(role, func(t *testing.T) {
+			store := &mocks.Store{}
+			staffID := uuid.New()
+			store.On("GetAccount", mock.Anything, account.ID).
+				Return(account, nil).
+				Once()
+			store.On("GetAccountHolder", mock.Anything, db.GetAccountHolderParams{AccountID: account.ID, UserID: staffID}).
+				Return(db.AccountHolder{}, sql.ErrNoRows).
+				Once()
+
+			server, err := NewServer(store, util.Config{
+				TokenSymetricKey:    "12345678901234567890123456789012",
+				AccessTokenDuration: time.Minute,
+			})
+			require.NoError(t, err)
+			rec := httptest.NewRecorder()
+
+			req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/account/%d", account.ID), nil)
+			require.NoError(t, err)
+			addAuthorization(t, req, server.tokenMaker, "Bearer", staffID, role, time.Minute)
+
+			server.router.ServeHTTP(rec, req)
+			require.Equal(t, http.StatusOK, rec.Code)
+			requireBodyMatchAccount(t, rec.Body, getAccountSuccessResponse{Data: account})
+		})
+	}
+}
+
+func TestCloseAccountAPI(t *testing.T) {
+	account := randomAccount()
+	sweepTo := randomAccount()
+	sweepTo.ID = account.ID + 1
+	sweepTo.OwnerID = account.OwnerID
+	sweepTo.Currency = account.Currency
+	otherOwner := sweepTo
+	otherOwner.OwnerID = uuid.New()
+
+	closed := account
+	closed.Balance = 0
+	closed.Status = db.AccountStatusClosed
+
+	testCases := []struct {
+		name  string
+		body  any
+		build func(store *mocks.Store)
+		check func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			name: "StatusOKSweep",
+			body: closeAccountRequest{SweepToAccountID: sweepTo.ID},
+			build: func(store *mocks.Store) {
+				store.On("GetAccount", mock.Anything, account.ID).
+					Return(account, nil).
+					Once()
+				store.On("GetAccount", mock.Anything, sweepTo.ID).
+					Return(sweepTo, nil).
+					Once()
+				store.On("CloseAccountTx", mock.Anything, db.CloseAccountTxParams{
+					AccountID:        account.ID,
+					SweepToAccountID: sweepTo.ID,
+				}).
+					Return(db.CloseAccountTxResult{Account: closed}, nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+
+				var res closeAccountSuccessResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+				require.Equal(t, db.AccountStatusClosed, res.Data.Account.Status)
+			},
+		},
+		{
+			name: "StatusForbiddenNonZeroBalance",
+			body: closeAccountRequest{},
+			build: func(store *mocks.Store) {
+				store.On("GetAccount", mock.Anything, account.ID).
+					Return(account, nil).
+					Once()
+				store.On("CloseAccountTx", mock.Anything, db.CloseAccountTxParams{AccountID: account.ID}).
+					Return(db.CloseAccountTxResult{}, &db.AccountBalanceError{AccountID: account.ID, Balance: account.Balance}).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, rec.Code)
+			},
+		},
+		{
+			name: "StatusForbiddenOpenPots",
+			body: closeAccountRequest{},
+			build: func(store *mocks.Store) {
+				store.On("GetAccount", mock.Anything, account.ID).
+					Return(account, nil).
+					Once()
+				store.On("CloseAccountTx", mock.Anything, db.CloseAccountTxParams{AccountID: account.ID}).
+					Return(db.CloseAccountTxResult{}, db.ErrAccountHasPots).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, rec.Code)
+			},
+		},
+		{
+			name: "StatusForbiddenSweepOtherOwner",
+			body: closeAccountRequest{SweepToAccountID: otherOwner.ID},
+			build: func(store *mocks.Store) {
+				store.On("GetAccount", mock.Anything, account.ID).
+					Return(account, nil).
+					Once()
+				store.On("GetAccount", mock.Anything, otherOwner.ID).
+					Return(otherOwner, nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, rec.Code)
+			},
+		},
+		{
+			name:  "StatusBadRequestSweepToItself",
+			body:  closeAccountRequest{SweepToAccountID: account.ID},
+			build: func(store *mocks.Store) {},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			name: "StatusNotFound",
+			body: closeAccountRequest{},
+			build: func(store *mocks.Store) {
+				store.On("GetAccount", mock.Anything, account.ID).
+					Return(db.Account{}, sql.ErrNoRows).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, rec.Code)
+			},
+		},
+	}
+
+	for _, ts := range testCases {
+		t.Run(ts.name, func(t *testing.T) {
+			store := &mocks.Store{}
+			ts.build(store)
+
+			server, err := NewServer(store, util.Config{
+				TokenSymetricKey:    "12345678901234567890123456789012",
+				AccessTokenDuration: time.Minute,
+			})
+			require.NoError(t, err)
+			rec := httptest.NewRecorder()
+
+			data, err := json.Marshal(ts.body)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("/account/%d/close", account.ID), bytes.NewReader(data))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+			addAuthorization(t, req, server.tokenMaker, "Bearer", account.OwnerID, util.RoleCustomer, time.Minute)
 
 			server.router.ServeHTTP(rec, req)
 			ts.check(t, rec)
+			store.AssertExpectations(t)
 		})
 	}
 }
@@ -410,12 +669,13 @@ func TestGetAccountAPI(t *testing.T) {
 func randomAccount() db.Account {
 	return db.Account{
 		ID:       util.GenRandomNum(1, 10000),
+		OwnerID:  uuid.New(),
 		Balance:  util.GenRandomMoney(),
 		Currency: util.GenRandomCurrency(),
 	}
 }
 
-func requireBodyMatchAccount[V createUserSuccessResponse | getAccountErrorResponse | createTransferSuccessResponse | fetchAccountSuccessResponse | createAccountSuccessResponse | getAccountSuccessResponse](t *testing.T, body *bytes.Buffer, res V) {
+func requireBodyMatchAccount[V createUserSuccessResponse | Problem | createTransferSuccessResponse | fetchAccountSuccessResponse | createAccountSuccessResponse | getAccountSuccessResponse](t *testing.T, body *bytes.Buffer, res V) {
 	bodyData, err := io.ReadAll(body)
 	require.NoError(t, err)
 
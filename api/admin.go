@@ -0,0 +1,297 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	db "github.com/flukis/simplebank/db/sqlc"
+	"github.com/flukis/simplebank/jobs"
+	"github.com/flukis/simplebank/util"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// FetchAllAccounts lists the accounts of every user
+func (s *Server) FetchAllAccounts(c echo.Context) error {
+	req := new(fetchAccountRequest)
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	arg := db.FetchAccountsParams{
+		Limit:  req.Limit,
+		Offset: (req.PageID - 1) * req.Limit,
+	}
+
+	accounts, err := s.store.FetchAccounts(c.Request().Context(), arg)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(
+		http.StatusOK,
+		&fetchAccountSuccessResponse{
+			Data: accounts,
+			Meta: Meta{
+				Limit: req.Limit,
+				Page:  req.PageID,
+			},
+		},
+	)
+}
+
+type fetchUsersSuccessResponse struct {
+	Data []UserResponse `json:"data"`
+	Meta Meta           `json:"meta"`
+}
+
+// FetchUsers lists every registered user
+func (s *Server) FetchUsers(c echo.Context) error {
+	req := new(fetchAccountRequest)
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	arg := db.FetchUsersParams{
+		Limit:  req.Limit,
+		Offset: (req.PageID - 1) * req.Limit,
+	}
+
+	users, err := s.store.FetchUsers(c.Request().Context(), arg)
+	if err != nil {
+		return err
+	}
+
+	data := make([]UserResponse, len(users))
+	for i, user := range users {
+		data[i] = generateUserResponse(user)
+	}
+
+	return c.JSON(
+		http.StatusOK,
+		&fetchUsersSuccessResponse{
+			Data: data,
+			Meta: Meta{
+				Limit: req.Limit,
+				Page:  req.PageID,
+			},
+		},
+	)
+}
+
+type setAccountStatusSuccessResponse struct {
+	Data db.Account `json:"data"`
+}
+
+// FreezeAccount stops all postings to and from an account
+func (s *Server) FreezeAccount(c echo.Context) error {
+	return s.setAccountStatus(c, db.AccountStatusFrozen)
+}
+
+// UnfreezeAccount reactivates a frozen or dormant account
+func (s *Server) UnfreezeAccount(c echo.Context) error {
+	return s.setAccountStatus(c, db.AccountStatusActive)
+}
+
+func (s *Server) setAccountStatus(c echo.Context, status string) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return invalidParam("id", err)
+	}
+
+	account, err := s.store.SetAccountStatusTx(c.Request().Context(), id, status)
+	if err != nil {
+		return notFound(CodeAccountNotFound, err)
+	}
+
+	return c.JSON(
+		http.StatusOK,
+		&setAccountStatusSuccessResponse{
+			Data: account,
+		},
+	)
+}
+
+type getTransferSuccessResponse struct {
+	Data db.Transfer `json:"data"`
+}
+
+// GetAnyTransfer returns a transfer regardless of who owns its accounts
+func (s *Server) GetAnyTransfer(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return invalidParam("id", err)
+	}
+
+	transfer, err := s.store.GetTransfer(c.Request().Context(), id)
+	if err != nil {
+		return notFound(CodeTransferNotFound, err)
+	}
+
+	return c.JSON(
+		http.StatusOK,
+		&getTransferSuccessResponse{
+			Data: transfer,
+		},
+	)
+}
+
+type updateUserRoleRequest struct {
+	Role string `json:"role" binding:"required,oneof=customer teller admin auditor"`
+}
+
+func (r updateUserRoleRequest) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Role, validation.Required, validation.In(util.RoleCustomer, util.RoleTeller, util.RoleAdmin, util.RoleAuditor)),
+	)
+}
+
+type updateUserRoleSuccessResponse struct {
+	Data UserResponse `json:"data"`
+}
+
+// UpdateUserRole grants a role to a user. It takes effect on their next login.
+func (s *Server) UpdateUserRole(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return invalidParam("id", err)
+	}
+
+	req := new(updateUserRoleRequest)
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	user, err := s.store.UpdateUserRole(c.Request().Context(), db.UpdateUserRoleParams{
+		ID:   id,
+		Role: req.Role,
+	})
+	if err != nil {
+		return notFound(CodeUserNotFound, err)
+	}
+
+	return c.JSON(
+		http.StatusOK,
+		&updateUserRoleSuccessResponse{
+			Data: generateUserResponse(user),
+		},
+	)
+}
+
+type runEndOfDayRequest struct {
+	Date string `json:"date" binding:"required"`
+}
+
+func (r runEndOfDayRequest) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Date, validation.Required, validation.Date(jobs.DateLayout)),
+	)
+}
+
+type runEndOfDaySuccessResponse struct {
+	Data jobs.EndOfDayResult `json:"data"`
+}
+
+// RunEndOfDay runs the end of day job for a past date, e.g. to catch up on a
+// missed day. Dates that were already run are not posted again.
+func (s *Server) RunEndOfDay(c echo.Context) error {
+	req := new(runEndOfDayRequest)
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	date, _ := time.Parse(jobs.DateLayout, req.Date)
+	result, err := jobs.NewEndOfDay(s.store).Run(c.Request().Context(), date)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(
+		http.StatusOK,
+		&runEndOfDaySuccessResponse{
+			Data: result,
+		},
+	)
+}
+
+type listGLAccountsSuccessResponse struct {
+	Data []db.GlAccount `json:"data"`
+}
+
+// ListGLAccounts returns the chart of accounts
+func (s *Server) ListGLAccounts(c echo.Context) error {
+	accounts, err := s.store.ListGLAccounts(c.Request().Context())
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(
+		http.StatusOK,
+		&listGLAccountsSuccessResponse{
+			Data: accounts,
+		},
+	)
+}
+
+type trialBalanceRequest struct {
+	AsOf string `query:"as_of" form:"as_of"`
+}
+
+func (r trialBalanceRequest) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.AsOf, validation.Date(jobs.DateLayout)),
+	)
+}
+
+type trialBalanceSuccessResponse struct {
+	Data db.TrialBalance `json:"data"`
+}
+
+// TrialBalance reports the GL balances at the end of the as_of date, or
+// right now when no date is given
+func (s *Server) TrialBalance(c echo.Context) error {
+	req := new(trialBalanceRequest)
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	asOf := time.Now()
+	if req.AsOf != "" {
+		date, _ := time.Parse(jobs.DateLayout, req.AsOf)
+		asOf = date.AddDate(0, 0, 1)
+	}
+
+	report, err := s.store.TrialBalance(c.Request().Context(), asOf)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(
+		http.StatusOK,
+		&trialBalanceSuccessResponse{
+			Data: report,
+		},
+	)
+}
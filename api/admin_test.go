@@ -0,0 +1,359 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mocks "github.com/flukis/simplebank/db/mock"
+	db "github.com/flukis/simplebank/db/sqlc"
+	"github.com/flukis/simplebank/jobs"
+	"github.com/flukis/simplebank/util"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminAPI(t *testing.T) {
+	account := randomAccount()
+	user := randomUser(t, "secret")
+
+	transfer := db.Transfer{
+		ID:            util.GenRandomNum(1, 1000),
+		FromAccountID: account.ID,
+		ToAccountID:   util.GenRandomNum(1, 1000),
+		Amount:        util.GenRandomMoney(),
+	}
+
+	testCases := []struct {
+		name   string
+		method string
+		url    string
+		body   any
+		role   string
+		build  func(store *mocks.Store)
+		check  func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "FetchAllAccountsOK",
+			method: http.MethodGet,
+			url:    "/admin/accounts?page=1&limit=5",
+			role:   util.RoleAuditor,
+			build: func(store *mocks.Store) {
+				store.On("FetchAccounts", mock.Anything, db.FetchAccountsParams{Limit: 5, Offset: 0}).
+					Return([]db.Account{account}, nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+				requireBodyMatchAccount(t, rec.Body, fetchAccountSuccessResponse{
+					Data: []db.Account{account},
+					Meta: Meta{Limit: 5, Page: 1},
+				})
+			},
+		},
+		{
+			name:   "FetchAllAccountsForbiddenCustomer",
+			method: http.MethodGet,
+			url:    "/admin/accounts?page=1&limit=5",
+			role:   util.RoleCustomer,
+			build:  func(store *mocks.Store) {},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, rec.Code)
+			},
+		},
+		{
+			name:   "FetchUsersOK",
+			method: http.MethodGet,
+			url:    "/admin/users?page=1&limit=5",
+			role:   util.RoleAdmin,
+			build: func(store *mocks.Store) {
+				store.On("FetchUsers", mock.Anything, db.FetchUsersParams{Limit: 5, Offset: 0}).
+					Return([]db.User{user}, nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+
+				var res fetchUsersSuccessResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+				require.Equal(t, []UserResponse{generateUserResponse(user)}, res.Data)
+				require.NotContains(t, rec.Body.String(), "hashed_password")
+			},
+		},
+		{
+			name:   "FreezeAccountOK",
+			method: http.MethodPost,
+			url:    fmt.Sprintf("/admin/accounts/%d/freeze", account.ID),
+			role:   util.RoleAdmin,
+			build: func(store *mocks.Store) {
+				frozen := account
+				frozen.Status = db.AccountStatusFrozen
+				store.On("SetAccountStatusTx", mock.Anything, account.ID, db.AccountStatusFrozen).
+					Return(frozen, nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+
+				var res setAccountStatusSuccessResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+				require.Equal(t, db.AccountStatusFrozen, res.Data.Status)
+			},
+		},
+		{
+			name:   "FreezeAccountForbiddenAuditor",
+			method: http.MethodPost,
+			url:    fmt.Sprintf("/admin/accounts/%d/freeze", account.ID),
+			role:   util.RoleAuditor,
+			build:  func(store *mocks.Store) {},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, rec.Code)
+			},
+		},
+		{
+			name:   "FreezeAccountNotFound",
+			method: http.MethodPost,
+			url:    fmt.Sprintf("/admin/accounts/%d/freeze", account.ID),
+			role:   util.RoleAdmin,
+			build: func(store *mocks.Store) {
+				store.On("SetAccountStatusTx", mock.Anything, account.ID, db.AccountStatusFrozen).
+					Return(db.Account{}, sql.ErrNoRows).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, rec.Code)
+			},
+		},
+		{
+			name:   "UnfreezeAccountOK",
+			method: http.MethodPost,
+			url:    fmt.Sprintf("/admin/accounts/%d/unfreeze", account.ID),
+			role:   util.RoleAdmin,
+			build: func(store *mocks.Store) {
+				active := account
+				active.Status = db.AccountStatusActive
+				store.On("SetAccountStatusTx", mock.Anything, account.ID, db.AccountStatusActive).
+					Return(active, nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+
+				var res setAccountStatusSuccessResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+				require.Equal(t, db.AccountStatusActive, res.Data.Status)
+			},
+		},
+		{
+			name:   "UnfreezeAccountClosed",
+			method: http.MethodPost,
+			url:    fmt.Sprintf("/admin/accounts/%d/unfreeze", account.ID),
+			role:   util.RoleAdmin,
+			build: func(store *mocks.Store) {
+				store.On("SetAccountStatusTx", mock.Anything, account.ID, db.AccountStatusActive).
+					Return(db.Account{}, &db.AccountTransitionError{
+						AccountID: account.ID,
+						From:      db.AccountStatusClosed,
+						To:        db.AccountStatusActive,
+					}).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, rec.Code)
+			},
+		},
+		{
+			name:   "GetAnyTransferOK",
+			method: http.MethodGet,
+			url:    fmt.Sprintf("/admin/transfers/%d", transfer.ID),
+			role:   util.RoleAuditor,
+			build: func(store *mocks.Store) {
+				store.On("GetTransfer", mock.Anything, transfer.ID).
+					Return(transfer, nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+
+				var res getTransferSuccessResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+				require.Equal(t, transfer, res.Data)
+			},
+		},
+		{
+			name:   "RunEndOfDayOK",
+			method: http.MethodPost,
+			url:    "/admin/jobs/end-of-day",
+			body:   runEndOfDayRequest{Date: "2023-03-15"},
+			role:   util.RoleAdmin,
+			build: func(store *mocks.Store) {
+				date := time.Date(2023, time.March, 15, 0, 0, 0, 0, time.UTC)
+				store.On("CollectLoanInstallments", mock.Anything, date).
+					Return(db.LoanCollectionResult{}, nil).
+					Once()
+				store.On("MatureTermDeposits", mock.Anything, date).
+					Return([]db.TermDepositClosure{}, nil).
+					Once()
+				store.On("AccrueInterest", mock.Anything, date).
+					Return([]db.InterestAccrual{{ID: 1}}, nil).
+					Once()
+				store.On("DeleteIdempotencyKeysBefore", mock.Anything, mock.AnythingOfType("time.Time")).
+					Return(int64(0), nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+
+				var res runEndOfDaySuccessResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+				require.Equal(t, "2023-03-15", res.Data.Date)
+				require.Equal(t, 1, res.Data.Accrued)
+			},
+		},
+		{
+			name:   "RunEndOfDayBadDate",
+			method: http.MethodPost,
+			url:    "/admin/jobs/end-of-day",
+			body:   runEndOfDayRequest{Date: "15/03/2023"},
+			role:   util.RoleAdmin,
+			build:  func(store *mocks.Store) {},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			name:   "RunEndOfDayFutureDate",
+			method: http.MethodPost,
+			url:    "/admin/jobs/end-of-day",
+			body:   runEndOfDayRequest{Date: time.Now().AddDate(0, 0, 1).Format(jobs.DateLayout)},
+			role:   util.RoleAdmin,
+			build:  func(store *mocks.Store) {},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			name:   "ListGLAccountsOK",
+			method: http.MethodGet,
+			url:    "/admin/gl/accounts",
+			role:   util.RoleAuditor,
+			build: func(store *mocks.Store) {
+				store.On("ListGLAccounts", mock.Anything).
+					Return([]db.GlAccount{{ID: 1, Code: "2100", Name: "Customer current accounts", Type: db.GLTypeLiability}}, nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+
+				var res listGLAccountsSuccessResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+				require.Len(t, res.Data, 1)
+				require.Equal(t, "2100", res.Data[0].Code)
+			},
+		},
+		{
+			name:   "TrialBalanceAsOf",
+			method: http.MethodGet,
+			url:    "/admin/reports/trial-balance?as_of=2023-03-31",
+			role:   util.RoleAuditor,
+			build: func(store *mocks.Store) {
+				asOf := time.Date(2023, time.April, 1, 0, 0, 0, 0, time.UTC)
+				store.On("TrialBalance", mock.Anything, asOf).
+					Return(db.TrialBalance{AsOf: asOf, Currencies: []db.TrialBalanceCurrency{}}, nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+			},
+		},
+		{
+			name:   "TrialBalanceBadDate",
+			method: http.MethodGet,
+			url:    "/admin/reports/trial-balance?as_of=yesterday",
+			role:   util.RoleAuditor,
+			build:  func(store *mocks.Store) {},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			name:   "TrialBalanceForbiddenTeller",
+			method: http.MethodGet,
+			url:    "/admin/reports/trial-balance",
+			role:   util.RoleTeller,
+			build:  func(store *mocks.Store) {},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, rec.Code)
+			},
+		},
+		{
+			name:   "UpdateUserRoleOK",
+			method: http.MethodPut,
+			url:    fmt.Sprintf("/admin/users/%s/role", user.ID),
+			body:   updateUserRoleRequest{Role: util.RoleTeller},
+			role:   util.RoleAdmin,
+			build: func(store *mocks.Store) {
+				teller := user
+				teller.Role = util.RoleTeller
+				store.On("UpdateUserRole", mock.Anything, db.UpdateUserRoleParams{
+					ID:   user.ID,
+					Role: util.RoleTeller,
+				}).
+					Return(teller, nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+
+				var res updateUserRoleSuccessResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+				require.Equal(t, util.RoleTeller, res.Data.Role)
+			},
+		},
+		{
+			name:   "UpdateUserRoleBadRequest",
+			method: http.MethodPut,
+			url:    fmt.Sprintf("/admin/users/%s/role", user.ID),
+			body:   updateUserRoleRequest{Role: "root"},
+			role:   util.RoleAdmin,
+			build:  func(store *mocks.Store) {},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+	}
+
+	for _, ts := range testCases {
+		t.Run(ts.name, func(t *testing.T) {
+			store := &mocks.Store{}
+			ts.build(store)
+
+			server, err := NewServer(store, util.Config{
+				TokenSymetricKey:    "12345678901234567890123456789012",
+				AccessTokenDuration: time.Minute,
+			})
+			require.NoError(t, err)
+			rec := httptest.NewRecorder()
+
+			data, err := json.Marshal(ts.body)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(ts.method, ts.url, bytes.NewReader(data))
+			require.NoError(t, err)
+			req.Header = http.Header{
+				"Content-Type": {"application/json"},
+			}
+			addAuthorization(t, req, server.tokenMaker, "Bearer", uuid.New(), ts.role, time.Minute)
+
+			server.router.ServeHTTP(rec, req)
+			ts.check(t, rec)
+			store.AssertExpectations(t)
+		})
+	}
+}
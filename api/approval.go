@@ -0,0 +1,128 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	db "github.com/flukis/simplebank/db/sqlc"
+	"github.com/labstack/echo/v4"
+)
+
+type listApprovalsSuccessResponse struct {
+	Data []db.TransferApproval `json:"data"`
+}
+
+// ListTransferApprovals returns the transfers from an account that are
+// waiting for approval
+func (s *Server) ListTransferApprovals(c echo.Context) error {
+	account, err := s.holderAccount(c, db.PermView)
+	if err != nil {
+		return err
+	}
+
+	approvals, err := s.store.ListPendingTransferApprovals(c.Request().Context(), account.ID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(
+		http.StatusOK,
+		&listApprovalsSuccessResponse{
+			Data: approvals,
+		},
+	)
+}
+
+type approveTransferSuccessResponse struct {
+	Data db.ApproveTransferTxResult `json:"data"`
+}
+
+// ApproveTransfer makes a transfer another holder made above their spend
+// limit. Only holders allowed to approve may do so.
+func (s *Server) ApproveTransfer(c echo.Context) error {
+	approval, access, err := s.transferApproval(c)
+	if err != nil {
+		return err
+	}
+
+	if !access.Can(db.PermApprove) {
+		return errAccountNotOwned
+	}
+
+	result, err := s.store.ApproveTransferTx(c.Request().Context(), db.DecideTransferApprovalTxParams{
+		ID:        approval.ID,
+		DecidedBy: authPayload(c).UserID,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(
+		http.StatusOK,
+		&approveTransferSuccessResponse{
+			Data: result,
+		},
+	)
+}
+
+type rejectTransferSuccessResponse struct {
+	Data db.TransferApproval `json:"data"`
+}
+
+// RejectTransfer turns down a transfer waiting for approval. Besides the
+// holders allowed to approve it, the holder who made it may withdraw it.
+func (s *Server) RejectTransfer(c echo.Context) error {
+	approval, access, err := s.transferApproval(c)
+	if err != nil {
+		return err
+	}
+
+	if !access.Can(db.PermApprove) && approval.RequestedBy != authPayload(c).UserID {
+		return errAccountNotOwned
+	}
+
+	result, err := s.store.RejectTransferTx(c.Request().Context(), db.DecideTransferApprovalTxParams{
+		ID:        approval.ID,
+		DecidedBy: authPayload(c).UserID,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(
+		http.StatusOK,
+		&rejectTransferSuccessResponse{
+			Data: result,
+		},
+	)
+}
+
+// transferApproval loads the approval of the :id param together with the
+// access of the authenticated user to its source account. It fails when the
+// approval cannot be loaded or the user cannot even view the account.
+func (s *Server) transferApproval(c echo.Context) (db.TransferApproval, db.AccountAccess, error) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return db.TransferApproval{}, db.AccountAccess{}, invalidParam("id", err)
+	}
+
+	approval, err := s.store.GetTransferApproval(c.Request().Context(), id)
+	if err != nil {
+		return approval, db.AccountAccess{}, notFound(CodeApprovalNotFound, err)
+	}
+
+	account, err := s.store.GetAccount(c.Request().Context(), approval.FromAccountID)
+	if err != nil {
+		return approval, db.AccountAccess{}, err
+	}
+
+	access, err := s.accountAccess(c, account)
+	if err != nil {
+		return approval, access, err
+	}
+	if !access.Can(db.PermView) {
+		return approval, access, errAccountNotOwned
+	}
+
+	return approval, access, nil
+}
@@ -0,0 +1,348 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mocks "github.com/flukis/simplebank/db/mock"
+	db "github.com/flukis/simplebank/db/sqlc"
+	"github.com/flukis/simplebank/util"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransferApprovalAPI(t *testing.T) {
+	account := randomAccount()
+	account.AccountType = db.AccountTypeBusiness
+	toAccount := randomAccount()
+	toAccount.Currency = account.Currency
+
+	employee := db.AccountHolder{
+		AccountID:   account.ID,
+		UserID:      uuid.New(),
+		CanInitiate: true,
+		SpendLimit:  500,
+	}
+	manager := db.AccountHolder{
+		AccountID:   account.ID,
+		UserID:      uuid.New(),
+		CanInitiate: true,
+		CanApprove:  true,
+	}
+	viewer := db.AccountHolder{
+		AccountID: account.ID,
+		UserID:    uuid.New(),
+	}
+	holderArg := func(holder db.AccountHolder) db.GetAccountHolderParams {
+		return db.GetAccountHolderParams{
+			AccountID: holder.AccountID,
+			UserID:    holder.UserID,
+		}
+	}
+
+	approval := db.TransferApproval{
+		ID:            util.GenRandomNum(1, 10000),
+		FromAccountID: account.ID,
+		ToAccountID:   toAccount.ID,
+		Amount:        501,
+		RequestedBy:   employee.UserID,
+		Status:        db.ApprovalStatusPending,
+	}
+	transfer := generateTransferResult(account, toAccount, approval.Amount)
+
+	testCases := []struct {
+		name   string
+		method string
+		url    string
+		body   any
+		userID uuid.UUID
+		build  func(store *mocks.Store)
+		check  func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "TransferWithinSpendLimit",
+			method: http.MethodPost,
+			url:    "/account/transfer",
+			body: createTransferRequest{
+				FromAccountID: account.ID,
+				ToAccountID:   toAccount.ID,
+				Currency:      account.Currency,
+				Amount:        employee.SpendLimit,
+			},
+			userID: employee.UserID,
+			build: func(store *mocks.Store) {
+				store.On("GetAccount", mock.Anything, account.ID).
+					Return(account, nil).
+					Once()
+				store.On("GetAccountHolder", mock.Anything, holderArg(employee)).
+					Return(employee, nil).
+					Once()
+				store.On("GetAccount", mock.Anything, toAccount.ID).
+					Return(toAccount, nil).
+					Once()
+				store.On("TransferTx", mock.Anything, db.TransferTxParams{
+					FromAccountID: account.ID,
+					ToAccountID:   toAccount.ID,
+					Amount:        employee.SpendLimit,
+				}).
+					Return(transfer, nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+			},
+		},
+		{
+			name:   "TransferAboveSpendLimit",
+			method: http.MethodPost,
+			url:    "/account/transfer",
+			body: createTransferRequest{
+				FromAccountID: account.ID,
+				ToAccountID:   toAccount.ID,
+				Currency:      account.Currency,
+				Amount:        approval.Amount,
+			},
+			userID: employee.UserID,
+			build: func(store *mocks.Store) {
+				store.On("GetAccount", mock.Anything, account.ID).
+					Return(account, nil).
+					Once()
+				store.On("GetAccountHolder", mock.Anything, holderArg(employee)).
+					Return(employee, nil).
+					Once()
+				store.On("GetAccount", mock.Anything, toAccount.ID).
+					Return(toAccount, nil).
+					Once()
+				store.On("CreateTransferApproval", mock.Anything, db.CreateTransferApprovalParams{
+					FromAccountID: account.ID,
+					ToAccountID:   toAccount.ID,
+					Amount:        approval.Amount,
+					RequestedBy:   employee.UserID,
+				}).
+					Return(approval, nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusAccepted, rec.Code)
+
+				var res createTransferPendingResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+				require.Equal(t, approval, res.Data)
+			},
+		},
+		{
+			name:   "TransferByViewer",
+			method: http.MethodPost,
+			url:    "/account/transfer",
+			body: createTransferRequest{
+				FromAccountID: account.ID,
+				ToAccountID:   toAccount.ID,
+				Currency:      account.Currency,
+				Amount:        1,
+			},
+			userID: viewer.UserID,
+			build: func(store *mocks.Store) {
+				store.On("GetAccount", mock.Anything, account.ID).
+					Return(account, nil).
+					Once()
+				store.On("GetAccountHolder", mock.Anything, holderArg(viewer)).
+					Return(viewer, nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, rec.Code)
+			},
+		},
+		{
+			name:   "ListPending",
+			method: http.MethodGet,
+			url:    fmt.Sprintf("/account/%d/approvals", account.ID),
+			userID: viewer.UserID,
+			build: func(store *mocks.Store) {
+				store.On("GetAccount", mock.Anything, account.ID).
+					Return(account, nil).
+					Once()
+				store.On("GetAccountHolder", mock.Anything, holderArg(viewer)).
+					Return(viewer, nil).
+					Once()
+				store.On("ListPendingTransferApprovals", mock.Anything, account.ID).
+					Return([]db.TransferApproval{approval}, nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+
+				var res listApprovalsSuccessResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+				require.Equal(t, []db.TransferApproval{approval}, res.Data)
+			},
+		},
+		{
+			name:   "ApproveOK",
+			method: http.MethodPost,
+			url:    fmt.Sprintf("/approvals/%d/approve", approval.ID),
+			userID: manager.UserID,
+			build: func(store *mocks.Store) {
+				store.On("GetTransferApproval", mock.Anything, approval.ID).
+					Return(approval, nil).
+					Once()
+				store.On("GetAccount", mock.Anything, account.ID).
+					Return(account, nil).
+					Once()
+				store.On("GetAccountHolder", mock.Anything, holderArg(manager)).
+					Return(manager, nil).
+					Once()
+				store.On("ApproveTransferTx", mock.Anything, db.DecideTransferApprovalTxParams{
+					ID:        approval.ID,
+					DecidedBy: manager.UserID,
+				}).
+					Return(db.ApproveTransferTxResult{Transfer: transfer}, nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+
+				var res approveTransferSuccessResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+				require.Equal(t, transfer.Transfer, res.Data.Transfer.Transfer)
+			},
+		},
+		{
+			name:   "ApproveWithoutRight",
+			method: http.MethodPost,
+			url:    fmt.Sprintf("/approvals/%d/approve", approval.ID),
+			userID: employee.UserID,
+			build: func(store *mocks.Store) {
+				store.On("GetTransferApproval", mock.Anything, approval.ID).
+					Return(approval, nil).
+					Once()
+				store.On("GetAccount", mock.Anything, account.ID).
+					Return(account, nil).
+					Once()
+				store.On("GetAccountHolder", mock.Anything, holderArg(employee)).
+					Return(employee, nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, rec.Code)
+			},
+		},
+		{
+			name:   "ApproveAlreadyDecided",
+			method: http.MethodPost,
+			url:    fmt.Sprintf("/approvals/%d/approve", approval.ID),
+			userID: account.OwnerID,
+			build: func(store *mocks.Store) {
+				store.On("GetTransferApproval", mock.Anything, approval.ID).
+					Return(approval, nil).
+					Once()
+				store.On("GetAccount", mock.Anything, account.ID).
+					Return(account, nil).
+					Once()
+				store.On("ApproveTransferTx", mock.Anything, mock.Anything).
+					Return(db.ApproveTransferTxResult{}, db.ErrApprovalDecided).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, rec.Code)
+			},
+		},
+		{
+			name:   "ApproveNotFound",
+			method: http.MethodPost,
+			url:    fmt.Sprintf("/approvals/%d/approve", approval.ID),
+			userID: manager.UserID,
+			build: func(store *mocks.Store) {
+				store.On("GetTransferApproval", mock.Anything, approval.ID).
+					Return(db.TransferApproval{}, sql.ErrNoRows).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, rec.Code)
+			},
+		},
+		{
+			name:   "RejectByRequester",
+			method: http.MethodPost,
+			url:    fmt.Sprintf("/approvals/%d/reject", approval.ID),
+			userID: employee.UserID,
+			build: func(store *mocks.Store) {
+				store.On("GetTransferApproval", mock.Anything, approval.ID).
+					Return(approval, nil).
+					Once()
+				store.On("GetAccount", mock.Anything, account.ID).
+					Return(account, nil).
+					Once()
+				store.On("GetAccountHolder", mock.Anything, holderArg(employee)).
+					Return(employee, nil).
+					Once()
+				store.On("RejectTransferTx", mock.Anything, db.DecideTransferApprovalTxParams{
+					ID:        approval.ID,
+					DecidedBy: employee.UserID,
+				}).
+					Return(db.TransferApproval{Status: db.ApprovalStatusRejected}, nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+			},
+		},
+		{
+			name:   "RejectByViewer",
+			method: http.MethodPost,
+			url:    fmt.Sprintf("/approvals/%d/reject", approval.ID),
+			userID: viewer.UserID,
+			build: func(store *mocks.Store) {
+				store.On("GetTransferApproval", mock.Anything, approval.ID).
+					Return(approval, nil).
+					Once()
+				store.On("GetAccount", mock.Anything, account.ID).
+					Return(account, nil).
+					Once()
+				store.On("GetAccountHolder", mock.Anything, holderArg(viewer)).
+					Return(viewer, nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, rec.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		ts := testCases[i]
+
+		t.Run(ts.name, func(t *testing.T) {
+			store := &mocks.Store{}
+			ts.build(store)
+
+			server, err := NewServer(store, util.Config{
+				TokenSymetricKey:    "12345678901234567890123456789012",
+				AccessTokenDuration: time.Minute,
+			})
+			require.NoError(t, err)
+			rec := httptest.NewRecorder()
+
+			data, err := json.Marshal(ts.body)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(ts.method, ts.url, bytes.NewReader(data))
+			require.NoError(t, err)
+			req.Header = http.Header{
+				"Content-Type": {"application/json"},
+			}
+			addAuthorization(t, req, server.tokenMaker, "Bearer", ts.userID, util.RoleCustomer, time.Minute)
+
+			server.router.ServeHTTP(rec, req)
+			ts.check(t, rec)
+			store.AssertExpectations(t)
+		})
+	}
+}
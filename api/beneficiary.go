@@ -0,0 +1,134 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	db "github.com/flukis/simplebank/db/sqlc"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/labstack/echo/v4"
+)
+
+var (
+	errBeneficiaryNotOwned = errors.New("beneficiary doesn't belong to the authenticated user")
+	errCannotReceive       = errors.New("the account cannot receive payments")
+)
+
+type createBeneficiaryRequest struct {
+	Nickname  string `json:"nickname" binding:"required"`
+	AccountID int64  `json:"account_id" binding:"required,min=1"`
+	Currency  string `json:"currency" binding:"required,oneof=USD EUR IDR"`
+}
+
+func (r createBeneficiaryRequest) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Nickname, validation.Required, validation.Length(1, 50)),
+		validation.Field(&r.AccountID, validation.Required, validation.Min(1)),
+		validation.Field(&r.Currency, validation.Required, validation.In("USD", "EUR", "IDR")),
+	)
+}
+
+type beneficiarySuccessResponse struct {
+	Data db.Beneficiary `json:"data"`
+}
+
+// CreateBeneficiary saves an account the authenticated user wants to pay
+// to. Large payments to it are capped until its cooling-off period is over.
+func (s *Server) CreateBeneficiary(c echo.Context) error {
+	req := new(createBeneficiaryRequest)
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	account, err := s.validAccount(c, req.AccountID, req.Currency)
+	if err != nil {
+		return err
+	}
+	if account.Status == db.AccountStatusClosed || account.AccountType == db.AccountTypePot {
+		return errCannotReceive
+	}
+
+	beneficiary, err := s.store.CreateBeneficiary(c.Request().Context(), db.CreateBeneficiaryParams{
+		OwnerID:         authPayload(c).UserID,
+		Nickname:        req.Nickname,
+		AccountID:       account.ID,
+		Currency:        account.Currency,
+		CoolingOffUntil: time.Now().Add(s.config.BeneficiaryCoolingOff),
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrUniqueViolation) {
+			return newError(http.StatusForbidden, CodeAlreadyExists, errors.New("a beneficiary with this nickname or account already exists"))
+		}
+		return err
+	}
+
+	return c.JSON(
+		http.StatusOK,
+		&beneficiarySuccessResponse{
+			Data: beneficiary,
+		},
+	)
+}
+
+type listBeneficiariesSuccessResponse struct {
+	Data []db.Beneficiary `json:"data"`
+}
+
+// ListBeneficiaries returns the beneficiaries of the authenticated user
+func (s *Server) ListBeneficiaries(c echo.Context) error {
+	beneficiaries, err := s.store.ListBeneficiaries(c.Request().Context(), authPayload(c).UserID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(
+		http.StatusOK,
+		&listBeneficiariesSuccessResponse{
+			Data: beneficiaries,
+		},
+	)
+}
+
+// DeleteBeneficiary removes a beneficiary of the authenticated user
+func (s *Server) DeleteBeneficiary(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return invalidParam("id", err)
+	}
+
+	if _, err := s.ownBeneficiary(c, id); err != nil {
+		return err
+	}
+
+	beneficiary, err := s.store.DeleteBeneficiary(c.Request().Context(), id)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(
+		http.StatusOK,
+		&beneficiarySuccessResponse{
+			Data: beneficiary,
+		},
+	)
+}
+
+// ownBeneficiary loads a beneficiary of the authenticated user
+func (s *Server) ownBeneficiary(c echo.Context, id int64) (db.Beneficiary, error) {
+	beneficiary, err := s.store.GetBeneficiary(c.Request().Context(), id)
+	if err != nil {
+		return beneficiary, notFound(CodeBeneficiaryNotFound, err)
+	}
+
+	if beneficiary.OwnerID != authPayload(c).UserID {
+		return beneficiary, errBeneficiaryNotOwned
+	}
+
+	return beneficiary, nil
+}
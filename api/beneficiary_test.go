@@ -0,0 +1,343 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mocks "github.com/flukis/simplebank/db/mock"
+	db "github.com/flukis/simplebank/db/sqlc"
+	"github.com/flukis/simplebank/util"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBeneficiaryAPI(t *testing.T) {
+	from := randomAccount()
+	from.Tier = "standard"
+	to := randomAccount()
+	to.Currency = from.Currency
+
+	coolingOff := 24 * time.Hour
+	beneficiary := db.Beneficiary{
+		ID:              util.GenRandomNum(1, 10000),
+		OwnerID:         from.OwnerID,
+		Nickname:        "Landlord",
+		AccountID:       to.ID,
+		Currency:        to.Currency,
+		CoolingOffUntil: time.Now().Add(coolingOff),
+	}
+	cooled := beneficiary
+	cooled.CoolingOffUntil = time.Now().Add(-time.Hour)
+	limit := db.TransferLimit{
+		Tier:                   from.Tier,
+		Currency:               from.Currency,
+		NewPayeeMaxPerTransfer: 1000,
+	}
+	coolingOffErr := beneficiary.CheckCoolingOff(limit, 0, 1001, time.Now())
+	transfer := generateTransferResult(from, to, 1001)
+
+	testCases := []struct {
+		name   string
+		method string
+		url    string
+		body   any
+		userID uuid.UUID
+		build  func(store *mocks.Store)
+		check  func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "CreateOK",
+			method: http.MethodPost,
+			url:    "/beneficiaries/",
+			body: createBeneficiaryRequest{
+				Nickname:  beneficiary.Nickname,
+				AccountID: to.ID,
+				Currency:  to.Currency,
+			},
+			userID: from.OwnerID,
+			build: func(store *mocks.Store) {
+				store.On("GetAccount", mock.Anything, to.ID).
+					Return(to, nil).
+					Once()
+				store.On("CreateBeneficiary", mock.Anything, mock.MatchedBy(func(arg db.CreateBeneficiaryParams) bool {
+					return arg.OwnerID == from.OwnerID &&
+						arg.Nickname == beneficiary.Nickname &&
+						arg.AccountID == to.ID &&
+						time.Until(arg.CoolingOffUntil) > coolingOff-time.Minute
+				})).
+					Return(beneficiary, nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+
+				var res beneficiarySuccessResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+				require.Equal(t, beneficiary.ID, res.Data.ID)
+			},
+		},
+		{
+			name:   "CreateCurrencyMismatch",
+			method: http.MethodPost,
+			url:    "/beneficiaries/",
+			body: createBeneficiaryRequest{
+				Nickname:  beneficiary.Nickname,
+				AccountID: to.ID,
+				Currency:  otherCurrency(to.Currency),
+			},
+			userID: from.OwnerID,
+			build: func(store *mocks.Store) {
+				store.On("GetAccount", mock.Anything, to.ID).
+					Return(to, nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			name:   "CreateDuplicate",
+			method: http.MethodPost,
+			url:    "/beneficiaries/",
+			body: createBeneficiaryRequest{
+				Nickname:  beneficiary.Nickname,
+				AccountID: to.ID,
+				Currency:  to.Currency,
+			},
+			userID: from.OwnerID,
+			build: func(store *mocks.Store) {
+				store.On("GetAccount", mock.Anything, to.ID).
+					Return(to, nil).
+					Once()
+				store.On("CreateBeneficiary", mock.Anything, mock.Anything).
+					Return(db.Beneficiary{}, db.ErrUniqueViolation).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, rec.Code)
+			},
+		},
+		{
+			name:   "List",
+			method: http.MethodGet,
+			url:    "/beneficiaries/",
+			userID: from.OwnerID,
+			build: func(store *mocks.Store) {
+				store.On("ListBeneficiaries", mock.Anything, from.OwnerID).
+					Return([]db.Beneficiary{beneficiary}, nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+
+				var res listBeneficiariesSuccessResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+				require.Len(t, res.Data, 1)
+			},
+		},
+		{
+			name:   "DeleteNotOwned",
+			method: http.MethodDelete,
+			url:    fmt.Sprintf("/beneficiaries/%d", beneficiary.ID),
+			userID: uuid.New(),
+			build: func(store *mocks.Store) {
+				store.On("GetBeneficiary", mock.Anything, beneficiary.ID).
+					Return(beneficiary, nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, rec.Code)
+			},
+		},
+		{
+			name:   "DeleteOK",
+			method: http.MethodDelete,
+			url:    fmt.Sprintf("/beneficiaries/%d", beneficiary.ID),
+			userID: from.OwnerID,
+			build: func(store *mocks.Store) {
+				store.On("GetBeneficiary", mock.Anything, beneficiary.ID).
+					Return(beneficiary, nil).
+					Once()
+				store.On("DeleteBeneficiary", mock.Anything, beneficiary.ID).
+					Return(beneficiary, nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+			},
+		},
+		{
+			name:   "TransferCoolingOff",
+			method: http.MethodPost,
+			url:    "/account/transfer",
+			body: createTransferRequest{
+				FromAccountID: from.ID,
+				BeneficiaryID: beneficiary.ID,
+				Currency:      from.Currency,
+				Amount:        1001,
+			},
+			userID: from.OwnerID,
+			build: func(store *mocks.Store) {
+				store.On("GetBeneficiary", mock.Anything, beneficiary.ID).
+					Return(beneficiary, nil).
+					Once()
+				store.On("GetAccount", mock.Anything, from.ID).
+					Return(from, nil).
+					Once()
+				store.On("GetAccount", mock.Anything, to.ID).
+					Return(to, nil).
+					Once()
+				store.On("TransferTx", mock.Anything, db.TransferTxParams{
+					FromAccountID: from.ID,
+					ToAccountID:   to.ID,
+					Amount:        1001,
+				}).
+					Return(db.TransferTxResult{}, coolingOffErr).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, rec.Code)
+
+				var res Problem
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+				require.Equal(t, CodeTransferLimit, res.Code)
+				require.Equal(t, db.LimitNewPayee, res.Limit.Limit)
+			},
+		},
+		{
+			name:   "TransferAfterCoolingOff",
+			method: http.MethodPost,
+			url:    "/account/transfer",
+			body: createTransferRequest{
+				FromAccountID: from.ID,
+				BeneficiaryID: cooled.ID,
+				Currency:      from.Currency,
+				Amount:        1001,
+			},
+			userID: from.OwnerID,
+			build: func(store *mocks.Store) {
+				store.On("GetBeneficiary", mock.Anything, cooled.ID).
+					Return(cooled, nil).
+					Once()
+				store.On("GetAccount", mock.Anything, from.ID).
+					Return(from, nil).
+					Once()
+				store.On("GetAccount", mock.Anything, to.ID).
+					Return(to, nil).
+					Once()
+				store.On("TransferTx", mock.Anything, db.TransferTxParams{
+					FromAccountID: from.ID,
+					ToAccountID:   to.ID,
+					Amount:        1001,
+				}).
+					Return(transfer, nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+			},
+		},
+		{
+			name:   "TransferOtherUsersBeneficiary",
+			method: http.MethodPost,
+			url:    "/account/transfer",
+			body: createTransferRequest{
+				FromAccountID: from.ID,
+				BeneficiaryID: beneficiary.ID,
+				Currency:      from.Currency,
+				Amount:        10,
+			},
+			userID: uuid.New(),
+			build: func(store *mocks.Store) {
+				store.On("GetBeneficiary", mock.Anything, beneficiary.ID).
+					Return(beneficiary, nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, rec.Code)
+			},
+		},
+		{
+			name:   "TransferUnknownBeneficiary",
+			method: http.MethodPost,
+			url:    "/account/transfer",
+			body: createTransferRequest{
+				FromAccountID: from.ID,
+				BeneficiaryID: beneficiary.ID,
+				Currency:      from.Currency,
+				Amount:        10,
+			},
+			userID: from.OwnerID,
+			build: func(store *mocks.Store) {
+				store.On("GetBeneficiary", mock.Anything, beneficiary.ID).
+					Return(db.Beneficiary{}, sql.ErrNoRows).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, rec.Code)
+			},
+		},
+		{
+			name:   "TransferAccountAndBeneficiary",
+			method: http.MethodPost,
+			url:    "/account/transfer",
+			body: createTransferRequest{
+				FromAccountID: from.ID,
+				ToAccountID:   to.ID,
+				BeneficiaryID: beneficiary.ID,
+				Currency:      from.Currency,
+				Amount:        10,
+			},
+			userID: from.OwnerID,
+			build:  func(store *mocks.Store) {},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		ts := testCases[i]
+
+		t.Run(ts.name, func(t *testing.T) {
+			store := &mocks.Store{}
+			ts.build(store)
+
+			server, err := NewServer(store, util.Config{
+				TokenSymetricKey:      "12345678901234567890123456789012",
+				AccessTokenDuration:   time.Minute,
+				BeneficiaryCoolingOff: coolingOff,
+			})
+			require.NoError(t, err)
+			rec := httptest.NewRecorder()
+
+			data, err := json.Marshal(ts.body)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(ts.method, ts.url, bytes.NewReader(data))
+			require.NoError(t, err)
+			req.Header = http.Header{
+				"Content-Type": {"application/json"},
+			}
+			addAuthorization(t, req, server.tokenMaker, "Bearer", ts.userID, util.RoleCustomer, time.Minute)
+
+			server.router.ServeHTTP(rec, req)
+			ts.check(t, rec)
+			store.AssertExpectations(t)
+		})
+	}
+}
+
+func otherCurrency(currency string) string {
+	if currency == "USD" {
+		return "EUR"
+	}
+	return "USD"
+}
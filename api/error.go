@@ -0,0 +1,359 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strings"
+
+	db "github.com/flukis/simplebank/db/sqlc"
+	"github.com/flukis/simplebank/jobs"
+	"github.com/flukis/simplebank/util"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/labstack/echo/v4"
+)
+
+// Codes of the errors the API answers with. Clients may rely on them, unlike
+// on the detail that comes with them.
+const (
+	CodeBadRequest       = "BAD_REQUEST"
+	CodeValidationFailed = "VALIDATION_FAILED"
+	CodeUnauthorized     = "UNAUTHORIZED"
+	CodeForbidden        = "FORBIDDEN"
+	CodeNotFound         = "NOT_FOUND"
+	CodeMethodNotAllowed = "METHOD_NOT_ALLOWED"
+	CodeAlreadyExists    = "ALREADY_EXISTS"
+	CodeInvalidReference = "INVALID_REFERENCE"
+	CodeInternal         = "INTERNAL_ERROR"
+
+	CodeIdempotencyKeyReused     = "IDEMPOTENCY_KEY_REUSED"
+	CodeIdempotencyKeyInProgress = "IDEMPOTENCY_KEY_IN_PROGRESS"
+
+	CodeUserNotFound  = "USER_NOT_FOUND"
+	CodeWrongPassword = "WRONG_PASSWORD"
+
+	CodeAccountNotFound      = "ACCOUNT_NOT_FOUND"
+	CodeAccountNotOwned      = "ACCOUNT_NOT_OWNED"
+	CodeAccountNotActive     = "ACCOUNT_NOT_ACTIVE"
+	CodeAccountTransition    = "ACCOUNT_TRANSITION_NOT_ALLOWED"
+	CodeAccountBalance       = "ACCOUNT_BALANCE_NOT_ALLOWED"
+	CodeAccountHasPots       = "ACCOUNT_HAS_POTS"
+	CodeAccountNumberInvalid = "ACCOUNT_NUMBER_INVALID"
+	CodeCurrencyMismatch     = "CURRENCY_MISMATCH"
+	CodeInsufficientFunds    = "INSUFFICIENT_FUNDS"
+	CodeTransferLimit        = "TRANSFER_LIMIT_EXCEEDED"
+	CodeSpendLimit           = "SPEND_LIMIT_EXCEEDED"
+	CodeSameAccount          = "SAME_ACCOUNT"
+
+	CodeHolderNotFound = "HOLDER_NOT_FOUND"
+	CodePotHolder      = "POT_HOLDER"
+	CodeOwnerHolder    = "OWNER_HOLDER"
+
+	CodePotNotFound       = "POT_NOT_FOUND"
+	CodePotTransfer       = "POT_TRANSFER_NOT_ALLOWED"
+	CodeNestedPot         = "NESTED_POT"
+	CodeNotPotOfAccount   = "NOT_POT_OF_ACCOUNT"
+	CodeRoundUpPotExists  = "ROUND_UP_POT_EXISTS"
+	CodeTransferNotFound  = "TRANSFER_NOT_FOUND"
+	CodeApprovalNotFound  = "APPROVAL_NOT_FOUND"
+	CodeApprovalDecided   = "APPROVAL_DECIDED"
+	CodeSelfApproval      = "SELF_APPROVAL"
+	CodeApprovalNotHolder = "APPROVAL_NOT_ALLOWED"
+
+	CodeBeneficiaryNotFound = "BENEFICIARY_NOT_FOUND"
+	CodeBeneficiaryNotOwned = "BENEFICIARY_NOT_OWNED"
+
+	CodePaymentRequestNotFound = "PAYMENT_REQUEST_NOT_FOUND"
+	CodePaymentRequestDecided  = "PAYMENT_REQUEST_DECIDED"
+	CodePaymentRequestExpired  = "PAYMENT_REQUEST_EXPIRED"
+	CodePaymentRequestNotParty = "PAYMENT_REQUEST_NOT_PARTY"
+	CodePaymentRequestNotPayer = "PAYMENT_REQUEST_NOT_PAYER"
+	CodePaymentRequestSelf     = "PAYMENT_REQUEST_SELF"
+	CodeSplitTooSmall          = "SPLIT_TOO_SMALL"
+
+	CodeQRInvalid        = "QR_INVALID"
+	CodeQRAmountMismatch = "QR_AMOUNT_MISMATCH"
+
+	CodeTillNotFound          = "TILL_NOT_FOUND"
+	CodeTillNotOwned          = "TILL_NOT_OWNED"
+	CodeTillNotOpen           = "TILL_NOT_OPEN"
+	CodeInsufficientTillCash  = "INSUFFICIENT_TILL_CASH"
+	CodeTermNotOffered        = "TERM_NOT_OFFERED"
+	CodeTermDepositNotFound   = "TERM_DEPOSIT_NOT_FOUND"
+	CodeTermDepositClosed     = "TERM_DEPOSIT_CLOSED"
+	CodeLoanNotFound          = "LOAN_NOT_FOUND"
+	CodeBusinessDayOpen       = "BUSINESS_DAY_OPEN"
+	CodeAccountReceiveBlocked = "ACCOUNT_CANNOT_RECEIVE"
+)
+
+// problemType is the prefix of the type of every problem, followed by its
+// code in kebab case
+const problemType = "urn:simplebank:problem:"
+
+// Problem is the RFC 7807 body of every error response
+type Problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Code     string `json:"code"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+	// what is wrong with each invalid field, for VALIDATION_FAILED
+	Errors map[string]string `json:"errors,omitempty"`
+	// the limit that was hit, for TRANSFER_LIMIT_EXCEEDED
+	Limit *db.TransferLimitError `json:"limit,omitempty"`
+}
+
+// Error is an error with the status and code it is answered with. Handlers
+// return it, or any error errorCode knows, and leave the response to
+// HTTPErrorHandler.
+type Error struct {
+	Status int
+	Code   string
+	// shown instead of the message of Err when set
+	Detail string
+	Fields map[string]string
+	Err    error
+}
+
+func newError(status int, code string, err error) *Error {
+	return &Error{
+		Status: status,
+		Code:   code,
+		Err:    err,
+	}
+}
+
+func (e *Error) Error() string {
+	if e.Err == nil {
+		return e.Code
+	}
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// notFound answers a missing row with code, with a detail of its own rather
+// than the one of database/sql. Other errors are returned as they are.
+func notFound(code string, err error) error {
+	if !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	return &Error{
+		Status: http.StatusNotFound,
+		Code:   code,
+		Detail: strings.ToLower(strings.ReplaceAll(code, "_", " ")),
+		Err:    err,
+	}
+}
+
+// invalidParam answers a path param that doesn't parse
+func invalidParam(name string, err error) error {
+	return &Error{
+		Status: http.StatusBadRequest,
+		Code:   CodeValidationFailed,
+		Detail: "invalid " + name,
+		Fields: map[string]string{
+			name: "is not valid",
+		},
+		Err: err,
+	}
+}
+
+type knownError struct {
+	err    error
+	status int
+	code   string
+}
+
+// knownErrors are sentinel errors that are answered the same wherever they
+// come from
+var knownErrors = []knownError{
+	{errAccountNotOwned, http.StatusForbidden, CodeAccountNotOwned},
+	{errSpendLimit, http.StatusForbidden, CodeSpendLimit},
+	{errPotHolder, http.StatusForbidden, CodePotHolder},
+	{errOwnerHolder, http.StatusBadRequest, CodeOwnerHolder},
+	{errHolderExists, http.StatusForbidden, CodeAlreadyExists},
+	{errBeneficiaryNotOwned, http.StatusForbidden, CodeBeneficiaryNotOwned},
+	{errPaymentRequestNotParty, http.StatusForbidden, CodePaymentRequestNotParty},
+	{errPaymentRequestNotPayer, http.StatusForbidden, CodePaymentRequestNotPayer},
+	{errPaymentRequestSelf, http.StatusBadRequest, CodePaymentRequestSelf},
+	{errSplitTooSmall, http.StatusBadRequest, CodeSplitTooSmall},
+	{errQRAmountMismatch, http.StatusBadRequest, CodeQRAmountMismatch},
+	{errTillNotOwned, http.StatusForbidden, CodeTillNotOwned},
+	{errSameAccount, http.StatusBadRequest, CodeSameAccount},
+	{errCannotReceive, http.StatusForbidden, CodeAccountReceiveBlocked},
+
+	{jobs.ErrBusinessDayOpen, http.StatusBadRequest, CodeBusinessDayOpen},
+	{util.ErrWrongPassword, http.StatusUnauthorized, CodeWrongPassword},
+	{util.ErrAccountNumberFormat, http.StatusBadRequest, CodeAccountNumberInvalid},
+	{util.ErrAccountNumberCheck, http.StatusBadRequest, CodeAccountNumberInvalid},
+	{util.ErrQRFormat, http.StatusBadRequest, CodeQRInvalid},
+	{util.ErrQRCRC, http.StatusBadRequest, CodeQRInvalid},
+	{util.ErrQRAccount, http.StatusBadRequest, CodeQRInvalid},
+	{util.ErrQRCurrency, http.StatusBadRequest, CodeQRInvalid},
+
+	{db.ErrAccountHasPots, http.StatusForbidden, CodeAccountHasPots},
+	{db.ErrPotTransfer, http.StatusForbidden, CodePotTransfer},
+	{db.ErrNestedPot, http.StatusForbidden, CodeNestedPot},
+	{db.ErrNotPotOfAccount, http.StatusForbidden, CodeNotPotOfAccount},
+	{db.ErrRoundUpPotExists, http.StatusForbidden, CodeRoundUpPotExists},
+	{db.ErrApprovalDecided, http.StatusForbidden, CodeApprovalDecided},
+	{db.ErrSelfApproval, http.StatusForbidden, CodeSelfApproval},
+	{db.ErrPaymentRequestDecided, http.StatusForbidden, CodePaymentRequestDecided},
+	{db.ErrPaymentRequestExpired, http.StatusForbidden, CodePaymentRequestExpired},
+	{db.ErrTillNotOpen, http.StatusForbidden, CodeTillNotOpen},
+	{db.ErrInsufficientTillCash, http.StatusForbidden, CodeInsufficientTillCash},
+	{db.ErrTermNotOffered, http.StatusBadRequest, CodeTermNotOffered},
+	{db.ErrTermDepositClosed, http.StatusForbidden, CodeTermDepositClosed},
+}
+
+// errorCode returns the status and code err is answered with
+func errorCode(err error) (int, string) {
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr.Status, apiErr.Code
+	}
+
+	for _, known := range knownErrors {
+		if errors.Is(err, known.err) {
+			return known.status, known.code
+		}
+	}
+
+	var (
+		httpErr       *echo.HTTPError
+		validationErr validation.Errors
+		statusErr     *db.AccountStatusError
+		transitionErr *db.AccountTransitionError
+		balanceErr    *db.AccountBalanceError
+		fundsErr      *db.InsufficientFundsError
+		limitErr      *db.TransferLimitError
+	)
+	switch {
+	case errors.As(err, &validationErr):
+		return http.StatusBadRequest, CodeValidationFailed
+	case errors.As(err, &statusErr):
+		return http.StatusForbidden, CodeAccountNotActive
+	case errors.As(err, &transitionErr):
+		return http.StatusForbidden, CodeAccountTransition
+	case errors.As(err, &balanceErr):
+		return http.StatusForbidden, CodeAccountBalance
+	case errors.As(err, &fundsErr):
+		return http.StatusForbidden, CodeInsufficientFunds
+	case errors.As(err, &limitErr):
+		return http.StatusForbidden, CodeTransferLimit
+	case errors.Is(err, sql.ErrNoRows):
+		return http.StatusNotFound, CodeNotFound
+	case errors.Is(err, db.ErrUniqueViolation):
+		return http.StatusForbidden, CodeAlreadyExists
+	case errors.Is(err, db.ErrForeignKeyViolation):
+		return http.StatusForbidden, CodeInvalidReference
+	case errors.As(err, &httpErr):
+		switch httpErr.Code {
+		case http.StatusBadRequest:
+			return httpErr.Code, CodeBadRequest
+		case http.StatusUnauthorized:
+			return httpErr.Code, CodeUnauthorized
+		case http.StatusForbidden:
+			return httpErr.Code, CodeForbidden
+		case http.StatusNotFound:
+			return httpErr.Code, CodeNotFound
+		case http.StatusMethodNotAllowed:
+			return httpErr.Code, CodeMethodNotAllowed
+		}
+		if httpErr.Code < http.StatusInternalServerError {
+			return httpErr.Code, strings.ToUpper(strings.ReplaceAll(http.StatusText(httpErr.Code), " ", "_"))
+		}
+	}
+	return http.StatusInternalServerError, CodeInternal
+}
+
+// NewProblem returns the problem err is answered with. The detail of server
+// errors, database errors and missing rows is never shown.
+func NewProblem(err error) Problem {
+	status, code := errorCode(err)
+	problem := Problem{
+		Type:   problemType + strings.ToLower(strings.ReplaceAll(code, "_", "-")),
+		Title:  http.StatusText(status),
+		Status: status,
+		Code:   code,
+	}
+
+	var (
+		apiErr        *Error
+		httpErr       *echo.HTTPError
+		validationErr validation.Errors
+		limitErr      *db.TransferLimitError
+	)
+	switch {
+	case status >= http.StatusInternalServerError:
+	case errors.As(err, &apiErr) && apiErr.Detail != "":
+		problem.Detail = apiErr.Detail
+		problem.Errors = apiErr.Fields
+	case errors.As(err, &validationErr):
+		problem.Detail = "the request is invalid"
+		problem.Errors = validationFields(validationErr)
+	case errors.As(err, &httpErr):
+		if message, ok := httpErr.Message.(string); ok {
+			problem.Detail = message
+		}
+	case errors.Is(err, sql.ErrNoRows):
+		problem.Detail = "not found"
+	case errors.Is(err, db.ErrUniqueViolation), errors.Is(err, db.ErrForeignKeyViolation):
+		problem.Detail = "the request conflicts with existing data"
+	default:
+		problem.Detail = err.Error()
+		if apiErr != nil {
+			problem.Errors = apiErr.Fields
+		}
+	}
+
+	if errors.As(err, &limitErr) {
+		problem.Limit = limitErr
+	}
+	return problem
+}
+
+// validationFields flattens the errors of ozzo, naming nested fields with
+// dots
+func validationFields(errs validation.Errors) map[string]string {
+	fields := make(map[string]string, len(errs))
+	for name, err := range errs {
+		var nested validation.Errors
+		if errors.As(err, &nested) {
+			for nestedName, message := range validationFields(nested) {
+				fields[name+"."+nestedName] = message
+			}
+			continue
+		}
+		fields[name] = err.Error()
+	}
+	return fields
+}
+
+// HTTPErrorHandler answers the errors handlers and middleware return with a
+// problem+json body
+func (s *Server) HTTPErrorHandler(err error, c echo.Context) {
+	if c.Response().Committed {
+		return
+	}
+
+	problem := NewProblem(err)
+	problem.Instance = c.Request().URL.Path
+	if problem.Status >= http.StatusInternalServerError {
+		c.Logger().Error(err)
+	}
+
+	if c.Request().Method == http.MethodHead {
+		err = c.NoContent(problem.Status)
+	} else {
+		c.Response().Header().Set(echo.HeaderContentType, "application/problem+json")
+		err = c.JSON(problem.Status, problem)
+	}
+	if err != nil {
+		c.Logger().Error(err)
+	}
+}
@@ -0,0 +1,225 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mocks "github.com/flukis/simplebank/db/mock"
+	db "github.com/flukis/simplebank/db/sqlc"
+	"github.com/flukis/simplebank/util"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewProblem(t *testing.T) {
+	limitErr := &db.TransferLimitError{Limit: db.LimitDailyAmount, Max: 1000}
+
+	testCases := []struct {
+		name   string
+		err    error
+		status int
+		code   string
+		detail string
+	}{
+		{
+			name:   "KnownError",
+			err:    errAccountNotOwned,
+			status: http.StatusForbidden,
+			code:   CodeAccountNotOwned,
+			detail: errAccountNotOwned.Error(),
+		},
+		{
+			name:   "WrappedKnownError",
+			err:    fmt.Errorf("transfer: %w", db.ErrPotTransfer),
+			status: http.StatusForbidden,
+			code:   CodePotTransfer,
+			detail: "transfer: " + db.ErrPotTransfer.Error(),
+		},
+		{
+			name:   "NotFound",
+			err:    notFound(CodeAccountNotFound, sql.ErrNoRows),
+			status: http.StatusNotFound,
+			code:   CodeAccountNotFound,
+			detail: "account not found",
+		},
+		{
+			name:   "NoRows",
+			err:    sql.ErrNoRows,
+			status: http.StatusNotFound,
+			code:   CodeNotFound,
+			detail: "not found",
+		},
+		{
+			name:   "UniqueViolation",
+			err:    db.ErrUniqueViolation,
+			status: http.StatusForbidden,
+			code:   CodeAlreadyExists,
+			detail: "the request conflicts with existing data",
+		},
+		{
+			name:   "HTTPError",
+			err:    echo.NewHTTPError(http.StatusBadRequest, "malformed body"),
+			status: http.StatusBadRequest,
+			code:   CodeBadRequest,
+			detail: "malformed body",
+		},
+		{
+			name:   "TransferLimit",
+			err:    limitErr,
+			status: http.StatusForbidden,
+			code:   CodeTransferLimit,
+			detail: limitErr.Error(),
+		},
+		{
+			name:   "Internal",
+			err:    errors.New("connection refused"),
+			status: http.StatusInternalServerError,
+			code:   CodeInternal,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			problem := NewProblem(tc.err)
+			require.Equal(t, tc.status, problem.Status)
+			require.Equal(t, tc.code, problem.Code)
+			require.Equal(t, tc.detail, problem.Detail)
+			require.Equal(t, http.StatusText(tc.status), problem.Title)
+			require.Contains(t, problem.Type, problemType)
+		})
+	}
+}
+
+func TestNewProblemValidation(t *testing.T) {
+	err := validation.Errors{
+		"amount": errors.New("must be no less than 1"),
+		"payers": validation.Errors{
+			"0": validation.Errors{
+				"user_id": errors.New("cannot be blank"),
+			},
+		},
+	}
+
+	problem := NewProblem(err)
+	require.Equal(t, http.StatusBadRequest, problem.Status)
+	require.Equal(t, CodeValidationFailed, problem.Code)
+	require.Equal(t, map[string]string{
+		"amount":           "must be no less than 1",
+		"payers.0.user_id": "cannot be blank",
+	}, problem.Errors)
+}
+
+func TestNewProblemLimit(t *testing.T) {
+	limitErr := &db.TransferLimitError{Limit: db.LimitNewPayee, Max: 500}
+
+	problem := NewProblem(limitErr)
+	require.Equal(t, limitErr, problem.Limit)
+}
+
+func TestHTTPErrorHandler(t *testing.T) {
+	userID := uuid.New()
+
+	testCases := []struct {
+		name      string
+		method    string
+		url       string
+		body      string
+		setupAuth func(t *testing.T, req *http.Request, tokenMaker util.JWTMaker)
+		build     func(store *mocks.Store)
+		check     func(t *testing.T, problem Problem)
+	}{
+		{
+			name:      "Unauthorized",
+			method:    http.MethodGet,
+			url:       "/account/1",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker util.JWTMaker) {},
+			build:     func(store *mocks.Store) {},
+			check: func(t *testing.T, problem Problem) {
+				require.Equal(t, http.StatusUnauthorized, problem.Status)
+				require.Equal(t, CodeUnauthorized, problem.Code)
+				require.Equal(t, "/account/1", problem.Instance)
+			},
+		},
+		{
+			name:   "AccountNotFound",
+			method: http.MethodGet,
+			url:    "/account/1",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker util.JWTMaker) {
+				addAuthorization(t, req, tokenMaker, "Bearer", userID, util.RoleCustomer, time.Minute)
+			},
+			build: func(store *mocks.Store) {
+				store.On("GetAccount", mock.Anything, int64(1)).
+					Return(db.Account{}, sql.ErrNoRows).
+					Once()
+			},
+			check: func(t *testing.T, problem Problem) {
+				require.Equal(t, http.StatusNotFound, problem.Status)
+				require.Equal(t, CodeAccountNotFound, problem.Code)
+				require.NotContains(t, problem.Detail, "sql")
+			},
+		},
+		{
+			name:      "ValidationFailed",
+			method:    http.MethodPost,
+			url:       "/login",
+			body:      `{"username": "abc"}`,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker util.JWTMaker) {},
+			build:     func(store *mocks.Store) {},
+			check: func(t *testing.T, problem Problem) {
+				require.Equal(t, http.StatusBadRequest, problem.Status)
+				require.Equal(t, CodeValidationFailed, problem.Code)
+				require.Contains(t, problem.Errors, "username")
+				require.Contains(t, problem.Errors, "password")
+			},
+		},
+		{
+			name:      "RouteNotFound",
+			method:    http.MethodGet,
+			url:       "/nowhere",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker util.JWTMaker) {},
+			build:     func(store *mocks.Store) {},
+			check: func(t *testing.T, problem Problem) {
+				require.Equal(t, http.StatusNotFound, problem.Status)
+				require.Equal(t, CodeNotFound, problem.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			store := &mocks.Store{}
+			tc.build(store)
+
+			server, err := NewServer(store, util.Config{
+				TokenSymetricKey:    "12345678901234567890123456789012",
+				AccessTokenDuration: time.Minute,
+			})
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(tc.method, tc.url, bytes.NewBufferString(tc.body))
+			require.NoError(t, err)
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			tc.setupAuth(t, req, server.tokenMaker)
+
+			rec := httptest.NewRecorder()
+			server.router.ServeHTTP(rec, req)
+			require.Equal(t, "application/problem+json", rec.Header().Get(echo.HeaderContentType))
+
+			var problem Problem
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+			require.Equal(t, rec.Code, problem.Status)
+			tc.check(t, problem)
+			store.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	db "github.com/flukis/simplebank/db/sqlc"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+var (
+	errSpendLimit   = errors.New("the amount is above the spend limit of the authenticated user on this account")
+	errPotHolder    = errors.New("pots are held by the holders of their parent account")
+	errOwnerHolder  = errors.New("the owner cannot be added as a holder")
+	errHolderExists = errors.New("the user already holds this account")
+)
+
+// accountAccess returns what the authenticated user may do with account
+func (s *Server) accountAccess(c echo.Context, account db.Account) (db.AccountAccess, error) {
+	payload := authPayload(c)
+	return db.GetAccountAccess(c.Request().Context(), s.store, account, payload.UserID, payload.Role)
+}
+
+type addHolderRequest struct {
+	UserID      uuid.UUID `json:"user_id" binding:"required"`
+	CanInitiate bool      `json:"can_initiate"`
+	CanApprove  bool      `json:"can_approve"`
+	SpendLimit  int64     `json:"spend_limit" binding:"min=0"`
+}
+
+func (r addHolderRequest) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.UserID, validation.Required),
+		validation.Field(&r.SpendLimit, validation.Min(0)),
+	)
+}
+
+type addHolderSuccessResponse struct {
+	Data db.AccountHolder `json:"data"`
+}
+
+// AddAccountHolder lets another user use an account, e.g. a partner on a
+// joint account or an employee on a business account
+func (s *Server) AddAccountHolder(c echo.Context) error {
+	req := new(addHolderRequest)
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	account, err := s.holderAccount(c, db.PermManage)
+	if err != nil {
+		return err
+	}
+
+	if account.AccountType == db.AccountTypePot {
+		return errPotHolder
+	}
+	if req.UserID == account.OwnerID {
+		return errOwnerHolder
+	}
+
+	if _, err := s.store.GetUser(c.Request().Context(), req.UserID); err != nil {
+		return notFound(CodeUserNotFound, err)
+	}
+
+	holder, err := s.store.CreateAccountHolder(c.Request().Context(), db.CreateAccountHolderParams{
+		AccountID:   account.ID,
+		UserID:      req.UserID,
+		CanInitiate: req.CanInitiate,
+		CanApprove:  req.CanApprove,
+		SpendLimit:  req.SpendLimit,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrUniqueViolation) {
+			return errHolderExists
+		}
+		return err
+	}
+
+	return c.JSON(
+		http.StatusOK,
+		&addHolderSuccessResponse{
+			Data: holder,
+		},
+	)
+}
+
+type listHoldersSuccessResponse struct {
+	Data []db.AccountHolder `json:"data"`
+}
+
+// ListAccountHolders returns the users holding an account besides its owner
+func (s *Server) ListAccountHolders(c echo.Context) error {
+	account, err := s.holderAccount(c, db.PermView)
+	if err != nil {
+		return err
+	}
+
+	holders, err := s.store.ListAccountHolders(c.Request().Context(), account.ID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(
+		http.StatusOK,
+		&listHoldersSuccessResponse{
+			Data: holders,
+		},
+	)
+}
+
+type removeHolderSuccessResponse struct {
+	Data db.AccountHolder `json:"data"`
+}
+
+// RemoveAccountHolder takes away all rights a holder had on an account
+func (s *Server) RemoveAccountHolder(c echo.Context) error {
+	userID, err := uuid.Parse(c.Param("user_id"))
+	if err != nil {
+		return invalidParam("user_id", err)
+	}
+
+	account, err := s.holderAccount(c, db.PermManage)
+	if err != nil {
+		return err
+	}
+
+	holder, err := s.store.DeleteAccountHolder(c.Request().Context(), db.DeleteAccountHolderParams{
+		AccountID: account.ID,
+		UserID:    userID,
+	})
+	if err != nil {
+		return notFound(CodeHolderNotFound, err)
+	}
+
+	return c.JSON(
+		http.StatusOK,
+		&removeHolderSuccessResponse{
+			Data: holder,
+		},
+	)
+}
+
+// holderAccount loads the account of the :id param and checks that the
+// authenticated user has need on it
+func (s *Server) holderAccount(c echo.Context, need db.AccountPermission) (db.Account, error) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return db.Account{}, invalidParam("id", err)
+	}
+
+	account, err := s.store.GetAccount(c.Request().Context(), id)
+	if err != nil {
+		return account, notFound(CodeAccountNotFound, err)
+	}
+
+	access, err := s.accountAccess(c, account)
+	if err != nil {
+		return account, err
+	}
+	if !access.Can(need) {
+		return account, errAccountNotOwned
+	}
+
+	return account, nil
+}
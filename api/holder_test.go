@@ -0,0 +1,304 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mocks "github.com/flukis/simplebank/db/mock"
+	db "github.com/flukis/simplebank/db/sqlc"
+	"github.com/flukis/simplebank/util"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountHolderAPI(t *testing.T) {
+	account := randomAccount()
+	account.AccountType = db.AccountTypeBusiness
+	holder := db.AccountHolder{
+		AccountID:   account.ID,
+		UserID:      uuid.New(),
+		CanInitiate: true,
+		SpendLimit:  500,
+	}
+	holderArg := db.GetAccountHolderParams{
+		AccountID: account.ID,
+		UserID:    holder.UserID,
+	}
+
+	testCases := []struct {
+		name   string
+		method string
+		url    string
+		body   any
+		userID uuid.UUID
+		build  func(store *mocks.Store)
+		check  func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "AddOK",
+			method: http.MethodPost,
+			url:    fmt.Sprintf("/account/%d/holders", account.ID),
+			body: addHolderRequest{
+				UserID:      holder.UserID,
+				CanInitiate: holder.CanInitiate,
+				SpendLimit:  holder.SpendLimit,
+			},
+			userID: account.OwnerID,
+			build: func(store *mocks.Store) {
+				store.On("GetAccount", mock.Anything, account.ID).
+					Return(account, nil).
+					Once()
+				store.On("GetUser", mock.Anything, holder.UserID).
+					Return(db.User{ID: holder.UserID}, nil).
+					Once()
+				store.On("CreateAccountHolder", mock.Anything, db.CreateAccountHolderParams{
+					AccountID:   account.ID,
+					UserID:      holder.UserID,
+					CanInitiate: holder.CanInitiate,
+					SpendLimit:  holder.SpendLimit,
+				}).
+					Return(holder, nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+
+				var res addHolderSuccessResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+				require.Equal(t, holder, res.Data)
+			},
+		},
+		{
+			name:   "AddTwice",
+			method: http.MethodPost,
+			url:    fmt.Sprintf("/account/%d/holders", account.ID),
+			body:   addHolderRequest{UserID: holder.UserID},
+			userID: account.OwnerID,
+			build: func(store *mocks.Store) {
+				store.On("GetAccount", mock.Anything, account.ID).
+					Return(account, nil).
+					Once()
+				store.On("GetUser", mock.Anything, holder.UserID).
+					Return(db.User{ID: holder.UserID}, nil).
+					Once()
+				store.On("CreateAccountHolder", mock.Anything, mock.Anything).
+					Return(db.AccountHolder{}, db.ErrUniqueViolation).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, rec.Code)
+			},
+		},
+		{
+			name:   "AddOwner",
+			method: http.MethodPost,
+			url:    fmt.Sprintf("/account/%d/holders", account.ID),
+			body:   addHolderRequest{UserID: account.OwnerID},
+			userID: account.OwnerID,
+			build: func(store *mocks.Store) {
+				store.On("GetAccount", mock.Anything, account.ID).
+					Return(account, nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			name:   "AddUnknownUser",
+			method: http.MethodPost,
+			url:    fmt.Sprintf("/account/%d/holders", account.ID),
+			body:   addHolderRequest{UserID: holder.UserID},
+			userID: account.OwnerID,
+			build: func(store *mocks.Store) {
+				store.On("GetAccount", mock.Anything, account.ID).
+					Return(account, nil).
+					Once()
+				store.On("GetUser", mock.Anything, holder.UserID).
+					Return(db.User{}, sql.ErrNoRows).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, rec.Code)
+			},
+		},
+		{
+			name:   "AddByHolder",
+			method: http.MethodPost,
+			url:    fmt.Sprintf("/account/%d/holders", account.ID),
+			body:   addHolderRequest{UserID: uuid.New()},
+			userID: holder.UserID,
+			build: func(store *mocks.Store) {
+				store.On("GetAccount", mock.Anything, account.ID).
+					Return(account, nil).
+					Once()
+				store.On("GetAccountHolder", mock.Anything, holderArg).
+					Return(db.AccountHolder{CanInitiate: true, CanApprove: true}, nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, rec.Code)
+			},
+		},
+		{
+			name:   "ListByHolder",
+			method: http.MethodGet,
+			url:    fmt.Sprintf("/account/%d/holders", account.ID),
+			userID: holder.UserID,
+			build: func(store *mocks.Store) {
+				store.On("GetAccount", mock.Anything, account.ID).
+					Return(account, nil).
+					Once()
+				store.On("GetAccountHolder", mock.Anything, holderArg).
+					Return(holder, nil).
+					Once()
+				store.On("ListAccountHolders", mock.Anything, account.ID).
+					Return([]db.AccountHolder{holder}, nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+
+				var res listHoldersSuccessResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+				require.Equal(t, []db.AccountHolder{holder}, res.Data)
+			},
+		},
+		{
+			name:   "ListByStranger",
+			method: http.MethodGet,
+			url:    fmt.Sprintf("/account/%d/holders", account.ID),
+			userID: uuid.New(),
+			build: func(store *mocks.Store) {
+				store.On("GetAccount", mock.Anything, account.ID).
+					Return(account, nil).
+					Once()
+				store.On("GetAccountHolder", mock.Anything, mock.Anything).
+					Return(db.AccountHolder{}, sql.ErrNoRows).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, rec.Code)
+			},
+		},
+		{
+			name:   "RemoveOK",
+			method: http.MethodDelete,
+			url:    fmt.Sprintf("/account/%d/holders/%s", account.ID, holder.UserID),
+			userID: account.OwnerID,
+			build: func(store *mocks.Store) {
+				store.On("GetAccount", mock.Anything, account.ID).
+					Return(account, nil).
+					Once()
+				store.On("DeleteAccountHolder", mock.Anything, db.DeleteAccountHolderParams{
+					AccountID: account.ID,
+					UserID:    holder.UserID,
+				}).
+					Return(holder, nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+			},
+		},
+		{
+			name:   "RemoveNotHolder",
+			method: http.MethodDelete,
+			url:    fmt.Sprintf("/account/%d/holders/%s", account.ID, holder.UserID),
+			userID: account.OwnerID,
+			build: func(store *mocks.Store) {
+				store.On("GetAccount", mock.Anything, account.ID).
+					Return(account, nil).
+					Once()
+				store.On("DeleteAccountHolder", mock.Anything, mock.Anything).
+					Return(db.AccountHolder{}, sql.ErrNoRows).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, rec.Code)
+			},
+		},
+		{
+			name:   "RemoveBadUserID",
+			method: http.MethodDelete,
+			url:    fmt.Sprintf("/account/%d/holders/nobody", account.ID),
+			userID: account.OwnerID,
+			build:  func(store *mocks.Store) {},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			name:   "GetAccountByHolder",
+			method: http.MethodGet,
+			url:    fmt.Sprintf("/account/%d", account.ID),
+			userID: holder.UserID,
+			build: func(store *mocks.Store) {
+				store.On("GetAccount", mock.Anything, account.ID).
+					Return(account, nil).
+					Once()
+				store.On("GetAccountHolder", mock.Anything, holderArg).
+					Return(holder, nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+			},
+		},
+		{
+			name:   "CloseAccountByHolder",
+			method: http.MethodPost,
+			url:    fmt.Sprintf("/account/%d/close", account.ID),
+			body:   closeAccountRequest{},
+			userID: holder.UserID,
+			build: func(store *mocks.Store) {
+				store.On("GetAccount", mock.Anything, account.ID).
+					Return(account, nil).
+					Once()
+				store.On("GetAccountHolder", mock.Anything, holderArg).
+					Return(db.AccountHolder{CanInitiate: true, CanApprove: true}, nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, rec.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		ts := testCases[i]
+
+		t.Run(ts.name, func(t *testing.T) {
+			store := &mocks.Store{}
+			ts.build(store)
+
+			server, err := NewServer(store, util.Config{
+				TokenSymetricKey:    "12345678901234567890123456789012",
+				AccessTokenDuration: time.Minute,
+			})
+			require.NoError(t, err)
+			rec := httptest.NewRecorder()
+
+			data, err := json.Marshal(ts.body)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(ts.method, ts.url, bytes.NewReader(data))
+			require.NoError(t, err)
+			req.Header = http.Header{
+				"Content-Type": {"application/json"},
+			}
+			addAuthorization(t, req, server.tokenMaker, "Bearer", ts.userID, util.RoleCustomer, time.Minute)
+
+			server.router.ServeHTTP(rec, req)
+			ts.check(t, rec)
+			store.AssertExpectations(t)
+		})
+	}
+}
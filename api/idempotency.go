@@ -0,0 +1,188 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	db "github.com/flukis/simplebank/db/sqlc"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// IdempotencyKeyHeader carries the key a client sends with every attempt
+	// of one request
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader is set on responses replayed for a key
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLength = 255
+	// idempotencyKeyLease is how long a request may handle its key before
+	// another one sent with it may take over, when the first is taken for
+	// lost with the process that was handling it
+	idempotencyKeyLease = 5 * time.Minute
+)
+
+var (
+	errIdempotencyKeyLength     = newError(http.StatusBadRequest, CodeBadRequest, errors.New("the idempotency key is too long"))
+	errIdempotencyKeyReused     = newError(http.StatusUnprocessableEntity, CodeIdempotencyKeyReused, errors.New("the idempotency key was sent with another request"))
+	errIdempotencyKeyInProgress = newError(http.StatusConflict, CodeIdempotencyKeyInProgress, errors.New("the request with the idempotency key is still being handled"))
+)
+
+// Idempotency answers a request sent again with the Idempotency-Key of an
+// earlier one with the response to the earlier one, so a client that lost a
+// response can retry without the request running twice. Keys are per user.
+// Server errors are not kept, so the request can be retried after one, and
+// a key whose request never answered can be taken over once its lease is
+// up. It must be registered after AuthMiddleware and before any middleware
+// that rewrites the request.
+func (s *Server) Idempotency(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := c.Request()
+		key := req.Header.Get(IdempotencyKeyHeader)
+		payload := authPayload(c)
+		if key == "" || payload == nil || req.Method == http.MethodGet || req.Method == http.MethodHead {
+			return next(c)
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			return errIdempotencyKeyLength
+		}
+
+		hash, err := requestHash(req)
+		if err != nil {
+			return newError(http.StatusBadRequest, CodeBadRequest, err)
+		}
+
+		saved, claimed, err := s.claimIdempotencyKey(req.Context(), db.CreateIdempotencyKeyParams{
+			UserID:      payload.UserID,
+			Key:         key,
+			RequestHash: hash,
+		})
+		if err != nil {
+			return err
+		}
+		if !claimed {
+			return replay(c, saved)
+		}
+
+		res := c.Response()
+		recorder := &responseRecorder{ResponseWriter: res.Writer}
+		res.Writer = recorder
+		if err := next(c); err != nil {
+			// answered here, so the body of the error is kept too
+			c.Error(err)
+		}
+		res.Writer = recorder.ResponseWriter
+
+		// kept even when the client is gone, it may retry
+		ctx := context.Background()
+		if res.Status >= http.StatusInternalServerError {
+			err = s.store.DeleteIdempotencyKey(ctx, db.DeleteIdempotencyKeyParams{
+				UserID: payload.UserID,
+				Key:    key,
+			})
+		} else {
+			_, err = s.store.SaveIdempotentResponse(ctx, db.SaveIdempotentResponseParams{
+				UserID:       payload.UserID,
+				Key:          key,
+				StatusCode:   int32(res.Status),
+				ContentType:  res.Header().Get(echo.HeaderContentType),
+				ResponseBody: recorder.body.Bytes(),
+			})
+		}
+		if err != nil {
+			c.Logger().Error(err)
+		}
+		return nil
+	}
+}
+
+// claimIdempotencyKey makes the request the one handling its key, taking
+// the key over when the request that had it is gone past its lease. When
+// another request answered for the key, its response is returned instead.
+func (s *Server) claimIdempotencyKey(ctx context.Context, arg db.CreateIdempotencyKeyParams) (db.IdempotencyKey, bool, error) {
+	saved, err := s.store.CreateIdempotencyKey(ctx, arg)
+	if err == nil {
+		return saved, true, nil
+	}
+	if !errors.Is(err, db.ErrUniqueViolation) {
+		return saved, false, err
+	}
+
+	saved, err = s.store.GetIdempotencyKey(ctx, db.GetIdempotencyKeyParams{
+		UserID: arg.UserID,
+		Key:    arg.Key,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		// the first request failed with a server error in the meantime
+		return saved, false, errIdempotencyKeyInProgress
+	}
+	if err != nil {
+		return saved, false, err
+	}
+
+	switch {
+	case saved.RequestHash != arg.RequestHash:
+		return saved, false, errIdempotencyKeyReused
+	case saved.StatusCode != 0:
+		return saved, false, nil
+	}
+
+	saved, err = s.store.TakeOverIdempotencyKey(ctx, db.TakeOverIdempotencyKeyParams{
+		UserID:        arg.UserID,
+		Key:           arg.Key,
+		StartedBefore: time.Now().Add(-idempotencyKeyLease),
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		// still within its lease, or taken over by another retry first
+		return saved, false, errIdempotencyKeyInProgress
+	}
+	if err != nil {
+		return saved, false, err
+	}
+	return saved, true, nil
+}
+
+// replay answers a request with the response kept for its key
+func replay(c echo.Context, saved db.IdempotencyKey) error {
+	c.Response().Header().Set(IdempotentReplayedHeader, "true")
+	if saved.ContentType == "" {
+		return c.NoContent(int(saved.StatusCode))
+	}
+	return c.Blob(int(saved.StatusCode), saved.ContentType, saved.ResponseBody)
+}
+
+// requestHash sums up the method, path and body of req, leaving the body to
+// be read again
+func requestHash(req *http.Request) (string, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return "", err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	sum := sha256.New()
+	sum.Write([]byte(req.Method + " " + req.URL.RequestURI() + "\n"))
+	sum.Write(body)
+	return hex.EncodeToString(sum.Sum(nil)), nil
+}
+
+// responseRecorder keeps a copy of the body written through it
+type responseRecorder struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
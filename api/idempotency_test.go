@@ -0,0 +1,239 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	mocks "github.com/flukis/simplebank/db/mock"
+	db "github.com/flukis/simplebank/db/sqlc"
+	"github.com/flukis/simplebank/util"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdempotencyMiddleware(t *testing.T) {
+	store := db.NewMemoryStore()
+	user, err := store.CreateUser(context.Background(), db.CreateUserParams{
+		Username:       util.GenRandomOwner(),
+		HashedPassword: "secret",
+		FullName:       util.GenRandomOwner(),
+		Email:          util.GenRandomEmail(),
+	})
+	require.NoError(t, err)
+
+	server, err := NewServer(store, util.Config{
+		TokenSymetricKey:    "12345678901234567890123456789012",
+		AccessTokenDuration: time.Minute,
+	})
+	require.NoError(t, err)
+
+	// answers with the number of times it ran, failing as the request asks
+	calls := 0
+	server.router.POST("/idempotent", func(c echo.Context) error {
+		calls++
+		switch c.QueryParam("fail") {
+		case "server":
+			if calls == 1 {
+				return errors.New("lost connection")
+			}
+		case "client":
+			return newError(http.StatusForbidden, CodeForbidden, errors.New("not allowed"))
+		}
+		return c.JSON(http.StatusCreated, map[string]int{"calls": calls})
+	}, server.AuthMiddleware, server.Idempotency)
+
+	send := func(t *testing.T, url, key, body string) *httptest.ResponseRecorder {
+		req, err := http.NewRequest(http.MethodPost, url, strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		if key != "" {
+			req.Header.Set(IdempotencyKeyHeader, key)
+		}
+		addAuthorization(t, req, server.tokenMaker, "Bearer", user.ID, util.RoleCustomer, time.Minute)
+
+		rec := httptest.NewRecorder()
+		server.router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("Replays", func(t *testing.T) {
+		calls = 0
+		key := uuid.NewString()
+
+		first := send(t, "/idempotent", key, `{"amount":1}`)
+		require.Equal(t, http.StatusCreated, first.Code)
+		require.Empty(t, first.Header().Get(IdempotentReplayedHeader))
+
+		again := send(t, "/idempotent", key, `{"amount":1}`)
+		require.Equal(t, http.StatusCreated, again.Code)
+		require.Equal(t, "true", again.Header().Get(IdempotentReplayedHeader))
+		require.Equal(t, first.Header().Get(echo.HeaderContentType), again.Header().Get(echo.HeaderContentType))
+		require.Equal(t, first.Body.String(), again.Body.String())
+		require.Equal(t, 1, calls)
+	})
+
+	t.Run("WithoutKey", func(t *testing.T) {
+		calls = 0
+		send(t, "/idempotent", "", `{"amount":1}`)
+		send(t, "/idempotent", "", `{"amount":1}`)
+		require.Equal(t, 2, calls)
+	})
+
+	t.Run("KeyReused", func(t *testing.T) {
+		calls = 0
+		key := uuid.NewString()
+
+		require.Equal(t, http.StatusCreated, send(t, "/idempotent", key, `{"amount":1}`).Code)
+		rec := send(t, "/idempotent", key, `{"amount":2}`)
+		require.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		require.Contains(t, rec.Body.String(), CodeIdempotencyKeyReused)
+		require.Equal(t, 1, calls)
+	})
+
+	t.Run("ClientErrorReplayed", func(t *testing.T) {
+		calls = 0
+		key := uuid.NewString()
+
+		first := send(t, "/idempotent?fail=client", key, `{}`)
+		require.Equal(t, http.StatusForbidden, first.Code)
+		again := send(t, "/idempotent?fail=client", key, `{}`)
+		require.Equal(t, http.StatusForbidden, again.Code)
+		require.Equal(t, "application/problem+json", again.Header().Get(echo.HeaderContentType))
+		require.Equal(t, first.Body.String(), again.Body.String())
+		require.Equal(t, 1, calls)
+	})
+
+	t.Run("ServerErrorRetried", func(t *testing.T) {
+		calls = 0
+		key := uuid.NewString()
+
+		require.Equal(t, http.StatusInternalServerError, send(t, "/idempotent?fail=server", key, `{}`).Code)
+		rec := send(t, "/idempotent?fail=server", key, `{}`)
+		require.Equal(t, http.StatusCreated, rec.Code)
+		require.Empty(t, rec.Header().Get(IdempotentReplayedHeader))
+		require.Equal(t, 2, calls)
+	})
+
+	t.Run("InProgress", func(t *testing.T) {
+		calls = 0
+		key := uuid.NewString()
+
+		req := httptest.NewRequest(http.MethodPost, "/idempotent", strings.NewReader(`{}`))
+		hash, err := requestHash(req)
+		require.NoError(t, err)
+		_, err = store.CreateIdempotencyKey(context.Background(), db.CreateIdempotencyKeyParams{
+			UserID:      user.ID,
+			Key:         key,
+			RequestHash: hash,
+		})
+		require.NoError(t, err)
+
+		rec := send(t, "/idempotent", key, `{}`)
+		require.Equal(t, http.StatusConflict, rec.Code)
+		require.Contains(t, rec.Body.String(), CodeIdempotencyKeyInProgress)
+		require.Zero(t, calls)
+	})
+}
+
+func TestIdempotencyKeyTakeOver(t *testing.T) {
+	userID := uuid.New()
+	key := uuid.NewString()
+	req := httptest.NewRequest(http.MethodPost, "/idempotent", strings.NewReader(`{}`))
+	hash, err := requestHash(req)
+	require.NoError(t, err)
+	inProgress := db.IdempotencyKey{
+		UserID:      userID,
+		Key:         key,
+		RequestHash: hash,
+		CreatedAt:   time.Now().Add(-time.Hour),
+	}
+
+	// the lease is counted back from now
+	takeOver := mock.MatchedBy(func(arg db.TakeOverIdempotencyKeyParams) bool {
+		lease := time.Since(arg.StartedBefore)
+		return arg.UserID == userID && arg.Key == key && lease >= idempotencyKeyLease && lease < idempotencyKeyLease+time.Minute
+	})
+
+	testCases := []struct {
+		name  string
+		build func(store *mocks.Store)
+		check func(t *testing.T, rec *httptest.ResponseRecorder, calls int)
+	}{
+		{
+			name: "LeaseExpired",
+			build: func(store *mocks.Store) {
+				store.On("TakeOverIdempotencyKey", mock.Anything, takeOver).
+					Return(inProgress, nil).
+					Once()
+				store.On("SaveIdempotentResponse", mock.Anything, mock.MatchedBy(func(arg db.SaveIdempotentResponseParams) bool {
+					return arg.Key == key && arg.StatusCode == http.StatusCreated
+				})).
+					Return(db.IdempotencyKey{}, nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, calls int) {
+				require.Equal(t, http.StatusCreated, rec.Code)
+				require.Empty(t, rec.Header().Get(IdempotentReplayedHeader))
+				require.Equal(t, 1, calls)
+			},
+		},
+		{
+			name: "LeaseHeld",
+			build: func(store *mocks.Store) {
+				store.On("TakeOverIdempotencyKey", mock.Anything, takeOver).
+					Return(db.IdempotencyKey{}, sql.ErrNoRows).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, calls int) {
+				require.Equal(t, http.StatusConflict, rec.Code)
+				require.Contains(t, rec.Body.String(), CodeIdempotencyKeyInProgress)
+				require.Zero(t, calls)
+			},
+		},
+	}
+
+	for i := range testCases {
+		ts := testCases[i]
+
+		t.Run(ts.name, func(t *testing.T) {
+			store := &mocks.Store{}
+			store.On("CreateIdempotencyKey", mock.Anything, mock.Anything).
+				Return(db.IdempotencyKey{}, db.ErrUniqueViolation).
+				Once()
+			store.On("GetIdempotencyKey", mock.Anything, db.GetIdempotencyKeyParams{UserID: userID, Key: key}).
+				Return(inProgress, nil).
+				Once()
+			ts.build(store)
+
+			server, err := NewServer(store, util.Config{
+				TokenSymetricKey:    "12345678901234567890123456789012",
+				AccessTokenDuration: time.Minute,
+			})
+			require.NoError(t, err)
+			calls := 0
+			server.router.POST("/idempotent", func(c echo.Context) error {
+				calls++
+				return c.JSON(http.StatusCreated, map[string]int{"calls": calls})
+			}, server.AuthMiddleware, server.Idempotency)
+
+			req, err := http.NewRequest(http.MethodPost, "/idempotent", strings.NewReader(`{}`))
+			require.NoError(t, err)
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req.Header.Set(IdempotencyKeyHeader, key)
+			addAuthorization(t, req, server.tokenMaker, "Bearer", userID, util.RoleCustomer, time.Minute)
+
+			rec := httptest.NewRecorder()
+			server.router.ServeHTTP(rec, req)
+			ts.check(t, rec, calls)
+			store.AssertExpectations(t)
+		})
+	}
+}
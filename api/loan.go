@@ -0,0 +1,115 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	db "github.com/flukis/simplebank/db/sqlc"
+	"github.com/flukis/simplebank/util"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/labstack/echo/v4"
+)
+
+type createLoanRequest struct {
+	AccountID    int64  `json:"account_id" binding:"required,min=1"`
+	Principal    int64  `json:"principal" binding:"required,gt=0"`
+	RateBps      int64  `json:"rate_bps" binding:"min=0"`
+	Term         int32  `json:"term" binding:"required,min=1"`
+	Frequency    string `json:"frequency" binding:"required,oneof=weekly monthly"`
+	Amortization string `json:"amortization" binding:"required,oneof=annuity flat"`
+	LateFee      int64  `json:"late_fee" binding:"min=0"`
+}
+
+func (r createLoanRequest) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.AccountID, validation.Required, validation.Min(1)),
+		validation.Field(&r.Principal, validation.Required, validation.Min(1)),
+		validation.Field(&r.RateBps, validation.Min(0)),
+		validation.Field(&r.Term, validation.Required, validation.Min(1), validation.Max(360)),
+		validation.Field(&r.Frequency, validation.Required, validation.In(util.FrequencyWeekly, util.FrequencyMonthly)),
+		validation.Field(&r.Amortization, validation.Required, validation.In(util.AmortizationAnnuity, util.AmortizationFlat)),
+		validation.Field(&r.LateFee, validation.Min(0)),
+	)
+}
+
+type createLoanSuccessResponse struct {
+	Data db.CreateLoanTxResult `json:"data"`
+}
+
+// CreateLoan books a loan for an account and disburses the principal into it
+func (s *Server) CreateLoan(c echo.Context) error {
+	req := new(createLoanRequest)
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	result, err := s.store.CreateLoanTx(c.Request().Context(), db.CreateLoanTxParams{
+		AccountID:    req.AccountID,
+		Principal:    req.Principal,
+		RateBps:      req.RateBps,
+		Term:         req.Term,
+		Frequency:    req.Frequency,
+		Amortization: req.Amortization,
+		LateFee:      req.LateFee,
+	})
+	if err != nil {
+		return notFound(CodeAccountNotFound, err)
+	}
+
+	return c.JSON(
+		http.StatusOK,
+		&createLoanSuccessResponse{
+			Data: result,
+		},
+	)
+}
+
+type getLoanSuccessResponse struct {
+	Data        db.Loan              `json:"data"`
+	Schedule    []db.LoanInstallment `json:"schedule"`
+	Outstanding db.LoanBalance       `json:"outstanding"`
+}
+
+// GetLoan returns a loan with its repayment schedule and what is left to pay
+func (s *Server) GetLoan(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return invalidParam("id", err)
+	}
+
+	loan, err := s.store.GetLoan(c.Request().Context(), id)
+	if err != nil {
+		return notFound(CodeLoanNotFound, err)
+	}
+
+	account, err := s.store.GetAccount(c.Request().Context(), loan.AccountID)
+	if err != nil {
+		return err
+	}
+
+	access, err := s.accountAccess(c, account)
+	if err != nil {
+		return err
+	}
+	if !access.Can(db.PermView) {
+		return errAccountNotOwned
+	}
+
+	schedule, err := s.store.ListLoanInstallments(c.Request().Context(), loan.ID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(
+		http.StatusOK,
+		&getLoanSuccessResponse{
+			Data:        loan,
+			Schedule:    schedule,
+			Outstanding: db.OutstandingLoanBalance(schedule),
+		},
+	)
+}
@@ -0,0 +1,249 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mocks "github.com/flukis/simplebank/db/mock"
+	db "github.com/flukis/simplebank/db/sqlc"
+	"github.com/flukis/simplebank/util"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoanAPI(t *testing.T) {
+	account := randomAccount()
+	adminID := uuid.New()
+
+	loan := db.Loan{
+		ID:           util.GenRandomNum(1, 1000),
+		AccountID:    account.ID,
+		Currency:     account.Currency,
+		Principal:    100000,
+		RateBps:      1000,
+		Term:         2,
+		Frequency:    util.FrequencyMonthly,
+		Amortization: util.AmortizationFlat,
+		LateFee:      500,
+		Status:       db.LoanStatusActive,
+	}
+	schedule := []db.LoanInstallment{
+		{ID: 1, LoanID: loan.ID, Number: 1, Principal: 50000, Interest: 833, LateFee: 500, Status: db.InstallmentOverdue},
+		{ID: 2, LoanID: loan.ID, Number: 2, Principal: 50000, Interest: 834, Status: db.InstallmentPending},
+	}
+
+	validRequest := createLoanRequest{
+		AccountID:    account.ID,
+		Principal:    loan.Principal,
+		RateBps:      loan.RateBps,
+		Term:         loan.Term,
+		Frequency:    loan.Frequency,
+		Amortization: loan.Amortization,
+		LateFee:      loan.LateFee,
+	}
+
+	testCases := []struct {
+		name   string
+		method string
+		url    string
+		body   any
+		userID uuid.UUID
+		role   string
+		build  func(store *mocks.Store)
+		check  func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "CreateLoanOK",
+			method: http.MethodPost,
+			url:    "/admin/loans",
+			body:   validRequest,
+			userID: adminID,
+			role:   util.RoleAdmin,
+			build: func(store *mocks.Store) {
+				store.On("CreateLoanTx", mock.Anything, db.CreateLoanTxParams{
+					AccountID:    account.ID,
+					Principal:    loan.Principal,
+					RateBps:      loan.RateBps,
+					Term:         loan.Term,
+					Frequency:    loan.Frequency,
+					Amortization: loan.Amortization,
+					LateFee:      loan.LateFee,
+				}).
+					Return(db.CreateLoanTxResult{Loan: loan, Installments: schedule}, nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+
+				var res createLoanSuccessResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+				require.Equal(t, loan.ID, res.Data.Loan.ID)
+				require.Len(t, res.Data.Installments, 2)
+			},
+		},
+		{
+			name:   "CreateLoanBadFrequency",
+			method: http.MethodPost,
+			url:    "/admin/loans",
+			body: func() createLoanRequest {
+				req := validRequest
+				req.Frequency = "daily"
+				return req
+			}(),
+			userID: adminID,
+			role:   util.RoleAdmin,
+			build:  func(store *mocks.Store) {},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			name:   "CreateLoanAccountNotFound",
+			method: http.MethodPost,
+			url:    "/admin/loans",
+			body:   validRequest,
+			userID: adminID,
+			role:   util.RoleAdmin,
+			build: func(store *mocks.Store) {
+				store.On("CreateLoanTx", mock.Anything, mock.Anything).
+					Return(db.CreateLoanTxResult{}, sql.ErrNoRows).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, rec.Code)
+			},
+		},
+		{
+			name:   "CreateLoanFrozenAccount",
+			method: http.MethodPost,
+			url:    "/admin/loans",
+			body:   validRequest,
+			userID: adminID,
+			role:   util.RoleAdmin,
+			build: func(store *mocks.Store) {
+				store.On("CreateLoanTx", mock.Anything, mock.Anything).
+					Return(db.CreateLoanTxResult{}, &db.AccountStatusError{AccountID: account.ID, Status: db.AccountStatusFrozen}).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, rec.Code)
+			},
+		},
+		{
+			name:   "CreateLoanForbiddenTeller",
+			method: http.MethodPost,
+			url:    "/admin/loans",
+			body:   validRequest,
+			userID: adminID,
+			role:   util.RoleTeller,
+			build:  func(store *mocks.Store) {},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, rec.Code)
+			},
+		},
+		{
+			name:   "GetLoanOK",
+			method: http.MethodGet,
+			url:    fmt.Sprintf("/loans/%d", loan.ID),
+			userID: account.OwnerID,
+			role:   util.RoleCustomer,
+			build: func(store *mocks.Store) {
+				store.On("GetLoan", mock.Anything, loan.ID).
+					Return(loan, nil).
+					Once()
+				store.On("GetAccount", mock.Anything, account.ID).
+					Return(account, nil).
+					Once()
+				store.On("ListLoanInstallments", mock.Anything, loan.ID).
+					Return(schedule, nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+
+				var res getLoanSuccessResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+				require.Equal(t, loan.ID, res.Data.ID)
+				require.Len(t, res.Schedule, 2)
+				require.Equal(t, db.LoanBalance{
+					Principal:           100000,
+					Interest:            1667,
+					LateFees:            500,
+					Total:               102167,
+					Arrears:             51333,
+					OverdueInstallments: 1,
+				}, res.Outstanding)
+			},
+		},
+		{
+			name:   "GetLoanNotOwned",
+			method: http.MethodGet,
+			url:    fmt.Sprintf("/loans/%d", loan.ID),
+			userID: uuid.New(),
+			role:   util.RoleCustomer,
+			build: func(store *mocks.Store) {
+				store.On("GetLoan", mock.Anything, loan.ID).
+					Return(loan, nil).
+					Once()
+				store.On("GetAccount", mock.Anything, account.ID).
+					Return(account, nil).
+					Once()
+				store.On("GetAccountHolder", mock.Anything, mock.Anything).
+					Return(db.AccountHolder{}, sql.ErrNoRows)
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, rec.Code)
+			},
+		},
+		{
+			name:   "GetLoanNotFound",
+			method: http.MethodGet,
+			url:    fmt.Sprintf("/loans/%d", loan.ID),
+			userID: account.OwnerID,
+			role:   util.RoleCustomer,
+			build: func(store *mocks.Store) {
+				store.On("GetLoan", mock.Anything, loan.ID).
+					Return(db.Loan{}, sql.ErrNoRows).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, rec.Code)
+			},
+		},
+	}
+
+	for _, ts := range testCases {
+		t.Run(ts.name, func(t *testing.T) {
+			store := &mocks.Store{}
+			ts.build(store)
+
+			server, err := NewServer(store, util.Config{
+				TokenSymetricKey:    "12345678901234567890123456789012",
+				AccessTokenDuration: time.Minute,
+			})
+			require.NoError(t, err)
+			rec := httptest.NewRecorder()
+
+			data, err := json.Marshal(ts.body)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(ts.method, ts.url, bytes.NewReader(data))
+			require.NoError(t, err)
+			req.Header = http.Header{
+				"Content-Type": {"application/json"},
+			}
+			addAuthorization(t, req, server.tokenMaker, "Bearer", ts.userID, ts.role, time.Minute)
+
+			server.router.ServeHTTP(rec, req)
+			ts.check(t, rec)
+			store.AssertExpectations(t)
+		})
+	}
+}
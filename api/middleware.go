@@ -1,36 +1,72 @@
 package api
 
 import (
+	"errors"
 	"net/http"
 	"strings"
 
+	"github.com/flukis/simplebank/util"
 	"github.com/labstack/echo/v4"
 )
 
+var (
+	errMissingToken = newError(http.StatusUnauthorized, CodeUnauthorized, errors.New("a bearer token is required"))
+	errInvalidToken = newError(http.StatusUnauthorized, CodeUnauthorized, errors.New("the token is invalid or has expired"))
+	errRoleRequired = newError(http.StatusForbidden, CodeForbidden, errors.New("the role of the authenticated user doesn't allow this"))
+)
+
 func (s *Server) AuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 	return func(c echo.Context) error {
 		authHeader := c.Request().Header.Get("Authorization")
 		if len(authHeader) == 0 {
-			return c.NoContent(http.StatusUnauthorized)
+			return errMissingToken
 		}
 
 		fields := strings.Fields(authHeader)
 		if len(fields) < 2 {
-			return c.NoContent(http.StatusUnauthorized)
+			return errMissingToken
 		}
 
 		authType := strings.ToLower(fields[0])
 		if authType != "bearer" {
-			return c.NoContent(http.StatusUnauthorized)
+			return errMissingToken
 		}
 
 		accessToken := fields[1]
 		payload, err := s.tokenMaker.VerifyToken(accessToken)
 		if err != nil {
-			return c.NoContent(http.StatusUnauthorized)
+			return errInvalidToken
 		}
 
 		c.Set("payload", payload)
 		return next(c)
 	}
 }
+
+// RequireRole only lets requests through whose token carries one of roles.
+// It must be registered after AuthMiddleware.
+func (s *Server) RequireRole(roles ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			payload := authPayload(c)
+			if payload == nil {
+				return errMissingToken
+			}
+
+			for _, role := range roles {
+				if payload.Role == role {
+					return next(c)
+				}
+			}
+			return errRoleRequired
+		}
+	}
+}
+
+func authPayload(c echo.Context) *util.Payload {
+	payload, ok := c.Get("payload").(*util.Payload)
+	if !ok {
+		return nil
+	}
+	return payload
+}
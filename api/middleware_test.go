@@ -0,0 +1,158 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mocks "github.com/flukis/simplebank/db/mock"
+	"github.com/flukis/simplebank/util"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func addAuthorization(t *testing.T, req *http.Request, tokenMaker util.JWTMaker, authType string, userID uuid.UUID, role string, duration time.Duration) {
+	token, payload, err := tokenMaker.CreateToken(userID, "user", role, duration)
+	require.NoError(t, err)
+	require.NotEmpty(t, payload)
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s %s", authType, token))
+}
+
+func TestAuthMiddleware(t *testing.T) {
+	testCases := []struct {
+		name      string
+		setupAuth func(t *testing.T, req *http.Request, tokenMaker util.JWTMaker)
+		check     func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			name: "StatusOK",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker util.JWTMaker) {
+				addAuthorization(t, req, tokenMaker, "Bearer", uuid.New(), util.RoleCustomer, time.Minute)
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+			},
+		},
+		{
+			name:      "StatusUnauthorizedNoAuthorization",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker util.JWTMaker) {},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, rec.Code)
+			},
+		},
+		{
+			name: "StatusUnauthorizedUnsupportedAuthorization",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker util.JWTMaker) {
+				addAuthorization(t, req, tokenMaker, "Basic", uuid.New(), util.RoleCustomer, time.Minute)
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, rec.Code)
+			},
+		},
+		{
+			name: "StatusUnauthorizedInvalidFormat",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker util.JWTMaker) {
+				req.Header.Set("Authorization", "Bearer")
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, rec.Code)
+			},
+		},
+		{
+			name: "StatusUnauthorizedExpiredToken",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker util.JWTMaker) {
+				addAuthorization(t, req, tokenMaker, "Bearer", uuid.New(), util.RoleCustomer, -time.Minute)
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, rec.Code)
+			},
+		},
+	}
+
+	for _, ts := range testCases {
+		t.Run(ts.name, func(t *testing.T) {
+			server, err := NewServer(&mocks.Store{}, util.Config{
+				TokenSymetricKey:    "12345678901234567890123456789012",
+				AccessTokenDuration: time.Minute,
+			})
+			require.NoError(t, err)
+
+			path := "/auth"
+			server.router.GET(path, func(c echo.Context) error {
+				return c.NoContent(http.StatusOK)
+			}, server.AuthMiddleware)
+
+			rec := httptest.NewRecorder()
+			req, err := http.NewRequest(http.MethodGet, path, nil)
+			require.NoError(t, err)
+
+			ts.setupAuth(t, req, server.tokenMaker)
+			server.router.ServeHTTP(rec, req)
+			ts.check(t, rec)
+		})
+	}
+}
+
+func TestRequireRole(t *testing.T) {
+	testCases := []struct {
+		name  string
+		role  string
+		check func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			name: "StatusOKAdmin",
+			role: util.RoleAdmin,
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+			},
+		},
+		{
+			name: "StatusOKAuditor",
+			role: util.RoleAuditor,
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+			},
+		},
+		{
+			name: "StatusForbiddenCustomer",
+			role: util.RoleCustomer,
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, rec.Code)
+			},
+		},
+		{
+			name: "StatusForbiddenTeller",
+			role: util.RoleTeller,
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, rec.Code)
+			},
+		},
+	}
+
+	for _, ts := range testCases {
+		t.Run(ts.name, func(t *testing.T) {
+			server, err := NewServer(&mocks.Store{}, util.Config{
+				TokenSymetricKey:    "12345678901234567890123456789012",
+				AccessTokenDuration: time.Minute,
+			})
+			require.NoError(t, err)
+
+			path := "/role"
+			server.router.GET(path, func(c echo.Context) error {
+				return c.NoContent(http.StatusOK)
+			}, server.AuthMiddleware, server.RequireRole(util.RoleAdmin, util.RoleAuditor))
+
+			rec := httptest.NewRecorder()
+			req, err := http.NewRequest(http.MethodGet, path, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, "Bearer", uuid.New(), ts.role, time.Minute)
+			server.router.ServeHTTP(rec, req)
+			ts.check(t, rec)
+		})
+	}
+}
@@ -0,0 +1,683 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3gen"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	swaggerFiles "github.com/swaggo/files/v2"
+)
+
+// apiRoute documents one route of serverRouter. The OpenAPI document is
+// built from apiRoutes, the request and response types being read by
+// reflection, so TestOpenAPIRoutes fails when a route is added to one and
+// not the other.
+type apiRoute struct {
+	Method  string
+	Path    string // as registered in echo, e.g. /account/:id
+	Tag     string
+	Summary string
+	// callable without an access token
+	Public bool
+	// roles the route is restricted to, besides the authorization checks of
+	// the handler
+	Roles []string
+	// path params that take an account number as well as an id
+	Numbers []string
+	// struct of the query params, read from its query tags
+	Query any
+	// JSON body, whose *account_id fields take account numbers as well when
+	// the route is behind AccountNumbers
+	Body          any
+	NumbersInBody bool
+	// responses by status, nil for one without a body
+	Responses map[int]any
+	// statuses answered with a Problem
+	Errors []int
+}
+
+// pngImage stands for an image/png response
+type pngImage struct{}
+
+var apiRoutes = []apiRoute{
+	{
+		Method: http.MethodPost, Path: "/user", Tag: "users", Public: true,
+		Summary:   "Register a user",
+		Body:      createUserRequest{},
+		Responses: map[int]any{http.StatusOK: createUserSuccessResponse{}},
+		Errors:    []int{http.StatusBadRequest, http.StatusForbidden, http.StatusInternalServerError},
+	},
+	{
+		Method: http.MethodPost, Path: "/login", Tag: "users", Public: true,
+		Summary:   "Log in and get an access token",
+		Body:      loginRequest{},
+		Responses: map[int]any{http.StatusOK: loginSuccessResponse{}},
+		Errors:    []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusNotFound, http.StatusInternalServerError},
+	},
+
+	{
+		Method: http.MethodPost, Path: "/account/", Tag: "accounts",
+		Summary:   "Open an account",
+		Body:      createAccountRequest{},
+		Responses: map[int]any{http.StatusOK: createAccountSuccessResponse{}},
+		Errors:    []int{http.StatusBadRequest, http.StatusForbidden, http.StatusInternalServerError},
+	},
+	{
+		Method: http.MethodGet, Path: "/account/:id", Tag: "accounts", Numbers: []string{"id"},
+		Summary:   "Get an account",
+		Responses: map[int]any{http.StatusOK: getAccountSuccessResponse{}},
+		Errors:    []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusInternalServerError},
+	},
+	{
+		Method: http.MethodGet, Path: "/account/", Tag: "accounts",
+		Summary:   "List the accounts of the authenticated user",
+		Query:     fetchAccountRequest{},
+		Responses: map[int]any{http.StatusOK: fetchAccountSuccessResponse{}},
+		Errors:    []int{http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError},
+	},
+	{
+		Method: http.MethodPost, Path: "/account/:id/close", Tag: "accounts", Numbers: []string{"id"},
+		Summary:       "Close an account, sweeping its balance to another",
+		Body:          closeAccountRequest{},
+		NumbersInBody: true,
+		Responses:     map[int]any{http.StatusOK: closeAccountSuccessResponse{}},
+		Errors:        []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusInternalServerError},
+	},
+	{
+		Method: http.MethodPost, Path: "/account/:id/pots", Tag: "pots", Numbers: []string{"id"},
+		Summary:   "Create a savings pot in an account",
+		Body:      createPotRequest{},
+		Responses: map[int]any{http.StatusOK: createPotSuccessResponse{}},
+		Errors:    []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusInternalServerError},
+	},
+	{
+		Method: http.MethodGet, Path: "/account/:id/pots", Tag: "pots", Numbers: []string{"id"},
+		Summary:   "List the pots of an account",
+		Responses: map[int]any{http.StatusOK: listPotsSuccessResponse{}},
+		Errors:    []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusInternalServerError},
+	},
+	{
+		Method: http.MethodPost, Path: "/account/:id/pots/:pot/move", Tag: "pots", Numbers: []string{"id", "pot"},
+		Summary:   "Move money between an account and one of its pots",
+		Body:      movePotRequest{},
+		Responses: map[int]any{http.StatusOK: movePotSuccessResponse{}},
+		Errors:    []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusInternalServerError},
+	},
+	{
+		Method: http.MethodPost, Path: "/account/:id/holders", Tag: "holders", Numbers: []string{"id"},
+		Summary:   "Add a holder to an account",
+		Body:      addHolderRequest{},
+		Responses: map[int]any{http.StatusOK: addHolderSuccessResponse{}},
+		Errors:    []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusInternalServerError},
+	},
+	{
+		Method: http.MethodGet, Path: "/account/:id/holders", Tag: "holders", Numbers: []string{"id"},
+		Summary:   "List the holders of an account",
+		Responses: map[int]any{http.StatusOK: listHoldersSuccessResponse{}},
+		Errors:    []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusInternalServerError},
+	},
+	{
+		Method: http.MethodDelete, Path: "/account/:id/holders/:user_id", Tag: "holders", Numbers: []string{"id"},
+		Summary:   "Remove a holder from an account",
+		Responses: map[int]any{http.StatusOK: removeHolderSuccessResponse{}},
+		Errors:    []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusInternalServerError},
+	},
+	{
+		Method: http.MethodGet, Path: "/account/:id/approvals", Tag: "approvals", Numbers: []string{"id"},
+		Summary:   "List the transfers of an account waiting for approval",
+		Responses: map[int]any{http.StatusOK: listApprovalsSuccessResponse{}},
+		Errors:    []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusInternalServerError},
+	},
+	{
+		Method: http.MethodGet, Path: "/account/:id/qr", Tag: "qr", Numbers: []string{"id"},
+		Summary:   "Get the EMVCo payment code of an account, dynamic when it has an amount",
+		Query:     accountQRRequest{},
+		Responses: map[int]any{http.StatusOK: accountQRSuccessResponse{}},
+		Errors:    []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusInternalServerError},
+	},
+	{
+		Method: http.MethodGet, Path: "/account/:id/qr/png", Tag: "qr", Numbers: []string{"id"},
+		Summary:   "Render the payment code of an account as a PNG",
+		Query:     accountQRRequest{},
+		Responses: map[int]any{http.StatusOK: pngImage{}},
+		Errors:    []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusInternalServerError},
+	},
+	{
+		Method: http.MethodPost, Path: "/account/transfer", Tag: "transfers",
+		Summary:       "Transfer money, or file it for approval when above the spend limit",
+		Body:          createTransferRequest{},
+		NumbersInBody: true,
+		Responses: map[int]any{
+			http.StatusOK:       createTransferSuccessResponse{},
+			http.StatusAccepted: createTransferPendingResponse{},
+		},
+		Errors: []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusInternalServerError},
+	},
+
+	{
+		Method: http.MethodPost, Path: "/pay/qr", Tag: "qr",
+		Summary:       "Pay a scanned payment code",
+		Body:          payQRRequest{},
+		NumbersInBody: true,
+		Responses: map[int]any{
+			http.StatusOK:       createTransferSuccessResponse{},
+			http.StatusAccepted: createTransferPendingResponse{},
+		},
+		Errors: []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusInternalServerError},
+	},
+
+	{
+		Method: http.MethodPost, Path: "/approvals/:id/approve", Tag: "approvals",
+		Summary:   "Approve a transfer and execute it",
+		Responses: map[int]any{http.StatusOK: approveTransferSuccessResponse{}},
+		Errors:    []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusInternalServerError},
+	},
+	{
+		Method: http.MethodPost, Path: "/approvals/:id/reject", Tag: "approvals",
+		Summary:   "Reject a transfer",
+		Responses: map[int]any{http.StatusOK: rejectTransferSuccessResponse{}},
+		Errors:    []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusInternalServerError},
+	},
+
+	{
+		Method: http.MethodPost, Path: "/beneficiaries/", Tag: "beneficiaries",
+		Summary:       "Save a beneficiary",
+		Body:          createBeneficiaryRequest{},
+		NumbersInBody: true,
+		Responses:     map[int]any{http.StatusOK: beneficiarySuccessResponse{}},
+		Errors:        []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusInternalServerError},
+	},
+	{
+		Method: http.MethodGet, Path: "/beneficiaries/", Tag: "beneficiaries",
+		Summary:   "List the beneficiaries of the authenticated user",
+		Responses: map[int]any{http.StatusOK: listBeneficiariesSuccessResponse{}},
+		Errors:    []int{http.StatusInternalServerError},
+	},
+	{
+		Method: http.MethodDelete, Path: "/beneficiaries/:id", Tag: "beneficiaries",
+		Summary:   "Delete a beneficiary",
+		Responses: map[int]any{http.StatusOK: beneficiarySuccessResponse{}},
+		Errors:    []int{http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError},
+	},
+
+	{
+		Method: http.MethodPost, Path: "/payment-requests/", Tag: "payment requests",
+		Summary:       "Ask another user for money",
+		Body:          createPaymentRequestRequest{},
+		NumbersInBody: true,
+		Responses:     map[int]any{http.StatusOK: paymentRequestSuccessResponse{}},
+		Errors:        []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusInternalServerError},
+	},
+	{
+		Method: http.MethodPost, Path: "/payment-requests/split", Tag: "payment requests",
+		Summary:       "Split a bill into payment requests",
+		Body:          splitBillRequest{},
+		NumbersInBody: true,
+		Responses:     map[int]any{http.StatusOK: splitBillSuccessResponse{}},
+		Errors:        []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusInternalServerError},
+	},
+	{
+		Method: http.MethodGet, Path: "/payment-requests/", Tag: "payment requests",
+		Summary:   "List the payment requests made by or to the authenticated user",
+		Responses: map[int]any{http.StatusOK: listPaymentRequestsSuccessResponse{}},
+		Errors:    []int{http.StatusInternalServerError},
+	},
+	{
+		Method: http.MethodGet, Path: "/payment-requests/:id", Tag: "payment requests",
+		Summary:   "Get a payment request",
+		Responses: map[int]any{http.StatusOK: paymentRequestSuccessResponse{}},
+		Errors:    []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusInternalServerError},
+	},
+	{
+		Method: http.MethodPost, Path: "/payment-requests/:id/accept", Tag: "payment requests",
+		Summary:       "Pay a payment request",
+		Body:          acceptPaymentRequestRequest{},
+		NumbersInBody: true,
+		Responses:     map[int]any{http.StatusOK: acceptPaymentRequestSuccessResponse{}},
+		Errors:        []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusInternalServerError},
+	},
+	{
+		Method: http.MethodPost, Path: "/payment-requests/:id/decline", Tag: "payment requests",
+		Summary:   "Decline a payment request",
+		Responses: map[int]any{http.StatusOK: paymentRequestSuccessResponse{}},
+		Errors:    []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusInternalServerError},
+	},
+
+	{
+		Method: http.MethodGet, Path: "/loans/:id", Tag: "loans",
+		Summary:   "Get a loan and its schedule",
+		Responses: map[int]any{http.StatusOK: getLoanSuccessResponse{}},
+		Errors:    []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusInternalServerError},
+	},
+
+	{
+		Method: http.MethodGet, Path: "/term-deposits/rates", Tag: "term deposits",
+		Summary:   "List the rates of term deposits",
+		Responses: map[int]any{http.StatusOK: listTermDepositRatesSuccessResponse{}},
+		Errors:    []int{http.StatusInternalServerError},
+	},
+	{
+		Method: http.MethodPost, Path: "/term-deposits/", Tag: "term deposits",
+		Summary:       "Open a term deposit funded from an account",
+		Body:          openTermDepositRequest{},
+		NumbersInBody: true,
+		Responses:     map[int]any{http.StatusOK: openTermDepositSuccessResponse{}},
+		Errors:        []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusInternalServerError},
+	},
+	{
+		Method: http.MethodGet, Path: "/term-deposits/:id", Tag: "term deposits",
+		Summary:   "Get a term deposit",
+		Responses: map[int]any{http.StatusOK: getTermDepositSuccessResponse{}},
+		Errors:    []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusInternalServerError},
+	},
+	{
+		Method: http.MethodPost, Path: "/term-deposits/:id/withdraw", Tag: "term deposits",
+		Summary:   "Withdraw a term deposit before it matures",
+		Responses: map[int]any{http.StatusOK: withdrawTermDepositSuccessResponse{}},
+		Errors:    []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusInternalServerError},
+	},
+
+	{
+		Method: http.MethodPost, Path: "/teller/tills", Tag: "teller", Roles: []string{"teller"},
+		Summary:   "Open a till",
+		Body:      openTillRequest{},
+		Responses: map[int]any{http.StatusOK: tillSuccessResponse{}},
+		Errors:    []int{http.StatusBadRequest, http.StatusForbidden, http.StatusInternalServerError},
+	},
+	{
+		Method: http.MethodGet, Path: "/teller/tills/:id", Tag: "teller", Roles: []string{"teller"},
+		Summary:   "Get a till",
+		Responses: map[int]any{http.StatusOK: getTillSuccessResponse{}},
+		Errors:    []int{http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError},
+	},
+	{
+		Method: http.MethodPost, Path: "/teller/tills/:id/close", Tag: "teller", Roles: []string{"teller"},
+		Summary:   "Close a till, recording the counted cash",
+		Body:      closeTillRequest{},
+		Responses: map[int]any{http.StatusOK: tillSuccessResponse{}},
+		Errors:    []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusInternalServerError},
+	},
+	{
+		Method: http.MethodPost, Path: "/teller/deposits", Tag: "teller", Roles: []string{"teller"},
+		Summary:       "Deposit cash into an account",
+		Body:          cashRequest{},
+		NumbersInBody: true,
+		Responses:     map[int]any{http.StatusOK: cashSuccessResponse{}},
+		Errors:        []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusInternalServerError},
+	},
+	{
+		Method: http.MethodPost, Path: "/teller/withdrawals", Tag: "teller", Roles: []string{"teller"},
+		Summary:       "Pay out cash from an account",
+		Body:          cashRequest{},
+		NumbersInBody: true,
+		Responses:     map[int]any{http.StatusOK: cashSuccessResponse{}},
+		Errors:        []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusInternalServerError},
+	},
+
+	{
+		Method: http.MethodGet, Path: "/admin/accounts", Tag: "admin", Roles: []string{"admin", "auditor"},
+		Summary:   "List all accounts",
+		Query:     fetchAccountRequest{},
+		Responses: map[int]any{http.StatusOK: fetchAccountSuccessResponse{}},
+		Errors:    []int{http.StatusBadRequest, http.StatusInternalServerError},
+	},
+	{
+		Method: http.MethodGet, Path: "/admin/users", Tag: "admin", Roles: []string{"admin", "auditor"},
+		Summary:   "List all users",
+		Query:     fetchAccountRequest{},
+		Responses: map[int]any{http.StatusOK: fetchUsersSuccessResponse{}},
+		Errors:    []int{http.StatusBadRequest, http.StatusInternalServerError},
+	},
+	{
+		Method: http.MethodGet, Path: "/admin/transfers/:id", Tag: "admin", Roles: []string{"admin", "auditor"},
+		Summary:   "Get any transfer",
+		Responses: map[int]any{http.StatusOK: getTransferSuccessResponse{}},
+		Errors:    []int{http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError},
+	},
+	{
+		Method: http.MethodGet, Path: "/admin/gl/accounts", Tag: "admin", Roles: []string{"admin", "auditor"},
+		Summary:   "List the general ledger accounts",
+		Responses: map[int]any{http.StatusOK: listGLAccountsSuccessResponse{}},
+		Errors:    []int{http.StatusInternalServerError},
+	},
+	{
+		Method: http.MethodGet, Path: "/admin/reports/trial-balance", Tag: "admin", Roles: []string{"admin", "auditor"},
+		Summary:   "Report the trial balance of the general ledger",
+		Query:     trialBalanceRequest{},
+		Responses: map[int]any{http.StatusOK: trialBalanceSuccessResponse{}},
+		Errors:    []int{http.StatusBadRequest, http.StatusInternalServerError},
+	},
+	{
+		Method: http.MethodPost, Path: "/admin/accounts/:id/freeze", Tag: "admin", Roles: []string{"admin"}, Numbers: []string{"id"},
+		Summary:   "Freeze an account",
+		Responses: map[int]any{http.StatusOK: setAccountStatusSuccessResponse{}},
+		Errors:    []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusInternalServerError},
+	},
+	{
+		Method: http.MethodPost, Path: "/admin/accounts/:id/unfreeze", Tag: "admin", Roles: []string{"admin"}, Numbers: []string{"id"},
+		Summary:   "Unfreeze an account",
+		Responses: map[int]any{http.StatusOK: setAccountStatusSuccessResponse{}},
+		Errors:    []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusInternalServerError},
+	},
+	{
+		Method: http.MethodPut, Path: "/admin/users/:id/role", Tag: "admin", Roles: []string{"admin"},
+		Summary:   "Change the role of a user",
+		Body:      updateUserRoleRequest{},
+		Responses: map[int]any{http.StatusOK: updateUserRoleSuccessResponse{}},
+		Errors:    []int{http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError},
+	},
+	{
+		Method: http.MethodPost, Path: "/admin/jobs/end-of-day", Tag: "admin", Roles: []string{"admin"},
+		Summary:   "Run the end of day job for a date",
+		Body:      runEndOfDayRequest{},
+		Responses: map[int]any{http.StatusOK: runEndOfDaySuccessResponse{}},
+		Errors:    []int{http.StatusBadRequest, http.StatusInternalServerError},
+	},
+	{
+		Method: http.MethodPost, Path: "/admin/loans", Tag: "admin", Roles: []string{"admin"},
+		Summary:       "Disburse a loan into an account",
+		Body:          createLoanRequest{},
+		NumbersInBody: true,
+		Responses:     map[int]any{http.StatusOK: createLoanSuccessResponse{}},
+		Errors:        []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusInternalServerError},
+	},
+
+	{
+		Method: http.MethodGet, Path: "/openapi.json", Tag: "docs", Public: true,
+		Summary:   "Get this document",
+		Responses: map[int]any{http.StatusOK: nil},
+	},
+}
+
+// uuidParams are the path params holding a user id
+var uuidParams = map[string]bool{
+	"/account/:id/holders/:user_id user_id": true,
+	"/admin/users/:id/role id":              true,
+}
+
+var echoParam = regexp.MustCompile(`:(\w+)`)
+
+// openAPIPath turns the echo path of a route into an OpenAPI one
+func openAPIPath(path string) string {
+	return echoParam.ReplaceAllString(path, "{$1}")
+}
+
+// NewOpenAPI builds the OpenAPI document of apiRoutes
+func NewOpenAPI() (*openapi3.T, error) {
+	doc := &openapi3.T{
+		OpenAPI: "3.0.3",
+		Info: &openapi3.Info{
+			Title:       "Simple Bank",
+			Description: "Errors are answered with an RFC 7807 `Problem` as application/problem+json, whose `code` tells them apart. Routes behind authentication take the access token of `/login` as a bearer token.",
+			Version:     "1.0.0",
+		},
+		Paths: openapi3.Paths{},
+		Components: &openapi3.Components{
+			Schemas: openapi3.Schemas{},
+			SecuritySchemes: openapi3.SecuritySchemes{
+				"bearerAuth": &openapi3.SecuritySchemeRef{
+					Value: openapi3.NewJWTSecurityScheme(),
+				},
+			},
+		},
+	}
+
+	problemSchema, err := newSchemaRef(Problem{})
+	if err != nil {
+		return nil, err
+	}
+	doc.Components.Schemas["Problem"] = problemSchema
+
+	for _, route := range apiRoutes {
+		op, err := newOperation(route)
+		if err != nil {
+			return nil, fmt.Errorf("%s %s: %w", route.Method, route.Path, err)
+		}
+
+		path := openAPIPath(route.Path)
+		item := doc.Paths[path]
+		if item == nil {
+			item = &openapi3.PathItem{}
+			doc.Paths[path] = item
+		}
+		item.SetOperation(route.Method, op)
+	}
+	return doc, nil
+}
+
+func newOperation(route apiRoute) (*openapi3.Operation, error) {
+	op := openapi3.NewOperation()
+	op.Summary = route.Summary
+	op.Tags = []string{route.Tag}
+	op.Responses = openapi3.Responses{}
+
+	if !route.Public {
+		op.Security = &openapi3.SecurityRequirements{{"bearerAuth": []string{}}}
+		op.AddResponse(http.StatusUnauthorized, problemResponse("Missing or invalid access token"))
+	}
+	if len(route.Roles) > 0 {
+		op.Description = "Only for the " + strings.Join(route.Roles, " and ") + " roles."
+		op.AddResponse(http.StatusForbidden, problemResponse("Not allowed for the role of the user"))
+	}
+
+	for _, match := range echoParam.FindAllStringSubmatch(route.Path, -1) {
+		name := match[1]
+		schema := openapi3.NewInt64Schema()
+		switch {
+		case uuidParams[route.Path+" "+name]:
+			schema = openapi3.NewUUIDSchema()
+		case containsString(route.Numbers, name):
+			schema = accountIDSchema()
+		}
+		op.AddParameter(openapi3.NewPathParameter(name).WithSchema(schema))
+	}
+
+	if route.Query != nil {
+		params, err := queryParameters(route.Query)
+		if err != nil {
+			return nil, err
+		}
+		for _, param := range params {
+			op.AddParameter(param)
+		}
+	}
+
+	if route.Body != nil {
+		body, err := newSchemaRef(route.Body)
+		if err != nil {
+			return nil, err
+		}
+		if route.NumbersInBody {
+			for name, prop := range body.Value.Properties {
+				if strings.HasSuffix(name, "account_id") {
+					prop.Value = accountIDSchema()
+				}
+			}
+		}
+		op.RequestBody = &openapi3.RequestBodyRef{
+			Value: openapi3.NewRequestBody().WithRequired(true).WithJSONSchemaRef(body),
+		}
+	}
+
+	for status, res := range route.Responses {
+		description := http.StatusText(status)
+		switch res.(type) {
+		case nil:
+			op.AddResponse(status, openapi3.NewResponse().WithDescription(description))
+		case pngImage:
+			op.AddResponse(status, openapi3.NewResponse().
+				WithDescription(description).
+				WithContent(openapi3.NewContentWithSchema(openapi3.NewBytesSchema(), []string{"image/png"})))
+		default:
+			schema, err := newSchemaRef(res)
+			if err != nil {
+				return nil, err
+			}
+			op.AddResponse(status, openapi3.NewResponse().WithDescription(description).WithJSONSchemaRef(schema))
+		}
+	}
+	for _, status := range route.Errors {
+		op.AddResponse(status, problemResponse(http.StatusText(status)))
+	}
+	return op, nil
+}
+
+// problemResponse is a response with a Problem body
+func problemResponse(description string) *openapi3.Response {
+	return openapi3.NewResponse().
+		WithDescription(description).
+		WithContent(openapi3.Content{
+			"application/problem+json": openapi3.NewMediaType().
+				WithSchemaRef(openapi3.NewSchemaRef("#/components/schemas/Problem", nil)),
+		})
+}
+
+// accountIDSchema is the schema of the ids the AccountNumbers middleware
+// also takes account numbers for
+func accountIDSchema() *openapi3.Schema {
+	schema := openapi3.NewOneOfSchema(
+		openapi3.NewInt64Schema(),
+		openapi3.NewStringSchema().WithPattern(`^[A-Z]{2}[0-9]{2}[A-Z0-9 ]+$`),
+	)
+	schema.Description = "An account id, or its account number"
+	return schema
+}
+
+// queryParameters returns a query param for every field of v with a query
+// tag
+func queryParameters(v any) ([]*openapi3.Parameter, error) {
+	t := reflect.TypeOf(v)
+	var params []*openapi3.Parameter
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, ok := field.Tag.Lookup("query")
+		if !ok {
+			continue
+		}
+
+		schema, err := openapi3gen.NewSchemaRefForValue(reflect.Zero(field.Type).Interface(), nil)
+		if err != nil {
+			return nil, err
+		}
+		applyBinding(schema.Value, field.Tag.Get("binding"))
+
+		param := openapi3.NewQueryParameter(name).WithSchema(schema.Value)
+		param.Required = hasBinding(field.Tag.Get("binding"), "required")
+		params = append(params, param)
+	}
+	return params, nil
+}
+
+// the schema of these doesn't follow from their fields
+var (
+	uuidType       = reflect.TypeOf(uuid.UUID{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+)
+
+// newSchemaRef returns the schema of the JSON encoding of v, with the rules
+// of the binding tags of its fields
+func newSchemaRef(v any) (*openapi3.SchemaRef, error) {
+	return openapi3gen.NewSchemaRefForValue(v, nil, openapi3gen.SchemaCustomizer(customizeSchema))
+}
+
+func customizeSchema(name string, t reflect.Type, tag reflect.StructTag, schema *openapi3.Schema) error {
+	switch {
+	case t == uuidType:
+		*schema = *openapi3.NewUUIDSchema()
+	case t == rawMessageType:
+		*schema = openapi3.Schema{}
+	case t.PkgPath() == "database/sql" && strings.HasPrefix(t.Name(), "Null"):
+		// encoded as the struct itself, e.g. {"Int64": 1, "Valid": true}
+		*schema = *openapi3.NewObjectSchema()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			fieldSchema, err := openapi3gen.NewSchemaRefForValue(reflect.Zero(field.Type).Interface(), nil)
+			if err != nil {
+				return err
+			}
+			schema.WithProperty(field.Name, fieldSchema.Value)
+		}
+	case t.Kind() == reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name != "" && name != "-" && hasBinding(field.Tag.Get("binding"), "required") {
+				schema.Required = append(schema.Required, name)
+			}
+		}
+	}
+
+	applyBinding(schema, tag.Get("binding"))
+	return nil
+}
+
+// applyBinding adds the rules of a binding tag to the schema of its field.
+// The tags are kept in line with the Validate methods.
+func applyBinding(schema *openapi3.Schema, binding string) {
+	for _, rule := range strings.Split(binding, ",") {
+		name, arg, _ := strings.Cut(rule, "=")
+		switch name {
+		case "oneof":
+			for _, value := range strings.Fields(arg) {
+				schema.Enum = append(schema.Enum, value)
+			}
+		case "min", "gt":
+			min, err := strconv.ParseFloat(arg, 64)
+			if err != nil {
+				continue
+			}
+			schema.Min = &min
+			schema.ExclusiveMin = name == "gt"
+		}
+	}
+}
+
+func hasBinding(binding, rule string) bool {
+	for _, r := range strings.Split(binding, ",") {
+		if r == rule {
+			return true
+		}
+	}
+	return false
+}
+
+// ServeOpenAPI answers with the OpenAPI document of the API
+func (s *Server) ServeOpenAPI(c echo.Context) error {
+	return c.JSONBlob(http.StatusOK, s.openAPI)
+}
+
+// swaggerInitializer replaces the one of the Swagger UI distribution, which
+// loads the petstore example
+const swaggerInitializer = `window.onload = function() {
+  window.ui = SwaggerUIBundle({
+    url: "/openapi.json",
+    dom_id: "#swagger-ui",
+    deepLinking: true,
+    presets: [SwaggerUIBundle.presets.apis, SwaggerUIStandalonePreset],
+    plugins: [SwaggerUIBundle.plugins.DownloadUrl],
+    layout: "StandaloneLayout"
+  });
+};
+`
+
+// docsRoutes serves the OpenAPI document and a Swagger UI reading it
+func docsRoutes(router *echo.Echo, server *Server) {
+	router.GET("/openapi.json", server.ServeOpenAPI)
+
+	files := http.StripPrefix("/docs/", http.FileServer(http.FS(swaggerFiles.FS)))
+	router.GET("/docs", func(c echo.Context) error {
+		return c.Redirect(http.StatusMovedPermanently, "/docs/")
+	})
+	router.GET("/docs/swagger-initializer.js", func(c echo.Context) error {
+		return c.Blob(http.StatusOK, "application/javascript", []byte(swaggerInitializer))
+	})
+	router.GET("/docs/*", echo.WrapHandler(files))
+}
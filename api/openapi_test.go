@@ -0,0 +1,110 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	mocks "github.com/flukis/simplebank/db/mock"
+	"github.com/flukis/simplebank/util"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/require"
+)
+
+// docsPaths are the routes of the Swagger UI, left out of the document
+var docsPaths = map[string]bool{
+	"/docs":                        true,
+	"/docs/*":                      true,
+	"/docs/swagger-initializer.js": true,
+}
+
+func TestOpenAPIRoutes(t *testing.T) {
+	server, err := NewServer(&mocks.Store{}, util.Config{
+		TokenSymetricKey:    "12345678901234567890123456789012",
+		AccessTokenDuration: time.Minute,
+	})
+	require.NoError(t, err)
+
+	routes := map[string]bool{}
+	for _, route := range server.router.Routes() {
+		// groups with middleware route everything under them to the not
+		// found handler of echo
+		if strings.HasPrefix(route.Name, "github.com/labstack/echo/v4.init") {
+			continue
+		}
+		path := "/" + strings.TrimPrefix(route.Path, "/")
+		if docsPaths[path] {
+			continue
+		}
+		routes[route.Method+" "+path] = true
+	}
+
+	documented := map[string]bool{}
+	for _, route := range apiRoutes {
+		key := route.Method + " " + route.Path
+		require.False(t, documented[key], "%s is documented twice", key)
+		documented[key] = true
+	}
+
+	for route := range routes {
+		require.True(t, documented[route], "%s is missing from apiRoutes", route)
+	}
+	for route := range documented {
+		require.True(t, routes[route], "%s is documented but not routed", route)
+	}
+}
+
+func TestOpenAPIDocument(t *testing.T) {
+	server, err := NewServer(&mocks.Store{}, util.Config{
+		TokenSymetricKey:    "12345678901234567890123456789012",
+		AccessTokenDuration: time.Minute,
+	})
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	req, err := http.NewRequest(http.MethodGet, "/openapi.json", nil)
+	require.NoError(t, err)
+	server.router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	doc, err := openapi3.NewLoader().LoadFromData(rec.Body.Bytes())
+	require.NoError(t, err)
+	require.NoError(t, doc.Validate(context.Background()))
+
+	transfer := doc.Paths.Find("/account/transfer").Post
+	require.NotNil(t, transfer)
+	require.NotNil(t, transfer.Security)
+	body := transfer.RequestBody.Value.Content.Get("application/json").Schema.Value
+	require.ElementsMatch(t, []string{"from_account_id", "currency", "amount"}, body.Required)
+	require.Len(t, body.Properties["from_account_id"].Value.OneOf, 2)
+	require.NotNil(t, transfer.Responses.Get(http.StatusAccepted))
+
+	login := doc.Paths.Find("/login").Post
+	require.Nil(t, login.Security)
+	require.Equal(t, "#/components/schemas/Problem", login.Responses.Get(http.StatusBadRequest).Value.Content.Get("application/problem+json").Schema.Ref)
+}
+
+func TestSwaggerUI(t *testing.T) {
+	server, err := NewServer(&mocks.Store{}, util.Config{
+		TokenSymetricKey:    "12345678901234567890123456789012",
+		AccessTokenDuration: time.Minute,
+	})
+	require.NoError(t, err)
+
+	for path, want := range map[string]string{
+		"/docs/":                       "swagger-ui",
+		"/docs/swagger-initializer.js": "/openapi.json",
+		"/docs/swagger-ui-bundle.js":   "SwaggerUIBundle",
+	} {
+		rec := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, path, nil)
+		require.NoError(t, err)
+		server.router.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code, path)
+		require.Contains(t, rec.Body.String(), want, path)
+	}
+}
@@ -0,0 +1,376 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	db "github.com/flukis/simplebank/db/sqlc"
+	"github.com/flukis/simplebank/util"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+var (
+	errPaymentRequestNotParty = errors.New("payment request doesn't involve the authenticated user")
+	errPaymentRequestNotPayer = errors.New("only the payer may accept a payment request")
+	errPaymentRequestSelf     = errors.New("a payment request cannot be sent to yourself")
+	errSplitTooSmall          = errors.New("the total is too small to split between the payers")
+)
+
+type createPaymentRequestRequest struct {
+	PayerID     uuid.UUID `json:"payer_id" binding:"required"`
+	ToAccountID int64     `json:"to_account_id" binding:"required,min=1"`
+	Amount      int64     `json:"amount" binding:"required,gt=0"`
+	Memo        string    `json:"memo"`
+	// defaults to the configured expiry from now
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+func (r createPaymentRequestRequest) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.PayerID, validation.Required),
+		validation.Field(&r.ToAccountID, validation.Required, validation.Min(1)),
+		validation.Field(&r.Amount, validation.Required, validation.Min(1)),
+		validation.Field(&r.Memo, validation.Length(0, 140)),
+		validation.Field(&r.ExpiresAt, validation.Min(time.Now())),
+	)
+}
+
+type paymentRequestSuccessResponse struct {
+	Data db.PaymentRequest `json:"data"`
+}
+
+// CreatePaymentRequest asks another user to pay into an account of the
+// authenticated user
+func (s *Server) CreatePaymentRequest(c echo.Context) error {
+	req := new(createPaymentRequestRequest)
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	account, err := s.payeeAccount(c, req.ToAccountID)
+	if err != nil {
+		return err
+	}
+	if err := s.validPayer(c, req.PayerID); err != nil {
+		return err
+	}
+
+	request, err := s.store.CreatePaymentRequest(c.Request().Context(), db.CreatePaymentRequestParams{
+		RequesterID: authPayload(c).UserID,
+		PayerID:     req.PayerID,
+		ToAccountID: account.ID,
+		Amount:      req.Amount,
+		Currency:    account.Currency,
+		Memo:        req.Memo,
+		ExpiresAt:   s.paymentRequestExpiry(req.ExpiresAt),
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(
+		http.StatusOK,
+		&paymentRequestSuccessResponse{
+			Data: request,
+		},
+	)
+}
+
+type splitBillPayer struct {
+	UserID uuid.UUID `json:"user_id"`
+	// relative to the shares of the other payers, defaults to 1
+	Share int64 `json:"share"`
+}
+
+func (p splitBillPayer) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.UserID, validation.Required),
+		validation.Field(&p.Share, validation.Min(int64(0))),
+	)
+}
+
+// splitBillRequest splits total between payers. The requester may be one of
+// them to keep their own share, no request is made for it.
+type splitBillRequest struct {
+	ToAccountID int64            `json:"to_account_id" binding:"required,min=1"`
+	Total       int64            `json:"total" binding:"required,gt=0"`
+	Memo        string           `json:"memo"`
+	ExpiresAt   time.Time        `json:"expires_at"`
+	Payers      []splitBillPayer `json:"payers" binding:"required"`
+}
+
+func (r splitBillRequest) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.ToAccountID, validation.Required, validation.Min(1)),
+		validation.Field(&r.Total, validation.Required, validation.Min(1)),
+		validation.Field(&r.Memo, validation.Length(0, 140)),
+		validation.Field(&r.ExpiresAt, validation.Min(time.Now())),
+		validation.Field(&r.Payers, validation.Required, validation.Length(1, 50)),
+	)
+}
+
+type splitBillSuccessResponse struct {
+	Data []db.PaymentRequest `json:"data"`
+}
+
+// SplitBill sends a payment request for their share of a total to each of
+// several users. The shares add up to the total to the minor unit.
+func (s *Server) SplitBill(c echo.Context) error {
+	req := new(splitBillRequest)
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	shares := make([]int64, len(req.Payers))
+	for i, payer := range req.Payers {
+		shares[i] = payer.Share
+		if shares[i] == 0 {
+			shares[i] = 1
+		}
+	}
+	parts, err := util.SplitAmount(req.Total, shares)
+	if err != nil {
+		return newError(http.StatusBadRequest, CodeSplitTooSmall, err)
+	}
+
+	account, err := s.payeeAccount(c, req.ToAccountID)
+	if err != nil {
+		return err
+	}
+
+	requester := authPayload(c).UserID
+	expiresAt := s.paymentRequestExpiry(req.ExpiresAt)
+	args := make([]db.CreatePaymentRequestParams, 0, len(req.Payers))
+	for i, payer := range req.Payers {
+		if payer.UserID == requester {
+			continue
+		}
+		if parts[i] == 0 {
+			return errSplitTooSmall
+		}
+		if err := s.validPayer(c, payer.UserID); err != nil {
+			return err
+		}
+
+		args = append(args, db.CreatePaymentRequestParams{
+			RequesterID: requester,
+			PayerID:     payer.UserID,
+			ToAccountID: account.ID,
+			Amount:      parts[i],
+			Currency:    account.Currency,
+			Memo:        req.Memo,
+			ExpiresAt:   expiresAt,
+		})
+	}
+	if len(args) == 0 {
+		return errPaymentRequestSelf
+	}
+
+	requests, err := s.store.CreatePaymentRequestsTx(c.Request().Context(), args)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(
+		http.StatusOK,
+		&splitBillSuccessResponse{
+			Data: requests,
+		},
+	)
+}
+
+type listPaymentRequestsSuccessResponse struct {
+	Data []db.PaymentRequest `json:"data"`
+}
+
+// ListPaymentRequests returns the payment requests the authenticated user
+// sent or received, newest first
+func (s *Server) ListPaymentRequests(c echo.Context) error {
+	requests, err := s.store.ListPaymentRequests(c.Request().Context(), authPayload(c).UserID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(
+		http.StatusOK,
+		&listPaymentRequestsSuccessResponse{
+			Data: requests,
+		},
+	)
+}
+
+// GetPaymentRequest returns a payment request to its requester or payer
+func (s *Server) GetPaymentRequest(c echo.Context) error {
+	request, err := s.paymentRequest(c)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(
+		http.StatusOK,
+		&paymentRequestSuccessResponse{
+			Data: request,
+		},
+	)
+}
+
+type acceptPaymentRequestRequest struct {
+	FromAccountID int64 `json:"from_account_id" binding:"required,min=1"`
+}
+
+func (r acceptPaymentRequestRequest) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.FromAccountID, validation.Required, validation.Min(1)),
+	)
+}
+
+type acceptPaymentRequestSuccessResponse struct {
+	Data db.AcceptPaymentRequestTxResult `json:"data"`
+}
+
+// AcceptPaymentRequest pays a payment request of the authenticated user from
+// one of their accounts
+func (s *Server) AcceptPaymentRequest(c echo.Context) error {
+	req := new(acceptPaymentRequestRequest)
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	request, err := s.paymentRequest(c)
+	if err != nil {
+		return err
+	}
+	if request.PayerID != authPayload(c).UserID {
+		return errPaymentRequestNotPayer
+	}
+
+	from, err := s.validAccount(c, req.FromAccountID, request.Currency)
+	if err != nil {
+		return err
+	}
+	access, err := s.accountAccess(c, from)
+	if err != nil {
+		return err
+	}
+	if !access.Can(db.PermInitiate) {
+		return errAccountNotOwned
+	}
+	if access.NeedsApproval(request.Amount) {
+		return errSpendLimit
+	}
+
+	result, err := s.store.AcceptPaymentRequestTx(c.Request().Context(), db.AcceptPaymentRequestTxParams{
+		ID:            request.ID,
+		FromAccountID: from.ID,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(
+		http.StatusOK,
+		&acceptPaymentRequestSuccessResponse{
+			Data: result,
+		},
+	)
+}
+
+// DeclinePaymentRequest turns down a payment request. Besides the payer, the
+// requester may withdraw it.
+func (s *Server) DeclinePaymentRequest(c echo.Context) error {
+	request, err := s.paymentRequest(c)
+	if err != nil {
+		return err
+	}
+
+	declined, err := s.store.DeclinePaymentRequestTx(c.Request().Context(), request.ID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(
+		http.StatusOK,
+		&paymentRequestSuccessResponse{
+			Data: declined,
+		},
+	)
+}
+
+// paymentRequest loads the payment request of the :id param for its
+// requester or payer
+func (s *Server) paymentRequest(c echo.Context) (db.PaymentRequest, error) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return db.PaymentRequest{}, invalidParam("id", err)
+	}
+
+	request, err := s.store.GetPaymentRequest(c.Request().Context(), id)
+	if err != nil {
+		return request, notFound(CodePaymentRequestNotFound, err)
+	}
+
+	user := authPayload(c).UserID
+	if request.RequesterID != user && request.PayerID != user {
+		return request, errPaymentRequestNotParty
+	}
+
+	return request, nil
+}
+
+// payeeAccount loads the account a payment request pays into, unless the
+// authenticated user may not use it to receive money
+func (s *Server) payeeAccount(c echo.Context, id int64) (db.Account, error) {
+	account, err := s.store.GetAccount(c.Request().Context(), id)
+	if err != nil {
+		return account, notFound(CodeAccountNotFound, err)
+	}
+
+	access, err := s.accountAccess(c, account)
+	if err != nil {
+		return account, err
+	}
+	if !access.Can(db.PermInitiate) {
+		return account, errAccountNotOwned
+	}
+	if account.Status == db.AccountStatusClosed || account.AccountType == db.AccountTypePot {
+		return account, errCannotReceive
+	}
+
+	return account, nil
+}
+
+// validPayer checks that a payment request can be sent to user
+func (s *Server) validPayer(c echo.Context, user uuid.UUID) error {
+	if user == authPayload(c).UserID {
+		return errPaymentRequestSelf
+	}
+
+	if _, err := s.store.GetUser(c.Request().Context(), user); err != nil {
+		return notFound(CodeUserNotFound, err)
+	}
+
+	return nil
+}
+
+func (s *Server) paymentRequestExpiry(expiresAt time.Time) time.Time {
+	if expiresAt.IsZero() {
+		return time.Now().Add(s.config.PaymentRequestExpiry)
+	}
+	return expiresAt
+}
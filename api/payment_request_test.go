@@ -0,0 +1,425 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mocks "github.com/flukis/simplebank/db/mock"
+	db "github.com/flukis/simplebank/db/sqlc"
+	"github.com/flukis/simplebank/util"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPaymentRequestAPI(t *testing.T) {
+	to := randomAccount()
+	requester := to.OwnerID
+	from := randomAccount()
+	from.Currency = to.Currency
+	payer := db.User{ID: from.OwnerID}
+	friend := db.User{ID: uuid.New()}
+
+	expiry := 48 * time.Hour
+	request := db.PaymentRequest{
+		ID:          util.GenRandomNum(1, 10000),
+		RequesterID: requester,
+		PayerID:     payer.ID,
+		ToAccountID: to.ID,
+		Amount:      250,
+		Currency:    to.Currency,
+		Memo:        "dinner",
+		Status:      db.PaymentRequestPending,
+		ExpiresAt:   time.Now().Add(expiry),
+	}
+	accepted := request
+	accepted.Status = db.PaymentRequestAccepted
+
+	testCases := []struct {
+		name   string
+		method string
+		url    string
+		body   any
+		userID uuid.UUID
+		build  func(store *mocks.Store)
+		check  func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "CreateOK",
+			method: http.MethodPost,
+			url:    "/payment-requests/",
+			body: createPaymentRequestRequest{
+				PayerID:     payer.ID,
+				ToAccountID: to.ID,
+				Amount:      request.Amount,
+				Memo:        request.Memo,
+			},
+			userID: requester,
+			build: func(store *mocks.Store) {
+				store.On("GetAccount", mock.Anything, to.ID).
+					Return(to, nil).
+					Once()
+				store.On("GetUser", mock.Anything, payer.ID).
+					Return(payer, nil).
+					Once()
+				store.On("CreatePaymentRequest", mock.Anything, mock.MatchedBy(func(arg db.CreatePaymentRequestParams) bool {
+					return arg.RequesterID == requester &&
+						arg.PayerID == payer.ID &&
+						arg.Amount == request.Amount &&
+						arg.Currency == to.Currency &&
+						time.Until(arg.ExpiresAt) > expiry-time.Minute
+				})).
+					Return(request, nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+
+				var res paymentRequestSuccessResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+				require.Equal(t, request.ID, res.Data.ID)
+			},
+		},
+		{
+			name:   "CreateToSelf",
+			method: http.MethodPost,
+			url:    "/payment-requests/",
+			body: createPaymentRequestRequest{
+				PayerID:     requester,
+				ToAccountID: to.ID,
+				Amount:      request.Amount,
+			},
+			userID: requester,
+			build: func(store *mocks.Store) {
+				store.On("GetAccount", mock.Anything, to.ID).
+					Return(to, nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			name:   "CreateUnknownPayer",
+			method: http.MethodPost,
+			url:    "/payment-requests/",
+			body: createPaymentRequestRequest{
+				PayerID:     payer.ID,
+				ToAccountID: to.ID,
+				Amount:      request.Amount,
+			},
+			userID: requester,
+			build: func(store *mocks.Store) {
+				store.On("GetAccount", mock.Anything, to.ID).
+					Return(to, nil).
+					Once()
+				store.On("GetUser", mock.Anything, payer.ID).
+					Return(db.User{}, sql.ErrNoRows).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, rec.Code)
+			},
+		},
+		{
+			name:   "SplitOK",
+			method: http.MethodPost,
+			url:    "/payment-requests/split",
+			body: splitBillRequest{
+				ToAccountID: to.ID,
+				Total:       100,
+				Memo:        "groceries",
+				Payers: []splitBillPayer{
+					{UserID: requester},
+					{UserID: payer.ID},
+					{UserID: friend.ID},
+				},
+			},
+			userID: requester,
+			build: func(store *mocks.Store) {
+				store.On("GetAccount", mock.Anything, to.ID).
+					Return(to, nil).
+					Once()
+				store.On("GetUser", mock.Anything, payer.ID).
+					Return(payer, nil).
+					Once()
+				store.On("GetUser", mock.Anything, friend.ID).
+					Return(friend, nil).
+					Once()
+				// the requester keeps the share that got the extra unit
+				store.On("CreatePaymentRequestsTx", mock.Anything, mock.MatchedBy(func(args []db.CreatePaymentRequestParams) bool {
+					return len(args) == 2 &&
+						args[0].PayerID == payer.ID && args[0].Amount == 33 &&
+						args[1].PayerID == friend.ID && args[1].Amount == 33
+				})).
+					Return([]db.PaymentRequest{request, request}, nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+
+				var res splitBillSuccessResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+				require.Len(t, res.Data, 2)
+			},
+		},
+		{
+			name:   "SplitByShares",
+			method: http.MethodPost,
+			url:    "/payment-requests/split",
+			body: splitBillRequest{
+				ToAccountID: to.ID,
+				Total:       1000,
+				Payers: []splitBillPayer{
+					{UserID: payer.ID, Share: 1},
+					{UserID: friend.ID, Share: 2},
+				},
+			},
+			userID: requester,
+			build: func(store *mocks.Store) {
+				store.On("GetAccount", mock.Anything, to.ID).
+					Return(to, nil).
+					Once()
+				store.On("GetUser", mock.Anything, payer.ID).
+					Return(payer, nil).
+					Once()
+				store.On("GetUser", mock.Anything, friend.ID).
+					Return(friend, nil).
+					Once()
+				store.On("CreatePaymentRequestsTx", mock.Anything, mock.MatchedBy(func(args []db.CreatePaymentRequestParams) bool {
+					return len(args) == 2 && args[0].Amount == 333 && args[1].Amount == 667
+				})).
+					Return([]db.PaymentRequest{request, request}, nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+			},
+		},
+		{
+			name:   "SplitTooSmall",
+			method: http.MethodPost,
+			url:    "/payment-requests/split",
+			body: splitBillRequest{
+				ToAccountID: to.ID,
+				Total:       1,
+				Payers: []splitBillPayer{
+					{UserID: payer.ID},
+					{UserID: friend.ID},
+				},
+			},
+			userID: requester,
+			build: func(store *mocks.Store) {
+				store.On("GetAccount", mock.Anything, to.ID).
+					Return(to, nil).
+					Once()
+				store.On("GetUser", mock.Anything, payer.ID).
+					Return(payer, nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			name:   "List",
+			method: http.MethodGet,
+			url:    "/payment-requests/",
+			userID: payer.ID,
+			build: func(store *mocks.Store) {
+				store.On("ListPaymentRequests", mock.Anything, payer.ID).
+					Return([]db.PaymentRequest{request}, nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+
+				var res listPaymentRequestsSuccessResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+				require.Len(t, res.Data, 1)
+			},
+		},
+		{
+			name:   "GetNotParty",
+			method: http.MethodGet,
+			url:    fmt.Sprintf("/payment-requests/%d", request.ID),
+			userID: friend.ID,
+			build: func(store *mocks.Store) {
+				store.On("GetPaymentRequest", mock.Anything, request.ID).
+					Return(request, nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, rec.Code)
+			},
+		},
+		{
+			name:   "AcceptOK",
+			method: http.MethodPost,
+			url:    fmt.Sprintf("/payment-requests/%d/accept", request.ID),
+			body: acceptPaymentRequestRequest{
+				FromAccountID: from.ID,
+			},
+			userID: payer.ID,
+			build: func(store *mocks.Store) {
+				store.On("GetPaymentRequest", mock.Anything, request.ID).
+					Return(request, nil).
+					Once()
+				store.On("GetAccount", mock.Anything, from.ID).
+					Return(from, nil).
+					Once()
+				store.On("AcceptPaymentRequestTx", mock.Anything, db.AcceptPaymentRequestTxParams{
+					ID:            request.ID,
+					FromAccountID: from.ID,
+				}).
+					Return(db.AcceptPaymentRequestTxResult{
+						Request:  accepted,
+						Transfer: generateTransferResult(from, to, request.Amount),
+					}, nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+
+				var res acceptPaymentRequestSuccessResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+				require.Equal(t, db.PaymentRequestAccepted, res.Data.Request.Status)
+			},
+		},
+		{
+			name:   "AcceptByRequester",
+			method: http.MethodPost,
+			url:    fmt.Sprintf("/payment-requests/%d/accept", request.ID),
+			body: acceptPaymentRequestRequest{
+				FromAccountID: from.ID,
+			},
+			userID: requester,
+			build: func(store *mocks.Store) {
+				store.On("GetPaymentRequest", mock.Anything, request.ID).
+					Return(request, nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, rec.Code)
+			},
+		},
+		{
+			name:   "AcceptCurrencyMismatch",
+			method: http.MethodPost,
+			url:    fmt.Sprintf("/payment-requests/%d/accept", request.ID),
+			body: acceptPaymentRequestRequest{
+				FromAccountID: from.ID,
+			},
+			userID: payer.ID,
+			build: func(store *mocks.Store) {
+				other := request
+				other.Currency = otherCurrency(from.Currency)
+				store.On("GetPaymentRequest", mock.Anything, request.ID).
+					Return(other, nil).
+					Once()
+				store.On("GetAccount", mock.Anything, from.ID).
+					Return(from, nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			name:   "AcceptExpired",
+			method: http.MethodPost,
+			url:    fmt.Sprintf("/payment-requests/%d/accept", request.ID),
+			body: acceptPaymentRequestRequest{
+				FromAccountID: from.ID,
+			},
+			userID: payer.ID,
+			build: func(store *mocks.Store) {
+				store.On("GetPaymentRequest", mock.Anything, request.ID).
+					Return(request, nil).
+					Once()
+				store.On("GetAccount", mock.Anything, from.ID).
+					Return(from, nil).
+					Once()
+				store.On("AcceptPaymentRequestTx", mock.Anything, mock.Anything).
+					Return(db.AcceptPaymentRequestTxResult{}, db.ErrPaymentRequestExpired).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, rec.Code)
+			},
+		},
+		{
+			name:   "DeclineByRequester",
+			method: http.MethodPost,
+			url:    fmt.Sprintf("/payment-requests/%d/decline", request.ID),
+			userID: requester,
+			build: func(store *mocks.Store) {
+				declined := request
+				declined.Status = db.PaymentRequestDeclined
+				store.On("GetPaymentRequest", mock.Anything, request.ID).
+					Return(request, nil).
+					Once()
+				store.On("DeclinePaymentRequestTx", mock.Anything, request.ID).
+					Return(declined, nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+			},
+		},
+		{
+			name:   "DeclineDecided",
+			method: http.MethodPost,
+			url:    fmt.Sprintf("/payment-requests/%d/decline", request.ID),
+			userID: payer.ID,
+			build: func(store *mocks.Store) {
+				store.On("GetPaymentRequest", mock.Anything, request.ID).
+					Return(accepted, nil).
+					Once()
+				store.On("DeclinePaymentRequestTx", mock.Anything, request.ID).
+					Return(db.PaymentRequest{}, db.ErrPaymentRequestDecided).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, rec.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		ts := testCases[i]
+
+		t.Run(ts.name, func(t *testing.T) {
+			store := &mocks.Store{}
+			ts.build(store)
+
+			server, err := NewServer(store, util.Config{
+				TokenSymetricKey:     "12345678901234567890123456789012",
+				AccessTokenDuration:  time.Minute,
+				PaymentRequestExpiry: expiry,
+			})
+			require.NoError(t, err)
+			rec := httptest.NewRecorder()
+
+			data, err := json.Marshal(ts.body)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(ts.method, ts.url, bytes.NewReader(data))
+			require.NoError(t, err)
+			req.Header = http.Header{
+				"Content-Type": {"application/json"},
+			}
+			addAuthorization(t, req, server.tokenMaker, "Bearer", ts.userID, util.RoleCustomer, time.Minute)
+
+			server.router.ServeHTTP(rec, req)
+			ts.check(t, rec)
+			store.AssertExpectations(t)
+		})
+	}
+}
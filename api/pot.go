@@ -0,0 +1,185 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	db "github.com/flukis/simplebank/db/sqlc"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/labstack/echo/v4"
+)
+
+type createPotRequest struct {
+	Name         string `json:"name" binding:"required"`
+	TargetAmount int64  `json:"target_amount" binding:"min=0"`
+	RoundUpTo    int64  `json:"round_up_to" binding:"min=0"`
+}
+
+func (r createPotRequest) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Name, validation.Required, validation.Length(1, 50)),
+		validation.Field(&r.TargetAmount, validation.Min(0)),
+		validation.Field(&r.RoundUpTo, validation.Min(0)),
+	)
+}
+
+type createPotSuccessResponse struct {
+	Data db.CreatePotTxResult `json:"data"`
+}
+
+// CreatePot opens a named pot under an account
+func (s *Server) CreatePot(c echo.Context) error {
+	req := new(createPotRequest)
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	account, err := s.potParent(c, db.PermManage)
+	if err != nil {
+		return err
+	}
+
+	result, err := s.store.CreatePotTx(c.Request().Context(), db.CreatePotTxParams{
+		ParentAccountID: account.ID,
+		Name:            req.Name,
+		TargetAmount:    req.TargetAmount,
+		RoundUpTo:       req.RoundUpTo,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrUniqueViolation) {
+			return newError(http.StatusForbidden, CodeAlreadyExists, errors.New("the account already has a pot with this name"))
+		}
+		return err
+	}
+
+	return c.JSON(
+		http.StatusOK,
+		&createPotSuccessResponse{
+			Data: result,
+		},
+	)
+}
+
+type listPotsResponse struct {
+	Account      db.Account               `json:"account"`
+	Pots         []db.ListPotsByParentRow `json:"pots"`
+	TotalBalance int64                    `json:"total_balance"`
+}
+
+type listPotsSuccessResponse struct {
+	Data listPotsResponse `json:"data"`
+}
+
+// ListPots returns the open pots of an account and the balance of the
+// account together with all of its pots
+func (s *Server) ListPots(c echo.Context) error {
+	account, err := s.potParent(c, db.PermView)
+	if err != nil {
+		return err
+	}
+
+	pots, err := s.store.ListPotsByParent(c.Request().Context(), account.ID)
+	if err != nil {
+		return err
+	}
+
+	total := account.Balance
+	for _, pot := range pots {
+		total += pot.Balance
+	}
+
+	return c.JSON(
+		http.StatusOK,
+		&listPotsSuccessResponse{
+			Data: listPotsResponse{
+				Account:      account,
+				Pots:         pots,
+				TotalBalance: total,
+			},
+		},
+	)
+}
+
+type movePotRequest struct {
+	Amount    int64  `json:"amount" binding:"required,gt=0"`
+	Direction string `json:"direction" binding:"required,oneof=in out"`
+}
+
+func (r movePotRequest) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Amount, validation.Required, validation.Min(1)),
+		validation.Field(&r.Direction, validation.Required, validation.In(db.PotMoveIn, db.PotMoveOut)),
+	)
+}
+
+type movePotSuccessResponse struct {
+	Data db.TransferTxResult `json:"data"`
+}
+
+// MovePot moves money into or out of one of the account's pots
+func (s *Server) MovePot(c echo.Context) error {
+	potID, err := strconv.ParseInt(c.Param("pot"), 10, 64)
+	if err != nil {
+		return invalidParam("pot", err)
+	}
+
+	req := new(movePotRequest)
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	account, err := s.potParent(c, db.PermInitiate)
+	if err != nil {
+		return err
+	}
+
+	result, err := s.store.MovePotTx(c.Request().Context(), db.MovePotTxParams{
+		AccountID:    account.ID,
+		PotAccountID: potID,
+		Amount:       req.Amount,
+		Direction:    req.Direction,
+	})
+	if err != nil {
+		return notFound(CodePotNotFound, err)
+	}
+
+	return c.JSON(
+		http.StatusOK,
+		&movePotSuccessResponse{
+			Data: result,
+		},
+	)
+}
+
+// potParent loads the account of the :id param and checks that the
+// authenticated user has need on it
+func (s *Server) potParent(c echo.Context, need db.AccountPermission) (db.Account, error) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return db.Account{}, invalidParam("id", err)
+	}
+
+	account, err := s.store.GetAccount(c.Request().Context(), id)
+	if err != nil {
+		return account, notFound(CodeAccountNotFound, err)
+	}
+
+	access, err := s.accountAccess(c, account)
+	if err != nil {
+		return account, err
+	}
+	if !access.Can(need) {
+		return account, errAccountNotOwned
+	}
+
+	return account, nil
+}
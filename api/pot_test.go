@@ -0,0 +1,254 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mocks "github.com/flukis/simplebank/db/mock"
+	db "github.com/flukis/simplebank/db/sqlc"
+	"github.com/flukis/simplebank/util"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPotAPI(t *testing.T) {
+	account := randomAccount()
+	account.Balance = 1000
+	pot := db.Pot{
+		AccountID:       util.GenRandomNum(10001, 20000),
+		ParentAccountID: account.ID,
+		Name:            "Holiday",
+		TargetAmount:    50000,
+	}
+
+	testCases := []struct {
+		name   string
+		method string
+		url    string
+		body   any
+		userID uuid.UUID
+		build  func(store *mocks.Store)
+		check  func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "CreateOK",
+			method: http.MethodPost,
+			url:    fmt.Sprintf("/account/%d/pots", account.ID),
+			body:   createPotRequest{Name: pot.Name, TargetAmount: pot.TargetAmount},
+			userID: account.OwnerID,
+			build: func(store *mocks.Store) {
+				store.On("GetAccount", mock.Anything, account.ID).
+					Return(account, nil).
+					Once()
+				store.On("CreatePotTx", mock.Anything, db.CreatePotTxParams{
+					ParentAccountID: account.ID,
+					Name:            pot.Name,
+					TargetAmount:    pot.TargetAmount,
+				}).
+					Return(db.CreatePotTxResult{Pot: pot}, nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+
+				var res createPotSuccessResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+				require.Equal(t, pot, res.Data.Pot)
+			},
+		},
+		{
+			name:   "CreateDuplicateName",
+			method: http.MethodPost,
+			url:    fmt.Sprintf("/account/%d/pots", account.ID),
+			body:   createPotRequest{Name: pot.Name},
+			userID: account.OwnerID,
+			build: func(store *mocks.Store) {
+				store.On("GetAccount", mock.Anything, account.ID).
+					Return(account, nil).
+					Once()
+				store.On("CreatePotTx", mock.Anything, mock.Anything).
+					Return(db.CreatePotTxResult{}, db.ErrUniqueViolation).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, rec.Code)
+			},
+		},
+		{
+			name:   "CreateSecondRoundUpPot",
+			method: http.MethodPost,
+			url:    fmt.Sprintf("/account/%d/pots", account.ID),
+			body:   createPotRequest{Name: "Change", RoundUpTo: 100},
+			userID: account.OwnerID,
+			build: func(store *mocks.Store) {
+				store.On("GetAccount", mock.Anything, account.ID).
+					Return(account, nil).
+					Once()
+				store.On("CreatePotTx", mock.Anything, mock.Anything).
+					Return(db.CreatePotTxResult{}, db.ErrRoundUpPotExists).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, rec.Code)
+			},
+		},
+		{
+			name:   "CreateNoName",
+			method: http.MethodPost,
+			url:    fmt.Sprintf("/account/%d/pots", account.ID),
+			body:   createPotRequest{},
+			userID: account.OwnerID,
+			build:  func(store *mocks.Store) {},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			name:   "CreateNotOwned",
+			method: http.MethodPost,
+			url:    fmt.Sprintf("/account/%d/pots", account.ID),
+			body:   createPotRequest{Name: pot.Name},
+			userID: uuid.New(),
+			build: func(store *mocks.Store) {
+				store.On("GetAccount", mock.Anything, account.ID).
+					Return(account, nil).
+					Once()
+				store.On("GetAccountHolder", mock.Anything, mock.Anything).
+					Return(db.AccountHolder{}, sql.ErrNoRows)
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, rec.Code)
+			},
+		},
+		{
+			name:   "ListOK",
+			method: http.MethodGet,
+			url:    fmt.Sprintf("/account/%d/pots", account.ID),
+			userID: account.OwnerID,
+			build: func(store *mocks.Store) {
+				store.On("GetAccount", mock.Anything, account.ID).
+					Return(account, nil).
+					Once()
+				store.On("ListPotsByParent", mock.Anything, account.ID).
+					Return([]db.ListPotsByParentRow{
+						{AccountID: pot.AccountID, Name: pot.Name, Balance: 250},
+						{AccountID: pot.AccountID + 1, Name: "Tax", Balance: 750},
+					}, nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+
+				var res listPotsSuccessResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+				require.Len(t, res.Data.Pots, 2)
+				require.Equal(t, int64(2000), res.Data.TotalBalance)
+			},
+		},
+		{
+			name:   "MoveInOK",
+			method: http.MethodPost,
+			url:    fmt.Sprintf("/account/%d/pots/%d/move", account.ID, pot.AccountID),
+			body:   movePotRequest{Amount: 100, Direction: db.PotMoveIn},
+			userID: account.OwnerID,
+			build: func(store *mocks.Store) {
+				store.On("GetAccount", mock.Anything, account.ID).
+					Return(account, nil).
+					Once()
+				store.On("MovePotTx", mock.Anything, db.MovePotTxParams{
+					AccountID:    account.ID,
+					PotAccountID: pot.AccountID,
+					Amount:       100,
+					Direction:    db.PotMoveIn,
+				}).
+					Return(db.TransferTxResult{}, nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+			},
+		},
+		{
+			name:   "MoveBadDirection",
+			method: http.MethodPost,
+			url:    fmt.Sprintf("/account/%d/pots/%d/move", account.ID, pot.AccountID),
+			body:   movePotRequest{Amount: 100, Direction: "sideways"},
+			userID: account.OwnerID,
+			build:  func(store *mocks.Store) {},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			name:   "MoveOtherAccountsPot",
+			method: http.MethodPost,
+			url:    fmt.Sprintf("/account/%d/pots/%d/move", account.ID, pot.AccountID),
+			body:   movePotRequest{Amount: 100, Direction: db.PotMoveOut},
+			userID: account.OwnerID,
+			build: func(store *mocks.Store) {
+				store.On("GetAccount", mock.Anything, account.ID).
+					Return(account, nil).
+					Once()
+				store.On("MovePotTx", mock.Anything, mock.Anything).
+					Return(db.TransferTxResult{}, db.ErrNotPotOfAccount).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, rec.Code)
+			},
+		},
+		{
+			name:   "MovePotNotFound",
+			method: http.MethodPost,
+			url:    fmt.Sprintf("/account/%d/pots/%d/move", account.ID, pot.AccountID),
+			body:   movePotRequest{Amount: 100, Direction: db.PotMoveOut},
+			userID: account.OwnerID,
+			build: func(store *mocks.Store) {
+				store.On("GetAccount", mock.Anything, account.ID).
+					Return(account, nil).
+					Once()
+				store.On("MovePotTx", mock.Anything, mock.Anything).
+					Return(db.TransferTxResult{}, sql.ErrNoRows).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, rec.Code)
+			},
+		},
+	}
+
+	for _, ts := range testCases {
+		t.Run(ts.name, func(t *testing.T) {
+			store := &mocks.Store{}
+			ts.build(store)
+
+			server, err := NewServer(store, util.Config{
+				TokenSymetricKey:    "12345678901234567890123456789012",
+				AccessTokenDuration: time.Minute,
+			})
+			require.NoError(t, err)
+			rec := httptest.NewRecorder()
+
+			data, err := json.Marshal(ts.body)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(ts.method, ts.url, bytes.NewReader(data))
+			require.NoError(t, err)
+			req.Header = http.Header{
+				"Content-Type": {"application/json"},
+			}
+			addAuthorization(t, req, server.tokenMaker, "Bearer", ts.userID, util.RoleCustomer, time.Minute)
+
+			server.router.ServeHTTP(rec, req)
+			ts.check(t, rec)
+			store.AssertExpectations(t)
+		})
+	}
+}
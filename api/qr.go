@@ -0,0 +1,186 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	db "github.com/flukis/simplebank/db/sqlc"
+	"github.com/flukis/simplebank/util"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/labstack/echo/v4"
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+var errQRAmountMismatch = errors.New("amount doesn't match the amount of the qr code")
+
+// accountQRRequest asks for a static code, or for a dynamic one when it has
+// an amount
+type accountQRRequest struct {
+	Amount    int64  `query:"amount" form:"amount"`
+	Reference string `query:"reference" form:"reference"`
+	Size      int    `query:"size" form:"size"`
+}
+
+func (r accountQRRequest) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Amount, validation.Min(int64(0))),
+		validation.Field(&r.Reference, validation.Length(0, 25)),
+		validation.Field(&r.Size, validation.Min(0), validation.Max(1024)),
+	)
+}
+
+type accountQRResponse struct {
+	Payload string `json:"payload"`
+	util.QRPayment
+}
+
+type accountQRSuccessResponse struct {
+	Data accountQRResponse `json:"data"`
+}
+
+// GetAccountQR returns the EMVCo payload others scan to pay into an account
+func (s *Server) GetAccountQR(c echo.Context) error {
+	req := new(accountQRRequest)
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	payment, payload, err := s.accountQR(c, *req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(
+		http.StatusOK,
+		&accountQRSuccessResponse{
+			Data: accountQRResponse{
+				Payload:   payload,
+				QRPayment: payment,
+			},
+		},
+	)
+}
+
+// GetAccountQRImage renders the payload of GetAccountQR as a PNG
+func (s *Server) GetAccountQRImage(c echo.Context) error {
+	req := new(accountQRRequest)
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	_, payload, err := s.accountQR(c, *req)
+	if err != nil {
+		return err
+	}
+
+	size := 256
+	if req.Size > 0 {
+		size = req.Size
+	}
+	image, err := qrcode.Encode(payload, qrcode.Medium, size)
+	if err != nil {
+		return err
+	}
+
+	return c.Blob(http.StatusOK, "image/png", image)
+}
+
+// accountQR builds the payment code req asks for of the :id account
+func (s *Server) accountQR(c echo.Context, req accountQRRequest) (util.QRPayment, string, error) {
+	var payment util.QRPayment
+
+	account, err := s.holderAccount(c, db.PermView)
+	if err != nil {
+		return payment, "", err
+	}
+	if account.Status == db.AccountStatusClosed || account.AccountType == db.AccountTypePot {
+		return payment, "", errCannotReceive
+	}
+
+	owner, err := s.store.GetUser(c.Request().Context(), account.OwnerID)
+	if err != nil {
+		return payment, "", err
+	}
+
+	payment = util.QRPayment{
+		Dynamic:       req.Amount > 0,
+		AccountNumber: account.Number,
+		Currency:      account.Currency,
+		Amount:        req.Amount,
+		MerchantName:  owner.FullName,
+		Reference:     req.Reference,
+	}
+	payload, err := util.EncodeQRPayment(payment)
+	if err != nil {
+		return payment, "", newError(http.StatusBadRequest, CodeQRInvalid, err)
+	}
+
+	return payment, payload, nil
+}
+
+// payQRRequest pays a scanned code. Static codes need the amount, for
+// dynamic ones it may be left out.
+type payQRRequest struct {
+	FromAccountID int64  `json:"from_account_id" binding:"required,min=1"`
+	Payload       string `json:"payload" binding:"required"`
+	Amount        int64  `json:"amount" binding:"omitempty,gt=0"`
+}
+
+func (r payQRRequest) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.FromAccountID, validation.Required, validation.Min(1)),
+		validation.Field(&r.Payload, validation.Required),
+		validation.Field(&r.Amount, validation.Min(int64(0))),
+	)
+}
+
+// PayQR executes the transfer a scanned payment code asks for, exactly as
+// CreateTransfer would to the account it names
+func (s *Server) PayQR(c echo.Context) error {
+	req := new(payQRRequest)
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	payment, err := util.DecodeQRPayment(req.Payload)
+	if err != nil {
+		return newError(http.StatusBadRequest, CodeQRInvalid, err)
+	}
+
+	amount := req.Amount
+	if payment.Dynamic {
+		if amount != 0 && amount != payment.Amount {
+			return errQRAmountMismatch
+		}
+		amount = payment.Amount
+	}
+
+	to, err := s.store.GetAccountByNumber(c.Request().Context(), payment.AccountNumber)
+	if err != nil {
+		return notFound(CodeAccountNotFound, err)
+	}
+
+	transfer := createTransferRequest{
+		FromAccountID: req.FromAccountID,
+		ToAccountID:   to.ID,
+		Currency:      payment.Currency,
+		Amount:        amount,
+	}
+	if err := transfer.Validate(); err != nil {
+		return err
+	}
+
+	return s.transfer(c, transfer)
+}
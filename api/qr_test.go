@@ -0,0 +1,333 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	mocks "github.com/flukis/simplebank/db/mock"
+	db "github.com/flukis/simplebank/db/sqlc"
+	"github.com/flukis/simplebank/util"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQRAPI(t *testing.T) {
+	owner := randomUser(t, util.GenRandomString(8))
+	to := randomAccount()
+	to.OwnerID = owner.ID
+	to.Number = randomAccountNumber(t)
+	from := randomAccount()
+	from.Currency = to.Currency
+
+	static, err := util.EncodeQRPayment(util.QRPayment{
+		AccountNumber: to.Number,
+		Currency:      to.Currency,
+		MerchantName:  owner.FullName,
+	})
+	require.NoError(t, err)
+	dynamic, err := util.EncodeQRPayment(util.QRPayment{
+		Dynamic:       true,
+		AccountNumber: to.Number,
+		Currency:      to.Currency,
+		Amount:        1050,
+		MerchantName:  owner.FullName,
+	})
+	require.NoError(t, err)
+	// a payload whose crc is off by one
+	badCRC := static[:len(static)-1] + "0"
+	if strings.HasSuffix(static, "0") {
+		badCRC = static[:len(static)-1] + "1"
+	}
+	otherCurrencyCode, err := util.EncodeQRPayment(util.QRPayment{
+		AccountNumber: to.Number,
+		Currency:      otherCurrency(to.Currency),
+		MerchantName:  owner.FullName,
+	})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name   string
+		method string
+		url    string
+		body   any
+		userID uuid.UUID
+		build  func(store *mocks.Store)
+		check  func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "GetStatic",
+			method: http.MethodGet,
+			url:    fmt.Sprintf("/account/%d/qr", to.ID),
+			userID: owner.ID,
+			build: func(store *mocks.Store) {
+				store.On("GetAccount", mock.Anything, to.ID).
+					Return(to, nil).
+					Once()
+				store.On("GetUser", mock.Anything, owner.ID).
+					Return(owner, nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+
+				var res accountQRSuccessResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+				require.Equal(t, static, res.Data.Payload)
+				require.False(t, res.Data.Dynamic)
+			},
+		},
+		{
+			name:   "GetDynamic",
+			method: http.MethodGet,
+			url:    fmt.Sprintf("/account/%d/qr?amount=1050", to.ID),
+			userID: owner.ID,
+			build: func(store *mocks.Store) {
+				store.On("GetAccount", mock.Anything, to.ID).
+					Return(to, nil).
+					Once()
+				store.On("GetUser", mock.Anything, owner.ID).
+					Return(owner, nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+
+				var res accountQRSuccessResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+				require.Equal(t, dynamic, res.Data.Payload)
+				require.Equal(t, int64(1050), res.Data.Amount)
+			},
+		},
+		{
+			name:   "GetImage",
+			method: http.MethodGet,
+			url:    fmt.Sprintf("/account/%d/qr/png?size=128", to.ID),
+			userID: owner.ID,
+			build: func(store *mocks.Store) {
+				store.On("GetAccount", mock.Anything, to.ID).
+					Return(to, nil).
+					Once()
+				store.On("GetUser", mock.Anything, owner.ID).
+					Return(owner, nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+				require.Equal(t, "image/png", rec.Header().Get("Content-Type"))
+				require.True(t, strings.HasPrefix(rec.Body.String(), "\x89PNG"))
+			},
+		},
+		{
+			name:   "GetNotOwned",
+			method: http.MethodGet,
+			url:    fmt.Sprintf("/account/%d/qr", to.ID),
+			userID: uuid.New(),
+			build: func(store *mocks.Store) {
+				store.On("GetAccount", mock.Anything, to.ID).
+					Return(to, nil).
+					Once()
+				store.On("GetAccountHolder", mock.Anything, mock.Anything).
+					Return(db.AccountHolder{}, sql.ErrNoRows).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, rec.Code)
+			},
+		},
+		{
+			name:   "PayStatic",
+			method: http.MethodPost,
+			url:    "/pay/qr",
+			body: payQRRequest{
+				FromAccountID: from.ID,
+				Payload:       static,
+				Amount:        700,
+			},
+			userID: from.OwnerID,
+			build: func(store *mocks.Store) {
+				store.On("GetAccountByNumber", mock.Anything, to.Number).
+					Return(to, nil).
+					Once()
+				store.On("GetAccount", mock.Anything, from.ID).
+					Return(from, nil).
+					Once()
+				store.On("GetAccount", mock.Anything, to.ID).
+					Return(to, nil).
+					Once()
+				store.On("TransferTx", mock.Anything, db.TransferTxParams{
+					FromAccountID: from.ID,
+					ToAccountID:   to.ID,
+					Amount:        700,
+				}).
+					Return(generateTransferResult(from, to, 700), nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+			},
+		},
+		{
+			name:   "PayStaticWithoutAmount",
+			method: http.MethodPost,
+			url:    "/pay/qr",
+			body: payQRRequest{
+				FromAccountID: from.ID,
+				Payload:       static,
+			},
+			userID: from.OwnerID,
+			build: func(store *mocks.Store) {
+				store.On("GetAccountByNumber", mock.Anything, to.Number).
+					Return(to, nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			name:   "PayDynamic",
+			method: http.MethodPost,
+			url:    "/pay/qr",
+			body: payQRRequest{
+				FromAccountID: from.ID,
+				Payload:       dynamic,
+			},
+			userID: from.OwnerID,
+			build: func(store *mocks.Store) {
+				store.On("GetAccountByNumber", mock.Anything, to.Number).
+					Return(to, nil).
+					Once()
+				store.On("GetAccount", mock.Anything, from.ID).
+					Return(from, nil).
+					Once()
+				store.On("GetAccount", mock.Anything, to.ID).
+					Return(to, nil).
+					Once()
+				store.On("TransferTx", mock.Anything, db.TransferTxParams{
+					FromAccountID: from.ID,
+					ToAccountID:   to.ID,
+					Amount:        1050,
+				}).
+					Return(generateTransferResult(from, to, 1050), nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+			},
+		},
+		{
+			name:   "PayDynamicOtherAmount",
+			method: http.MethodPost,
+			url:    "/pay/qr",
+			body: payQRRequest{
+				FromAccountID: from.ID,
+				Payload:       dynamic,
+				Amount:        1,
+			},
+			userID: from.OwnerID,
+			build:  func(store *mocks.Store) {},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			name:   "PayBadCRC",
+			method: http.MethodPost,
+			url:    "/pay/qr",
+			body: payQRRequest{
+				FromAccountID: from.ID,
+				Payload:       badCRC,
+				Amount:        700,
+			},
+			userID: from.OwnerID,
+			build:  func(store *mocks.Store) {},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rec.Code)
+
+				var res Problem
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+				require.Equal(t, CodeQRInvalid, res.Code)
+				require.Equal(t, util.ErrQRCRC.Error(), res.Detail)
+			},
+		},
+		{
+			name:   "PayUnknownAccount",
+			method: http.MethodPost,
+			url:    "/pay/qr",
+			body: payQRRequest{
+				FromAccountID: from.ID,
+				Payload:       static,
+				Amount:        700,
+			},
+			userID: from.OwnerID,
+			build: func(store *mocks.Store) {
+				store.On("GetAccountByNumber", mock.Anything, to.Number).
+					Return(db.Account{}, sql.ErrNoRows).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, rec.Code)
+			},
+		},
+		{
+			name:   "PayCurrencyMismatch",
+			method: http.MethodPost,
+			url:    "/pay/qr",
+			body: payQRRequest{
+				FromAccountID: from.ID,
+				Payload:       otherCurrencyCode,
+				Amount:        700,
+			},
+			userID: from.OwnerID,
+			build: func(store *mocks.Store) {
+				store.On("GetAccountByNumber", mock.Anything, to.Number).
+					Return(to, nil).
+					Once()
+				store.On("GetAccount", mock.Anything, from.ID).
+					Return(from, nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		ts := testCases[i]
+
+		t.Run(ts.name, func(t *testing.T) {
+			store := &mocks.Store{}
+			ts.build(store)
+
+			server, err := NewServer(store, util.Config{
+				TokenSymetricKey:    "12345678901234567890123456789012",
+				AccessTokenDuration: time.Minute,
+			})
+			require.NoError(t, err)
+			rec := httptest.NewRecorder()
+
+			data, err := json.Marshal(ts.body)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(ts.method, ts.url, bytes.NewReader(data))
+			require.NoError(t, err)
+			req.Header = http.Header{
+				"Content-Type": {"application/json"},
+			}
+			addAuthorization(t, req, server.tokenMaker, "Bearer", ts.userID, util.RoleCustomer, time.Minute)
+
+			server.router.ServeHTTP(rec, req)
+			ts.check(t, rec)
+			store.AssertExpectations(t)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"os"
@@ -21,29 +22,32 @@ type Server struct {
 	passwordHashing util.Argon2Param
 	tokenMaker      util.JWTMaker
 	config          util.Config
+	openAPI         []byte
 }
 
 func NewServer(store db.Store, cfg util.Config) (*Server, error) {
 	v := validator.New()
-	arg := util.Argon2Param{
-		Memory:      64 * 1024,
-		Iterations:  3,
-		Parallelism: 2,
-		SaltLength:  16,
-		KeyLength:   32,
-	}
-
 	tokenMaker, err := util.NewJWTMaker(cfg.TokenSymetricKey)
 	if err != nil {
 		return nil, fmt.Errorf("cannot create token maker: %w", err)
 	}
 
+	doc, err := NewOpenAPI()
+	if err != nil {
+		return nil, fmt.Errorf("cannot build openapi document: %w", err)
+	}
+	openAPI, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("cannot encode openapi document: %w", err)
+	}
+
 	server := &Server{
 		store:           store,
 		validate:        v,
-		passwordHashing: arg,
+		passwordHashing: util.DefaultArgon2Param,
 		tokenMaker:      *tokenMaker,
 		config:          cfg,
+		openAPI:         openAPI,
 	}
 	serverRouter(server)
 	return server, nil
@@ -51,22 +55,113 @@ func NewServer(store db.Store, cfg util.Config) (*Server, error) {
 
 func serverRouter(server *Server) {
 	router := echo.New()
+	router.HTTPErrorHandler = server.HTTPErrorHandler
+
+	docsRoutes(router, server)
 
 	router.POST("/user", server.CreateUser)
 	router.POST("/login", server.LoginUser)
 
-	accountGroup := router.Group("account", server.AuthMiddleware)
+	accountGroup := router.Group("account", server.AuthMiddleware, server.Idempotency, server.AccountNumbers("id", "pot"))
 	{
 		accountGroup.POST("/", server.CreateAccount)
 		accountGroup.GET("/:id", server.GetAccount)
 		accountGroup.GET("/", server.FetchAccount)
+		accountGroup.POST("/:id/close", server.CloseAccount)
+		accountGroup.POST("/:id/pots", server.CreatePot)
+		accountGroup.GET("/:id/pots", server.ListPots)
+		accountGroup.POST("/:id/pots/:pot/move", server.MovePot)
+		accountGroup.POST("/:id/holders", server.AddAccountHolder)
+		accountGroup.GET("/:id/holders", server.ListAccountHolders)
+		accountGroup.DELETE("/:id/holders/:user_id", server.RemoveAccountHolder)
+		accountGroup.GET("/:id/approvals", server.ListTransferApprovals)
+		accountGroup.GET("/:id/qr", server.GetAccountQR)
+		accountGroup.GET("/:id/qr/png", server.GetAccountQRImage)
 
 		accountGroup.POST("/transfer", server.CreateTransfer)
 	}
 
+	payGroup := router.Group("pay", server.AuthMiddleware, server.Idempotency, server.AccountNumbers())
+	{
+		payGroup.POST("/qr", server.PayQR)
+	}
+
+	approvalGroup := router.Group("approvals", server.AuthMiddleware, server.Idempotency)
+	{
+		approvalGroup.POST("/:id/approve", server.ApproveTransfer)
+		approvalGroup.POST("/:id/reject", server.RejectTransfer)
+	}
+
+	beneficiaryGroup := router.Group("beneficiaries", server.AuthMiddleware, server.Idempotency, server.AccountNumbers())
+	{
+		beneficiaryGroup.POST("/", server.CreateBeneficiary)
+		beneficiaryGroup.GET("/", server.ListBeneficiaries)
+		beneficiaryGroup.DELETE("/:id", server.DeleteBeneficiary)
+	}
+
+	paymentRequestGroup := router.Group("payment-requests", server.AuthMiddleware, server.Idempotency, server.AccountNumbers())
+	{
+		paymentRequestGroup.POST("/", server.CreatePaymentRequest)
+		paymentRequestGroup.POST("/split", server.SplitBill)
+		paymentRequestGroup.GET("/", server.ListPaymentRequests)
+		paymentRequestGroup.GET("/:id", server.GetPaymentRequest)
+		paymentRequestGroup.POST("/:id/accept", server.AcceptPaymentRequest)
+		paymentRequestGroup.POST("/:id/decline", server.DeclinePaymentRequest)
+	}
+
+	loanGroup := router.Group("loans", server.AuthMiddleware)
+	{
+		loanGroup.GET("/:id", server.GetLoan)
+	}
+
+	termDepositGroup := router.Group("term-deposits", server.AuthMiddleware, server.Idempotency, server.AccountNumbers())
+	{
+		termDepositGroup.GET("/rates", server.ListTermDepositRates)
+		termDepositGroup.POST("/", server.OpenTermDeposit)
+		termDepositGroup.GET("/:id", server.GetTermDeposit)
+		termDepositGroup.POST("/:id/withdraw", server.WithdrawTermDeposit)
+	}
+
+	tellerGroup := router.Group("teller", server.AuthMiddleware, server.RequireRole(util.RoleTeller), server.Idempotency, server.AccountNumbers())
+	{
+		tellerGroup.POST("/tills", server.OpenTill)
+		tellerGroup.GET("/tills/:id", server.GetTill)
+		tellerGroup.POST("/tills/:id/close", server.CloseTill)
+		tellerGroup.POST("/deposits", server.DepositCash)
+		tellerGroup.POST("/withdrawals", server.WithdrawCash)
+	}
+
+	adminGroup := router.Group("admin", server.AuthMiddleware, server.Idempotency)
+	{
+		readers := server.RequireRole(util.RoleAdmin, util.RoleAuditor)
+		admins := server.RequireRole(util.RoleAdmin)
+
+		adminGroup.GET("/accounts", server.FetchAllAccounts, readers)
+		adminGroup.GET("/users", server.FetchUsers, readers)
+		adminGroup.GET("/transfers/:id", server.GetAnyTransfer, readers)
+		adminGroup.GET("/gl/accounts", server.ListGLAccounts, readers)
+		adminGroup.GET("/reports/trial-balance", server.TrialBalance, readers)
+		adminGroup.POST("/accounts/:id/freeze", server.FreezeAccount, admins, server.AccountNumbers("id"))
+		adminGroup.POST("/accounts/:id/unfreeze", server.UnfreezeAccount, admins, server.AccountNumbers("id"))
+		adminGroup.PUT("/users/:id/role", server.UpdateUserRole, admins)
+		adminGroup.POST("/jobs/end-of-day", server.RunEndOfDay, admins)
+		adminGroup.POST("/loans", server.CreateLoan, admins, server.AccountNumbers())
+	}
+
 	server.router = router
 }
 
+// Mount serves h for every path under prefix, next to the routes of s
+func (s *Server) Mount(prefix string, h http.Handler) {
+	s.router.Any(prefix+"/*", echo.WrapHandler(h))
+}
+
+// Handler returns the router of the server, for serving it some other way
+// than Start, e.g. from an httptest.Server
+func (s *Server) Handler() http.Handler {
+	return s.router
+}
+
 func (s *Server) Start(addr string) {
 	go func() {
 		if err := s.router.Start(addr); err != nil && err != http.ErrServerClosed {
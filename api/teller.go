@@ -0,0 +1,202 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	db "github.com/flukis/simplebank/db/sqlc"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/labstack/echo/v4"
+)
+
+var errTillNotOwned = errors.New("till doesn't belong to the authenticated teller")
+
+type openTillRequest struct {
+	Currency    string `json:"currency" binding:"required,oneof=USD EUR IDR"`
+	OpeningCash int64  `json:"opening_cash" binding:"min=0"`
+}
+
+func (r openTillRequest) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Currency, validation.Required, validation.In("USD", "EUR", "IDR")),
+		validation.Field(&r.OpeningCash, validation.Min(0)),
+	)
+}
+
+type tillSuccessResponse struct {
+	Data db.TellerTill `json:"data"`
+}
+
+// OpenTill opens the teller's till for today in one currency
+func (s *Server) OpenTill(c echo.Context) error {
+	req := new(openTillRequest)
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	till, err := s.store.CreateTellerTill(c.Request().Context(), db.CreateTellerTillParams{
+		TellerID:     authPayload(c).UserID,
+		Currency:     req.Currency,
+		BusinessDate: time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC),
+		OpeningCash:  req.OpeningCash,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrUniqueViolation) {
+			return newError(http.StatusForbidden, CodeAlreadyExists, errors.New("a till in this currency was already opened today"))
+		}
+		return err
+	}
+
+	return c.JSON(
+		http.StatusOK,
+		&tillSuccessResponse{
+			Data: till,
+		},
+	)
+}
+
+type getTillSuccessResponse struct {
+	Data     db.TellerTill        `json:"data"`
+	Receipts []db.CashTransaction `json:"receipts"`
+}
+
+// GetTill returns one of the teller's tills with its receipts
+func (s *Server) GetTill(c echo.Context) error {
+	till, err := s.ownTill(c)
+	if err != nil {
+		return err
+	}
+
+	receipts, err := s.store.ListCashTransactionsByTill(c.Request().Context(), till.ID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(
+		http.StatusOK,
+		&getTillSuccessResponse{
+			Data:     till,
+			Receipts: receipts,
+		},
+	)
+}
+
+type closeTillRequest struct {
+	CountedCash int64 `json:"counted_cash" binding:"min=0"`
+}
+
+func (r closeTillRequest) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.CountedCash, validation.Min(0)),
+	)
+}
+
+// CloseTill balances the till against the cash the teller counted
+func (s *Server) CloseTill(c echo.Context) error {
+	req := new(closeTillRequest)
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	till, err := s.ownTill(c)
+	if err != nil {
+		return err
+	}
+
+	till, err = s.store.CloseTellerTillTx(c.Request().Context(), till.ID, req.CountedCash)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(
+		http.StatusOK,
+		&tillSuccessResponse{
+			Data: till,
+		},
+	)
+}
+
+// ownTill loads the till of the :id param and makes sure it belongs to the
+// authenticated teller
+func (s *Server) ownTill(c echo.Context) (db.TellerTill, error) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return db.TellerTill{}, invalidParam("id", err)
+	}
+
+	till, err := s.store.GetTellerTill(c.Request().Context(), id)
+	if err != nil {
+		return till, notFound(CodeTillNotFound, err)
+	}
+
+	if till.TellerID != authPayload(c).UserID {
+		return till, errTillNotOwned
+	}
+
+	return till, nil
+}
+
+type cashRequest struct {
+	AccountID int64 `json:"account_id" binding:"required,min=1"`
+	Amount    int64 `json:"amount" binding:"required,gt=0"`
+}
+
+func (r cashRequest) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.AccountID, validation.Required, validation.Min(1)),
+		validation.Field(&r.Amount, validation.Required, validation.Min(1)),
+	)
+}
+
+type cashSuccessResponse struct {
+	Data db.CashTxResult `json:"data"`
+}
+
+// DepositCash credits an account with cash received at the counter
+func (s *Server) DepositCash(c echo.Context) error {
+	return s.cash(c, s.store.DepositCashTx)
+}
+
+// WithdrawCash pays out cash at the counter from an account
+func (s *Server) WithdrawCash(c echo.Context) error {
+	return s.cash(c, s.store.WithdrawCashTx)
+}
+
+func (s *Server) cash(c echo.Context, post func(ctx context.Context, arg db.CashTxParams) (db.CashTxResult, error)) error {
+	req := new(cashRequest)
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	result, err := post(c.Request().Context(), db.CashTxParams{
+		TellerID:  authPayload(c).UserID,
+		AccountID: req.AccountID,
+		Amount:    req.Amount,
+	})
+	if err != nil {
+		return notFound(CodeAccountNotFound, err)
+	}
+
+	return c.JSON(
+		http.StatusOK,
+		&cashSuccessResponse{
+			Data: result,
+		},
+	)
+}
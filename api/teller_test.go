@@ -0,0 +1,247 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mocks "github.com/flukis/simplebank/db/mock"
+	db "github.com/flukis/simplebank/db/sqlc"
+	"github.com/flukis/simplebank/util"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTellerAPI(t *testing.T) {
+	tellerID := uuid.New()
+	account := randomAccount()
+
+	till := db.TellerTill{
+		ID:           util.GenRandomNum(1, 1000),
+		TellerID:     tellerID,
+		Currency:     account.Currency,
+		Status:       db.TillStatusOpen,
+		OpeningCash:  100000,
+		ExpectedCash: 100000,
+	}
+	otherTill := till
+	otherTill.TellerID = uuid.New()
+
+	testCases := []struct {
+		name   string
+		method string
+		url    string
+		body   any
+		role   string
+		build  func(store *mocks.Store)
+		check  func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "OpenTillOK",
+			method: http.MethodPost,
+			url:    "/teller/tills",
+			body:   openTillRequest{Currency: till.Currency, OpeningCash: till.OpeningCash},
+			role:   util.RoleTeller,
+			build: func(store *mocks.Store) {
+				store.On("CreateTellerTill", mock.Anything, mock.MatchedBy(func(arg db.CreateTellerTillParams) bool {
+					return arg.TellerID == tellerID && arg.Currency == till.Currency && arg.OpeningCash == till.OpeningCash
+				})).
+					Return(till, nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+			},
+		},
+		{
+			name:   "OpenTillForbiddenCustomer",
+			method: http.MethodPost,
+			url:    "/teller/tills",
+			body:   openTillRequest{Currency: till.Currency, OpeningCash: till.OpeningCash},
+			role:   util.RoleCustomer,
+			build:  func(store *mocks.Store) {},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, rec.Code)
+			},
+		},
+		{
+			name:   "GetTillOK",
+			method: http.MethodGet,
+			url:    fmt.Sprintf("/teller/tills/%d", till.ID),
+			role:   util.RoleTeller,
+			build: func(store *mocks.Store) {
+				store.On("GetTellerTill", mock.Anything, till.ID).
+					Return(till, nil).
+					Once()
+				store.On("ListCashTransactionsByTill", mock.Anything, till.ID).
+					Return([]db.CashTransaction{{ID: 1, TillID: till.ID, Kind: db.CashDeposit, Amount: 500}}, nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+
+				var res getTillSuccessResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+				require.Equal(t, till.ID, res.Data.ID)
+				require.Len(t, res.Receipts, 1)
+			},
+		},
+		{
+			name:   "GetTillOtherTeller",
+			method: http.MethodGet,
+			url:    fmt.Sprintf("/teller/tills/%d", otherTill.ID),
+			role:   util.RoleTeller,
+			build: func(store *mocks.Store) {
+				store.On("GetTellerTill", mock.Anything, otherTill.ID).
+					Return(otherTill, nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, rec.Code)
+			},
+		},
+		{
+			name:   "CloseTillOK",
+			method: http.MethodPost,
+			url:    fmt.Sprintf("/teller/tills/%d/close", till.ID),
+			body:   closeTillRequest{CountedCash: 99000},
+			role:   util.RoleTeller,
+			build: func(store *mocks.Store) {
+				closed := till
+				closed.Status = db.TillStatusClosed
+				closed.CountedCash = 99000
+				closed.Difference = -1000
+				store.On("GetTellerTill", mock.Anything, till.ID).
+					Return(till, nil).
+					Once()
+				store.On("CloseTellerTillTx", mock.Anything, till.ID, int64(99000)).
+					Return(closed, nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+
+				var res tillSuccessResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+				require.Equal(t, int64(-1000), res.Data.Difference)
+			},
+		},
+		{
+			name:   "DepositOK",
+			method: http.MethodPost,
+			url:    "/teller/deposits",
+			body:   cashRequest{AccountID: account.ID, Amount: 500},
+			role:   util.RoleTeller,
+			build: func(store *mocks.Store) {
+				store.On("DepositCashTx", mock.Anything, db.CashTxParams{
+					TellerID:  tellerID,
+					AccountID: account.ID,
+					Amount:    500,
+				}).
+					Return(db.CashTxResult{
+						Receipt: db.CashTransaction{ID: 1, Kind: db.CashDeposit, Amount: 500, BalanceAfter: account.Balance + 500},
+						Account: account,
+						Till:    till,
+					}, nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+
+				var res cashSuccessResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+				require.Equal(t, db.CashDeposit, res.Data.Receipt.Kind)
+			},
+		},
+		{
+			name:   "DepositNoOpenTill",
+			method: http.MethodPost,
+			url:    "/teller/deposits",
+			body:   cashRequest{AccountID: account.ID, Amount: 500},
+			role:   util.RoleTeller,
+			build: func(store *mocks.Store) {
+				store.On("DepositCashTx", mock.Anything, mock.Anything).
+					Return(db.CashTxResult{}, db.ErrTillNotOpen).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, rec.Code)
+			},
+		},
+		{
+			name:   "DepositBadAmount",
+			method: http.MethodPost,
+			url:    "/teller/deposits",
+			body:   cashRequest{AccountID: account.ID, Amount: -5},
+			role:   util.RoleTeller,
+			build:  func(store *mocks.Store) {},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			name:   "WithdrawInsufficientFunds",
+			method: http.MethodPost,
+			url:    "/teller/withdrawals",
+			body:   cashRequest{AccountID: account.ID, Amount: account.Balance + 1},
+			role:   util.RoleTeller,
+			build: func(store *mocks.Store) {
+				store.On("WithdrawCashTx", mock.Anything, mock.Anything).
+					Return(db.CashTxResult{}, &db.InsufficientFundsError{AccountID: account.ID, Balance: account.Balance, Amount: account.Balance + 1}).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, rec.Code)
+			},
+		},
+		{
+			name:   "WithdrawAccountNotFound",
+			method: http.MethodPost,
+			url:    "/teller/withdrawals",
+			body:   cashRequest{AccountID: account.ID, Amount: 1},
+			role:   util.RoleTeller,
+			build: func(store *mocks.Store) {
+				store.On("WithdrawCashTx", mock.Anything, mock.Anything).
+					Return(db.CashTxResult{}, sql.ErrNoRows).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, rec.Code)
+			},
+		},
+	}
+
+	for _, ts := range testCases {
+		t.Run(ts.name, func(t *testing.T) {
+			store := &mocks.Store{}
+			ts.build(store)
+
+			server, err := NewServer(store, util.Config{
+				TokenSymetricKey:    "12345678901234567890123456789012",
+				AccessTokenDuration: time.Minute,
+			})
+			require.NoError(t, err)
+			rec := httptest.NewRecorder()
+
+			data, err := json.Marshal(ts.body)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(ts.method, ts.url, bytes.NewReader(data))
+			require.NoError(t, err)
+			req.Header = http.Header{
+				"Content-Type": {"application/json"},
+			}
+			addAuthorization(t, req, server.tokenMaker, "Bearer", tellerID, ts.role, time.Minute)
+
+			server.router.ServeHTTP(rec, req)
+			ts.check(t, rec)
+			store.AssertExpectations(t)
+		})
+	}
+}
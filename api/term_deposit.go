@@ -0,0 +1,190 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	db "github.com/flukis/simplebank/db/sqlc"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/labstack/echo/v4"
+)
+
+type listTermDepositRatesSuccessResponse struct {
+	Data []db.TermDepositRate `json:"data"`
+}
+
+// ListTermDepositRates returns the terms and rates currently offered
+func (s *Server) ListTermDepositRates(c echo.Context) error {
+	rates, err := s.store.ListTermDepositRates(c.Request().Context())
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(
+		http.StatusOK,
+		&listTermDepositRatesSuccessResponse{
+			Data: rates,
+		},
+	)
+}
+
+type openTermDepositRequest struct {
+	AccountID       int64  `json:"account_id" binding:"required,min=1"`
+	PayoutAccountID int64  `json:"payout_account_id" binding:"min=0"`
+	Amount          int64  `json:"amount" binding:"required,gt=0"`
+	TermMonths      int32  `json:"term_months" binding:"required,min=1"`
+	OnMaturity      string `json:"on_maturity" binding:"oneof=payout rollover"`
+}
+
+func (r openTermDepositRequest) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.AccountID, validation.Required, validation.Min(1)),
+		validation.Field(&r.PayoutAccountID, validation.Min(0)),
+		validation.Field(&r.Amount, validation.Required, validation.Min(1)),
+		validation.Field(&r.TermMonths, validation.Required, validation.Min(1)),
+		validation.Field(&r.OnMaturity, validation.In(db.OnMaturityPayout, db.OnMaturityRollover)),
+	)
+}
+
+type openTermDepositSuccessResponse struct {
+	Data db.OpenTermDepositTxResult `json:"data"`
+}
+
+// OpenTermDeposit locks funds from an account in a term deposit. Principal
+// and interest are paid back to the funding account at maturity unless
+// another account of the same owner is given.
+func (s *Server) OpenTermDeposit(c echo.Context) error {
+	req := new(openTermDepositRequest)
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	account, err := s.store.GetAccount(c.Request().Context(), req.AccountID)
+	if err != nil {
+		return notFound(CodeAccountNotFound, err)
+	}
+
+	access, err := s.accountAccess(c, account)
+	if err != nil {
+		return err
+	}
+	if !access.Can(db.PermInitiate) {
+		return errAccountNotOwned
+	}
+	// there is no approval for deposits, holders can only lock up what
+	// they could transfer on their own
+	if access.NeedsApproval(req.Amount) {
+		return errSpendLimit
+	}
+
+	if req.PayoutAccountID == 0 {
+		req.PayoutAccountID = account.ID
+	} else if req.PayoutAccountID != account.ID {
+		payout, err := s.validAccount(c, req.PayoutAccountID, account.Currency)
+		if err != nil {
+			return err
+		}
+		if payout.OwnerID != account.OwnerID {
+			return errAccountNotOwned
+		}
+	}
+
+	if req.OnMaturity == "" {
+		req.OnMaturity = db.OnMaturityPayout
+	}
+
+	result, err := s.store.OpenTermDepositTx(c.Request().Context(), db.OpenTermDepositTxParams{
+		AccountID:       req.AccountID,
+		PayoutAccountID: req.PayoutAccountID,
+		Amount:          req.Amount,
+		TermMonths:      req.TermMonths,
+		OnMaturity:      req.OnMaturity,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(
+		http.StatusOK,
+		&openTermDepositSuccessResponse{
+			Data: result,
+		},
+	)
+}
+
+type getTermDepositSuccessResponse struct {
+	Data db.TermDeposit `json:"data"`
+}
+
+// GetTermDeposit returns a term deposit of the authenticated user
+func (s *Server) GetTermDeposit(c echo.Context) error {
+	deposit, err := s.termDeposit(c, db.PermView)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(
+		http.StatusOK,
+		&getTermDepositSuccessResponse{
+			Data: deposit,
+		},
+	)
+}
+
+type withdrawTermDepositSuccessResponse struct {
+	Data db.TermDepositClosure `json:"data"`
+}
+
+// WithdrawTermDeposit breaks a term deposit before maturity, paying reduced
+// interest as set by the deposit's penalty
+func (s *Server) WithdrawTermDeposit(c echo.Context) error {
+	deposit, err := s.termDeposit(c, db.PermInitiate)
+	if err != nil {
+		return err
+	}
+
+	closure, err := s.store.WithdrawTermDepositTx(c.Request().Context(), deposit.ID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(
+		http.StatusOK,
+		&withdrawTermDepositSuccessResponse{
+			Data: closure,
+		},
+	)
+}
+
+// termDeposit loads the term deposit of the :id param and checks that the
+// authenticated user has need on its funding account
+func (s *Server) termDeposit(c echo.Context, need db.AccountPermission) (db.TermDeposit, error) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return db.TermDeposit{}, invalidParam("id", err)
+	}
+
+	deposit, err := s.store.GetTermDeposit(c.Request().Context(), id)
+	if err != nil {
+		return deposit, notFound(CodeTermDepositNotFound, err)
+	}
+
+	account, err := s.store.GetAccount(c.Request().Context(), deposit.AccountID)
+	if err != nil {
+		return deposit, err
+	}
+
+	access, err := s.accountAccess(c, account)
+	if err != nil {
+		return deposit, err
+	}
+	if !access.Can(need) {
+		return deposit, errAccountNotOwned
+	}
+
+	return deposit, nil
+}
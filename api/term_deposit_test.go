@@ -0,0 +1,334 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mocks "github.com/flukis/simplebank/db/mock"
+	db "github.com/flukis/simplebank/db/sqlc"
+	"github.com/flukis/simplebank/util"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTermDepositAPI(t *testing.T) {
+	account := randomAccount()
+	payout := randomAccount()
+	payout.OwnerID = account.OwnerID
+	payout.Currency = account.Currency
+	otherAccount := randomAccount()
+	otherAccount.Currency = account.Currency
+
+	deposit := db.TermDeposit{
+		ID:              util.GenRandomNum(1, 1000),
+		AccountID:       account.ID,
+		PayoutAccountID: account.ID,
+		Currency:        account.Currency,
+		Principal:       10000,
+		RateBps:         400,
+		PenaltyBps:      200,
+		TermMonths:      6,
+		OnMaturity:      db.OnMaturityPayout,
+		Status:          db.TermDepositActive,
+	}
+
+	testCases := []struct {
+		name   string
+		method string
+		url    string
+		body   any
+		userID uuid.UUID
+		build  func(store *mocks.Store)
+		check  func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "ListRatesOK",
+			method: http.MethodGet,
+			url:    "/term-deposits/rates",
+			userID: account.OwnerID,
+			build: func(store *mocks.Store) {
+				store.On("ListTermDepositRates", mock.Anything).
+					Return([]db.TermDepositRate{{Currency: "USD", TermMonths: 6, RateBps: 400}}, nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+			},
+		},
+		{
+			name:   "OpenOK",
+			method: http.MethodPost,
+			url:    "/term-deposits/",
+			body:   openTermDepositRequest{AccountID: account.ID, Amount: 10000, TermMonths: 6},
+			userID: account.OwnerID,
+			build: func(store *mocks.Store) {
+				store.On("GetAccount", mock.Anything, account.ID).
+					Return(account, nil).
+					Once()
+				store.On("OpenTermDepositTx", mock.Anything, db.OpenTermDepositTxParams{
+					AccountID:       account.ID,
+					PayoutAccountID: account.ID,
+					Amount:          10000,
+					TermMonths:      6,
+					OnMaturity:      db.OnMaturityPayout,
+				}).
+					Return(db.OpenTermDepositTxResult{Deposit: deposit}, nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+
+				var res openTermDepositSuccessResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+				require.Equal(t, deposit.ID, res.Data.Deposit.ID)
+			},
+		},
+		{
+			name:   "OpenRolloverToPayoutAccount",
+			method: http.MethodPost,
+			url:    "/term-deposits/",
+			body: openTermDepositRequest{
+				AccountID:       account.ID,
+				PayoutAccountID: payout.ID,
+				Amount:          10000,
+				TermMonths:      6,
+				OnMaturity:      db.OnMaturityRollover,
+			},
+			userID: account.OwnerID,
+			build: func(store *mocks.Store) {
+				store.On("GetAccount", mock.Anything, account.ID).
+					Return(account, nil).
+					Once()
+				store.On("GetAccount", mock.Anything, payout.ID).
+					Return(payout, nil).
+					Once()
+				store.On("OpenTermDepositTx", mock.Anything, db.OpenTermDepositTxParams{
+					AccountID:       account.ID,
+					PayoutAccountID: payout.ID,
+					Amount:          10000,
+					TermMonths:      6,
+					OnMaturity:      db.OnMaturityRollover,
+				}).
+					Return(db.OpenTermDepositTxResult{Deposit: deposit}, nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+			},
+		},
+		{
+			name:   "OpenPayoutToOtherOwner",
+			method: http.MethodPost,
+			url:    "/term-deposits/",
+			body:   openTermDepositRequest{AccountID: account.ID, PayoutAccountID: otherAccount.ID, Amount: 10000, TermMonths: 6},
+			userID: account.OwnerID,
+			build: func(store *mocks.Store) {
+				store.On("GetAccount", mock.Anything, account.ID).
+					Return(account, nil).
+					Once()
+				store.On("GetAccount", mock.Anything, otherAccount.ID).
+					Return(otherAccount, nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, rec.Code)
+			},
+		},
+		{
+			name:   "OpenNotOwned",
+			method: http.MethodPost,
+			url:    "/term-deposits/",
+			body:   openTermDepositRequest{AccountID: account.ID, Amount: 10000, TermMonths: 6},
+			userID: uuid.New(),
+			build: func(store *mocks.Store) {
+				store.On("GetAccount", mock.Anything, account.ID).
+					Return(account, nil).
+					Once()
+				store.On("GetAccountHolder", mock.Anything, mock.Anything).
+					Return(db.AccountHolder{}, sql.ErrNoRows)
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, rec.Code)
+			},
+		},
+		{
+			name:   "OpenTermNotOffered",
+			method: http.MethodPost,
+			url:    "/term-deposits/",
+			body:   openTermDepositRequest{AccountID: account.ID, Amount: 10000, TermMonths: 7},
+			userID: account.OwnerID,
+			build: func(store *mocks.Store) {
+				store.On("GetAccount", mock.Anything, account.ID).
+					Return(account, nil).
+					Once()
+				store.On("OpenTermDepositTx", mock.Anything, mock.Anything).
+					Return(db.OpenTermDepositTxResult{}, db.ErrTermNotOffered).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			name:   "OpenInsufficientFunds",
+			method: http.MethodPost,
+			url:    "/term-deposits/",
+			body:   openTermDepositRequest{AccountID: account.ID, Amount: 10000, TermMonths: 6},
+			userID: account.OwnerID,
+			build: func(store *mocks.Store) {
+				store.On("GetAccount", mock.Anything, account.ID).
+					Return(account, nil).
+					Once()
+				store.On("OpenTermDepositTx", mock.Anything, mock.Anything).
+					Return(db.OpenTermDepositTxResult{}, &db.InsufficientFundsError{AccountID: account.ID, Amount: 10000}).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, rec.Code)
+			},
+		},
+		{
+			name:   "OpenBadOnMaturity",
+			method: http.MethodPost,
+			url:    "/term-deposits/",
+			body:   openTermDepositRequest{AccountID: account.ID, Amount: 10000, TermMonths: 6, OnMaturity: "reinvest"},
+			userID: account.OwnerID,
+			build:  func(store *mocks.Store) {},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			name:   "GetOK",
+			method: http.MethodGet,
+			url:    fmt.Sprintf("/term-deposits/%d", deposit.ID),
+			userID: account.OwnerID,
+			build: func(store *mocks.Store) {
+				store.On("GetTermDeposit", mock.Anything, deposit.ID).
+					Return(deposit, nil).
+					Once()
+				store.On("GetAccount", mock.Anything, account.ID).
+					Return(account, nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+			},
+		},
+		{
+			name:   "GetNotFound",
+			method: http.MethodGet,
+			url:    fmt.Sprintf("/term-deposits/%d", deposit.ID),
+			userID: account.OwnerID,
+			build: func(store *mocks.Store) {
+				store.On("GetTermDeposit", mock.Anything, deposit.ID).
+					Return(db.TermDeposit{}, sql.ErrNoRows).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, rec.Code)
+			},
+		},
+		{
+			name:   "WithdrawOK",
+			method: http.MethodPost,
+			url:    fmt.Sprintf("/term-deposits/%d/withdraw", deposit.ID),
+			userID: account.OwnerID,
+			build: func(store *mocks.Store) {
+				store.On("GetTermDeposit", mock.Anything, deposit.ID).
+					Return(deposit, nil).
+					Once()
+				store.On("GetAccount", mock.Anything, account.ID).
+					Return(account, nil).
+					Once()
+				closed := deposit
+				closed.Status = db.TermDepositWithdrawn
+				store.On("WithdrawTermDepositTx", mock.Anything, deposit.ID).
+					Return(db.TermDepositClosure{Deposit: closed, Interest: 12}, nil).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+
+				var res withdrawTermDepositSuccessResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+				require.Equal(t, db.TermDepositWithdrawn, res.Data.Deposit.Status)
+				require.Equal(t, int64(12), res.Data.Interest)
+			},
+		},
+		{
+			name:   "WithdrawClosed",
+			method: http.MethodPost,
+			url:    fmt.Sprintf("/term-deposits/%d/withdraw", deposit.ID),
+			userID: account.OwnerID,
+			build: func(store *mocks.Store) {
+				store.On("GetTermDeposit", mock.Anything, deposit.ID).
+					Return(deposit, nil).
+					Once()
+				store.On("GetAccount", mock.Anything, account.ID).
+					Return(account, nil).
+					Once()
+				store.On("WithdrawTermDepositTx", mock.Anything, deposit.ID).
+					Return(db.TermDepositClosure{}, db.ErrTermDepositClosed).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, rec.Code)
+			},
+		},
+		{
+			name:   "WithdrawNotOwned",
+			method: http.MethodPost,
+			url:    fmt.Sprintf("/term-deposits/%d/withdraw", deposit.ID),
+			userID: uuid.New(),
+			build: func(store *mocks.Store) {
+				store.On("GetTermDeposit", mock.Anything, deposit.ID).
+					Return(deposit, nil).
+					Once()
+				store.On("GetAccount", mock.Anything, account.ID).
+					Return(account, nil).
+					Once()
+				store.On("GetAccountHolder", mock.Anything, mock.Anything).
+					Return(db.AccountHolder{}, sql.ErrNoRows)
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, rec.Code)
+			},
+		},
+	}
+
+	for _, ts := range testCases {
+		t.Run(ts.name, func(t *testing.T) {
+			store := &mocks.Store{}
+			ts.build(store)
+
+			server, err := NewServer(store, util.Config{
+				TokenSymetricKey:    "12345678901234567890123456789012",
+				AccessTokenDuration: time.Minute,
+			})
+			require.NoError(t, err)
+			rec := httptest.NewRecorder()
+
+			data, err := json.Marshal(ts.body)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(ts.method, ts.url, bytes.NewReader(data))
+			require.NoError(t, err)
+			req.Header = http.Header{
+				"Content-Type": {"application/json"},
+			}
+			addAuthorization(t, req, server.tokenMaker, "Bearer", ts.userID, util.RoleCustomer, time.Minute)
+
+			server.router.ServeHTTP(rec, req)
+			ts.check(t, rec)
+			store.AssertExpectations(t)
+		})
+	}
+}
@@ -1,7 +1,6 @@
 package api
 
 import (
-	"database/sql"
 	"fmt"
 	"net/http"
 
@@ -10,26 +9,32 @@ import (
 	"github.com/labstack/echo/v4"
 )
 
-type createTransferErrorResponse struct {
-	Error string `json:"error"`
-}
-
 type createTransferSuccessResponse struct {
 	Data db.TransferTxResult `json:"data"`
 }
 
+// createTransferPendingResponse is returned instead of the transfer when it
+// needs to be approved first
+type createTransferPendingResponse struct {
+	Data db.TransferApproval `json:"data"`
+}
+
+// createTransferRequest names the destination either by account or by a
+// beneficiary of the authenticated user, never both
 type createTransferRequest struct {
 	FromAccountID int64  `json:"from_account_id" binding:"required,min=1"`
-	ToAccountID   int64  `json:"to_account_id" binding:"required,min=1"`
+	ToAccountID   int64  `json:"to_account_id" binding:"omitempty,min=1"`
+	BeneficiaryID int64  `json:"beneficiary_id" binding:"omitempty,min=1"`
 	Currency      string `json:"currency" binding:"required,oneof=USD EUR IDR"`
-	Amount        int64  `json:"amount" binding:"requied,gt=0"`
+	Amount        int64  `json:"amount" binding:"required,gt=0"`
 }
 
 func (r createTransferRequest) Validate() error {
 	return validation.ValidateStruct(&r,
 		validation.Field(&r.Currency, validation.Required, validation.In("USD", "EUR", "IDR")),
 		validation.Field(&r.FromAccountID, validation.Required, validation.Min(1)),
-		validation.Field(&r.ToAccountID, validation.Required, validation.Min(1)),
+		validation.Field(&r.ToAccountID, validation.When(r.BeneficiaryID == 0, validation.Required).Else(validation.Empty), validation.Min(1)),
+		validation.Field(&r.BeneficiaryID, validation.Min(1)),
 		validation.Field(&r.Amount, validation.Required, validation.Min(0)),
 	)
 }
@@ -37,26 +42,63 @@ func (r createTransferRequest) Validate() error {
 func (s *Server) CreateTransfer(c echo.Context) error {
 	req := new(createTransferRequest)
 	if err := c.Bind(req); err != nil {
-		return c.JSON(
-			http.StatusBadRequest,
-			&createTransferErrorResponse{
-				Error: err.Error(),
-			},
-		)
+		return err
 	}
 
 	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	return s.transfer(c, *req)
+}
+
+// transfer executes a validated transfer request on behalf of the
+// authenticated user, or files it for approval when it is above their spend
+// limit
+func (s *Server) transfer(c echo.Context, req createTransferRequest) error {
+	if req.BeneficiaryID != 0 {
+		saved, err := s.ownBeneficiary(c, req.BeneficiaryID)
+		if err != nil {
+			return err
+		}
+		req.ToAccountID = saved.AccountID
+	}
+
+	fromAccount, err := s.validAccount(c, req.FromAccountID, req.Currency)
+	if err != nil {
+		return err
+	}
+	access, err := s.accountAccess(c, fromAccount)
+	if err != nil {
+		return err
+	}
+	if !access.Can(db.PermInitiate) {
+		return errAccountNotOwned
+	}
+	if _, err := s.validAccount(c, req.ToAccountID, req.Currency); err != nil {
+		return err
+	}
+
+	// transfers above the holder's spend limit wait for another holder
+	if access.NeedsApproval(req.Amount) {
+		approval, err := s.store.CreateTransferApproval(c.Request().Context(), db.CreateTransferApprovalParams{
+			FromAccountID: req.FromAccountID,
+			ToAccountID:   req.ToAccountID,
+			Amount:        req.Amount,
+			RequestedBy:   authPayload(c).UserID,
+		})
+		if err != nil {
+			return err
+		}
+
 		return c.JSON(
-			http.StatusBadRequest,
-			&createTransferErrorResponse{
-				Error: err.Error(),
+			http.StatusAccepted,
+			&createTransferPendingResponse{
+				Data: approval,
 			},
 		)
 	}
 
-	s.validAccount(c, req.FromAccountID, req.Currency)
-	s.validAccount(c, req.ToAccountID, req.Currency)
-
 	arg := db.TransferTxParams{
 		FromAccountID: req.FromAccountID,
 		ToAccountID:   req.ToAccountID,
@@ -65,12 +107,7 @@ func (s *Server) CreateTransfer(c echo.Context) error {
 
 	transfer, err := s.store.TransferTx(c.Request().Context(), arg)
 	if err != nil {
-		return c.JSON(
-			http.StatusInternalServerError,
-			&createTransferErrorResponse{
-				Error: err.Error(),
-			},
-		)
+		return err
 	}
 
 	return c.JSON(
@@ -81,36 +118,15 @@ func (s *Server) CreateTransfer(c echo.Context) error {
 	)
 }
 
-func (s *Server) validAccount(c echo.Context, accountId int64, currency string) bool {
+func (s *Server) validAccount(c echo.Context, accountId int64, currency string) (db.Account, error) {
 	account, err := s.store.GetAccount(c.Request().Context(), accountId)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			c.JSON(
-				http.StatusNotFound,
-				&createTransferErrorResponse{
-					Error: err.Error(),
-				},
-			)
-			return false
-		}
-		c.JSON(
-			http.StatusInternalServerError,
-			&createTransferErrorResponse{
-				Error: err.Error(),
-			},
-		)
-		return false
+		return account, notFound(CodeAccountNotFound, err)
 	}
 
 	if account.Currency != currency {
-		c.JSON(
-			http.StatusBadRequest,
-			&createTransferErrorResponse{
-				Error: fmt.Sprintf("account with id %d have mismatch currency, expected %s got %s", accountId, account.Currency, currency),
-			},
-		)
-		return false
+		return account, newError(http.StatusBadRequest, CodeCurrencyMismatch, fmt.Errorf("account with id %d have mismatch currency, expected %s got %s", accountId, account.Currency, currency))
 	}
 
-	return true
+	return account, nil
 }
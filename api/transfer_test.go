@@ -12,6 +12,7 @@ import (
 	mocks "github.com/flukis/simplebank/db/mock"
 	db "github.com/flukis/simplebank/db/sqlc"
 	"github.com/flukis/simplebank/util"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
@@ -20,18 +21,29 @@ func TestTransferAPI(t *testing.T) {
 
 	fromAcc := db.Account{
 		ID:       util.GenRandomNum(1, 10000),
+		OwnerID:  uuid.New(),
 		Balance:  util.GenRandomMoney(),
 		Currency: "IDR",
 	}
 
 	toAcc := db.Account{
 		ID:       util.GenRandomNum(1, 10000),
+		OwnerID:  uuid.New(),
 		Balance:  util.GenRandomMoney(),
 		Currency: "IDR",
 	}
 
 	transfer := generateTransferResult(fromAcc, toAcc, 100)
 
+	resetsAt := time.Now().Add(time.Hour)
+	limitErr := &db.TransferLimitError{
+		Limit:    db.LimitDailyAmount,
+		Scope:    db.LimitScopeAccount,
+		Max:      1000,
+		Used:     950,
+		ResetsAt: &resetsAt,
+	}
+
 	type wrongCreateTransferParams struct {
 		FromAccountID int64  `json:"from_account_id" binding:"required,min=1"`
 		ToAccountID   int64  `json:"to_account_id" binding:"required,min=1"`
@@ -152,6 +164,113 @@ func TestTransferAPI(t *testing.T) {
 				require.Equal(t, http.StatusInternalServerError, rec.Code)
 			},
 		},
+		{
+			name: "StatusForbiddenTransferLimit",
+			body: createTransferRequest{
+				FromAccountID: fromAcc.ID,
+				ToAccountID:   toAcc.ID,
+				Currency:      "IDR",
+				Amount:        100,
+			},
+			build: func(store *mocks.Store) {
+				arg := db.TransferTxParams{
+					FromAccountID: fromAcc.ID,
+					ToAccountID:   toAcc.ID,
+					Amount:        100,
+				}
+				store.On("GetAccount", mock.Anything, fromAcc.ID).
+					Return(fromAcc, nil).
+					Once()
+				store.On("GetAccount", mock.Anything, toAcc.ID).
+					Return(toAcc, nil).
+					Once()
+				store.On("TransferTx", mock.Anything, arg).
+					Return(db.TransferTxResult{}, limitErr).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, rec.Code)
+
+				var res Problem
+				err := json.Unmarshal(rec.Body.Bytes(), &res)
+				require.NoError(t, err)
+				require.Equal(t, CodeTransferLimit, res.Code)
+				require.Equal(t, limitErr.Limit, res.Limit.Limit)
+				require.Equal(t, limitErr.Scope, res.Limit.Scope)
+				require.Equal(t, limitErr.Max, res.Limit.Max)
+				require.WithinDuration(t, *limitErr.ResetsAt, *res.Limit.ResetsAt, time.Second)
+			},
+		},
+		{
+			name: "StatusForbiddenNotOwner",
+			body: createTransferRequest{
+				FromAccountID: toAcc.ID,
+				ToAccountID:   fromAcc.ID,
+				Currency:      "IDR",
+				Amount:        100,
+			},
+			build: func(store *mocks.Store) {
+				store.On("GetAccount", mock.Anything, toAcc.ID).
+					Return(toAcc, nil).
+					Once()
+				store.On("GetAccountHolder", mock.Anything, mock.Anything).
+					Return(db.AccountHolder{}, sql.ErrNoRows)
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, rec.Code)
+			},
+		},
+		{
+			name: "StatusForbiddenAccountFrozen",
+			body: createTransferRequest{
+				FromAccountID: fromAcc.ID,
+				ToAccountID:   toAcc.ID,
+				Currency:      "IDR",
+				Amount:        100,
+			},
+			build: func(store *mocks.Store) {
+				arg := db.TransferTxParams{
+					FromAccountID: fromAcc.ID,
+					ToAccountID:   toAcc.ID,
+					Amount:        100,
+				}
+				store.On("GetAccount", mock.Anything, fromAcc.ID).
+					Return(fromAcc, nil).
+					Once()
+				store.On("GetAccount", mock.Anything, toAcc.ID).
+					Return(toAcc, nil).
+					Once()
+				store.On("TransferTx", mock.Anything, arg).
+					Return(db.TransferTxResult{}, &db.AccountStatusError{AccountID: toAcc.ID, Status: db.AccountStatusFrozen}).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, rec.Code)
+			},
+		},
+		{
+			name: "StatusForbiddenPotTransfer",
+			body: createTransferRequest{
+				FromAccountID: fromAcc.ID,
+				ToAccountID:   toAcc.ID,
+				Currency:      "IDR",
+				Amount:        100,
+			},
+			build: func(store *mocks.Store) {
+				store.On("GetAccount", mock.Anything, fromAcc.ID).
+					Return(fromAcc, nil).
+					Once()
+				store.On("GetAccount", mock.Anything, toAcc.ID).
+					Return(toAcc, nil).
+					Once()
+				store.On("TransferTx", mock.Anything, mock.Anything).
+					Return(db.TransferTxResult{}, db.ErrPotTransfer).
+					Once()
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, rec.Code)
+			},
+		},
 		{
 			name: "StatusOKButCurrencyNotSame",
 			body: createTransferRequest{
@@ -253,11 +372,12 @@ func TestTransferAPI(t *testing.T) {
 			data, err := json.Marshal(ts.body)
 			require.NoError(t, err)
 
-			req, err := http.NewRequest(http.MethodPost, "/transfer", bytes.NewReader(data))
+			req, err := http.NewRequest(http.MethodPost, "/account/transfer", bytes.NewReader(data))
 			require.NoError(t, err)
 			req.Header = http.Header{
 				"Content-Type": {"application/json"},
 			}
+			addAuthorization(t, req, server.tokenMaker, "Bearer", fromAcc.OwnerID, util.RoleCustomer, time.Minute)
 
 			server.router.ServeHTTP(rec, req)
 			ts.check(t, rec)
@@ -265,6 +385,49 @@ func TestTransferAPI(t *testing.T) {
 	}
 }
 
+func TestTransferAPIStaff(t *testing.T) {
+	fromAcc := randomAccount()
+	toAcc := randomAccount()
+	toAcc.Currency = fromAcc.Currency
+
+	for _, role := range []string{util.RoleTeller, util.RoleAdmin} {
+		t.Run(role, func(t *testing.T) {
+			store := &mocks.Store{}
+			staffID := uuid.New()
+			store.On("GetAccount", mock.Anything, fromAcc.ID).
+				Return(fromAcc, nil).
+				Once()
+			store.On("GetAccountHolder", mock.Anything, db.GetAccountHolderParams{AccountID: fromAcc.ID, UserID: staffID}).
+				Return(db.AccountHolder{}, sql.ErrNoRows).
+				Once()
+
+			server, err := NewServer(store, util.Config{
+				TokenSymetricKey:    "12345678901234567890123456789012",
+				AccessTokenDuration: time.Minute,
+			})
+			require.NoError(t, err)
+			rec := httptest.NewRecorder()
+
+			data, err := json.Marshal(createTransferRequest{
+				FromAccountID: fromAcc.ID,
+				ToAccountID:   toAcc.ID,
+				Currency:      fromAcc.Currency,
+				Amount:        100,
+			})
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodPost, "/account/transfer", bytes.NewReader(data))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+			addAuthorization(t, req, server.tokenMaker, "Bearer", staffID, role, time.Minute)
+
+			server.router.ServeHTTP(rec, req)
+			require.Equal(t, http.StatusForbidden, rec.Code)
+			store.AssertNotCalled(t, "TransferTx", mock.Anything, mock.Anything)
+		})
+	}
+}
+
 func generateTransferResult(a, b db.Account, amount int64) db.TransferTxResult {
 	transfer := db.Transfer{
 		ID:            util.GenRandomNum(0, 1000),
@@ -1,8 +1,9 @@
 package api
 
 import (
-	"database/sql"
+	"errors"
 	"net/http"
+	"time"
 
 	db "github.com/flukis/simplebank/db/sqlc"
 	"github.com/flukis/simplebank/util"
@@ -10,7 +11,6 @@ import (
 	"github.com/go-ozzo/ozzo-validation/v4/is"
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
-	"github.com/lib/pq"
 )
 
 type UserResponse struct {
@@ -18,6 +18,7 @@ type UserResponse struct {
 	Username string    `json:"username"`
 	FullName string    `json:"full_name"`
 	Email    string    `json:"email"`
+	Role     string    `json:"role"`
 }
 
 type createUserSuccessResponse struct {
@@ -31,10 +32,6 @@ type createUserRequest struct {
 	Password string `json:"password" binding:"required"`
 }
 
-type createUserErrorResponse struct {
-	Error string `json:"error"`
-}
-
 func (r createUserRequest) Validate() error {
 	return validation.ValidateStruct(&r,
 		validation.Field(&r.Email,
@@ -57,31 +54,16 @@ func (r createUserRequest) Validate() error {
 func (s *Server) CreateUser(c echo.Context) error {
 	req := new(createUserRequest)
 	if err := c.Bind(req); err != nil {
-		return c.JSON(
-			http.StatusBadRequest,
-			&createUserErrorResponse{
-				Error: err.Error(),
-			},
-		)
+		return err
 	}
 
 	if err := req.Validate(); err != nil {
-		return c.JSON(
-			http.StatusBadRequest,
-			&createUserErrorResponse{
-				Error: err.Error(),
-			},
-		)
+		return err
 	}
 
 	hashPassword, err := util.GenerateHashFromPassword(req.Password, s.passwordHashing)
 	if err != nil {
-		return c.JSON(
-			http.StatusInternalServerError,
-			&createUserErrorResponse{
-				Error: err.Error(),
-			},
-		)
+		return err
 	}
 
 	arg := db.CreateUserParams{
@@ -93,24 +75,10 @@ func (s *Server) CreateUser(c echo.Context) error {
 
 	user, err := s.store.CreateUser(c.Request().Context(), arg)
 	if err != nil {
-
-		if pqErr, ok := err.(*pq.Error); ok {
-			switch pqErr.Code.Name() {
-			case "unique_violation":
-				return c.JSON(
-					http.StatusForbidden,
-					&createUserErrorResponse{
-						Error: err.Error(),
-					},
-				)
-			}
+		if errors.Is(err, db.ErrUniqueViolation) {
+			return newError(http.StatusForbidden, CodeAlreadyExists, errors.New("a user with this username or email already exists"))
 		}
-		return c.JSON(
-			http.StatusInternalServerError,
-			&createUserErrorResponse{
-				Error: err.Error(),
-			},
-		)
+		return err
 	}
 
 	return c.JSON(
@@ -122,8 +90,9 @@ func (s *Server) CreateUser(c echo.Context) error {
 }
 
 type loginSuccessResponse struct {
-	User        UserResponse `json:"user"`
-	AccessToken string       `json:"access_token"`
+	User                 UserResponse `json:"user"`
+	AccessToken          string       `json:"access_token"`
+	AccessTokenExpiresAt time.Time    `json:"access_token_expires_at"`
 }
 
 type loginRequest struct {
@@ -147,75 +116,38 @@ func (r loginRequest) Validate() error {
 func (s *Server) LoginUser(c echo.Context) error {
 	req := new(loginRequest)
 	if err := c.Bind(req); err != nil {
-		return c.JSON(
-			http.StatusBadRequest,
-			&createUserErrorResponse{
-				Error: err.Error(),
-			},
-		)
+		return err
 	}
 
 	if err := req.Validate(); err != nil {
-		return c.JSON(
-			http.StatusBadRequest,
-			&createUserErrorResponse{
-				Error: err.Error(),
-			},
-		)
+		return err
 	}
 
 	user, err := s.store.GetUserByUsername(c.Request().Context(), req.Username)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return c.JSON(
-				http.StatusNotFound,
-				&createUserErrorResponse{
-					Error: err.Error(),
-				},
-			)
-		}
-		return c.JSON(
-			http.StatusInternalServerError,
-			&createUserErrorResponse{
-				Error: err.Error(),
-			},
-		)
+		return notFound(CodeUserNotFound, err)
 	}
 
 	isMatch, err := util.ComparePasswordAndHashPassword(req.Password, user.HashedPassword, s.passwordHashing)
 	if err != nil {
-		return c.JSON(
-			http.StatusInternalServerError,
-			&createUserErrorResponse{
-				Error: err.Error(),
-			},
-		)
+		return err
 	}
 
 	if !isMatch {
-		return c.JSON(
-			http.StatusUnauthorized,
-			&createUserErrorResponse{
-				Error: util.ErrWrongPassword.Error(),
-			},
-		)
+		return util.ErrWrongPassword
 	}
 
-	accessToken, _, err := s.tokenMaker.CreateToken(req.Username, s.config.AccessTokenDuration)
+	accessToken, payload, err := s.tokenMaker.CreateToken(user.ID, user.Username, user.Role, s.config.AccessTokenDuration)
 	if err != nil {
-		return c.JSON(
-			http.StatusInternalServerError,
-			&createUserErrorResponse{
-				Error: err.Error(),
-			},
-		)
+		return err
 	}
 
 	return c.JSON(
 		http.StatusOK,
 		&loginSuccessResponse{
-			User:        generateUserResponse(user),
-			AccessToken: accessToken,
+			User:                 generateUserResponse(user),
+			AccessToken:          accessToken,
+			AccessTokenExpiresAt: payload.ExpiredAt,
 		},
 	)
 }
@@ -226,5 +158,6 @@ func generateUserResponse(u db.User) UserResponse {
 		Username: u.Username,
 		FullName: u.FullName,
 		Email:    u.Email,
+		Role:     u.Role,
 	}
 }
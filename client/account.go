@@ -0,0 +1,74 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	db "github.com/flukis/simplebank/db/sqlc"
+	"github.com/google/uuid"
+)
+
+type CreateAccountParams struct {
+	OwnerID  uuid.UUID `json:"owner_id"`
+	Currency string    `json:"currency"`
+	// current when left empty
+	AccountType string `json:"account_type,omitempty"`
+}
+
+// CreateAccount opens an account
+func (c *Client) CreateAccount(ctx context.Context, arg CreateAccountParams) (db.Account, error) {
+	var res envelope[db.Account]
+	_, err := c.do(ctx, request{
+		method: http.MethodPost,
+		path:   "/account/",
+		body:   arg,
+	}, &res)
+	return res.Data, err
+}
+
+// GetAccount returns the account with id
+func (c *Client) GetAccount(ctx context.Context, id int64) (db.Account, error) {
+	return c.GetAccountByNumber(ctx, fmt.Sprint(id))
+}
+
+// GetAccountByNumber returns the account with an account number, e.g.
+// ID12 3456 7890. An id is taken as well.
+func (c *Client) GetAccountByNumber(ctx context.Context, number string) (db.Account, error) {
+	var res envelope[db.Account]
+	_, err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   "/account/" + url.PathEscape(number),
+	}, &res)
+	return res.Data, err
+}
+
+// ListAccounts returns a page of the accounts of the authenticated user.
+// Pages start at 1 and limit is a multiple of 5 up to 50.
+func (c *Client) ListAccounts(ctx context.Context, page, limit int32) ([]db.Account, error) {
+	query := url.Values{}
+	query.Set("page", fmt.Sprint(page))
+	query.Set("limit", fmt.Sprint(limit))
+
+	var res envelope[[]db.Account]
+	_, err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   "/account/?" + query.Encode(),
+	}, &res)
+	return res.Data, err
+}
+
+// CloseAccount closes an account, sweeping what is left on it to
+// sweepToAccountID unless it is 0
+func (c *Client) CloseAccount(ctx context.Context, id, sweepToAccountID int64) (db.CloseAccountTxResult, error) {
+	var res envelope[db.CloseAccountTxResult]
+	_, err := c.do(ctx, request{
+		method: http.MethodPost,
+		path:   fmt.Sprintf("/account/%d/close", id),
+		body: map[string]int64{
+			"sweep_to_account_id": sweepToAccountID,
+		},
+	}, &res)
+	return res.Data, err
+}
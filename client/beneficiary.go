@@ -0,0 +1,46 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	db "github.com/flukis/simplebank/db/sqlc"
+)
+
+type CreateBeneficiaryParams struct {
+	Nickname  string `json:"nickname"`
+	AccountID int64  `json:"account_id"`
+	Currency  string `json:"currency"`
+}
+
+// CreateBeneficiary saves an account to pay to
+func (c *Client) CreateBeneficiary(ctx context.Context, arg CreateBeneficiaryParams) (db.Beneficiary, error) {
+	var res envelope[db.Beneficiary]
+	_, err := c.do(ctx, request{
+		method: http.MethodPost,
+		path:   "/beneficiaries/",
+		body:   arg,
+	}, &res)
+	return res.Data, err
+}
+
+// ListBeneficiaries returns the beneficiaries of the authenticated user
+func (c *Client) ListBeneficiaries(ctx context.Context) ([]db.Beneficiary, error) {
+	var res envelope[[]db.Beneficiary]
+	_, err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   "/beneficiaries/",
+	}, &res)
+	return res.Data, err
+}
+
+// DeleteBeneficiary removes a beneficiary of the authenticated user
+func (c *Client) DeleteBeneficiary(ctx context.Context, id int64) (db.Beneficiary, error) {
+	var res envelope[db.Beneficiary]
+	_, err := c.do(ctx, request{
+		method: http.MethodDelete,
+		path:   fmt.Sprintf("/beneficiaries/%d", id),
+	}, &res)
+	return res.Data, err
+}
@@ -0,0 +1,262 @@
+// Package client is a Go client of the REST API served by api.Server. It logs
+// in on its own with the credentials it is given, sends an idempotency key
+// with every request that changes something, and retries server errors and
+// requests whose earlier attempt is still being handled with exponential
+// backoff.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/flukis/simplebank/api"
+	"github.com/google/uuid"
+)
+
+const (
+	// IdempotencyKeyHeader carries the key that is the same for every
+	// attempt of one request
+	IdempotencyKeyHeader = "Idempotency-Key"
+
+	defaultMaxRetries = 3
+	defaultBackoff    = 100 * time.Millisecond
+	// the token is renewed when it expires within this
+	tokenLeeway = 30 * time.Second
+)
+
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	maxRetries int
+	backoff    time.Duration
+
+	mu        sync.Mutex
+	username  string
+	password  string
+	token     string
+	expiresAt time.Time
+}
+
+type Option func(*Client)
+
+// WithHTTPClient sends the requests with hc instead of http.DefaultClient
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = hc
+	}
+}
+
+// WithCredentials logs in as username before the first request that needs a
+// token, and again whenever the token runs out
+func WithCredentials(username, password string) Option {
+	return func(c *Client) {
+		c.username = username
+		c.password = password
+	}
+}
+
+// WithToken uses an access token obtained elsewhere. It is not renewed
+// unless credentials are given as well.
+func WithToken(token string) Option {
+	return func(c *Client) {
+		c.token = token
+	}
+}
+
+// WithRetries retries a request up to max times after a server error, a
+// failed connection or while an earlier attempt is still being handled,
+// waiting backoff before the first retry and twice as long before each next
+// one
+func WithRetries(max int, backoff time.Duration) Option {
+	return func(c *Client) {
+		c.maxRetries = max
+		c.backoff = backoff
+	}
+}
+
+// New returns a client of the API served at baseURL, e.g.
+// http://localhost:8080
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: http.DefaultClient,
+		maxRetries: defaultMaxRetries,
+		backoff:    defaultBackoff,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Token returns the access token requests are sent with, logging in first
+// when there is none or it is about to expire
+func (c *Client) Token(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	token, expiresAt, canLogin := c.token, c.expiresAt, c.username != ""
+	c.mu.Unlock()
+
+	expiring := !expiresAt.IsZero() && time.Until(expiresAt) < tokenLeeway
+	if canLogin && (token == "" || expiring) {
+		return c.login(ctx)
+	}
+	return token, nil
+}
+
+func (c *Client) login(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	username, password := c.username, c.password
+	c.mu.Unlock()
+
+	res, err := c.Login(ctx, username, password)
+	if err != nil {
+		return "", err
+	}
+	return res.AccessToken, nil
+}
+
+// request is one call of the API
+type request struct {
+	method string
+	path   string
+	body   any
+	// sent without a token
+	public bool
+}
+
+// do sends req and decodes the body of a successful response into out,
+// returning the status it was answered with. Errors of the API are returned
+// as *Error.
+func (c *Client) do(ctx context.Context, req request, out any) (int, error) {
+	var body []byte
+	if req.body != nil {
+		var err error
+		body, err = json.Marshal(req.body)
+		if err != nil {
+			return 0, fmt.Errorf("cannot encode request: %w", err)
+		}
+	}
+
+	var key string
+	if req.method != http.MethodGet {
+		key = uuid.NewString()
+	}
+
+	relogged := false
+	for attempt := 0; ; attempt++ {
+		var token string
+		if !req.public {
+			var err error
+			if token, err = c.Token(ctx); err != nil {
+				return 0, err
+			}
+		}
+
+		res, err := c.send(ctx, req, body, key, token)
+		if err == nil && res.StatusCode == http.StatusUnauthorized && !req.public && !relogged && c.hasCredentials() {
+			res.Body.Close()
+			// the token was revoked or the clocks disagree, log in again
+			// once without spending a retry
+			relogged = true
+			if _, err := c.login(ctx); err != nil {
+				return 0, err
+			}
+			attempt--
+			continue
+		}
+
+		retryable := err != nil || res.StatusCode >= http.StatusInternalServerError || (key != "" && inProgress(res))
+		if !retryable || attempt >= c.maxRetries {
+			if err != nil {
+				return 0, err
+			}
+			defer res.Body.Close()
+			return res.StatusCode, decodeResponse(res, out)
+		}
+		if res != nil {
+			res.Body.Close()
+		}
+
+		if err := sleep(ctx, c.backoff<<attempt); err != nil {
+			return 0, err
+		}
+	}
+}
+
+// inProgress tells whether res answers that the request sent with the same
+// idempotency key is still being handled, leaving the body to be read again
+func inProgress(res *http.Response) bool {
+	if res.StatusCode != http.StatusConflict {
+		return false
+	}
+	data, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	res.Body = io.NopCloser(bytes.NewReader(data))
+	return err == nil && ErrorCode(newError(res.StatusCode, data)) == api.CodeIdempotencyKeyInProgress
+}
+
+func (c *Client) send(ctx context.Context, req request, body []byte, key, token string) (*http.Response, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, req.method, c.baseURL+req.path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Accept", "application/json")
+	if body != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+	if key != "" {
+		httpReq.Header.Set(IdempotencyKeyHeader, key)
+	}
+	if token != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	return c.httpClient.Do(httpReq)
+}
+
+func (c *Client) hasCredentials() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.username != ""
+}
+
+func decodeResponse(res *http.Response, out any) error {
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+
+	if res.StatusCode >= http.StatusBadRequest {
+		return newError(res.StatusCode, data)
+	}
+	if out == nil || len(data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("cannot decode response: %w", err)
+	}
+	return nil
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// envelope is the body of most successful responses
+type envelope[T any] struct {
+	Data T `json:"data"`
+}
@@ -0,0 +1,318 @@
+package client
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/flukis/simplebank/api"
+	mocks "github.com/flukis/simplebank/db/mock"
+	db "github.com/flukis/simplebank/db/sqlc"
+	"github.com/flukis/simplebank/util"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testPassword = "wap12345"
+
+// newTestServer serves api.NewServer over store
+func newTestServer(t *testing.T, store *mocks.Store, tokenDuration time.Duration) *httptest.Server {
+	server, err := api.NewServer(store, util.Config{
+		TokenSymetricKey:    "12345678901234567890123456789012",
+		AccessTokenDuration: tokenDuration,
+	})
+	require.NoError(t, err)
+	keepIdempotencyKeys(store)
+
+	ts := httptest.NewServer(server.Handler())
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+// keepIdempotencyKeys has store keep the idempotency keys of the server in a
+// map, the way the database would
+func keepIdempotencyKeys(store *mocks.Store) {
+	var mu sync.Mutex
+	keys := map[string]db.IdempotencyKey{}
+
+	store.On("CreateIdempotencyKey", mock.Anything, mock.Anything).
+		Return(func(ctx context.Context, arg db.CreateIdempotencyKeyParams) (db.IdempotencyKey, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			if _, ok := keys[arg.Key]; ok {
+				return db.IdempotencyKey{}, db.ErrUniqueViolation
+			}
+			keys[arg.Key] = db.IdempotencyKey{UserID: arg.UserID, Key: arg.Key, RequestHash: arg.RequestHash}
+			return keys[arg.Key], nil
+		}).
+		Maybe()
+	store.On("GetIdempotencyKey", mock.Anything, mock.Anything).
+		Return(func(ctx context.Context, arg db.GetIdempotencyKeyParams) (db.IdempotencyKey, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			key, ok := keys[arg.Key]
+			if !ok {
+				return key, sql.ErrNoRows
+			}
+			return key, nil
+		}).
+		Maybe()
+	store.On("SaveIdempotentResponse", mock.Anything, mock.Anything).
+		Return(func(ctx context.Context, arg db.SaveIdempotentResponseParams) (db.IdempotencyKey, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			key := keys[arg.Key]
+			key.StatusCode = arg.StatusCode
+			key.ContentType = arg.ContentType
+			key.ResponseBody = arg.ResponseBody
+			keys[arg.Key] = key
+			return key, nil
+		}).
+		Maybe()
+	store.On("DeleteIdempotencyKey", mock.Anything, mock.Anything).
+		Return(func(ctx context.Context, arg db.DeleteIdempotencyKeyParams) error {
+			mu.Lock()
+			defer mu.Unlock()
+			delete(keys, arg.Key)
+			return nil
+		}).
+		Maybe()
+}
+
+func randomUser(t *testing.T) db.User {
+	hashedPassword, err := util.GenerateHashFromPassword(testPassword, util.Argon2Param{
+		Memory:      64 * 1024,
+		Iterations:  3,
+		Parallelism: 2,
+		SaltLength:  16,
+		KeyLength:   32,
+	})
+	require.NoError(t, err)
+
+	return db.User{
+		ID:             uuid.New(),
+		Username:       util.GenRandomOwner(),
+		FullName:       util.GenRandomOwner(),
+		HashedPassword: hashedPassword,
+		Email:          util.GenRandomEmail(),
+		Role:           util.RoleCustomer,
+	}
+}
+
+func randomAccount(ownerID uuid.UUID) db.Account {
+	return db.Account{
+		ID:       util.GenRandomNum(1, 10000),
+		OwnerID:  ownerID,
+		Balance:  util.GenRandomMoney(),
+		Currency: "IDR",
+	}
+}
+
+func TestLoginOnFirstRequest(t *testing.T) {
+	user := randomUser(t)
+	account := randomAccount(user.ID)
+
+	store := &mocks.Store{}
+	store.On("GetUserByUsername", mock.Anything, user.Username).
+		Return(user, nil).
+		Once()
+	store.On("GetAccount", mock.Anything, account.ID).
+		Return(account, nil).
+		Twice()
+
+	ts := newTestServer(t, store, time.Minute)
+	c := New(ts.URL, WithCredentials(user.Username, testPassword))
+
+	for i := 0; i < 2; i++ {
+		got, err := c.GetAccount(context.Background(), account.ID)
+		require.NoError(t, err)
+		require.Equal(t, account, got)
+	}
+	store.AssertExpectations(t)
+}
+
+func TestTokenRenewedBeforeExpiry(t *testing.T) {
+	user := randomUser(t)
+	account := randomAccount(user.ID)
+
+	store := &mocks.Store{}
+	// tokens expiring within the leeway are renewed before every request
+	store.On("GetUserByUsername", mock.Anything, user.Username).
+		Return(user, nil).
+		Times(2)
+	store.On("GetAccount", mock.Anything, account.ID).
+		Return(account, nil).
+		Twice()
+
+	ts := newTestServer(t, store, tokenLeeway/2)
+	c := New(ts.URL, WithCredentials(user.Username, testPassword))
+
+	for i := 0; i < 2; i++ {
+		_, err := c.GetAccount(context.Background(), account.ID)
+		require.NoError(t, err)
+	}
+	store.AssertExpectations(t)
+}
+
+func TestLoginAgainOnUnauthorized(t *testing.T) {
+	user := randomUser(t)
+	account := randomAccount(user.ID)
+
+	store := &mocks.Store{}
+	store.On("GetUserByUsername", mock.Anything, user.Username).
+		Return(user, nil).
+		Once()
+	store.On("GetAccount", mock.Anything, account.ID).
+		Return(account, nil).
+		Once()
+
+	ts := newTestServer(t, store, time.Minute)
+	c := New(ts.URL, WithCredentials(user.Username, testPassword), WithToken("revoked"))
+
+	_, err := c.GetAccount(context.Background(), account.ID)
+	require.NoError(t, err)
+	store.AssertExpectations(t)
+}
+
+func TestError(t *testing.T) {
+	user := randomUser(t)
+
+	store := &mocks.Store{}
+	store.On("GetUserByUsername", mock.Anything, user.Username).
+		Return(user, nil).
+		Once()
+	store.On("GetAccount", mock.Anything, int64(42)).
+		Return(db.Account{}, sql.ErrNoRows).
+		Once()
+
+	ts := newTestServer(t, store, time.Minute)
+	c := New(ts.URL)
+
+	_, err := c.Login(context.Background(), user.Username, testPassword)
+	require.NoError(t, err)
+
+	_, err = c.GetAccount(context.Background(), 42)
+	require.Error(t, err)
+	require.Equal(t, api.CodeAccountNotFound, ErrorCode(err))
+
+	var apiErr *Error
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, http.StatusNotFound, apiErr.Status)
+	store.AssertExpectations(t)
+}
+
+func TestWrongPassword(t *testing.T) {
+	user := randomUser(t)
+
+	store := &mocks.Store{}
+	store.On("GetUserByUsername", mock.Anything, user.Username).
+		Return(user, nil).
+		Once()
+
+	ts := newTestServer(t, store, time.Minute)
+	c := New(ts.URL, WithCredentials(user.Username, "wrongpassword"))
+
+	_, err := c.GetAccount(context.Background(), 1)
+	require.Equal(t, api.CodeWrongPassword, ErrorCode(err))
+	store.AssertExpectations(t)
+}
+
+// flakyHandler answers the first failures requests with 503 before passing
+// them on to next, recording their idempotency keys
+type flakyHandler struct {
+	next     http.Handler
+	failures int
+
+	mu   sync.Mutex
+	keys []string
+}
+
+func (h *flakyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mu.Lock()
+	h.keys = append(h.keys, r.Header.Get(IdempotencyKeyHeader))
+	fail := len(h.keys) <= h.failures
+	h.mu.Unlock()
+
+	if fail {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		return
+	}
+	h.next.ServeHTTP(w, r)
+}
+
+func TestRetryServerErrors(t *testing.T) {
+	owner := uuid.New()
+
+	testCases := []struct {
+		name     string
+		failures int
+		build    func(store *mocks.Store, account db.Account)
+		check    func(t *testing.T, account, got db.Account, err error, keys []string)
+	}{
+		{
+			name:     "Recovers",
+			failures: 2,
+			build: func(store *mocks.Store, account db.Account) {
+				store.On("CreateAccount", mock.Anything, mock.Anything).
+					Return(account, nil).
+					Once()
+			},
+			check: func(t *testing.T, account, got db.Account, err error, keys []string) {
+				require.NoError(t, err)
+				require.Equal(t, account, got)
+				require.Len(t, keys, 3)
+				// every attempt of the request carries the same key
+				require.NotEmpty(t, keys[0])
+				require.Equal(t, keys[0], keys[1])
+				require.Equal(t, keys[0], keys[2])
+			},
+		},
+		{
+			name:     "GivesUp",
+			failures: 10,
+			build:    func(store *mocks.Store, account db.Account) {},
+			check: func(t *testing.T, account, got db.Account, err error, keys []string) {
+				var apiErr *Error
+				require.ErrorAs(t, err, &apiErr)
+				require.Equal(t, http.StatusServiceUnavailable, apiErr.Status)
+				require.Len(t, keys, 4)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			account := randomAccount(owner)
+			store := &mocks.Store{}
+			tc.build(store, account)
+			keepIdempotencyKeys(store)
+
+			server, err := api.NewServer(store, util.Config{
+				TokenSymetricKey:    "12345678901234567890123456789012",
+				AccessTokenDuration: time.Minute,
+			})
+			require.NoError(t, err)
+			tokenMaker, err := util.NewJWTMaker("12345678901234567890123456789012")
+			require.NoError(t, err)
+			token, _, err := tokenMaker.CreateToken(owner, "user", util.RoleCustomer, time.Minute)
+			require.NoError(t, err)
+
+			handler := &flakyHandler{next: server.Handler(), failures: tc.failures}
+			ts := httptest.NewServer(handler)
+			defer ts.Close()
+
+			c := New(ts.URL, WithToken(token), WithRetries(3, time.Millisecond))
+			got, err := c.CreateAccount(context.Background(), CreateAccountParams{
+				OwnerID:  owner,
+				Currency: "IDR",
+			})
+			tc.check(t, account, got, err, handler.keys)
+			store.AssertExpectations(t)
+		})
+	}
+}
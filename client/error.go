@@ -0,0 +1,47 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/flukis/simplebank/api"
+)
+
+// Error is an error response of the API. Code is one of the api.Code
+// constants.
+type Error struct {
+	api.Problem
+}
+
+func newError(status int, body []byte) *Error {
+	e := new(Error)
+	if err := json.Unmarshal(body, &e.Problem); err != nil || e.Code == "" {
+		// not a problem, e.g. from a proxy in front of the API
+		e.Problem = api.Problem{
+			Title:  http.StatusText(status),
+			Code:   http.StatusText(status),
+			Detail: string(body),
+		}
+	}
+	e.Status = status
+	return e
+}
+
+func (e *Error) Error() string {
+	if e.Detail == "" {
+		return fmt.Sprintf("%d %s", e.Status, e.Code)
+	}
+	return fmt.Sprintf("%d %s: %s", e.Status, e.Code, e.Detail)
+}
+
+// ErrorCode returns the code of the API error in err, or "" when it isn't
+// one
+func ErrorCode(err error) string {
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr.Code
+	}
+	return ""
+}
@@ -0,0 +1,107 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	db "github.com/flukis/simplebank/db/sqlc"
+)
+
+// TransferParams names the destination either by ToAccountID or by
+// BeneficiaryID
+type TransferParams struct {
+	FromAccountID int64  `json:"from_account_id"`
+	ToAccountID   int64  `json:"to_account_id,omitempty"`
+	BeneficiaryID int64  `json:"beneficiary_id,omitempty"`
+	Currency      string `json:"currency"`
+	Amount        int64  `json:"amount"`
+}
+
+// TransferResult holds the transfer, or the approval it waits for when it
+// was above the spend limit of the authenticated user
+type TransferResult struct {
+	Transfer *db.TransferTxResult
+	Approval *db.TransferApproval
+}
+
+// Pending reports whether the transfer waits for approval
+func (r TransferResult) Pending() bool {
+	return r.Approval != nil
+}
+
+// CreateTransfer moves money between accounts
+func (c *Client) CreateTransfer(ctx context.Context, arg TransferParams) (TransferResult, error) {
+	return c.transfer(ctx, "/account/transfer", arg)
+}
+
+type PayQRParams struct {
+	FromAccountID int64  `json:"from_account_id"`
+	Payload       string `json:"payload"`
+	// may be left out for a code with an amount
+	Amount int64 `json:"amount,omitempty"`
+}
+
+// PayQR pays a scanned payment code
+func (c *Client) PayQR(ctx context.Context, arg PayQRParams) (TransferResult, error) {
+	return c.transfer(ctx, "/pay/qr", arg)
+}
+
+func (c *Client) transfer(ctx context.Context, path string, body any) (TransferResult, error) {
+	var (
+		result TransferResult
+		res    envelope[json.RawMessage]
+	)
+	status, err := c.do(ctx, request{
+		method: http.MethodPost,
+		path:   path,
+		body:   body,
+	}, &res)
+	if err != nil {
+		return result, err
+	}
+
+	if status == http.StatusAccepted {
+		result.Approval = new(db.TransferApproval)
+		err = json.Unmarshal(res.Data, result.Approval)
+	} else {
+		result.Transfer = new(db.TransferTxResult)
+		err = json.Unmarshal(res.Data, result.Transfer)
+	}
+	if err != nil {
+		return TransferResult{}, fmt.Errorf("cannot decode response: %w", err)
+	}
+	return result, nil
+}
+
+// ApproveTransfer makes a transfer waiting for approval
+func (c *Client) ApproveTransfer(ctx context.Context, approvalID int64) (db.ApproveTransferTxResult, error) {
+	var res envelope[db.ApproveTransferTxResult]
+	_, err := c.do(ctx, request{
+		method: http.MethodPost,
+		path:   fmt.Sprintf("/approvals/%d/approve", approvalID),
+	}, &res)
+	return res.Data, err
+}
+
+// RejectTransfer turns down a transfer waiting for approval
+func (c *Client) RejectTransfer(ctx context.Context, approvalID int64) (db.TransferApproval, error) {
+	var res envelope[db.TransferApproval]
+	_, err := c.do(ctx, request{
+		method: http.MethodPost,
+		path:   fmt.Sprintf("/approvals/%d/reject", approvalID),
+	}, &res)
+	return res.Data, err
+}
+
+// ListTransferApprovals returns the transfers from an account that wait for
+// approval
+func (c *Client) ListTransferApprovals(ctx context.Context, accountID int64) ([]db.TransferApproval, error) {
+	var res envelope[[]db.TransferApproval]
+	_, err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   fmt.Sprintf("/account/%d/approvals", accountID),
+	}, &res)
+	return res.Data, err
+}
@@ -0,0 +1,230 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/flukis/simplebank/api"
+	mocks "github.com/flukis/simplebank/db/mock"
+	db "github.com/flukis/simplebank/db/sqlc"
+	"github.com/flukis/simplebank/util"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateTransfer(t *testing.T) {
+	user := randomUser(t)
+	from := randomAccount(user.ID)
+	to := randomAccount(uuid.New())
+	transfer := db.TransferTxResult{
+		Transfer: db.Transfer{
+			ID:            util.GenRandomNum(1, 1000),
+			FromAccountID: from.ID,
+			ToAccountID:   to.ID,
+			Amount:        100,
+		},
+	}
+
+	store := &mocks.Store{}
+	store.On("GetUserByUsername", mock.Anything, user.Username).
+		Return(user, nil).
+		Once()
+	store.On("GetAccount", mock.Anything, from.ID).
+		Return(from, nil).
+		Once()
+	store.On("GetAccount", mock.Anything, to.ID).
+		Return(to, nil).
+		Once()
+	store.On("TransferTx", mock.Anything, db.TransferTxParams{
+		FromAccountID: from.ID,
+		ToAccountID:   to.ID,
+		Amount:        100,
+	}).
+		Return(transfer, nil).
+		Once()
+
+	ts := newTestServer(t, store, time.Minute)
+	c := New(ts.URL, WithCredentials(user.Username, testPassword))
+
+	result, err := c.CreateTransfer(context.Background(), TransferParams{
+		FromAccountID: from.ID,
+		ToAccountID:   to.ID,
+		Currency:      from.Currency,
+		Amount:        100,
+	})
+	require.NoError(t, err)
+	require.False(t, result.Pending())
+	require.Equal(t, transfer.Transfer.ID, result.Transfer.Transfer.ID)
+	store.AssertExpectations(t)
+}
+
+// lostResponseHandler passes every request on to next, but answers the
+// first one with 502 as if a proxy lost the response after the transfer
+// was made
+type lostResponseHandler struct {
+	next http.Handler
+
+	mu       sync.Mutex
+	requests int
+}
+
+func (h *lostResponseHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mu.Lock()
+	h.requests++
+	lost := h.requests == 1
+	h.mu.Unlock()
+
+	if lost {
+		h.next.ServeHTTP(httptest.NewRecorder(), r)
+		http.Error(w, "bad gateway", http.StatusBadGateway)
+		return
+	}
+	h.next.ServeHTTP(w, r)
+}
+
+func TestRetryCommittedTransfer(t *testing.T) {
+	user := randomUser(t)
+	from := randomAccount(user.ID)
+	to := randomAccount(uuid.New())
+	transfer := db.TransferTxResult{
+		Transfer: db.Transfer{
+			ID:            util.GenRandomNum(1, 1000),
+			FromAccountID: from.ID,
+			ToAccountID:   to.ID,
+			Amount:        100,
+		},
+	}
+
+	store := &mocks.Store{}
+	store.On("GetAccount", mock.Anything, from.ID).
+		Return(from, nil).
+		Once()
+	store.On("GetAccount", mock.Anything, to.ID).
+		Return(to, nil).
+		Once()
+	store.On("TransferTx", mock.Anything, mock.Anything).
+		Return(transfer, nil).
+		Once()
+	keepIdempotencyKeys(store)
+
+	server, err := api.NewServer(store, util.Config{
+		TokenSymetricKey:    "12345678901234567890123456789012",
+		AccessTokenDuration: time.Minute,
+	})
+	require.NoError(t, err)
+	tokenMaker, err := util.NewJWTMaker("12345678901234567890123456789012")
+	require.NoError(t, err)
+	token, _, err := tokenMaker.CreateToken(user.ID, user.Username, util.RoleCustomer, time.Minute)
+	require.NoError(t, err)
+
+	handler := &lostResponseHandler{next: server.Handler()}
+	ts := httptest.NewServer(handler)
+	defer ts.Close()
+
+	c := New(ts.URL, WithToken(token), WithRetries(3, time.Millisecond))
+	result, err := c.CreateTransfer(context.Background(), TransferParams{
+		FromAccountID: from.ID,
+		ToAccountID:   to.ID,
+		Currency:      from.Currency,
+		Amount:        100,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, handler.requests)
+	// the retry got the response of the first attempt instead of moving the
+	// money again
+	require.Equal(t, transfer.Transfer.ID, result.Transfer.Transfer.ID)
+	store.AssertNumberOfCalls(t, "TransferTx", 1)
+	store.AssertExpectations(t)
+}
+
+func TestRetryTransferInProgress(t *testing.T) {
+	approval := db.TransferApproval{
+		ID:            util.GenRandomNum(1, 1000),
+		FromAccountID: 1,
+		ToAccountID:   2,
+		Amount:        5000,
+		Status:        "pending",
+	}
+
+	// the first attempt finds the key taken by an earlier one still running
+	var keys []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get(IdempotencyKeyHeader))
+		if len(keys) == 1 {
+			w.Header().Set("Content-Type", "application/problem+json")
+			w.WriteHeader(http.StatusConflict)
+			require.NoError(t, json.NewEncoder(w).Encode(api.Problem{
+				Status: http.StatusConflict,
+				Code:   api.CodeIdempotencyKeyInProgress,
+			}))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		require.NoError(t, json.NewEncoder(w).Encode(envelope[db.TransferApproval]{Data: approval}))
+	}))
+	defer ts.Close()
+
+	c := New(ts.URL, WithToken("token"), WithRetries(3, time.Millisecond))
+	result, err := c.CreateTransfer(context.Background(), TransferParams{
+		FromAccountID: 1,
+		ToAccountID:   2,
+		Currency:      "IDR",
+		Amount:        5000,
+	})
+	require.NoError(t, err)
+	require.Equal(t, approval.ID, result.Approval.ID)
+	require.Len(t, keys, 2)
+	require.NotEmpty(t, keys[0])
+	require.Equal(t, keys[0], keys[1])
+
+	// without retries left the conflict is returned
+	keys = nil
+	c = New(ts.URL, WithToken("token"), WithRetries(0, time.Millisecond))
+	_, err = c.CreateTransfer(context.Background(), TransferParams{
+		FromAccountID: 1,
+		ToAccountID:   2,
+		Currency:      "IDR",
+		Amount:        5000,
+	})
+	require.Equal(t, api.CodeIdempotencyKeyInProgress, ErrorCode(err))
+}
+
+func TestCreateTransferPending(t *testing.T) {
+	approval := db.TransferApproval{
+		ID:            util.GenRandomNum(1, 1000),
+		FromAccountID: 1,
+		ToAccountID:   2,
+		Amount:        5000,
+		Status:        "pending",
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/account/transfer", r.URL.Path)
+		require.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		require.NoError(t, json.NewEncoder(w).Encode(envelope[db.TransferApproval]{Data: approval}))
+	}))
+	defer ts.Close()
+
+	c := New(ts.URL, WithToken("token"))
+	result, err := c.CreateTransfer(context.Background(), TransferParams{
+		FromAccountID: 1,
+		ToAccountID:   2,
+		Currency:      "IDR",
+		Amount:        5000,
+	})
+	require.NoError(t, err)
+	require.True(t, result.Pending())
+	require.Nil(t, result.Transfer)
+	require.Equal(t, approval.ID, result.Approval.ID)
+}
@@ -0,0 +1,60 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/flukis/simplebank/api"
+)
+
+type CreateUserParams struct {
+	Email    string `json:"email"`
+	Username string `json:"username"`
+	FullName string `json:"fullname"`
+	Password string `json:"password"`
+}
+
+// CreateUser registers a user
+func (c *Client) CreateUser(ctx context.Context, arg CreateUserParams) (api.UserResponse, error) {
+	var res envelope[api.UserResponse]
+	_, err := c.do(ctx, request{
+		method: http.MethodPost,
+		path:   "/user",
+		body:   arg,
+		public: true,
+	}, &res)
+	return res.Data, err
+}
+
+type LoginResult struct {
+	User                 api.UserResponse `json:"user"`
+	AccessToken          string           `json:"access_token"`
+	AccessTokenExpiresAt time.Time        `json:"access_token_expires_at"`
+}
+
+// Login logs in as username. The client keeps the credentials and sends the
+// token with every next request, logging in again when it runs out.
+func (c *Client) Login(ctx context.Context, username, password string) (LoginResult, error) {
+	var res LoginResult
+	_, err := c.do(ctx, request{
+		method: http.MethodPost,
+		path:   "/login",
+		body: map[string]string{
+			"username": username,
+			"password": password,
+		},
+		public: true,
+	}, &res)
+	if err != nil {
+		return res, err
+	}
+
+	c.mu.Lock()
+	c.username = username
+	c.password = password
+	c.token = res.AccessToken
+	c.expiresAt = res.AccessTokenExpiresAt
+	c.mu.Unlock()
+	return res, nil
+}
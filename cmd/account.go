@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+
+	db "github.com/flukis/simplebank/db/sqlc"
+	"github.com/flukis/simplebank/util"
+	"github.com/spf13/cobra"
+)
+
+func (a *app) accountCommand() *cobra.Command {
+	account := &cobra.Command{
+		Use:   "account",
+		Short: "Manage accounts",
+	}
+	account.AddCommand(
+		a.openAccountCommand(),
+		a.balanceCommand(),
+		a.accountStatusCommand("freeze", "Freeze accounts, blocking money in and out", db.AccountStatusFrozen),
+		a.accountStatusCommand("unfreeze", "Unfreeze accounts", db.AccountStatusActive),
+	)
+	return account
+}
+
+func (a *app) openAccountCommand() *cobra.Command {
+	var owner, currency, accountType string
+
+	open := &cobra.Command{
+		Use:   "open",
+		Short: "Open an account for a user",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, _, closeStore, err := a.store()
+			if err != nil {
+				return err
+			}
+			defer closeStore()
+
+			ctx := cmd.Context()
+			user, err := store.GetUserByUsername(ctx, owner)
+			if err != nil {
+				return fmt.Errorf("cannot find user %s: %w", owner, err)
+			}
+
+			account, err := store.CreateAccount(ctx, db.CreateAccountParams{
+				OwnerID:     user.ID,
+				Currency:    currency,
+				AccountType: accountType,
+			})
+			if err != nil {
+				return fmt.Errorf("cannot open account: %w", err)
+			}
+
+			return printAccounts(cmd.OutOrStdout(), account)
+		},
+	}
+
+	flags := open.Flags()
+	flags.StringVar(&owner, "owner", "", "username of the owner")
+	flags.StringVar(&currency, "currency", "", "one of USD, EUR and IDR")
+	flags.StringVar(&accountType, "type", db.AccountTypeCurrent, "one of current, savings and business")
+	open.MarkFlagRequired("owner")
+	open.MarkFlagRequired("currency")
+	return open
+}
+
+func (a *app) balanceCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "balance ACCOUNT...",
+		Short: "Show the balance of accounts, by id or account number",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, _, closeStore, err := a.store()
+			if err != nil {
+				return err
+			}
+			defer closeStore()
+
+			accounts := make([]db.Account, 0, len(args))
+			for _, arg := range args {
+				account, err := findAccount(cmd.Context(), store, arg)
+				if err != nil {
+					return err
+				}
+				accounts = append(accounts, account)
+			}
+			return printAccounts(cmd.OutOrStdout(), accounts...)
+		},
+	}
+}
+
+func (a *app) accountStatusCommand(use, short, status string) *cobra.Command {
+	return &cobra.Command{
+		Use:   use + " ACCOUNT...",
+		Short: short + ", by id or account number",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, _, closeStore, err := a.store()
+			if err != nil {
+				return err
+			}
+			defer closeStore()
+
+			accounts := make([]db.Account, 0, len(args))
+			for _, arg := range args {
+				account, err := findAccount(cmd.Context(), store, arg)
+				if err != nil {
+					return err
+				}
+				account, err = store.SetAccountStatusTx(cmd.Context(), account.ID, status)
+				if err != nil {
+					return fmt.Errorf("cannot %s account %s: %w", use, arg, err)
+				}
+				accounts = append(accounts, account)
+			}
+			return printAccounts(cmd.OutOrStdout(), accounts...)
+		},
+	}
+}
+
+// findAccount loads the account arg names by id or by account number
+func findAccount(ctx context.Context, store db.Store, arg string) (db.Account, error) {
+	var (
+		account db.Account
+		err     error
+	)
+	if id, parseErr := strconv.ParseInt(arg, 10, 64); parseErr == nil {
+		account, err = store.GetAccount(ctx, id)
+	} else {
+		number := util.NormalizeAccountNumber(arg)
+		if err := util.ValidateAccountNumber(number); err != nil {
+			return account, fmt.Errorf("%s: %w", arg, err)
+		}
+		account, err = store.GetAccountByNumber(ctx, number)
+	}
+	if err != nil {
+		return account, fmt.Errorf("cannot find account %s: %w", arg, err)
+	}
+	return account, nil
+}
+
+func printAccounts(w io.Writer, accounts ...db.Account) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tNUMBER\tOWNER\tTYPE\tSTATUS\tBALANCE\tCURRENCY")
+	for _, account := range accounts {
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%d\t%s\n",
+			account.ID, account.Number, account.OwnerID, account.AccountType, account.Status, account.Balance, account.Currency)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,218 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"testing"
+
+	mocks "github.com/flukis/simplebank/db/mock"
+	db "github.com/flukis/simplebank/db/sqlc"
+	"github.com/flukis/simplebank/util"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// run executes the simplebank command over store, with the app.env of the
+// repository
+func run(t *testing.T, store db.Store, stdin string, args ...string) (string, error) {
+	root := newRootCommand(func(conf util.Config) (db.Store, func(), error) {
+		return store, func() {}, nil
+	})
+
+	var out bytes.Buffer
+	root.SetOut(&out)
+	root.SetErr(&out)
+	root.SetIn(strings.NewReader(stdin))
+	root.SetArgs(append([]string{"--config", ".."}, args...))
+	err := root.Execute()
+	return out.String(), err
+}
+
+func validPassword(password, hash string) bool {
+	ok, err := util.ComparePasswordAndHashPassword(password, hash, util.DefaultArgon2Param)
+	return err == nil && ok
+}
+
+func randomAccount(t *testing.T) db.Account {
+	number, err := util.NewAccountNumber("0123456789")
+	require.NoError(t, err)
+
+	return db.Account{
+		ID:          util.GenRandomNum(1, 1000),
+		OwnerID:     uuid.New(),
+		Balance:     util.GenRandomMoney(),
+		Currency:    util.GenRandomCurrency(),
+		AccountType: db.AccountTypeCurrent,
+		Status:      db.AccountStatusActive,
+		Number:      number,
+	}
+}
+
+func TestCreateUserCommand(t *testing.T) {
+	store := mocks.NewStore(t)
+	user := db.User{
+		ID:       uuid.New(),
+		Username: "admin1",
+		Role:     util.RoleCustomer,
+	}
+	store.On("CreateUser", mock.Anything, mock.MatchedBy(func(arg db.CreateUserParams) bool {
+		return arg.Username == user.Username &&
+			validPassword("secret123", arg.HashedPassword)
+	})).Return(user, nil)
+	store.On("UpdateUserRole", mock.Anything, db.UpdateUserRoleParams{
+		ID:   user.ID,
+		Role: util.RoleAdmin,
+	}).Return(db.User{ID: user.ID, Username: user.Username, Role: util.RoleAdmin}, nil)
+
+	out, err := run(t, store, "secret123\n", "user", "create",
+		"--username", user.Username, "--full-name", "Admin", "--email", "admin@example.com", "--role", util.RoleAdmin)
+	require.NoError(t, err)
+	require.Equal(t, "created admin admin1 ("+user.ID.String()+")\n", out)
+}
+
+func TestCreateUserCommandInvalid(t *testing.T) {
+	store := mocks.NewStore(t)
+
+	_, err := run(t, store, "", "user", "create",
+		"--username", "admin1", "--full-name", "Admin", "--email", "admin@example.com", "--role", "root", "--password", "secret123")
+	require.ErrorContains(t, err, "unknown role")
+
+	_, err = run(t, store, "short\n", "user", "create",
+		"--username", "admin1", "--full-name", "Admin", "--email", "admin@example.com")
+	require.ErrorContains(t, err, "at least 8 characters")
+}
+
+func TestOpenAccountCommand(t *testing.T) {
+	store := mocks.NewStore(t)
+	account := randomAccount(t)
+	store.On("GetUserByUsername", mock.Anything, "budi").Return(db.User{ID: account.OwnerID}, nil)
+	store.On("CreateAccount", mock.Anything, db.CreateAccountParams{
+		OwnerID:     account.OwnerID,
+		Currency:    account.Currency,
+		AccountType: db.AccountTypeCurrent,
+	}).Return(account, nil)
+
+	out, err := run(t, store, "", "account", "open", "--owner", "budi", "--currency", account.Currency)
+	require.NoError(t, err)
+	require.Contains(t, out, account.Number)
+}
+
+func TestOpenAccountCommandUnknownOwner(t *testing.T) {
+	store := mocks.NewStore(t)
+	store.On("GetUserByUsername", mock.Anything, "budi").Return(db.User{}, sql.ErrNoRows)
+
+	_, err := run(t, store, "", "account", "open", "--owner", "budi", "--currency", "USD")
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestBalanceCommand(t *testing.T) {
+	store := mocks.NewStore(t)
+	account := randomAccount(t)
+	store.On("GetAccount", mock.Anything, account.ID).Return(account, nil)
+	store.On("GetAccountByNumber", mock.Anything, account.Number).Return(account, nil)
+
+	// account numbers are accepted as printed, with spaces
+	printed := strings.ToLower(account.Number[:4] + " " + account.Number[4:])
+	out, err := run(t, store, "", "account", "balance", printed)
+	require.NoError(t, err)
+	require.Contains(t, out, account.Number)
+
+	out, err = run(t, store, "", "account", "balance", account.Number, strconv.FormatInt(account.ID, 10))
+	require.NoError(t, err)
+	require.Equal(t, 3, strings.Count(out, "\n"))
+}
+
+func TestBalanceCommandInvalidNumber(t *testing.T) {
+	store := mocks.NewStore(t)
+
+	_, err := run(t, store, "", "account", "balance", "ID00SMPL0123456789")
+	require.ErrorIs(t, err, util.ErrAccountNumberCheck)
+}
+
+func TestFreezeCommand(t *testing.T) {
+	store := mocks.NewStore(t)
+	account := randomAccount(t)
+	frozen := account
+	frozen.Status = db.AccountStatusFrozen
+	store.On("GetAccount", mock.Anything, account.ID).Return(account, nil)
+	store.On("SetAccountStatusTx", mock.Anything, account.ID, db.AccountStatusFrozen).Return(frozen, nil)
+
+	out, err := run(t, store, "", "account", "freeze", strconv.FormatInt(account.ID, 10))
+	require.NoError(t, err)
+	require.Contains(t, out, db.AccountStatusFrozen)
+}
+
+func TestReplayCommandInvalidRange(t *testing.T) {
+	store := mocks.NewStore(t)
+
+	_, err := run(t, store, "", "jobs", "replay", "--from", "2024-03-02", "--to", "2024-03-01")
+	require.ErrorContains(t, err, "before --from")
+
+	_, err = run(t, store, "", "jobs", "replay", "--from", "yesterday")
+	require.ErrorContains(t, err, "invalid --from")
+}
+
+func TestKeysGenerateCommand(t *testing.T) {
+	out, err := run(t, nil, "", "keys", "generate")
+	require.NoError(t, err)
+
+	key, err := hex.DecodeString(strings.TrimSpace(out))
+	require.NoError(t, err)
+	require.Len(t, key, 32)
+
+	_, err = util.NewJWTMaker(strings.TrimSpace(out))
+	require.NoError(t, err)
+
+	_, err = run(t, nil, "", "keys", "generate", "--bytes", "8")
+	require.Error(t, err)
+}
+
+func TestMigrateCommandInvalidArgs(t *testing.T) {
+	_, err := run(t, nil, "", "migrate", "down")
+	require.ErrorContains(t, err, "--all")
+
+	_, err = run(t, nil, "", "migrate", "down", "1", "--all")
+	require.ErrorContains(t, err, "--all")
+
+	_, err = run(t, nil, "", "migrate", "up", "0")
+	require.ErrorContains(t, err, "invalid number")
+
+	_, err = run(t, nil, "", "migrate", "force", "latest")
+	require.ErrorContains(t, err, "invalid version")
+}
+
+func TestCommandsOnMemoryStore(t *testing.T) {
+	store, closeStore, err := openStore(util.Config{DBDriver: driverMemory})
+	require.NoError(t, err)
+	defer closeStore()
+
+	_, err = run(t, store, "secret123\n", "user", "create",
+		"--username", "budi", "--full-name", "Budi", "--email", "budi@example.com")
+	require.NoError(t, err)
+
+	out, err := run(t, store, "", "account", "open", "--owner", "budi", "--currency", "IDR")
+	require.NoError(t, err)
+
+	// a second current account in the same currency is refused
+	_, err = run(t, store, "", "account", "open", "--owner", "budi", "--currency", "IDR")
+	require.ErrorIs(t, err, db.ErrUniqueViolation)
+
+	accounts, err := store.FetchAccounts(context.Background(), db.FetchAccountsParams{Limit: 100})
+	require.NoError(t, err)
+	opened := accounts[len(accounts)-1]
+	require.Contains(t, out, opened.Number)
+
+	_, err = run(t, store, "", "account", "freeze", opened.Number)
+	require.NoError(t, err)
+	out, err = run(t, store, "", "account", "balance", opened.Number)
+	require.NoError(t, err)
+	require.Contains(t, out, db.AccountStatusFrozen)
+
+	_, _, err = openStore(util.Config{DBDriver: "mysql"})
+	require.ErrorContains(t, err, "unknown db driver")
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/flukis/simplebank/jobs"
+	"github.com/spf13/cobra"
+)
+
+func (a *app) jobsCommand() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "jobs",
+		Short: "Run background jobs by hand",
+	}
+	command.AddCommand(a.replayCommand())
+	return command
+}
+
+func (a *app) replayCommand() *cobra.Command {
+	var from, to string
+
+	replay := &cobra.Command{
+		Use:   "replay",
+		Short: "Run the end of day job again for days it failed for",
+		Long: "Run the end of day job for every day from --from to --to. " +
+			"The job posts nothing twice, so days that did succeed can be included.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			start, err := time.Parse(jobs.DateLayout, from)
+			if err != nil {
+				return fmt.Errorf("invalid --from: %w", err)
+			}
+			end := start
+			if to != "" {
+				if end, err = time.Parse(jobs.DateLayout, to); err != nil {
+					return fmt.Errorf("invalid --to: %w", err)
+				}
+			}
+			if end.Before(start) {
+				return errors.New("--to is before --from")
+			}
+
+			store, _, closeStore, err := a.store()
+			if err != nil {
+				return err
+			}
+			defer closeStore()
+
+			job := jobs.NewEndOfDay(store)
+			for date := start; !date.After(end); date = date.AddDate(0, 0, 1) {
+				result, err := job.Run(cmd.Context(), date)
+				if err != nil {
+					return fmt.Errorf("end of day for %s failed: %w", date.Format(jobs.DateLayout), err)
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "%s: %d installments collected, %d overdue, %d deposits matured, %d accrued, %d capitalized, %d idempotency keys expired\n",
+					result.Date, result.Collected, result.Overdue, result.Matured, result.Accrued, result.Capitalized, result.ExpiredKeys)
+			}
+			return nil
+		},
+	}
+
+	flags := replay.Flags()
+	flags.StringVar(&from, "from", "", "first day, as "+jobs.DateLayout)
+	flags.StringVar(&to, "to", "", "last day, the same as --from when left out")
+	replay.MarkFlagRequired("from")
+	return replay
+}
@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// minKeyBytes keeps the hex encoded key within the minimum size of
+// util.NewJWTMaker
+const minKeyBytes = 16
+
+func keysCommand() *cobra.Command {
+	keys := &cobra.Command{
+		Use:   "keys",
+		Short: "Manage signing keys",
+	}
+
+	var size int
+	generate := &cobra.Command{
+		Use:   "generate",
+		Short: "Print a random key for TOKEN_SYMMETRIC_KEY",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if size < minKeyBytes {
+				return fmt.Errorf("keys must have at least %d bytes", minKeyBytes)
+			}
+
+			key := make([]byte, size)
+			if _, err := rand.Read(key); err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), hex.EncodeToString(key))
+			return nil
+		},
+	}
+	generate.Flags().IntVar(&size, "bytes", 32, "random bytes in the key, printed as twice as many hex digits")
+
+	keys.AddCommand(generate)
+	return keys
+}
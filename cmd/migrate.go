@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/flukis/simplebank/db/migration"
+	db "github.com/flukis/simplebank/db/sqlc"
+	"github.com/flukis/simplebank/util"
+	"github.com/spf13/cobra"
+)
+
+func (a *app) migrateCommand() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "migrate",
+		Short: "Apply or roll back the database migrations built into the binary",
+	}
+
+	// run runs fn on the database of the config, then reports its version
+	run := func(cmd *cobra.Command, fn func(m *migration.Migrator) error) error {
+		conf, err := a.config()
+		if err != nil {
+			return err
+		}
+		return withMigrator(cmd.Context(), conf, func(m *migration.Migrator) error {
+			if err := fn(m); err != nil {
+				return err
+			}
+			return printVersion(cmd, m)
+		})
+	}
+
+	up := &cobra.Command{
+		Use:   "up [N]",
+		Short: "Apply all migrations, or the next N",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			n := 0
+			if len(args) == 1 {
+				var err error
+				if n, err = migrationCount(args[0]); err != nil {
+					return err
+				}
+			}
+			return run(cmd, func(m *migration.Migrator) error {
+				return m.Up(cmd.Context(), n)
+			})
+		},
+	}
+
+	var all bool
+	down := &cobra.Command{
+		Use:   "down N | --all",
+		Short: "Roll back the last N migrations, or all of them",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if all == (len(args) == 1) {
+				return errors.New("give either the number of migrations or --all")
+			}
+			n := 0
+			if !all {
+				var err error
+				if n, err = migrationCount(args[0]); err != nil {
+					return err
+				}
+			}
+			return run(cmd, func(m *migration.Migrator) error {
+				return m.Down(cmd.Context(), n)
+			})
+		},
+	}
+	down.Flags().BoolVar(&all, "all", false, "roll back every migration")
+
+	version := &cobra.Command{
+		Use:   "version",
+		Short: "Show the last migration applied",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd, func(m *migration.Migrator) error {
+				return nil
+			})
+		},
+	}
+
+	force := &cobra.Command{
+		Use:   "force VERSION",
+		Short: "Mark VERSION as applied without running it, after fixing a dirty database by hand",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			v, err := strconv.Atoi(args[0])
+			if err != nil || v < -1 {
+				return fmt.Errorf("invalid version %q", args[0])
+			}
+			return run(cmd, func(m *migration.Migrator) error {
+				return m.Force(cmd.Context(), v)
+			})
+		},
+	}
+
+	command.AddCommand(up, down, version, force)
+	return command
+}
+
+// withMigrator runs fn with a Migrator on the database of conf
+func withMigrator(ctx context.Context, conf util.Config, fn func(m *migration.Migrator) error) error {
+	switch conf.DBDriver {
+	case driverMemory:
+		return errors.New("the memory store has no migrations")
+	case driverSQLite:
+		conn, err := db.OpenSQLite(ctx, conf)
+		if err != nil {
+			return fmt.Errorf("cannot connect to db: %w", err)
+		}
+		m, err := migration.NewSQLite(conn)
+		if err != nil {
+			conn.Close()
+			return fmt.Errorf("cannot open migrations: %w", err)
+		}
+		defer m.Close()
+		return fn(m)
+	}
+
+	conn, pool, err := db.OpenDB(ctx, conf)
+	if err != nil {
+		return fmt.Errorf("cannot connect to db: %w", err)
+	}
+	defer pool.Close()
+	defer conn.Close()
+
+	m, err := migration.New(ctx, conn)
+	if err != nil {
+		return fmt.Errorf("cannot open migrations: %w", err)
+	}
+	defer m.Close()
+
+	return fn(m)
+}
+
+func migrationCount(arg string) (int, error) {
+	n, err := strconv.Atoi(arg)
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("invalid number of migrations %q", arg)
+	}
+	return n, nil
+}
+
+func printVersion(cmd *cobra.Command, m *migration.Migrator) error {
+	version, dirty, err := m.Version()
+	switch {
+	case err != nil:
+		return err
+	case version == 0:
+		fmt.Fprintln(cmd.OutOrStdout(), "no migration applied")
+	case dirty:
+		fmt.Fprintf(cmd.OutOrStdout(), "at version %d, dirty\n", version)
+	default:
+		fmt.Fprintf(cmd.OutOrStdout(), "at version %d\n", version)
+	}
+	return nil
+}
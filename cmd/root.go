@@ -0,0 +1,107 @@
+// Package cmd is the simplebank command: the server, and the tools operations
+// use on its database.
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	db "github.com/flukis/simplebank/db/sqlc"
+	"github.com/flukis/simplebank/util"
+	"github.com/spf13/cobra"
+)
+
+// DB_DRIVER values
+const (
+	driverPostgres = "postgres"
+	driverSQLite   = "sqlite"
+	driverMemory   = "memory"
+)
+
+// storeOpener connects to the database conf points at, returning how to
+// disconnect
+type storeOpener func(conf util.Config) (db.Store, func(), error)
+
+type app struct {
+	configPath string
+	openStore  storeOpener
+}
+
+// NewRootCommand returns the simplebank command with all its subcommands
+func NewRootCommand() *cobra.Command {
+	return newRootCommand(openStore)
+}
+
+func newRootCommand(openStore storeOpener) *cobra.Command {
+	a := &app{
+		openStore: openStore,
+	}
+
+	root := &cobra.Command{
+		Use:           "simplebank",
+		Short:         "Simple Bank server and admin tool",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+	root.PersistentFlags().StringVar(&a.configPath, "config", ".", "directory of app.env")
+
+	root.AddCommand(
+		a.serveCommand(),
+		a.userCommand(),
+		a.accountCommand(),
+		a.jobsCommand(),
+		a.migrateCommand(),
+		keysCommand(),
+	)
+	return root
+}
+
+func (a *app) config() (util.Config, error) {
+	conf, err := util.LoadConfig(a.configPath)
+	if err != nil {
+		return conf, fmt.Errorf("cannot load config: %w", err)
+	}
+	return conf, nil
+}
+
+// store loads the config and connects to its database. Call closeStore once
+// done with the store.
+func (a *app) store() (store db.Store, conf util.Config, closeStore func(), err error) {
+	conf, err = a.config()
+	if err != nil {
+		return nil, conf, nil, err
+	}
+
+	store, closeStore, err = a.openStore(conf)
+	if err != nil {
+		return nil, conf, nil, fmt.Errorf("cannot connect to db: %w", err)
+	}
+	return store, conf, closeStore, nil
+}
+
+// openStore opens the store of DB_DRIVER. SQLite keeps the data in the file
+// DB_SOURCE names, for small deployments without Postgres. The memory store
+// starts empty every time, for trying the server out. The function returned
+// with the store closes its connections.
+func openStore(conf util.Config) (db.Store, func(), error) {
+	switch conf.DBDriver {
+	case driverPostgres, "":
+		conn, pool, err := db.OpenDB(context.Background(), conf)
+		if err != nil {
+			return nil, nil, err
+		}
+		return db.NewStore(conn), func() {
+			conn.Close()
+			pool.Close()
+		}, nil
+	case driverSQLite:
+		conn, err := db.OpenSQLite(context.Background(), conf)
+		if err != nil {
+			return nil, nil, err
+		}
+		return db.NewSQLiteStore(conn), func() { conn.Close() }, nil
+	case driverMemory:
+		return db.NewMemoryStore(), func() {}, nil
+	}
+	return nil, nil, fmt.Errorf("unknown db driver %q", conf.DBDriver)
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+
+	"github.com/flukis/simplebank/api"
+	"github.com/flukis/simplebank/db/migration"
+	db "github.com/flukis/simplebank/db/sqlc"
+	"github.com/flukis/simplebank/gapi"
+	"github.com/flukis/simplebank/jobs"
+	"github.com/flukis/simplebank/pb"
+	"github.com/flukis/simplebank/util"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func (a *app) serveCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "serve",
+		Short: "Run the HTTP and gRPC servers and the end of day job",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, conf, closeStore, err := a.store()
+			if err != nil {
+				return err
+			}
+			defer closeStore()
+
+			if conf.AutoMigrate && conf.DBDriver != driverMemory {
+				err = withMigrator(cmd.Context(), conf, func(m *migration.Migrator) error {
+					return m.Up(cmd.Context(), 0)
+				})
+				if err != nil {
+					return fmt.Errorf("cannot migrate db: %w", err)
+				}
+			}
+			return serve(store, conf)
+		},
+	}
+}
+
+func serve(store db.Store, conf util.Config) error {
+	go jobs.NewEndOfDay(store).Schedule(context.Background())
+
+	grpcServer, err := runGRPCServer(store, conf)
+	if err != nil {
+		return err
+	}
+	defer grpcServer.GracefulStop()
+
+	server, err := api.NewServer(store, conf)
+	if err != nil {
+		return fmt.Errorf("cannot create server: %w", err)
+	}
+
+	// the gateway calls the gRPC server like any other client, so its
+	// requests go through the same interceptors
+	gateway := runtime.NewServeMux()
+	err = pb.RegisterSimpleBankHandlerFromEndpoint(
+		context.Background(),
+		gateway,
+		conf.GRPCServerAddr,
+		[]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())},
+	)
+	if err != nil {
+		return fmt.Errorf("cannot register gateway: %w", err)
+	}
+	server.Mount("/v1", gateway)
+
+	server.Start(conf.ServerAddr)
+	return nil
+}
+
+func runGRPCServer(store db.Store, conf util.Config) (*grpc.Server, error) {
+	server, err := gapi.NewServer(store, conf)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create grpc server: %w", err)
+	}
+
+	listener, err := net.Listen("tcp", conf.GRPCServerAddr)
+	if err != nil {
+		return nil, fmt.Errorf("cannot listen for grpc: %w", err)
+	}
+
+	grpcServer := server.GRPCServer()
+	go func() {
+		if err := grpcServer.Serve(listener); err != nil {
+			log.Fatal("cannot serve grpc: ", err)
+		}
+	}()
+	return grpcServer, nil
+}
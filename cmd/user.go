@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"strings"
+
+	db "github.com/flukis/simplebank/db/sqlc"
+	"github.com/flukis/simplebank/util"
+	"github.com/spf13/cobra"
+)
+
+func (a *app) userCommand() *cobra.Command {
+	user := &cobra.Command{
+		Use:   "user",
+		Short: "Manage users",
+	}
+	user.AddCommand(a.createUserCommand())
+	return user
+}
+
+func (a *app) createUserCommand() *cobra.Command {
+	var arg db.CreateUserParams
+	var password, role string
+
+	create := &cobra.Command{
+		Use:   "create",
+		Short: "Register a user, e.g. the first admin",
+		Long:  "Register a user. The password is read from the first line of stdin unless --password is given.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !util.IsSupportedRole(role) {
+				return fmt.Errorf("unknown role %q", role)
+			}
+			if password == "" {
+				line, err := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+				if err != nil && line == "" {
+					return fmt.Errorf("cannot read password: %w", err)
+				}
+				password = strings.TrimRight(line, "\r\n")
+			}
+			if len(password) < 8 {
+				return errors.New("the password must be at least 8 characters")
+			}
+
+			store, _, closeStore, err := a.store()
+			if err != nil {
+				return err
+			}
+			defer closeStore()
+
+			arg.HashedPassword, err = util.GenerateHashFromPassword(password, util.DefaultArgon2Param)
+			if err != nil {
+				return err
+			}
+
+			ctx := cmd.Context()
+			user, err := store.CreateUser(ctx, arg)
+			if err != nil {
+				return fmt.Errorf("cannot create user: %w", err)
+			}
+			if role != user.Role {
+				user, err = store.UpdateUserRole(ctx, db.UpdateUserRoleParams{
+					ID:   user.ID,
+					Role: role,
+				})
+				if err != nil {
+					return fmt.Errorf("cannot set role of user %s: %w", user.Username, err)
+				}
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "created %s %s (%s)\n", user.Role, user.Username, user.ID)
+			return nil
+		},
+	}
+
+	flags := create.Flags()
+	flags.StringVar(&arg.Username, "username", "", "username to log in with")
+	flags.StringVar(&arg.FullName, "full-name", "", "full name")
+	flags.StringVar(&arg.Email, "email", "", "email address")
+	flags.StringVar(&password, "password", "", "password, read from stdin when left out")
+	flags.StringVar(&role, "role", util.RoleCustomer, "one of customer, teller, admin and auditor")
+	create.MarkFlagRequired("username")
+	create.MarkFlagRequired("full-name")
+	create.MarkFlagRequired("email")
+	return create
+}
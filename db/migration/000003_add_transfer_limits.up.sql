@@ -0,0 +1,37 @@
+ALTER TABLE "accounts" ALTER COLUMN "created_at" SET DEFAULT (now());
+
+ALTER TABLE "entries" ALTER COLUMN "created_at" SET DEFAULT (now());
+
+ALTER TABLE "transfers" ALTER COLUMN "created_at" SET DEFAULT (now());
+
+ALTER TABLE "accounts" ADD COLUMN "tier" varchar NOT NULL DEFAULT 'standard';
+
+CREATE TABLE "transfer_limits" (
+  "tier" varchar NOT NULL,
+  "currency" varchar NOT NULL,
+  "max_per_transfer" bigint NOT NULL DEFAULT 0,
+  "account_daily_amount" bigint NOT NULL DEFAULT 0,
+  "account_monthly_amount" bigint NOT NULL DEFAULT 0,
+  "account_daily_count" bigint NOT NULL DEFAULT 0,
+  "user_daily_amount" bigint NOT NULL DEFAULT 0,
+  "user_monthly_amount" bigint NOT NULL DEFAULT 0,
+  "user_daily_count" bigint NOT NULL DEFAULT 0,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("tier", "currency")
+);
+
+COMMENT ON TABLE "transfer_limits" IS 'a limit of 0 means unlimited';
+
+CREATE INDEX ON "transfers" ("from_account_id", "created_at");
+
+INSERT INTO "transfer_limits" (
+  "tier", "currency",
+  "max_per_transfer", "account_daily_amount", "account_monthly_amount", "account_daily_count",
+  "user_daily_amount", "user_monthly_amount", "user_daily_count"
+) VALUES
+  ('standard', 'USD', 1000000, 2500000, 25000000, 100, 5000000, 50000000, 200),
+  ('standard', 'EUR', 1000000, 2500000, 25000000, 100, 5000000, 50000000, 200),
+  ('standard', 'IDR', 1000000000, 2500000000, 25000000000, 100, 5000000000, 50000000000, 200),
+  ('premium', 'USD', 10000000, 25000000, 250000000, 500, 50000000, 500000000, 1000),
+  ('premium', 'EUR', 10000000, 25000000, 250000000, 500, 50000000, 500000000, 1000),
+  ('premium', 'IDR', 10000000000, 25000000000, 250000000000, 500, 50000000000, 500000000000, 1000);
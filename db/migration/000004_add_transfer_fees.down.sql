@@ -0,0 +1,15 @@
+DROP TABLE IF EXISTS "fee_schedule_tiers";
+
+DROP TABLE IF EXISTS "fee_schedules";
+
+DELETE FROM "entries" WHERE "account_id" IN (SELECT "id" FROM "accounts" WHERE "account_type" = 'house_revenue');
+
+DELETE FROM "accounts" WHERE "account_type" = 'house_revenue';
+
+DELETE FROM "users" WHERE "id" = '00000000-0000-0000-0000-000000000001';
+
+ALTER TABLE IF EXISTS "accounts" DROP CONSTRAINT IF EXISTS "owner_id_currency_type_key";
+
+ALTER TABLE IF EXISTS "accounts" ADD CONSTRAINT "owner_id_currency_key" UNIQUE ("owner_id", "currency");
+
+ALTER TABLE IF EXISTS "accounts" DROP COLUMN IF EXISTS "account_type";
@@ -0,0 +1,46 @@
+ALTER TABLE "accounts" ADD COLUMN "account_type" varchar NOT NULL DEFAULT 'current';
+
+ALTER TABLE "accounts" DROP CONSTRAINT IF EXISTS "owner_id_currency_key";
+
+ALTER TABLE "accounts" ADD CONSTRAINT "owner_id_currency_type_key" UNIQUE ("owner_id", "currency", "account_type");
+
+CREATE TABLE "fee_schedules" (
+  "id" bigserial PRIMARY KEY,
+  "currency" varchar NOT NULL,
+  "account_type" varchar NOT NULL,
+  "kind" varchar NOT NULL,
+  "flat_amount" bigint NOT NULL DEFAULT 0,
+  "rate_bps" bigint NOT NULL DEFAULT 0,
+  "min_fee" bigint NOT NULL DEFAULT 0,
+  "max_fee" bigint NOT NULL DEFAULT 0,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "fee_schedules_kind_check" CHECK ("kind" IN ('flat', 'percentage', 'tiered')),
+  CONSTRAINT "currency_account_type_key" UNIQUE ("currency", "account_type")
+);
+
+CREATE TABLE "fee_schedule_tiers" (
+  "id" bigserial PRIMARY KEY,
+  "schedule_id" bigint NOT NULL,
+  "up_to" bigint NOT NULL DEFAULT 0,
+  "flat_amount" bigint NOT NULL DEFAULT 0,
+  "rate_bps" bigint NOT NULL DEFAULT 0
+);
+
+COMMENT ON COLUMN "fee_schedules"."rate_bps" IS 'basis points of the transferred amount';
+
+COMMENT ON COLUMN "fee_schedules"."max_fee" IS '0 means no cap';
+
+COMMENT ON COLUMN "fee_schedule_tiers"."up_to" IS 'inclusive upper bound of the transfer amount, 0 means no bound';
+
+CREATE INDEX ON "fee_schedule_tiers" ("schedule_id");
+
+ALTER TABLE "fee_schedule_tiers" ADD FOREIGN KEY ("schedule_id") REFERENCES "fee_schedules" ("id") ON DELETE CASCADE;
+
+-- the bank itself owns the house accounts fees are posted to
+INSERT INTO "users" ("id", "username", "hashed_password", "full_name", "email")
+VALUES ('00000000-0000-0000-0000-000000000001', 'simplebank', '!', 'Simplebank House', 'house@simplebank.internal');
+
+INSERT INTO "accounts" ("owner_id", "balance", "currency", "account_type") VALUES
+  ('00000000-0000-0000-0000-000000000001', 0, 'USD', 'house_revenue'),
+  ('00000000-0000-0000-0000-000000000001', 0, 'EUR', 'house_revenue'),
+  ('00000000-0000-0000-0000-000000000001', 0, 'IDR', 'house_revenue');
@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS "owner_id_currency_type_key";
+
+ALTER TABLE IF EXISTS "accounts" ADD CONSTRAINT "owner_id_currency_type_key" UNIQUE ("owner_id", "currency", "account_type");
+
+ALTER TABLE IF EXISTS "accounts" DROP COLUMN IF EXISTS "closed_at";
+
+UPDATE "accounts" SET "status" = 'frozen' WHERE "status" IN ('dormant', 'closed');
+
+ALTER TABLE IF EXISTS "accounts" DROP CONSTRAINT IF EXISTS "accounts_status_check";
+
+ALTER TABLE IF EXISTS "accounts" ADD CONSTRAINT "accounts_status_check" CHECK ("status" IN ('active', 'frozen'));
@@ -0,0 +1,12 @@
+ALTER TABLE "accounts" DROP CONSTRAINT IF EXISTS "accounts_status_check";
+
+ALTER TABLE "accounts" ADD CONSTRAINT "accounts_status_check" CHECK ("status" IN ('active', 'frozen', 'dormant', 'closed'));
+
+ALTER TABLE "accounts" ADD COLUMN "closed_at" timestamptz;
+
+-- closed accounts are kept for history and must not block opening a new one
+ALTER TABLE "accounts" DROP CONSTRAINT IF EXISTS "owner_id_currency_type_key";
+
+CREATE UNIQUE INDEX "owner_id_currency_type_key" ON "accounts" ("owner_id", "currency", "account_type") WHERE "status" <> 'closed';
+
+COMMENT ON COLUMN "accounts"."closed_at" IS 'set when the account is closed, closed accounts are never deleted';
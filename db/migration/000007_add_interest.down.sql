@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS "interest_accruals";
+
+DROP TABLE IF EXISTS "interest_capitalizations";
+
+DROP TABLE IF EXISTS "interest_rates";
+
+DELETE FROM "entries" WHERE "account_id" IN (SELECT "id" FROM "accounts" WHERE "account_type" = 'house_interest_expense');
+
+DELETE FROM "accounts" WHERE "account_type" = 'house_interest_expense';
@@ -0,0 +1,61 @@
+CREATE TABLE "interest_rates" (
+  "account_type" varchar NOT NULL,
+  "currency" varchar NOT NULL,
+  "rate_bps" bigint NOT NULL,
+  "day_count" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("account_type", "currency"),
+  CONSTRAINT "interest_rates_day_count_check" CHECK ("day_count" IN ('ACT/365', 'ACT/360', '30/360'))
+);
+
+CREATE TABLE "interest_accruals" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "accrual_date" date NOT NULL,
+  "balance" bigint NOT NULL,
+  "rate_bps" bigint NOT NULL,
+  "day_count" varchar NOT NULL,
+  "amount_micros" bigint NOT NULL,
+  "capitalization_id" bigint,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "account_id_accrual_date_key" UNIQUE ("account_id", "accrual_date")
+);
+
+CREATE TABLE "interest_capitalizations" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "period_end" date NOT NULL,
+  "accrued_micros" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "carry_micros" bigint NOT NULL,
+  "entry_id" bigint,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "account_id_period_end_key" UNIQUE ("account_id", "period_end")
+);
+
+COMMENT ON COLUMN "interest_rates"."rate_bps" IS 'annual rate in basis points';
+
+COMMENT ON COLUMN "interest_accruals"."amount_micros" IS 'millionths of the smallest currency unit';
+
+COMMENT ON COLUMN "interest_capitalizations"."carry_micros" IS 'accrued interest too small to post, carried to the next period';
+
+CREATE INDEX ON "interest_accruals" ("capitalization_id");
+
+ALTER TABLE "interest_accruals" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "interest_accruals" ADD FOREIGN KEY ("capitalization_id") REFERENCES "interest_capitalizations" ("id");
+
+ALTER TABLE "interest_capitalizations" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "interest_capitalizations" ADD FOREIGN KEY ("entry_id") REFERENCES "entries" ("id");
+
+INSERT INTO "interest_rates" ("account_type", "currency", "rate_bps", "day_count") VALUES
+  ('savings', 'USD', 250, 'ACT/365'),
+  ('savings', 'EUR', 150, 'ACT/360'),
+  ('savings', 'IDR', 300, '30/360');
+
+-- interest paid to customers is posted from these house accounts
+INSERT INTO "accounts" ("owner_id", "balance", "currency", "account_type") VALUES
+  ('00000000-0000-0000-0000-000000000001', 0, 'USD', 'house_interest_expense'),
+  ('00000000-0000-0000-0000-000000000001', 0, 'EUR', 'house_interest_expense'),
+  ('00000000-0000-0000-0000-000000000001', 0, 'IDR', 'house_interest_expense');
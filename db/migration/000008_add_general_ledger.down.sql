@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS "journal_lines";
+
+DROP TABLE IF EXISTS "journal_entries";
+
+DROP TABLE IF EXISTS "gl_account_mappings";
+
+DROP TABLE IF EXISTS "gl_accounts";
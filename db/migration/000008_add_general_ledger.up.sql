@@ -0,0 +1,87 @@
+CREATE TABLE "gl_accounts" (
+  "id" bigserial PRIMARY KEY,
+  "code" varchar UNIQUE NOT NULL,
+  "name" varchar NOT NULL,
+  "type" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "gl_accounts_type_check" CHECK ("type" IN ('asset', 'liability', 'equity', 'income', 'expense'))
+);
+
+CREATE TABLE "gl_account_mappings" (
+  "account_type" varchar PRIMARY KEY,
+  "gl_account_id" bigint NOT NULL
+);
+
+CREATE TABLE "journal_entries" (
+  "id" bigserial PRIMARY KEY,
+  "reference" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "journal_lines" (
+  "id" bigserial PRIMARY KEY,
+  "journal_entry_id" bigint NOT NULL,
+  "gl_account_id" bigint NOT NULL,
+  "account_id" bigint,
+  "currency" varchar NOT NULL,
+  "amount" bigint NOT NULL
+);
+
+COMMENT ON TABLE "gl_account_mappings" IS 'GL account every account of a type is booked to';
+
+COMMENT ON COLUMN "journal_entries"."reference" IS 'what caused the posting, e.g. transfer:42';
+
+COMMENT ON COLUMN "journal_lines"."account_id" IS 'customer or house account the line was posted for, if any';
+
+COMMENT ON COLUMN "journal_lines"."amount" IS 'debits are positive, credits negative, lines of an entry sum to zero per currency';
+
+CREATE INDEX ON "journal_entries" ("reference");
+
+CREATE INDEX ON "journal_lines" ("journal_entry_id");
+
+CREATE INDEX ON "journal_lines" ("gl_account_id", "currency");
+
+ALTER TABLE "gl_account_mappings" ADD FOREIGN KEY ("gl_account_id") REFERENCES "gl_accounts" ("id");
+
+ALTER TABLE "journal_lines" ADD FOREIGN KEY ("journal_entry_id") REFERENCES "journal_entries" ("id");
+
+ALTER TABLE "journal_lines" ADD FOREIGN KEY ("gl_account_id") REFERENCES "gl_accounts" ("id");
+
+ALTER TABLE "journal_lines" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+INSERT INTO "gl_accounts" ("code", "name", "type") VALUES
+  ('1000', 'Cash and cash equivalents', 'asset'),
+  ('2100', 'Customer current accounts', 'liability'),
+  ('2200', 'Customer savings accounts', 'liability'),
+  ('3900', 'Opening balance equity', 'equity'),
+  ('4100', 'Fee income', 'income'),
+  ('5100', 'Interest expense', 'expense');
+
+INSERT INTO "gl_account_mappings" ("account_type", "gl_account_id")
+SELECT m."account_type", g."id"
+FROM (VALUES
+  ('current', '2100'),
+  ('savings', '2200'),
+  ('house_revenue', '4100'),
+  ('house_interest_expense', '5100')
+) AS m ("account_type", "code")
+JOIN "gl_accounts" g ON g."code" = m."code";
+
+-- book the balances that existed before the ledger against equity
+INSERT INTO "journal_entries" ("reference") VALUES ('opening-balances');
+
+INSERT INTO "journal_lines" ("journal_entry_id", "gl_account_id", "account_id", "currency", "amount")
+SELECT j."id", m."gl_account_id", a."id", a."currency", -a."balance"
+FROM "accounts" a
+JOIN "gl_account_mappings" m ON m."account_type" = a."account_type"
+CROSS JOIN (SELECT "id" FROM "journal_entries" WHERE "reference" = 'opening-balances') j
+WHERE a."balance" <> 0;
+
+INSERT INTO "journal_lines" ("journal_entry_id", "gl_account_id", "currency", "amount")
+SELECT j."id", g."id", a."currency", SUM(a."balance")
+FROM "accounts" a
+JOIN "gl_account_mappings" m ON m."account_type" = a."account_type"
+CROSS JOIN (SELECT "id" FROM "journal_entries" WHERE "reference" = 'opening-balances') j
+CROSS JOIN (SELECT "id" FROM "gl_accounts" WHERE "code" = '3900') g
+WHERE a."balance" <> 0
+GROUP BY j."id", g."id", a."currency";
@@ -0,0 +1,13 @@
+DROP TABLE IF EXISTS "cash_transactions";
+
+DROP TABLE IF EXISTS "teller_tills";
+
+DELETE FROM "gl_account_mappings" WHERE "account_type" = 'house_cash';
+
+DELETE FROM "journal_lines" WHERE "account_id" IN (SELECT "id" FROM "accounts" WHERE "account_type" = 'house_cash');
+
+DELETE FROM "entries" WHERE "account_id" IN (SELECT "id" FROM "accounts" WHERE "account_type" = 'house_cash');
+
+DELETE FROM "accounts" WHERE "account_type" = 'house_cash';
+
+ALTER TABLE IF EXISTS "accounts" ALTER COLUMN "balance" DROP DEFAULT;
@@ -0,0 +1,57 @@
+ALTER TABLE "accounts" ALTER COLUMN "balance" SET DEFAULT 0;
+
+CREATE TABLE "teller_tills" (
+  "id" bigserial PRIMARY KEY,
+  "teller_id" uuid NOT NULL,
+  "currency" varchar NOT NULL,
+  "business_date" date NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'open',
+  "opening_cash" bigint NOT NULL,
+  "expected_cash" bigint NOT NULL,
+  "counted_cash" bigint NOT NULL DEFAULT 0,
+  "difference" bigint NOT NULL DEFAULT 0,
+  "opened_at" timestamptz NOT NULL DEFAULT (now()),
+  "closed_at" timestamptz,
+  CONSTRAINT "teller_tills_status_check" CHECK ("status" IN ('open', 'closed')),
+  CONSTRAINT "teller_id_currency_business_date_key" UNIQUE ("teller_id", "currency", "business_date")
+);
+
+CREATE TABLE "cash_transactions" (
+  "id" bigserial PRIMARY KEY,
+  "till_id" bigint NOT NULL,
+  "account_id" bigint NOT NULL,
+  "kind" varchar NOT NULL,
+  "amount" bigint NOT NULL,
+  "balance_after" bigint NOT NULL,
+  "entry_id" bigint NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "cash_transactions_kind_check" CHECK ("kind" IN ('deposit', 'withdrawal')),
+  CONSTRAINT "cash_transactions_amount_check" CHECK ("amount" > 0)
+);
+
+COMMENT ON COLUMN "teller_tills"."expected_cash" IS 'opening cash plus deposits minus withdrawals';
+
+COMMENT ON COLUMN "teller_tills"."difference" IS 'counted minus expected cash when the till is closed, negative when short';
+
+COMMENT ON COLUMN "cash_transactions"."balance_after" IS 'account balance printed on the receipt';
+
+CREATE INDEX ON "cash_transactions" ("till_id");
+
+CREATE INDEX ON "cash_transactions" ("account_id");
+
+ALTER TABLE "teller_tills" ADD FOREIGN KEY ("teller_id") REFERENCES "users" ("id");
+
+ALTER TABLE "cash_transactions" ADD FOREIGN KEY ("till_id") REFERENCES "teller_tills" ("id");
+
+ALTER TABLE "cash_transactions" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "cash_transactions" ADD FOREIGN KEY ("entry_id") REFERENCES "entries" ("id");
+
+-- the vault: cash held by the bank, booked to the cash GL account
+INSERT INTO "accounts" ("owner_id", "balance", "currency", "account_type") VALUES
+  ('00000000-0000-0000-0000-000000000001', 0, 'USD', 'house_cash'),
+  ('00000000-0000-0000-0000-000000000001', 0, 'EUR', 'house_cash'),
+  ('00000000-0000-0000-0000-000000000001', 0, 'IDR', 'house_cash');
+
+INSERT INTO "gl_account_mappings" ("account_type", "gl_account_id")
+SELECT 'house_cash', "id" FROM "gl_accounts" WHERE "code" = '1000';
@@ -0,0 +1,15 @@
+DROP TABLE IF EXISTS "loan_installments";
+
+DROP TABLE IF EXISTS "loans";
+
+DELETE FROM "gl_account_mappings" WHERE "account_type" IN ('house_loans', 'house_loan_interest');
+
+DELETE FROM "journal_lines" WHERE "account_id" IN (SELECT "id" FROM "accounts" WHERE "account_type" IN ('house_loans', 'house_loan_interest'));
+
+DELETE FROM "transfers" WHERE "from_account_id" IN (SELECT "id" FROM "accounts" WHERE "account_type" = 'house_loans');
+
+DELETE FROM "entries" WHERE "account_id" IN (SELECT "id" FROM "accounts" WHERE "account_type" IN ('house_loans', 'house_loan_interest'));
+
+DELETE FROM "accounts" WHERE "account_type" IN ('house_loans', 'house_loan_interest');
+
+DELETE FROM "gl_accounts" WHERE "code" IN ('1200', '4200');
@@ -0,0 +1,76 @@
+CREATE TABLE "loans" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "currency" varchar NOT NULL,
+  "principal" bigint NOT NULL,
+  "rate_bps" bigint NOT NULL,
+  "term" integer NOT NULL,
+  "frequency" varchar NOT NULL,
+  "amortization" varchar NOT NULL,
+  "late_fee" bigint NOT NULL DEFAULT 0,
+  "status" varchar NOT NULL DEFAULT 'active',
+  "disbursement_id" bigint NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "loans_principal_check" CHECK ("principal" > 0),
+  CONSTRAINT "loans_frequency_check" CHECK ("frequency" IN ('weekly', 'monthly')),
+  CONSTRAINT "loans_amortization_check" CHECK ("amortization" IN ('annuity', 'flat')),
+  CONSTRAINT "loans_status_check" CHECK ("status" IN ('active', 'paid_off'))
+);
+
+CREATE TABLE "loan_installments" (
+  "id" bigserial PRIMARY KEY,
+  "loan_id" bigint NOT NULL,
+  "number" integer NOT NULL,
+  "due_date" date NOT NULL,
+  "principal" bigint NOT NULL,
+  "interest" bigint NOT NULL,
+  "late_fee" bigint NOT NULL DEFAULT 0,
+  "status" varchar NOT NULL DEFAULT 'pending',
+  "entry_id" bigint,
+  "paid_at" timestamptz,
+  CONSTRAINT "loan_installments_status_check" CHECK ("status" IN ('pending', 'overdue', 'paid')),
+  CONSTRAINT "loan_id_number_key" UNIQUE ("loan_id", "number")
+);
+
+COMMENT ON COLUMN "loans"."account_id" IS 'borrower account the loan is paid into and collected from';
+
+COMMENT ON COLUMN "loans"."rate_bps" IS 'annual rate in basis points';
+
+COMMENT ON COLUMN "loans"."late_fee" IS 'charged once on every installment that cannot be collected on its due date';
+
+COMMENT ON COLUMN "loans"."disbursement_id" IS 'transfer that paid the principal to the borrower';
+
+COMMENT ON COLUMN "loan_installments"."entry_id" IS 'debit of the borrower account that paid the installment';
+
+CREATE INDEX ON "loans" ("account_id");
+
+CREATE INDEX ON "loan_installments" ("due_date") WHERE "status" <> 'paid';
+
+ALTER TABLE "loans" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "loans" ADD FOREIGN KEY ("disbursement_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "loan_installments" ADD FOREIGN KEY ("loan_id") REFERENCES "loans" ("id");
+
+ALTER TABLE "loan_installments" ADD FOREIGN KEY ("entry_id") REFERENCES "entries" ("id");
+
+INSERT INTO "gl_accounts" ("code", "name", "type") VALUES
+  ('1200', 'Loans receivable', 'asset'),
+  ('4200', 'Loan interest income', 'income');
+
+-- principal is lent from house_loans and interest is collected into house_loan_interest
+INSERT INTO "accounts" ("owner_id", "balance", "currency", "account_type") VALUES
+  ('00000000-0000-0000-0000-000000000001', 0, 'USD', 'house_loans'),
+  ('00000000-0000-0000-0000-000000000001', 0, 'EUR', 'house_loans'),
+  ('00000000-0000-0000-0000-000000000001', 0, 'IDR', 'house_loans'),
+  ('00000000-0000-0000-0000-000000000001', 0, 'USD', 'house_loan_interest'),
+  ('00000000-0000-0000-0000-000000000001', 0, 'EUR', 'house_loan_interest'),
+  ('00000000-0000-0000-0000-000000000001', 0, 'IDR', 'house_loan_interest');
+
+INSERT INTO "gl_account_mappings" ("account_type", "gl_account_id")
+SELECT m."account_type", g."id"
+FROM (VALUES
+  ('house_loans', '1200'),
+  ('house_loan_interest', '4200')
+) AS m ("account_type", "code")
+JOIN "gl_accounts" g ON g."code" = m."code";
@@ -0,0 +1,16 @@
+DROP TABLE IF EXISTS "term_deposits";
+
+DROP TABLE IF EXISTS "term_deposit_rates";
+
+DELETE FROM "gl_account_mappings" WHERE "account_type" = 'house_term_deposits';
+
+DELETE FROM "journal_lines" WHERE "account_id" IN (SELECT "id" FROM "accounts" WHERE "account_type" = 'house_term_deposits');
+
+DELETE FROM "transfers" WHERE "from_account_id" IN (SELECT "id" FROM "accounts" WHERE "account_type" = 'house_term_deposits')
+  OR "to_account_id" IN (SELECT "id" FROM "accounts" WHERE "account_type" = 'house_term_deposits');
+
+DELETE FROM "entries" WHERE "account_id" IN (SELECT "id" FROM "accounts" WHERE "account_type" = 'house_term_deposits');
+
+DELETE FROM "accounts" WHERE "account_type" = 'house_term_deposits';
+
+DELETE FROM "gl_accounts" WHERE "code" = '2300';
@@ -0,0 +1,76 @@
+CREATE TABLE "term_deposit_rates" (
+  "currency" varchar NOT NULL,
+  "term_months" integer NOT NULL,
+  "rate_bps" bigint NOT NULL,
+  "day_count" varchar NOT NULL,
+  "penalty_bps" bigint NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("currency", "term_months"),
+  CONSTRAINT "term_deposit_rates_day_count_check" CHECK ("day_count" IN ('ACT/365', 'ACT/360', '30/360'))
+);
+
+CREATE TABLE "term_deposits" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "payout_account_id" bigint NOT NULL,
+  "currency" varchar NOT NULL,
+  "principal" bigint NOT NULL,
+  "rate_bps" bigint NOT NULL,
+  "day_count" varchar NOT NULL,
+  "penalty_bps" bigint NOT NULL,
+  "term_months" integer NOT NULL,
+  "start_date" date NOT NULL,
+  "maturity_date" date NOT NULL,
+  "on_maturity" varchar NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'active',
+  "interest_paid" bigint NOT NULL DEFAULT 0,
+  "renewed_from_id" bigint,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "closed_at" timestamptz,
+  CONSTRAINT "term_deposits_principal_check" CHECK ("principal" > 0),
+  CONSTRAINT "term_deposits_on_maturity_check" CHECK ("on_maturity" IN ('payout', 'rollover')),
+  CONSTRAINT "term_deposits_status_check" CHECK ("status" IN ('active', 'matured', 'rolled_over', 'withdrawn'))
+);
+
+COMMENT ON COLUMN "term_deposit_rates"."rate_bps" IS 'annual rate in basis points';
+
+COMMENT ON COLUMN "term_deposit_rates"."penalty_bps" IS 'taken off the rate when a deposit is withdrawn before maturity';
+
+COMMENT ON COLUMN "term_deposits"."account_id" IS 'current account the deposit was funded from';
+
+COMMENT ON COLUMN "term_deposits"."payout_account_id" IS 'account principal and interest are paid to';
+
+COMMENT ON COLUMN "term_deposits"."renewed_from_id" IS 'deposit that was rolled over into this one';
+
+CREATE INDEX ON "term_deposits" ("account_id");
+
+CREATE INDEX ON "term_deposits" ("maturity_date") WHERE "status" = 'active';
+
+ALTER TABLE "term_deposits" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "term_deposits" ADD FOREIGN KEY ("payout_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "term_deposits" ADD FOREIGN KEY ("renewed_from_id") REFERENCES "term_deposits" ("id");
+
+INSERT INTO "term_deposit_rates" ("currency", "term_months", "rate_bps", "day_count", "penalty_bps") VALUES
+  ('USD', 3, 350, 'ACT/365', 200),
+  ('USD', 6, 400, 'ACT/365', 200),
+  ('USD', 12, 450, 'ACT/365', 250),
+  ('EUR', 3, 250, 'ACT/360', 150),
+  ('EUR', 6, 300, 'ACT/360', 150),
+  ('EUR', 12, 350, 'ACT/360', 200),
+  ('IDR', 3, 500, '30/360', 300),
+  ('IDR', 6, 550, '30/360', 300),
+  ('IDR', 12, 600, '30/360', 350);
+
+INSERT INTO "gl_accounts" ("code", "name", "type") VALUES
+  ('2300', 'Customer term deposits', 'liability');
+
+-- funds locked in term deposits are held in house_term_deposits until they are paid out
+INSERT INTO "accounts" ("owner_id", "balance", "currency", "account_type") VALUES
+  ('00000000-0000-0000-0000-000000000001', 0, 'USD', 'house_term_deposits'),
+  ('00000000-0000-0000-0000-000000000001', 0, 'EUR', 'house_term_deposits'),
+  ('00000000-0000-0000-0000-000000000001', 0, 'IDR', 'house_term_deposits');
+
+INSERT INTO "gl_account_mappings" ("account_type", "gl_account_id")
+SELECT 'house_term_deposits', "id" FROM "gl_accounts" WHERE "code" = '2300';
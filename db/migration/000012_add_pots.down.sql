@@ -0,0 +1,16 @@
+DROP TABLE IF EXISTS "pots";
+
+DELETE FROM "gl_account_mappings" WHERE "account_type" = 'pot';
+
+DELETE FROM "journal_lines" WHERE "account_id" IN (SELECT "id" FROM "accounts" WHERE "account_type" = 'pot');
+
+DELETE FROM "transfers" WHERE "from_account_id" IN (SELECT "id" FROM "accounts" WHERE "account_type" = 'pot')
+  OR "to_account_id" IN (SELECT "id" FROM "accounts" WHERE "account_type" = 'pot');
+
+DELETE FROM "entries" WHERE "account_id" IN (SELECT "id" FROM "accounts" WHERE "account_type" = 'pot');
+
+DELETE FROM "accounts" WHERE "account_type" = 'pot';
+
+DROP INDEX IF EXISTS "owner_id_currency_type_key";
+
+CREATE UNIQUE INDEX "owner_id_currency_type_key" ON "accounts" ("owner_id", "currency", "account_type") WHERE "status" <> 'closed';
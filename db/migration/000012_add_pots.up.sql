@@ -0,0 +1,31 @@
+CREATE TABLE "pots" (
+  "account_id" bigint PRIMARY KEY,
+  "parent_account_id" bigint NOT NULL,
+  "name" varchar NOT NULL,
+  "target_amount" bigint NOT NULL DEFAULT 0,
+  "round_up_to" bigint NOT NULL DEFAULT 0,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "pots_target_amount_check" CHECK ("target_amount" >= 0),
+  CONSTRAINT "pots_round_up_to_check" CHECK ("round_up_to" >= 0),
+  CONSTRAINT "parent_account_id_name_key" UNIQUE ("parent_account_id", "name")
+);
+
+COMMENT ON TABLE "pots" IS 'named sub-accounts of a main account, each backed by an account of type pot';
+
+COMMENT ON COLUMN "pots"."target_amount" IS 'amount the owner is saving up to, 0 when there is none';
+
+COMMENT ON COLUMN "pots"."round_up_to" IS 'outgoing transfers of the parent are rounded up to a multiple of this and the difference moved into the pot, 0 to disable';
+
+CREATE INDEX ON "pots" ("parent_account_id");
+
+ALTER TABLE "pots" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "pots" ADD FOREIGN KEY ("parent_account_id") REFERENCES "accounts" ("id");
+
+-- an owner may have any number of pots in a currency
+DROP INDEX IF EXISTS "owner_id_currency_type_key";
+
+CREATE UNIQUE INDEX "owner_id_currency_type_key" ON "accounts" ("owner_id", "currency", "account_type") WHERE "status" <> 'closed' AND "account_type" <> 'pot';
+
+INSERT INTO "gl_account_mappings" ("account_type", "gl_account_id")
+SELECT 'pot', "id" FROM "gl_accounts" WHERE "code" = '2200';
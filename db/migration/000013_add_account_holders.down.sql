@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "transfer_approvals";
+
+DROP TABLE IF EXISTS "account_holders";
+
+DELETE FROM "gl_account_mappings" WHERE "account_type" = 'business';
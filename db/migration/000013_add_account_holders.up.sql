@@ -0,0 +1,56 @@
+CREATE TABLE "account_holders" (
+  "account_id" bigint NOT NULL,
+  "user_id" uuid NOT NULL,
+  "can_initiate" boolean NOT NULL DEFAULT false,
+  "can_approve" boolean NOT NULL DEFAULT false,
+  "spend_limit" bigint NOT NULL DEFAULT 0,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("account_id", "user_id"),
+  CONSTRAINT "account_holders_spend_limit_check" CHECK ("spend_limit" >= 0)
+);
+
+CREATE TABLE "transfer_approvals" (
+  "id" bigserial PRIMARY KEY,
+  "from_account_id" bigint NOT NULL,
+  "to_account_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "requested_by" uuid NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'pending',
+  "decided_by" uuid,
+  "transfer_id" bigint,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "decided_at" timestamptz,
+  CONSTRAINT "transfer_approvals_amount_check" CHECK ("amount" > 0),
+  CONSTRAINT "transfer_approvals_status_check" CHECK ("status" IN ('pending', 'approved', 'rejected'))
+);
+
+COMMENT ON TABLE "account_holders" IS 'users other than the owner who may use an account, every holder may view it';
+
+COMMENT ON COLUMN "account_holders"."can_initiate" IS 'may move money out of the account';
+
+COMMENT ON COLUMN "account_holders"."can_approve" IS 'may approve transfers other holders made above their spend limit';
+
+COMMENT ON COLUMN "account_holders"."spend_limit" IS 'largest transfer the holder may make without approval, 0 for no limit';
+
+COMMENT ON TABLE "transfer_approvals" IS 'transfers above the spend limit of the holder who made them, waiting for another holder';
+
+CREATE INDEX ON "account_holders" ("user_id");
+
+CREATE INDEX ON "transfer_approvals" ("from_account_id") WHERE "status" = 'pending';
+
+ALTER TABLE "account_holders" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "account_holders" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id");
+
+ALTER TABLE "transfer_approvals" ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "transfer_approvals" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "transfer_approvals" ADD FOREIGN KEY ("requested_by") REFERENCES "users" ("id");
+
+ALTER TABLE "transfer_approvals" ADD FOREIGN KEY ("decided_by") REFERENCES "users" ("id");
+
+ALTER TABLE "transfer_approvals" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+INSERT INTO "gl_account_mappings" ("account_type", "gl_account_id")
+SELECT 'business', "id" FROM "gl_accounts" WHERE "code" = '2100';
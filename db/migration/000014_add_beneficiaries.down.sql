@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "beneficiaries";
+
+ALTER TABLE IF EXISTS "transfer_limits" DROP COLUMN IF EXISTS "new_payee_max_per_transfer";
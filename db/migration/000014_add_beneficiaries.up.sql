@@ -0,0 +1,23 @@
+CREATE TABLE "beneficiaries" (
+  "id" bigserial PRIMARY KEY,
+  "owner_id" uuid NOT NULL,
+  "nickname" varchar NOT NULL,
+  "account_id" bigint NOT NULL,
+  "currency" varchar NOT NULL,
+  "cooling_off_until" timestamptz NOT NULL DEFAULT (now()),
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "owner_id_nickname_key" UNIQUE ("owner_id", "nickname"),
+  CONSTRAINT "owner_id_account_id_key" UNIQUE ("owner_id", "account_id")
+);
+
+COMMENT ON TABLE "beneficiaries" IS 'accounts a user has saved to pay to';
+
+COMMENT ON COLUMN "beneficiaries"."cooling_off_until" IS 'until then payments to the beneficiary are capped by the new payee limit';
+
+ALTER TABLE "beneficiaries" ADD FOREIGN KEY ("owner_id") REFERENCES "users" ("id");
+
+ALTER TABLE "beneficiaries" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "transfer_limits" ADD COLUMN "new_payee_max_per_transfer" bigint NOT NULL DEFAULT 0;
+
+UPDATE "transfer_limits" SET "new_payee_max_per_transfer" = "max_per_transfer" / 10;
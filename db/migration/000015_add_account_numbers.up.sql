@@ -0,0 +1,32 @@
+-- account numbers are IBAN-style: country code ID, two check digits, bank
+-- code SMPL and ten random digits, e.g. ID92SMPL0123456789. Keep in sync
+-- with util.NewAccountNumber.
+CREATE FUNCTION "generate_account_number"() RETURNS varchar AS $$
+DECLARE
+  account varchar;
+  check_digits varchar;
+  candidate varchar;
+BEGIN
+  LOOP
+    account := lpad(floor(random() * 10000000000)::bigint::text, 10, '0');
+    -- SMPL, the account, ID and 00 with letters replaced by 10 to 35
+    check_digits := lpad((98 - ('28222521' || account || '181300')::numeric % 97)::text, 2, '0');
+    candidate := 'ID' || check_digits || 'SMPL' || account;
+    IF NOT EXISTS (SELECT 1 FROM "accounts" WHERE "number" = candidate) THEN
+      RETURN candidate;
+    END IF;
+  END LOOP;
+END;
+$$ LANGUAGE plpgsql VOLATILE;
+
+ALTER TABLE "accounts" ADD COLUMN "number" varchar;
+
+UPDATE "accounts" SET "number" = generate_account_number();
+
+ALTER TABLE "accounts" ALTER COLUMN "number" SET NOT NULL;
+
+ALTER TABLE "accounts" ALTER COLUMN "number" SET DEFAULT generate_account_number();
+
+ALTER TABLE "accounts" ADD CONSTRAINT "accounts_number_key" UNIQUE ("number");
+
+COMMENT ON COLUMN "accounts"."number" IS 'external account number with mod-97 check digits, shown to and accepted from users instead of the id';
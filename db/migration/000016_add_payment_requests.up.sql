@@ -0,0 +1,35 @@
+CREATE TABLE "payment_requests" (
+  "id" bigserial PRIMARY KEY,
+  "requester_id" uuid NOT NULL,
+  "payer_id" uuid NOT NULL,
+  "to_account_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "currency" varchar NOT NULL,
+  "memo" varchar NOT NULL DEFAULT '',
+  "status" varchar NOT NULL DEFAULT 'pending',
+  "transfer_id" bigint,
+  "expires_at" timestamptz NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "decided_at" timestamptz,
+  CONSTRAINT "payment_requests_amount_check" CHECK ("amount" > 0),
+  CONSTRAINT "payment_requests_status_check" CHECK ("status" IN ('pending', 'accepted', 'declined')),
+  CONSTRAINT "payment_requests_payer_check" CHECK ("payer_id" <> "requester_id")
+);
+
+COMMENT ON TABLE "payment_requests" IS 'money a user asked another user to pay into one of their accounts';
+
+COMMENT ON COLUMN "payment_requests"."transfer_id" IS 'the transfer that paid the request once it is accepted';
+
+COMMENT ON COLUMN "payment_requests"."expires_at" IS 'pending requests can no longer be accepted after this';
+
+ALTER TABLE "payment_requests" ADD FOREIGN KEY ("requester_id") REFERENCES "users" ("id");
+
+ALTER TABLE "payment_requests" ADD FOREIGN KEY ("payer_id") REFERENCES "users" ("id");
+
+ALTER TABLE "payment_requests" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "payment_requests" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+CREATE INDEX ON "payment_requests" ("requester_id");
+
+CREATE INDEX ON "payment_requests" ("payer_id");
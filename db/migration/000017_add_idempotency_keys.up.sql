@@ -0,0 +1,18 @@
+CREATE TABLE "idempotency_keys" (
+  "user_id" uuid NOT NULL,
+  "key" varchar NOT NULL,
+  "request_hash" varchar NOT NULL,
+  "status_code" integer NOT NULL DEFAULT 0,
+  "content_type" varchar NOT NULL DEFAULT '',
+  "response_body" bytea NOT NULL DEFAULT '',
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("user_id", "key")
+);
+
+COMMENT ON TABLE "idempotency_keys" IS 'the responses to requests sent with an Idempotency-Key header, replayed when the request is sent again';
+
+COMMENT ON COLUMN "idempotency_keys"."request_hash" IS 'of the method, path and body, a key may not be reused for another request';
+
+COMMENT ON COLUMN "idempotency_keys"."status_code" IS '0 while the first request is still being handled';
+
+ALTER TABLE "idempotency_keys" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id");
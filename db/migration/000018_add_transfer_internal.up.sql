@@ -0,0 +1,3 @@
+ALTER TABLE "transfers" ADD COLUMN "internal" boolean NOT NULL DEFAULT false;
+
+COMMENT ON COLUMN "transfers"."internal" IS 'made by the bank itself, e.g. pot moves, round-ups, sweeps and loan disbursements, and not counted towards transfer limits';
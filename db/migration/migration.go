@@ -0,0 +1,155 @@
+// Package migration embeds the schema migrations so the binary can apply
+// them without the files or the migrate tool at hand.
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+
+	sqlitemigration "github.com/flukis/simplebank/db/sqlite/migration"
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/database/sqlite3"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+)
+
+//go:embed *.sql
+var files embed.FS
+
+// lockID keys the advisory lock held while migrating, so replicas starting
+// together apply each migration once and the others wait for it
+const lockID int64 = 7_340_116_152
+
+// Migrator applies the embedded migrations to a Postgres database, or
+// those of the SQLite store to a SQLite one
+type Migrator struct {
+	// nil on SQLite, which needs no advisory lock
+	conn *sql.Conn
+	m    *migrate.Migrate
+}
+
+// New returns a Migrator on a connection of db. Close it to give the
+// connection back.
+func New(ctx context.Context, db *sql.DB) (*Migrator, error) {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	driver, err := postgres.WithConnection(ctx, conn, &postgres.Config{})
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("cannot open migration table: %w", err)
+	}
+
+	source, err := iofs.New(files, ".")
+	if err != nil {
+		driver.Close()
+		return nil, err
+	}
+
+	m, err := migrate.NewWithInstance("iofs", source, "postgres", driver)
+	if err != nil {
+		source.Close()
+		driver.Close()
+		return nil, err
+	}
+	return &Migrator{conn: conn, m: m}, nil
+}
+
+// NewSQLite returns a Migrator on a database of db.OpenSQLite. Closing it
+// closes db. SQLite lets one writer in at a time, so there is no lock to
+// take.
+func NewSQLite(db *sql.DB) (*Migrator, error) {
+	driver, err := sqlite3.WithInstance(db, &sqlite3.Config{})
+	if err != nil {
+		return nil, fmt.Errorf("cannot open migration table: %w", err)
+	}
+
+	source, err := iofs.New(sqlitemigration.Files, ".")
+	if err != nil {
+		driver.Close()
+		return nil, err
+	}
+
+	m, err := migrate.NewWithInstance("iofs", source, "sqlite3", driver)
+	if err != nil {
+		source.Close()
+		driver.Close()
+		return nil, err
+	}
+	return &Migrator{m: m}, nil
+}
+
+// Up applies the next n migrations, or all of them when n is 0
+func (m *Migrator) Up(ctx context.Context, n int) error {
+	return m.locked(ctx, func() error {
+		if n == 0 {
+			return m.m.Up()
+		}
+		return m.m.Steps(n)
+	})
+}
+
+// Down rolls back the last n migrations, or all of them when n is 0
+func (m *Migrator) Down(ctx context.Context, n int) error {
+	return m.locked(ctx, func() error {
+		if n == 0 {
+			return m.m.Down()
+		}
+		return m.m.Steps(-n)
+	})
+}
+
+// Force records version as applied and clean without running anything, to
+// recover from a migration that failed halfway and left the database dirty
+func (m *Migrator) Force(ctx context.Context, version int) error {
+	return m.locked(ctx, func() error {
+		return m.m.Force(version)
+	})
+}
+
+// Version returns the last migration applied, 0 when there is none, and
+// whether it failed halfway
+func (m *Migrator) Version() (version uint, dirty bool, err error) {
+	version, dirty, err = m.m.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		return 0, false, nil
+	}
+	return version, dirty, err
+}
+
+// Close gives the connection back to its pool, or closes the SQLite
+// database
+func (m *Migrator) Close() error {
+	sourceErr, dbErr := m.m.Close()
+	if sourceErr != nil {
+		return sourceErr
+	}
+	return dbErr
+}
+
+// locked runs fn under the advisory lock, waiting for the replica holding
+// it. Having nothing to apply is not an error.
+func (m *Migrator) locked(ctx context.Context, fn func() error) error {
+	if m.conn == nil {
+		return ignoreNoChange(fn())
+	}
+
+	if _, err := m.conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", lockID); err != nil {
+		return fmt.Errorf("cannot take migration lock: %w", err)
+	}
+	defer m.conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", lockID)
+
+	return ignoreNoChange(fn())
+}
+
+func ignoreNoChange(err error) error {
+	if errors.Is(err, migrate.ErrNoChange) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,45 @@
+package migration
+
+import (
+	"errors"
+	"io/fs"
+	"testing"
+
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmbeddedMigrations(t *testing.T) {
+	source, err := iofs.New(files, ".")
+	require.NoError(t, err)
+	defer source.Close()
+
+	version, err := source.First()
+	require.NoError(t, err)
+	require.EqualValues(t, 1, version)
+
+	count := 0
+	for {
+		count++
+
+		// every migration can be rolled back
+		up, _, err := source.ReadUp(version)
+		require.NoError(t, err)
+		up.Close()
+		down, _, err := source.ReadDown(version)
+		require.NoError(t, err, "version %d has no down migration", version)
+		down.Close()
+
+		next, err := source.Next(version)
+		if errors.Is(err, fs.ErrNotExist) {
+			break
+		}
+		require.NoError(t, err)
+		require.Equal(t, version+1, next)
+		version = next
+	}
+
+	names, err := fs.Glob(files, "*.up.sql")
+	require.NoError(t, err)
+	require.Len(t, names, count)
+}
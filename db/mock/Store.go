@@ -4,6 +4,7 @@ package mocks
 
 import (
 	context "context"
+	time "time"
 
 	db "github.com/flukis/simplebank/db/sqlc"
 	mock "github.com/stretchr/testify/mock"
@@ -16,22 +17,22 @@ type Store struct {
 	mock.Mock
 }
 
-// AddBalanceAccount provides a mock function with given fields: ctx, arg
-func (_m *Store) AddBalanceAccount(ctx context.Context, arg db.AddBalanceAccountParams) (db.Account, error) {
+// AcceptPaymentRequestTx provides a mock function with given fields: ctx, arg
+func (_m *Store) AcceptPaymentRequestTx(ctx context.Context, arg db.AcceptPaymentRequestTxParams) (db.AcceptPaymentRequestTxResult, error) {
 	ret := _m.Called(ctx, arg)
 
-	var r0 db.Account
+	var r0 db.AcceptPaymentRequestTxResult
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.AddBalanceAccountParams) (db.Account, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.AcceptPaymentRequestTxParams) (db.AcceptPaymentRequestTxResult, error)); ok {
 		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, db.AddBalanceAccountParams) db.Account); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.AcceptPaymentRequestTxParams) db.AcceptPaymentRequestTxResult); ok {
 		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(db.Account)
+		r0 = ret.Get(0).(db.AcceptPaymentRequestTxResult)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, db.AddBalanceAccountParams) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, db.AcceptPaymentRequestTxParams) error); ok {
 		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
@@ -40,22 +41,48 @@ func (_m *Store) AddBalanceAccount(ctx context.Context, arg db.AddBalanceAccount
 	return r0, r1
 }
 
-// CreateAccount provides a mock function with given fields: ctx, arg
-func (_m *Store) CreateAccount(ctx context.Context, arg db.CreateAccountParams) (db.Account, error) {
+// AccrueInterest provides a mock function with given fields: ctx, date
+func (_m *Store) AccrueInterest(ctx context.Context, date time.Time) ([]db.InterestAccrual, error) {
+	ret := _m.Called(ctx, date)
+
+	var r0 []db.InterestAccrual
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]db.InterestAccrual, error)); ok {
+		return rf(ctx, date)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []db.InterestAccrual); ok {
+		r0 = rf(ctx, date)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.InterestAccrual)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, date)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AddBalanceAccount provides a mock function with given fields: ctx, arg
+func (_m *Store) AddBalanceAccount(ctx context.Context, arg db.AddBalanceAccountParams) (db.Account, error) {
 	ret := _m.Called(ctx, arg)
 
 	var r0 db.Account
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.CreateAccountParams) (db.Account, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.AddBalanceAccountParams) (db.Account, error)); ok {
 		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, db.CreateAccountParams) db.Account); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.AddBalanceAccountParams) db.Account); ok {
 		r0 = rf(ctx, arg)
 	} else {
 		r0 = ret.Get(0).(db.Account)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, db.CreateAccountParams) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, db.AddBalanceAccountParams) error); ok {
 		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
@@ -64,22 +91,22 @@ func (_m *Store) CreateAccount(ctx context.Context, arg db.CreateAccountParams)
 	return r0, r1
 }
 
-// CreateEntry provides a mock function with given fields: ctx, arg
-func (_m *Store) CreateEntry(ctx context.Context, arg db.CreateEntryParams) (db.Entry, error) {
+// AddTellerTillCash provides a mock function with given fields: ctx, arg
+func (_m *Store) AddTellerTillCash(ctx context.Context, arg db.AddTellerTillCashParams) (db.TellerTill, error) {
 	ret := _m.Called(ctx, arg)
 
-	var r0 db.Entry
+	var r0 db.TellerTill
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.CreateEntryParams) (db.Entry, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.AddTellerTillCashParams) (db.TellerTill, error)); ok {
 		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, db.CreateEntryParams) db.Entry); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.AddTellerTillCashParams) db.TellerTill); ok {
 		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(db.Entry)
+		r0 = ret.Get(0).(db.TellerTill)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, db.CreateEntryParams) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, db.AddTellerTillCashParams) error); ok {
 		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
@@ -88,22 +115,22 @@ func (_m *Store) CreateEntry(ctx context.Context, arg db.CreateEntryParams) (db.
 	return r0, r1
 }
 
-// CreateTransfer provides a mock function with given fields: ctx, arg
-func (_m *Store) CreateTransfer(ctx context.Context, arg db.CreateTransferParams) (db.Transfer, error) {
+// ApproveTransferTx provides a mock function with given fields: ctx, arg
+func (_m *Store) ApproveTransferTx(ctx context.Context, arg db.DecideTransferApprovalTxParams) (db.ApproveTransferTxResult, error) {
 	ret := _m.Called(ctx, arg)
 
-	var r0 db.Transfer
+	var r0 db.ApproveTransferTxResult
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.CreateTransferParams) (db.Transfer, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.DecideTransferApprovalTxParams) (db.ApproveTransferTxResult, error)); ok {
 		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, db.CreateTransferParams) db.Transfer); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.DecideTransferApprovalTxParams) db.ApproveTransferTxResult); ok {
 		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(db.Transfer)
+		r0 = ret.Get(0).(db.ApproveTransferTxResult)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, db.CreateTransferParams) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, db.DecideTransferApprovalTxParams) error); ok {
 		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
@@ -112,23 +139,25 @@ func (_m *Store) CreateTransfer(ctx context.Context, arg db.CreateTransferParams
 	return r0, r1
 }
 
-// CreateUser provides a mock function with given fields: ctx, arg
-func (_m *Store) CreateUser(ctx context.Context, arg db.CreateUserParams) (db.User, error) {
-	ret := _m.Called(ctx, arg)
+// CapitalizeInterest provides a mock function with given fields: ctx, periodEnd
+func (_m *Store) CapitalizeInterest(ctx context.Context, periodEnd time.Time) ([]db.InterestCapitalization, error) {
+	ret := _m.Called(ctx, periodEnd)
 
-	var r0 db.User
+	var r0 []db.InterestCapitalization
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.CreateUserParams) (db.User, error)); ok {
-		return rf(ctx, arg)
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]db.InterestCapitalization, error)); ok {
+		return rf(ctx, periodEnd)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, db.CreateUserParams) db.User); ok {
-		r0 = rf(ctx, arg)
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []db.InterestCapitalization); ok {
+		r0 = rf(ctx, periodEnd)
 	} else {
-		r0 = ret.Get(0).(db.User)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.InterestCapitalization)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, db.CreateUserParams) error); ok {
-		r1 = rf(ctx, arg)
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, periodEnd)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -136,38 +165,46 @@ func (_m *Store) CreateUser(ctx context.Context, arg db.CreateUserParams) (db.Us
 	return r0, r1
 }
 
-// DeleteAccount provides a mock function with given fields: ctx, id
-func (_m *Store) DeleteAccount(ctx context.Context, id int64) error {
+// CloseAccount provides a mock function with given fields: ctx, id
+func (_m *Store) CloseAccount(ctx context.Context, id int64) (db.Account, error) {
 	ret := _m.Called(ctx, id)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+	var r0 db.Account
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.Account, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.Account); ok {
 		r0 = rf(ctx, id)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(db.Account)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// FetchAccounts provides a mock function with given fields: ctx, arg
-func (_m *Store) FetchAccounts(ctx context.Context, arg db.FetchAccountsParams) ([]db.Account, error) {
+// CloseAccountTx provides a mock function with given fields: ctx, arg
+func (_m *Store) CloseAccountTx(ctx context.Context, arg db.CloseAccountTxParams) (db.CloseAccountTxResult, error) {
 	ret := _m.Called(ctx, arg)
 
-	var r0 []db.Account
+	var r0 db.CloseAccountTxResult
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.FetchAccountsParams) ([]db.Account, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.CloseAccountTxParams) (db.CloseAccountTxResult, error)); ok {
 		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, db.FetchAccountsParams) []db.Account); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.CloseAccountTxParams) db.CloseAccountTxResult); ok {
 		r0 = rf(ctx, arg)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Account)
-		}
+		r0 = ret.Get(0).(db.CloseAccountTxResult)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, db.FetchAccountsParams) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, db.CloseAccountTxParams) error); ok {
 		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
@@ -176,24 +213,22 @@ func (_m *Store) FetchAccounts(ctx context.Context, arg db.FetchAccountsParams)
 	return r0, r1
 }
 
-// FetchEntries provides a mock function with given fields: ctx, arg
-func (_m *Store) FetchEntries(ctx context.Context, arg db.FetchEntriesParams) ([]db.Entry, error) {
+// CloseTellerTill provides a mock function with given fields: ctx, arg
+func (_m *Store) CloseTellerTill(ctx context.Context, arg db.CloseTellerTillParams) (db.TellerTill, error) {
 	ret := _m.Called(ctx, arg)
 
-	var r0 []db.Entry
+	var r0 db.TellerTill
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.FetchEntriesParams) ([]db.Entry, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.CloseTellerTillParams) (db.TellerTill, error)); ok {
 		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, db.FetchEntriesParams) []db.Entry); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.CloseTellerTillParams) db.TellerTill); ok {
 		r0 = rf(ctx, arg)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Entry)
-		}
+		r0 = ret.Get(0).(db.TellerTill)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, db.FetchEntriesParams) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, db.CloseTellerTillParams) error); ok {
 		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
@@ -202,24 +237,46 @@ func (_m *Store) FetchEntries(ctx context.Context, arg db.FetchEntriesParams) ([
 	return r0, r1
 }
 
-// FetchTransfer provides a mock function with given fields: ctx, arg
-func (_m *Store) FetchTransfer(ctx context.Context, arg db.FetchTransferParams) ([]db.Transfer, error) {
+// CloseTellerTillTx provides a mock function with given fields: ctx, id, countedCash
+func (_m *Store) CloseTellerTillTx(ctx context.Context, id int64, countedCash int64) (db.TellerTill, error) {
+	ret := _m.Called(ctx, id, countedCash)
+
+	var r0 db.TellerTill
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) (db.TellerTill, error)); ok {
+		return rf(ctx, id, countedCash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) db.TellerTill); ok {
+		r0 = rf(ctx, id, countedCash)
+	} else {
+		r0 = ret.Get(0).(db.TellerTill)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, id, countedCash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CloseTermDeposit provides a mock function with given fields: ctx, arg
+func (_m *Store) CloseTermDeposit(ctx context.Context, arg db.CloseTermDepositParams) (db.TermDeposit, error) {
 	ret := _m.Called(ctx, arg)
 
-	var r0 []db.Transfer
+	var r0 db.TermDeposit
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.FetchTransferParams) ([]db.Transfer, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.CloseTermDepositParams) (db.TermDeposit, error)); ok {
 		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, db.FetchTransferParams) []db.Transfer); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.CloseTermDepositParams) db.TermDeposit); ok {
 		r0 = rf(ctx, arg)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Transfer)
-		}
+		r0 = ret.Get(0).(db.TermDeposit)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, db.FetchTransferParams) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, db.CloseTermDepositParams) error); ok {
 		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
@@ -228,23 +285,23 @@ func (_m *Store) FetchTransfer(ctx context.Context, arg db.FetchTransferParams)
 	return r0, r1
 }
 
-// GetAccount provides a mock function with given fields: ctx, id
-func (_m *Store) GetAccount(ctx context.Context, id int64) (db.Account, error) {
-	ret := _m.Called(ctx, id)
+// CollectLoanInstallments provides a mock function with given fields: ctx, date
+func (_m *Store) CollectLoanInstallments(ctx context.Context, date time.Time) (db.LoanCollectionResult, error) {
+	ret := _m.Called(ctx, date)
 
-	var r0 db.Account
+	var r0 db.LoanCollectionResult
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.Account, error)); ok {
-		return rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (db.LoanCollectionResult, error)); ok {
+		return rf(ctx, date)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int64) db.Account); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) db.LoanCollectionResult); ok {
+		r0 = rf(ctx, date)
 	} else {
-		r0 = ret.Get(0).(db.Account)
+		r0 = ret.Get(0).(db.LoanCollectionResult)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
-		r1 = rf(ctx, id)
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, date)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -252,23 +309,23 @@ func (_m *Store) GetAccount(ctx context.Context, id int64) (db.Account, error) {
 	return r0, r1
 }
 
-// GetAccountForUpdate provides a mock function with given fields: ctx, id
-func (_m *Store) GetAccountForUpdate(ctx context.Context, id int64) (db.Account, error) {
-	ret := _m.Called(ctx, id)
+// CountOpenPots provides a mock function with given fields: ctx, parentAccountID
+func (_m *Store) CountOpenPots(ctx context.Context, parentAccountID int64) (int64, error) {
+	ret := _m.Called(ctx, parentAccountID)
 
-	var r0 db.Account
+	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.Account, error)); ok {
-		return rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (int64, error)); ok {
+		return rf(ctx, parentAccountID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int64) db.Account); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, int64) int64); ok {
+		r0 = rf(ctx, parentAccountID)
 	} else {
-		r0 = ret.Get(0).(db.Account)
+		r0 = ret.Get(0).(int64)
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
-		r1 = rf(ctx, id)
+		r1 = rf(ctx, parentAccountID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -276,23 +333,23 @@ func (_m *Store) GetAccountForUpdate(ctx context.Context, id int64) (db.Account,
 	return r0, r1
 }
 
-// GetEntry provides a mock function with given fields: ctx, id
-func (_m *Store) GetEntry(ctx context.Context, id int64) (db.Entry, error) {
-	ret := _m.Called(ctx, id)
+// CountUnpaidLoanInstallments provides a mock function with given fields: ctx, loanID
+func (_m *Store) CountUnpaidLoanInstallments(ctx context.Context, loanID int64) (int64, error) {
+	ret := _m.Called(ctx, loanID)
 
-	var r0 db.Entry
+	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.Entry, error)); ok {
-		return rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (int64, error)); ok {
+		return rf(ctx, loanID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int64) db.Entry); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, int64) int64); ok {
+		r0 = rf(ctx, loanID)
 	} else {
-		r0 = ret.Get(0).(db.Entry)
+		r0 = ret.Get(0).(int64)
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
-		r1 = rf(ctx, id)
+		r1 = rf(ctx, loanID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -300,23 +357,23 @@ func (_m *Store) GetEntry(ctx context.Context, id int64) (db.Entry, error) {
 	return r0, r1
 }
 
-// GetTransfer provides a mock function with given fields: ctx, id
-func (_m *Store) GetTransfer(ctx context.Context, id int64) (db.Transfer, error) {
-	ret := _m.Called(ctx, id)
+// CreateAccount provides a mock function with given fields: ctx, arg
+func (_m *Store) CreateAccount(ctx context.Context, arg db.CreateAccountParams) (db.Account, error) {
+	ret := _m.Called(ctx, arg)
 
-	var r0 db.Transfer
+	var r0 db.Account
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.Transfer, error)); ok {
-		return rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateAccountParams) (db.Account, error)); ok {
+		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int64) db.Transfer); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateAccountParams) db.Account); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(db.Transfer)
+		r0 = ret.Get(0).(db.Account)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
-		r1 = rf(ctx, id)
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateAccountParams) error); ok {
+		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -324,23 +381,23 @@ func (_m *Store) GetTransfer(ctx context.Context, id int64) (db.Transfer, error)
 	return r0, r1
 }
 
-// GetUser provides a mock function with given fields: ctx, id
-func (_m *Store) GetUser(ctx context.Context, id uuid.UUID) (db.User, error) {
-	ret := _m.Called(ctx, id)
+// CreateAccountHolder provides a mock function with given fields: ctx, arg
+func (_m *Store) CreateAccountHolder(ctx context.Context, arg db.CreateAccountHolderParams) (db.AccountHolder, error) {
+	ret := _m.Called(ctx, arg)
 
-	var r0 db.User
+	var r0 db.AccountHolder
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (db.User, error)); ok {
-		return rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateAccountHolderParams) (db.AccountHolder, error)); ok {
+		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) db.User); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateAccountHolderParams) db.AccountHolder); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(db.User)
+		r0 = ret.Get(0).(db.AccountHolder)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
-		r1 = rf(ctx, id)
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateAccountHolderParams) error); ok {
+		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -348,23 +405,23 @@ func (_m *Store) GetUser(ctx context.Context, id uuid.UUID) (db.User, error) {
 	return r0, r1
 }
 
-// GetUserByEmail provides a mock function with given fields: ctx, email
-func (_m *Store) GetUserByEmail(ctx context.Context, email string) (db.User, error) {
-	ret := _m.Called(ctx, email)
+// CreateBeneficiary provides a mock function with given fields: ctx, arg
+func (_m *Store) CreateBeneficiary(ctx context.Context, arg db.CreateBeneficiaryParams) (db.Beneficiary, error) {
+	ret := _m.Called(ctx, arg)
 
-	var r0 db.User
+	var r0 db.Beneficiary
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (db.User, error)); ok {
-		return rf(ctx, email)
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateBeneficiaryParams) (db.Beneficiary, error)); ok {
+		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) db.User); ok {
-		r0 = rf(ctx, email)
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateBeneficiaryParams) db.Beneficiary); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(db.User)
+		r0 = ret.Get(0).(db.Beneficiary)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, email)
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateBeneficiaryParams) error); ok {
+		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -372,23 +429,23 @@ func (_m *Store) GetUserByEmail(ctx context.Context, email string) (db.User, err
 	return r0, r1
 }
 
-// GetUserByUsername provides a mock function with given fields: ctx, username
-func (_m *Store) GetUserByUsername(ctx context.Context, username string) (db.User, error) {
-	ret := _m.Called(ctx, username)
+// CreateCashTransaction provides a mock function with given fields: ctx, arg
+func (_m *Store) CreateCashTransaction(ctx context.Context, arg db.CreateCashTransactionParams) (db.CashTransaction, error) {
+	ret := _m.Called(ctx, arg)
 
-	var r0 db.User
+	var r0 db.CashTransaction
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (db.User, error)); ok {
-		return rf(ctx, username)
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateCashTransactionParams) (db.CashTransaction, error)); ok {
+		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) db.User); ok {
-		r0 = rf(ctx, username)
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateCashTransactionParams) db.CashTransaction); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(db.User)
+		r0 = ret.Get(0).(db.CashTransaction)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, username)
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateCashTransactionParams) error); ok {
+		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -396,22 +453,22 @@ func (_m *Store) GetUserByUsername(ctx context.Context, username string) (db.Use
 	return r0, r1
 }
 
-// TransferTx provides a mock function with given fields: ctx, arg
-func (_m *Store) TransferTx(ctx context.Context, arg db.TransferTxParams) (db.TransferTxResult, error) {
+// CreateEntry provides a mock function with given fields: ctx, arg
+func (_m *Store) CreateEntry(ctx context.Context, arg db.CreateEntryParams) (db.Entry, error) {
 	ret := _m.Called(ctx, arg)
 
-	var r0 db.TransferTxResult
+	var r0 db.Entry
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.TransferTxParams) (db.TransferTxResult, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateEntryParams) (db.Entry, error)); ok {
 		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, db.TransferTxParams) db.TransferTxResult); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateEntryParams) db.Entry); ok {
 		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(db.TransferTxResult)
+		r0 = ret.Get(0).(db.Entry)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, db.TransferTxParams) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateEntryParams) error); ok {
 		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
@@ -420,22 +477,46 @@ func (_m *Store) TransferTx(ctx context.Context, arg db.TransferTxParams) (db.Tr
 	return r0, r1
 }
 
-// UpdateBalanceAccount provides a mock function with given fields: ctx, arg
-func (_m *Store) UpdateBalanceAccount(ctx context.Context, arg db.UpdateBalanceAccountParams) (db.Account, error) {
+// CreateFeeSchedule provides a mock function with given fields: ctx, arg
+func (_m *Store) CreateFeeSchedule(ctx context.Context, arg db.CreateFeeScheduleParams) (db.FeeSchedule, error) {
 	ret := _m.Called(ctx, arg)
 
-	var r0 db.Account
+	var r0 db.FeeSchedule
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateBalanceAccountParams) (db.Account, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateFeeScheduleParams) (db.FeeSchedule, error)); ok {
 		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateBalanceAccountParams) db.Account); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateFeeScheduleParams) db.FeeSchedule); ok {
 		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(db.Account)
+		r0 = ret.Get(0).(db.FeeSchedule)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, db.UpdateBalanceAccountParams) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateFeeScheduleParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateFeeScheduleTier provides a mock function with given fields: ctx, arg
+func (_m *Store) CreateFeeScheduleTier(ctx context.Context, arg db.CreateFeeScheduleTierParams) (db.FeeScheduleTier, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 db.FeeScheduleTier
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateFeeScheduleTierParams) (db.FeeScheduleTier, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateFeeScheduleTierParams) db.FeeScheduleTier); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.FeeScheduleTier)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateFeeScheduleTierParams) error); ok {
 		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
@@ -444,6 +525,2594 @@ func (_m *Store) UpdateBalanceAccount(ctx context.Context, arg db.UpdateBalanceA
 	return r0, r1
 }
 
+// CreateGLAccount provides a mock function with given fields: ctx, arg
+func (_m *Store) CreateGLAccount(ctx context.Context, arg db.CreateGLAccountParams) (db.GlAccount, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 db.GlAccount
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateGLAccountParams) (db.GlAccount, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateGLAccountParams) db.GlAccount); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.GlAccount)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateGLAccountParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateIdempotencyKey provides a mock function with given fields: ctx, arg
+func (_m *Store) CreateIdempotencyKey(ctx context.Context, arg db.CreateIdempotencyKeyParams) (db.IdempotencyKey, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 db.IdempotencyKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateIdempotencyKeyParams) (db.IdempotencyKey, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateIdempotencyKeyParams) db.IdempotencyKey); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.IdempotencyKey)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateIdempotencyKeyParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateInterestAccrual provides a mock function with given fields: ctx, arg
+func (_m *Store) CreateInterestAccrual(ctx context.Context, arg db.CreateInterestAccrualParams) (db.InterestAccrual, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 db.InterestAccrual
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateInterestAccrualParams) (db.InterestAccrual, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateInterestAccrualParams) db.InterestAccrual); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.InterestAccrual)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateInterestAccrualParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateInterestCapitalization provides a mock function with given fields: ctx, arg
+func (_m *Store) CreateInterestCapitalization(ctx context.Context, arg db.CreateInterestCapitalizationParams) (db.InterestCapitalization, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 db.InterestCapitalization
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateInterestCapitalizationParams) (db.InterestCapitalization, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateInterestCapitalizationParams) db.InterestCapitalization); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.InterestCapitalization)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateInterestCapitalizationParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateInterestRate provides a mock function with given fields: ctx, arg
+func (_m *Store) CreateInterestRate(ctx context.Context, arg db.CreateInterestRateParams) (db.InterestRate, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 db.InterestRate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateInterestRateParams) (db.InterestRate, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateInterestRateParams) db.InterestRate); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.InterestRate)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateInterestRateParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateJournalEntry provides a mock function with given fields: ctx, reference
+func (_m *Store) CreateJournalEntry(ctx context.Context, reference string) (db.JournalEntry, error) {
+	ret := _m.Called(ctx, reference)
+
+	var r0 db.JournalEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (db.JournalEntry, error)); ok {
+		return rf(ctx, reference)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) db.JournalEntry); ok {
+		r0 = rf(ctx, reference)
+	} else {
+		r0 = ret.Get(0).(db.JournalEntry)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, reference)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateJournalLine provides a mock function with given fields: ctx, arg
+func (_m *Store) CreateJournalLine(ctx context.Context, arg db.CreateJournalLineParams) (db.JournalLine, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 db.JournalLine
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateJournalLineParams) (db.JournalLine, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateJournalLineParams) db.JournalLine); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.JournalLine)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateJournalLineParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateLoan provides a mock function with given fields: ctx, arg
+func (_m *Store) CreateLoan(ctx context.Context, arg db.CreateLoanParams) (db.Loan, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 db.Loan
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateLoanParams) (db.Loan, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateLoanParams) db.Loan); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.Loan)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateLoanParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateLoanInstallment provides a mock function with given fields: ctx, arg
+func (_m *Store) CreateLoanInstallment(ctx context.Context, arg db.CreateLoanInstallmentParams) (db.LoanInstallment, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 db.LoanInstallment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateLoanInstallmentParams) (db.LoanInstallment, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateLoanInstallmentParams) db.LoanInstallment); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.LoanInstallment)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateLoanInstallmentParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateLoanTx provides a mock function with given fields: ctx, arg
+func (_m *Store) CreateLoanTx(ctx context.Context, arg db.CreateLoanTxParams) (db.CreateLoanTxResult, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 db.CreateLoanTxResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateLoanTxParams) (db.CreateLoanTxResult, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateLoanTxParams) db.CreateLoanTxResult); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.CreateLoanTxResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateLoanTxParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreatePaymentRequest provides a mock function with given fields: ctx, arg
+func (_m *Store) CreatePaymentRequest(ctx context.Context, arg db.CreatePaymentRequestParams) (db.PaymentRequest, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 db.PaymentRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreatePaymentRequestParams) (db.PaymentRequest, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreatePaymentRequestParams) db.PaymentRequest); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.PaymentRequest)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreatePaymentRequestParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreatePaymentRequestsTx provides a mock function with given fields: ctx, args
+func (_m *Store) CreatePaymentRequestsTx(ctx context.Context, args []db.CreatePaymentRequestParams) ([]db.PaymentRequest, error) {
+	ret := _m.Called(ctx, args)
+
+	var r0 []db.PaymentRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []db.CreatePaymentRequestParams) ([]db.PaymentRequest, error)); ok {
+		return rf(ctx, args)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []db.CreatePaymentRequestParams) []db.PaymentRequest); ok {
+		r0 = rf(ctx, args)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.PaymentRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []db.CreatePaymentRequestParams) error); ok {
+		r1 = rf(ctx, args)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreatePot provides a mock function with given fields: ctx, arg
+func (_m *Store) CreatePot(ctx context.Context, arg db.CreatePotParams) (db.Pot, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 db.Pot
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreatePotParams) (db.Pot, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreatePotParams) db.Pot); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.Pot)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreatePotParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreatePotTx provides a mock function with given fields: ctx, arg
+func (_m *Store) CreatePotTx(ctx context.Context, arg db.CreatePotTxParams) (db.CreatePotTxResult, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 db.CreatePotTxResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreatePotTxParams) (db.CreatePotTxResult, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreatePotTxParams) db.CreatePotTxResult); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.CreatePotTxResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreatePotTxParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateTellerTill provides a mock function with given fields: ctx, arg
+func (_m *Store) CreateTellerTill(ctx context.Context, arg db.CreateTellerTillParams) (db.TellerTill, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 db.TellerTill
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateTellerTillParams) (db.TellerTill, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateTellerTillParams) db.TellerTill); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.TellerTill)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateTellerTillParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateTermDeposit provides a mock function with given fields: ctx, arg
+func (_m *Store) CreateTermDeposit(ctx context.Context, arg db.CreateTermDepositParams) (db.TermDeposit, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 db.TermDeposit
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateTermDepositParams) (db.TermDeposit, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateTermDepositParams) db.TermDeposit); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.TermDeposit)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateTermDepositParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateTermDepositRate provides a mock function with given fields: ctx, arg
+func (_m *Store) CreateTermDepositRate(ctx context.Context, arg db.CreateTermDepositRateParams) (db.TermDepositRate, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 db.TermDepositRate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateTermDepositRateParams) (db.TermDepositRate, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateTermDepositRateParams) db.TermDepositRate); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.TermDepositRate)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateTermDepositRateParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateTransfer provides a mock function with given fields: ctx, arg
+func (_m *Store) CreateTransfer(ctx context.Context, arg db.CreateTransferParams) (db.Transfer, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 db.Transfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateTransferParams) (db.Transfer, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateTransferParams) db.Transfer); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.Transfer)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateTransferParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateTransferApproval provides a mock function with given fields: ctx, arg
+func (_m *Store) CreateTransferApproval(ctx context.Context, arg db.CreateTransferApprovalParams) (db.TransferApproval, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 db.TransferApproval
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateTransferApprovalParams) (db.TransferApproval, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateTransferApprovalParams) db.TransferApproval); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.TransferApproval)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateTransferApprovalParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateUser provides a mock function with given fields: ctx, arg
+func (_m *Store) CreateUser(ctx context.Context, arg db.CreateUserParams) (db.User, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 db.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateUserParams) (db.User, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateUserParams) db.User); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateUserParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DecidePaymentRequest provides a mock function with given fields: ctx, arg
+func (_m *Store) DecidePaymentRequest(ctx context.Context, arg db.DecidePaymentRequestParams) (db.PaymentRequest, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 db.PaymentRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.DecidePaymentRequestParams) (db.PaymentRequest, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.DecidePaymentRequestParams) db.PaymentRequest); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.PaymentRequest)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.DecidePaymentRequestParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DecideTransferApproval provides a mock function with given fields: ctx, arg
+func (_m *Store) DecideTransferApproval(ctx context.Context, arg db.DecideTransferApprovalParams) (db.TransferApproval, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 db.TransferApproval
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.DecideTransferApprovalParams) (db.TransferApproval, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.DecideTransferApprovalParams) db.TransferApproval); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.TransferApproval)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.DecideTransferApprovalParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeclinePaymentRequestTx provides a mock function with given fields: ctx, id
+func (_m *Store) DeclinePaymentRequestTx(ctx context.Context, id int64) (db.PaymentRequest, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 db.PaymentRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.PaymentRequest, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.PaymentRequest); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(db.PaymentRequest)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteAccountHolder provides a mock function with given fields: ctx, arg
+func (_m *Store) DeleteAccountHolder(ctx context.Context, arg db.DeleteAccountHolderParams) (db.AccountHolder, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 db.AccountHolder
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.DeleteAccountHolderParams) (db.AccountHolder, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.DeleteAccountHolderParams) db.AccountHolder); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.AccountHolder)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.DeleteAccountHolderParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteBeneficiary provides a mock function with given fields: ctx, id
+func (_m *Store) DeleteBeneficiary(ctx context.Context, id int64) (db.Beneficiary, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 db.Beneficiary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.Beneficiary, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.Beneficiary); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(db.Beneficiary)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteIdempotencyKey provides a mock function with given fields: ctx, arg
+func (_m *Store) DeleteIdempotencyKey(ctx context.Context, arg db.DeleteIdempotencyKeyParams) error {
+	ret := _m.Called(ctx, arg)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.DeleteIdempotencyKeyParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteIdempotencyKeysBefore provides a mock function with given fields: ctx, before
+func (_m *Store) DeleteIdempotencyKeysBefore(ctx context.Context, before time.Time) (int64, error) {
+	ret := _m.Called(ctx, before)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return rf(ctx, before)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, before)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DepositCashTx provides a mock function with given fields: ctx, arg
+func (_m *Store) DepositCashTx(ctx context.Context, arg db.CashTxParams) (db.CashTxResult, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 db.CashTxResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CashTxParams) (db.CashTxResult, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CashTxParams) db.CashTxResult); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.CashTxResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CashTxParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchAccounts provides a mock function with given fields: ctx, arg
+func (_m *Store) FetchAccounts(ctx context.Context, arg db.FetchAccountsParams) ([]db.Account, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 []db.Account
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.FetchAccountsParams) ([]db.Account, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.FetchAccountsParams) []db.Account); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Account)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.FetchAccountsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchAccountsByOwner provides a mock function with given fields: ctx, arg
+func (_m *Store) FetchAccountsByOwner(ctx context.Context, arg db.FetchAccountsByOwnerParams) ([]db.Account, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 []db.Account
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.FetchAccountsByOwnerParams) ([]db.Account, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.FetchAccountsByOwnerParams) []db.Account); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Account)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.FetchAccountsByOwnerParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchEntries provides a mock function with given fields: ctx, arg
+func (_m *Store) FetchEntries(ctx context.Context, arg db.FetchEntriesParams) ([]db.Entry, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 []db.Entry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.FetchEntriesParams) ([]db.Entry, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.FetchEntriesParams) []db.Entry); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Entry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.FetchEntriesParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchTransfer provides a mock function with given fields: ctx, arg
+func (_m *Store) FetchTransfer(ctx context.Context, arg db.FetchTransferParams) ([]db.Transfer, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 []db.Transfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.FetchTransferParams) ([]db.Transfer, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.FetchTransferParams) []db.Transfer); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Transfer)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.FetchTransferParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchUsers provides a mock function with given fields: ctx, arg
+func (_m *Store) FetchUsers(ctx context.Context, arg db.FetchUsersParams) ([]db.User, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 []db.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.FetchUsersParams) ([]db.User, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.FetchUsersParams) []db.User); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.FetchUsersParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAccount provides a mock function with given fields: ctx, id
+func (_m *Store) GetAccount(ctx context.Context, id int64) (db.Account, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 db.Account
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.Account, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.Account); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(db.Account)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAccountByNumber provides a mock function with given fields: ctx, number
+func (_m *Store) GetAccountByNumber(ctx context.Context, number string) (db.Account, error) {
+	ret := _m.Called(ctx, number)
+
+	var r0 db.Account
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (db.Account, error)); ok {
+		return rf(ctx, number)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) db.Account); ok {
+		r0 = rf(ctx, number)
+	} else {
+		r0 = ret.Get(0).(db.Account)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, number)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAccountForUpdate provides a mock function with given fields: ctx, id
+func (_m *Store) GetAccountForUpdate(ctx context.Context, id int64) (db.Account, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 db.Account
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.Account, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.Account); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(db.Account)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAccountHolder provides a mock function with given fields: ctx, arg
+func (_m *Store) GetAccountHolder(ctx context.Context, arg db.GetAccountHolderParams) (db.AccountHolder, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 db.AccountHolder
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetAccountHolderParams) (db.AccountHolder, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetAccountHolderParams) db.AccountHolder); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.AccountHolder)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.GetAccountHolderParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAccountTransferUsage provides a mock function with given fields: ctx, arg
+func (_m *Store) GetAccountTransferUsage(ctx context.Context, arg db.GetAccountTransferUsageParams) (db.GetAccountTransferUsageRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 db.GetAccountTransferUsageRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetAccountTransferUsageParams) (db.GetAccountTransferUsageRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetAccountTransferUsageParams) db.GetAccountTransferUsageRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.GetAccountTransferUsageRow)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.GetAccountTransferUsageParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBeneficiary provides a mock function with given fields: ctx, id
+func (_m *Store) GetBeneficiary(ctx context.Context, id int64) (db.Beneficiary, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 db.Beneficiary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.Beneficiary, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.Beneficiary); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(db.Beneficiary)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCoolingOffBeneficiaryForUpdate provides a mock function with given fields: ctx, arg
+func (_m *Store) GetCoolingOffBeneficiaryForUpdate(ctx context.Context, arg db.GetCoolingOffBeneficiaryForUpdateParams) (db.Beneficiary, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 db.Beneficiary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetCoolingOffBeneficiaryForUpdateParams) (db.Beneficiary, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetCoolingOffBeneficiaryForUpdateParams) db.Beneficiary); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.Beneficiary)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.GetCoolingOffBeneficiaryForUpdateParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetEntry provides a mock function with given fields: ctx, id
+func (_m *Store) GetEntry(ctx context.Context, id int64) (db.Entry, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 db.Entry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.Entry, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.Entry); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(db.Entry)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetFeeSchedule provides a mock function with given fields: ctx, arg
+func (_m *Store) GetFeeSchedule(ctx context.Context, arg db.GetFeeScheduleParams) (db.FeeSchedule, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 db.FeeSchedule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetFeeScheduleParams) (db.FeeSchedule, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetFeeScheduleParams) db.FeeSchedule); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.FeeSchedule)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.GetFeeScheduleParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetGLAccountForAccount provides a mock function with given fields: ctx, id
+func (_m *Store) GetGLAccountForAccount(ctx context.Context, id int64) (db.GetGLAccountForAccountRow, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 db.GetGLAccountForAccountRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.GetGLAccountForAccountRow, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.GetGLAccountForAccountRow); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(db.GetGLAccountForAccountRow)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetHouseAccount provides a mock function with given fields: ctx, arg
+func (_m *Store) GetHouseAccount(ctx context.Context, arg db.GetHouseAccountParams) (db.Account, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 db.Account
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetHouseAccountParams) (db.Account, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetHouseAccountParams) db.Account); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.Account)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.GetHouseAccountParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetIdempotencyKey provides a mock function with given fields: ctx, arg
+func (_m *Store) GetIdempotencyKey(ctx context.Context, arg db.GetIdempotencyKeyParams) (db.IdempotencyKey, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 db.IdempotencyKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetIdempotencyKeyParams) (db.IdempotencyKey, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetIdempotencyKeyParams) db.IdempotencyKey); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.IdempotencyKey)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.GetIdempotencyKeyParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLastInterestCapitalization provides a mock function with given fields: ctx, accountID
+func (_m *Store) GetLastInterestCapitalization(ctx context.Context, accountID int64) (db.InterestCapitalization, error) {
+	ret := _m.Called(ctx, accountID)
+
+	var r0 db.InterestCapitalization
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.InterestCapitalization, error)); ok {
+		return rf(ctx, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.InterestCapitalization); ok {
+		r0 = rf(ctx, accountID)
+	} else {
+		r0 = ret.Get(0).(db.InterestCapitalization)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLoan provides a mock function with given fields: ctx, id
+func (_m *Store) GetLoan(ctx context.Context, id int64) (db.Loan, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 db.Loan
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.Loan, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.Loan); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(db.Loan)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLoanForUpdate provides a mock function with given fields: ctx, id
+func (_m *Store) GetLoanForUpdate(ctx context.Context, id int64) (db.Loan, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 db.Loan
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.Loan, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.Loan); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(db.Loan)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLoanInstallmentForUpdate provides a mock function with given fields: ctx, id
+func (_m *Store) GetLoanInstallmentForUpdate(ctx context.Context, id int64) (db.LoanInstallment, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 db.LoanInstallment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.LoanInstallment, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.LoanInstallment); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(db.LoanInstallment)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOpenTellerTillForUpdate provides a mock function with given fields: ctx, arg
+func (_m *Store) GetOpenTellerTillForUpdate(ctx context.Context, arg db.GetOpenTellerTillForUpdateParams) (db.TellerTill, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 db.TellerTill
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetOpenTellerTillForUpdateParams) (db.TellerTill, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetOpenTellerTillForUpdateParams) db.TellerTill); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.TellerTill)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.GetOpenTellerTillForUpdateParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOwnerTransferUsage provides a mock function with given fields: ctx, arg
+func (_m *Store) GetOwnerTransferUsage(ctx context.Context, arg db.GetOwnerTransferUsageParams) (db.GetOwnerTransferUsageRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 db.GetOwnerTransferUsageRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetOwnerTransferUsageParams) (db.GetOwnerTransferUsageRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetOwnerTransferUsageParams) db.GetOwnerTransferUsageRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.GetOwnerTransferUsageRow)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.GetOwnerTransferUsageParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPayeeTransferTotal provides a mock function with given fields: ctx, arg
+func (_m *Store) GetPayeeTransferTotal(ctx context.Context, arg db.GetPayeeTransferTotalParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetPayeeTransferTotalParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetPayeeTransferTotalParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.GetPayeeTransferTotalParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPaymentRequest provides a mock function with given fields: ctx, id
+func (_m *Store) GetPaymentRequest(ctx context.Context, id int64) (db.PaymentRequest, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 db.PaymentRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.PaymentRequest, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.PaymentRequest); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(db.PaymentRequest)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPaymentRequestForUpdate provides a mock function with given fields: ctx, id
+func (_m *Store) GetPaymentRequestForUpdate(ctx context.Context, id int64) (db.PaymentRequest, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 db.PaymentRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.PaymentRequest, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.PaymentRequest); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(db.PaymentRequest)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPendingInterest provides a mock function with given fields: ctx, arg
+func (_m *Store) GetPendingInterest(ctx context.Context, arg db.GetPendingInterestParams) (db.GetPendingInterestRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 db.GetPendingInterestRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetPendingInterestParams) (db.GetPendingInterestRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetPendingInterestParams) db.GetPendingInterestRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.GetPendingInterestRow)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.GetPendingInterestParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPot provides a mock function with given fields: ctx, accountID
+func (_m *Store) GetPot(ctx context.Context, accountID int64) (db.Pot, error) {
+	ret := _m.Called(ctx, accountID)
+
+	var r0 db.Pot
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.Pot, error)); ok {
+		return rf(ctx, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.Pot); ok {
+		r0 = rf(ctx, accountID)
+	} else {
+		r0 = ret.Get(0).(db.Pot)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRoundUpPot provides a mock function with given fields: ctx, parentAccountID
+func (_m *Store) GetRoundUpPot(ctx context.Context, parentAccountID int64) (db.Pot, error) {
+	ret := _m.Called(ctx, parentAccountID)
+
+	var r0 db.Pot
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.Pot, error)); ok {
+		return rf(ctx, parentAccountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.Pot); ok {
+		r0 = rf(ctx, parentAccountID)
+	} else {
+		r0 = ret.Get(0).(db.Pot)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, parentAccountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTellerTill provides a mock function with given fields: ctx, id
+func (_m *Store) GetTellerTill(ctx context.Context, id int64) (db.TellerTill, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 db.TellerTill
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.TellerTill, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.TellerTill); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(db.TellerTill)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTellerTillForUpdate provides a mock function with given fields: ctx, id
+func (_m *Store) GetTellerTillForUpdate(ctx context.Context, id int64) (db.TellerTill, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 db.TellerTill
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.TellerTill, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.TellerTill); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(db.TellerTill)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTermDeposit provides a mock function with given fields: ctx, id
+func (_m *Store) GetTermDeposit(ctx context.Context, id int64) (db.TermDeposit, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 db.TermDeposit
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.TermDeposit, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.TermDeposit); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(db.TermDeposit)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTermDepositForUpdate provides a mock function with given fields: ctx, id
+func (_m *Store) GetTermDepositForUpdate(ctx context.Context, id int64) (db.TermDeposit, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 db.TermDeposit
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.TermDeposit, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.TermDeposit); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(db.TermDeposit)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTermDepositRate provides a mock function with given fields: ctx, arg
+func (_m *Store) GetTermDepositRate(ctx context.Context, arg db.GetTermDepositRateParams) (db.TermDepositRate, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 db.TermDepositRate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetTermDepositRateParams) (db.TermDepositRate, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetTermDepositRateParams) db.TermDepositRate); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.TermDepositRate)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.GetTermDepositRateParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTransfer provides a mock function with given fields: ctx, id
+func (_m *Store) GetTransfer(ctx context.Context, id int64) (db.Transfer, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 db.Transfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.Transfer, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.Transfer); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(db.Transfer)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTransferApproval provides a mock function with given fields: ctx, id
+func (_m *Store) GetTransferApproval(ctx context.Context, id int64) (db.TransferApproval, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 db.TransferApproval
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.TransferApproval, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.TransferApproval); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(db.TransferApproval)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTransferApprovalForUpdate provides a mock function with given fields: ctx, id
+func (_m *Store) GetTransferApprovalForUpdate(ctx context.Context, id int64) (db.TransferApproval, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 db.TransferApproval
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.TransferApproval, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.TransferApproval); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(db.TransferApproval)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTransferLimit provides a mock function with given fields: ctx, arg
+func (_m *Store) GetTransferLimit(ctx context.Context, arg db.GetTransferLimitParams) (db.TransferLimit, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 db.TransferLimit
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetTransferLimitParams) (db.TransferLimit, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetTransferLimitParams) db.TransferLimit); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.TransferLimit)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.GetTransferLimitParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTrialBalance provides a mock function with given fields: ctx, asOf
+func (_m *Store) GetTrialBalance(ctx context.Context, asOf time.Time) ([]db.GetTrialBalanceRow, error) {
+	ret := _m.Called(ctx, asOf)
+
+	var r0 []db.GetTrialBalanceRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]db.GetTrialBalanceRow, error)); ok {
+		return rf(ctx, asOf)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []db.GetTrialBalanceRow); ok {
+		r0 = rf(ctx, asOf)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.GetTrialBalanceRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, asOf)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUser provides a mock function with given fields: ctx, id
+func (_m *Store) GetUser(ctx context.Context, id uuid.UUID) (db.User, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 db.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (db.User, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) db.User); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(db.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserByEmail provides a mock function with given fields: ctx, email
+func (_m *Store) GetUserByEmail(ctx context.Context, email string) (db.User, error) {
+	ret := _m.Called(ctx, email)
+
+	var r0 db.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (db.User, error)); ok {
+		return rf(ctx, email)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) db.User); ok {
+		r0 = rf(ctx, email)
+	} else {
+		r0 = ret.Get(0).(db.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserByUsername provides a mock function with given fields: ctx, username
+func (_m *Store) GetUserByUsername(ctx context.Context, username string) (db.User, error) {
+	ret := _m.Called(ctx, username)
+
+	var r0 db.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (db.User, error)); ok {
+		return rf(ctx, username)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) db.User); ok {
+		r0 = rf(ctx, username)
+	} else {
+		r0 = ret.Get(0).(db.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserForUpdate provides a mock function with given fields: ctx, id
+func (_m *Store) GetUserForUpdate(ctx context.Context, id uuid.UUID) (db.User, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 db.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (db.User, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) db.User); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(db.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListAccountHolders provides a mock function with given fields: ctx, accountID
+func (_m *Store) ListAccountHolders(ctx context.Context, accountID int64) ([]db.AccountHolder, error) {
+	ret := _m.Called(ctx, accountID)
+
+	var r0 []db.AccountHolder
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]db.AccountHolder, error)); ok {
+		return rf(ctx, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []db.AccountHolder); ok {
+		r0 = rf(ctx, accountID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.AccountHolder)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListAccountsWithPendingInterest provides a mock function with given fields: ctx, periodEnd
+func (_m *Store) ListAccountsWithPendingInterest(ctx context.Context, periodEnd time.Time) ([]int64, error) {
+	ret := _m.Called(ctx, periodEnd)
+
+	var r0 []int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]int64, error)); ok {
+		return rf(ctx, periodEnd)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []int64); ok {
+		r0 = rf(ctx, periodEnd)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int64)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, periodEnd)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListBeneficiaries provides a mock function with given fields: ctx, ownerID
+func (_m *Store) ListBeneficiaries(ctx context.Context, ownerID uuid.UUID) ([]db.Beneficiary, error) {
+	ret := _m.Called(ctx, ownerID)
+
+	var r0 []db.Beneficiary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]db.Beneficiary, error)); ok {
+		return rf(ctx, ownerID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []db.Beneficiary); ok {
+		r0 = rf(ctx, ownerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Beneficiary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, ownerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListCashTransactionsByTill provides a mock function with given fields: ctx, tillID
+func (_m *Store) ListCashTransactionsByTill(ctx context.Context, tillID int64) ([]db.CashTransaction, error) {
+	ret := _m.Called(ctx, tillID)
+
+	var r0 []db.CashTransaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]db.CashTransaction, error)); ok {
+		return rf(ctx, tillID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []db.CashTransaction); ok {
+		r0 = rf(ctx, tillID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.CashTransaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, tillID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListDueLoanInstallments provides a mock function with given fields: ctx, dueDate
+func (_m *Store) ListDueLoanInstallments(ctx context.Context, dueDate time.Time) ([]db.LoanInstallment, error) {
+	ret := _m.Called(ctx, dueDate)
+
+	var r0 []db.LoanInstallment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]db.LoanInstallment, error)); ok {
+		return rf(ctx, dueDate)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []db.LoanInstallment); ok {
+		r0 = rf(ctx, dueDate)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.LoanInstallment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, dueDate)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListFeeScheduleTiers provides a mock function with given fields: ctx, scheduleID
+func (_m *Store) ListFeeScheduleTiers(ctx context.Context, scheduleID int64) ([]db.FeeScheduleTier, error) {
+	ret := _m.Called(ctx, scheduleID)
+
+	var r0 []db.FeeScheduleTier
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]db.FeeScheduleTier, error)); ok {
+		return rf(ctx, scheduleID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []db.FeeScheduleTier); ok {
+		r0 = rf(ctx, scheduleID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.FeeScheduleTier)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, scheduleID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListGLAccounts provides a mock function with given fields: ctx
+func (_m *Store) ListGLAccounts(ctx context.Context) ([]db.GlAccount, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []db.GlAccount
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]db.GlAccount, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []db.GlAccount); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.GlAccount)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListInterestAccruals provides a mock function with given fields: ctx, accountID
+func (_m *Store) ListInterestAccruals(ctx context.Context, accountID int64) ([]db.InterestAccrual, error) {
+	ret := _m.Called(ctx, accountID)
+
+	var r0 []db.InterestAccrual
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]db.InterestAccrual, error)); ok {
+		return rf(ctx, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []db.InterestAccrual); ok {
+		r0 = rf(ctx, accountID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.InterestAccrual)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListInterestBearingAccounts provides a mock function with given fields: ctx, asOf
+func (_m *Store) ListInterestBearingAccounts(ctx context.Context, asOf time.Time) ([]db.ListInterestBearingAccountsRow, error) {
+	ret := _m.Called(ctx, asOf)
+
+	var r0 []db.ListInterestBearingAccountsRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]db.ListInterestBearingAccountsRow, error)); ok {
+		return rf(ctx, asOf)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []db.ListInterestBearingAccountsRow); ok {
+		r0 = rf(ctx, asOf)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ListInterestBearingAccountsRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, asOf)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListJournalEntriesByReference provides a mock function with given fields: ctx, reference
+func (_m *Store) ListJournalEntriesByReference(ctx context.Context, reference string) ([]db.JournalEntry, error) {
+	ret := _m.Called(ctx, reference)
+
+	var r0 []db.JournalEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]db.JournalEntry, error)); ok {
+		return rf(ctx, reference)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []db.JournalEntry); ok {
+		r0 = rf(ctx, reference)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.JournalEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, reference)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListJournalLines provides a mock function with given fields: ctx, journalEntryID
+func (_m *Store) ListJournalLines(ctx context.Context, journalEntryID int64) ([]db.JournalLine, error) {
+	ret := _m.Called(ctx, journalEntryID)
+
+	var r0 []db.JournalLine
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]db.JournalLine, error)); ok {
+		return rf(ctx, journalEntryID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []db.JournalLine); ok {
+		r0 = rf(ctx, journalEntryID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.JournalLine)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, journalEntryID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListLoanInstallments provides a mock function with given fields: ctx, loanID
+func (_m *Store) ListLoanInstallments(ctx context.Context, loanID int64) ([]db.LoanInstallment, error) {
+	ret := _m.Called(ctx, loanID)
+
+	var r0 []db.LoanInstallment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]db.LoanInstallment, error)); ok {
+		return rf(ctx, loanID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []db.LoanInstallment); ok {
+		r0 = rf(ctx, loanID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.LoanInstallment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, loanID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListMaturingTermDeposits provides a mock function with given fields: ctx, maturityDate
+func (_m *Store) ListMaturingTermDeposits(ctx context.Context, maturityDate time.Time) ([]db.TermDeposit, error) {
+	ret := _m.Called(ctx, maturityDate)
+
+	var r0 []db.TermDeposit
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]db.TermDeposit, error)); ok {
+		return rf(ctx, maturityDate)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []db.TermDeposit); ok {
+		r0 = rf(ctx, maturityDate)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.TermDeposit)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, maturityDate)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListPaymentRequests provides a mock function with given fields: ctx, requesterID
+func (_m *Store) ListPaymentRequests(ctx context.Context, requesterID uuid.UUID) ([]db.PaymentRequest, error) {
+	ret := _m.Called(ctx, requesterID)
+
+	var r0 []db.PaymentRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]db.PaymentRequest, error)); ok {
+		return rf(ctx, requesterID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []db.PaymentRequest); ok {
+		r0 = rf(ctx, requesterID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.PaymentRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, requesterID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListPendingTransferApprovals provides a mock function with given fields: ctx, fromAccountID
+func (_m *Store) ListPendingTransferApprovals(ctx context.Context, fromAccountID int64) ([]db.TransferApproval, error) {
+	ret := _m.Called(ctx, fromAccountID)
+
+	var r0 []db.TransferApproval
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]db.TransferApproval, error)); ok {
+		return rf(ctx, fromAccountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []db.TransferApproval); ok {
+		r0 = rf(ctx, fromAccountID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.TransferApproval)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, fromAccountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListPotsByParent provides a mock function with given fields: ctx, parentAccountID
+func (_m *Store) ListPotsByParent(ctx context.Context, parentAccountID int64) ([]db.ListPotsByParentRow, error) {
+	ret := _m.Called(ctx, parentAccountID)
+
+	var r0 []db.ListPotsByParentRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]db.ListPotsByParentRow, error)); ok {
+		return rf(ctx, parentAccountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []db.ListPotsByParentRow); ok {
+		r0 = rf(ctx, parentAccountID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ListPotsByParentRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, parentAccountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListTermDepositRates provides a mock function with given fields: ctx
+func (_m *Store) ListTermDepositRates(ctx context.Context) ([]db.TermDepositRate, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []db.TermDepositRate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]db.TermDepositRate, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []db.TermDepositRate); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.TermDepositRate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkInterestAccrualsCapitalized provides a mock function with given fields: ctx, arg
+func (_m *Store) MarkInterestAccrualsCapitalized(ctx context.Context, arg db.MarkInterestAccrualsCapitalizedParams) error {
+	ret := _m.Called(ctx, arg)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.MarkInterestAccrualsCapitalizedParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MarkLoanInstallmentOverdue provides a mock function with given fields: ctx, arg
+func (_m *Store) MarkLoanInstallmentOverdue(ctx context.Context, arg db.MarkLoanInstallmentOverdueParams) (db.LoanInstallment, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 db.LoanInstallment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.MarkLoanInstallmentOverdueParams) (db.LoanInstallment, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.MarkLoanInstallmentOverdueParams) db.LoanInstallment); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.LoanInstallment)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.MarkLoanInstallmentOverdueParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkLoanInstallmentPaid provides a mock function with given fields: ctx, arg
+func (_m *Store) MarkLoanInstallmentPaid(ctx context.Context, arg db.MarkLoanInstallmentPaidParams) (db.LoanInstallment, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 db.LoanInstallment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.MarkLoanInstallmentPaidParams) (db.LoanInstallment, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.MarkLoanInstallmentPaidParams) db.LoanInstallment); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.LoanInstallment)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.MarkLoanInstallmentPaidParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MatureTermDeposits provides a mock function with given fields: ctx, date
+func (_m *Store) MatureTermDeposits(ctx context.Context, date time.Time) ([]db.TermDepositClosure, error) {
+	ret := _m.Called(ctx, date)
+
+	var r0 []db.TermDepositClosure
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]db.TermDepositClosure, error)); ok {
+		return rf(ctx, date)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []db.TermDepositClosure); ok {
+		r0 = rf(ctx, date)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.TermDepositClosure)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, date)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MovePotTx provides a mock function with given fields: ctx, arg
+func (_m *Store) MovePotTx(ctx context.Context, arg db.MovePotTxParams) (db.TransferTxResult, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 db.TransferTxResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.MovePotTxParams) (db.TransferTxResult, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.MovePotTxParams) db.TransferTxResult); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.TransferTxResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.MovePotTxParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OpenTermDepositTx provides a mock function with given fields: ctx, arg
+func (_m *Store) OpenTermDepositTx(ctx context.Context, arg db.OpenTermDepositTxParams) (db.OpenTermDepositTxResult, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 db.OpenTermDepositTxResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.OpenTermDepositTxParams) (db.OpenTermDepositTxResult, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.OpenTermDepositTxParams) db.OpenTermDepositTxResult); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.OpenTermDepositTxResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.OpenTermDepositTxParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RejectTransferTx provides a mock function with given fields: ctx, arg
+func (_m *Store) RejectTransferTx(ctx context.Context, arg db.DecideTransferApprovalTxParams) (db.TransferApproval, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 db.TransferApproval
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.DecideTransferApprovalTxParams) (db.TransferApproval, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.DecideTransferApprovalTxParams) db.TransferApproval); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.TransferApproval)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.DecideTransferApprovalTxParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveIdempotentResponse provides a mock function with given fields: ctx, arg
+func (_m *Store) SaveIdempotentResponse(ctx context.Context, arg db.SaveIdempotentResponseParams) (db.IdempotencyKey, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 db.IdempotencyKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.SaveIdempotentResponseParams) (db.IdempotencyKey, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.SaveIdempotentResponseParams) db.IdempotencyKey); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.IdempotencyKey)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.SaveIdempotentResponseParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetAccountStatusTx provides a mock function with given fields: ctx, id, status
+func (_m *Store) SetAccountStatusTx(ctx context.Context, id int64, status string) (db.Account, error) {
+	ret := _m.Called(ctx, id, status)
+
+	var r0 db.Account
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) (db.Account, error)); ok {
+		return rf(ctx, id, status)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) db.Account); ok {
+		r0 = rf(ctx, id, status)
+	} else {
+		r0 = ret.Get(0).(db.Account)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string) error); ok {
+		r1 = rf(ctx, id, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TakeOverIdempotencyKey provides a mock function with given fields: ctx, arg
+func (_m *Store) TakeOverIdempotencyKey(ctx context.Context, arg db.TakeOverIdempotencyKeyParams) (db.IdempotencyKey, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 db.IdempotencyKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.TakeOverIdempotencyKeyParams) (db.IdempotencyKey, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.TakeOverIdempotencyKeyParams) db.IdempotencyKey); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.IdempotencyKey)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.TakeOverIdempotencyKeyParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TransferTx provides a mock function with given fields: ctx, arg
+func (_m *Store) TransferTx(ctx context.Context, arg db.TransferTxParams) (db.TransferTxResult, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 db.TransferTxResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.TransferTxParams) (db.TransferTxResult, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.TransferTxParams) db.TransferTxResult); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.TransferTxResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.TransferTxParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TrialBalance provides a mock function with given fields: ctx, asOf
+func (_m *Store) TrialBalance(ctx context.Context, asOf time.Time) (db.TrialBalance, error) {
+	ret := _m.Called(ctx, asOf)
+
+	var r0 db.TrialBalance
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (db.TrialBalance, error)); ok {
+		return rf(ctx, asOf)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) db.TrialBalance); ok {
+		r0 = rf(ctx, asOf)
+	} else {
+		r0 = ret.Get(0).(db.TrialBalance)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, asOf)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TxStats provides a mock function with given fields:
+func (_m *Store) TxStats() db.TxStats {
+	ret := _m.Called()
+
+	var r0 db.TxStats
+	if rf, ok := ret.Get(0).(func() db.TxStats); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(db.TxStats)
+	}
+
+	return r0
+}
+
+// UpdateAccountStatus provides a mock function with given fields: ctx, arg
+func (_m *Store) UpdateAccountStatus(ctx context.Context, arg db.UpdateAccountStatusParams) (db.Account, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 db.Account
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateAccountStatusParams) (db.Account, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateAccountStatusParams) db.Account); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.Account)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.UpdateAccountStatusParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateBalanceAccount provides a mock function with given fields: ctx, arg
+func (_m *Store) UpdateBalanceAccount(ctx context.Context, arg db.UpdateBalanceAccountParams) (db.Account, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 db.Account
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateBalanceAccountParams) (db.Account, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateBalanceAccountParams) db.Account); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.Account)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.UpdateBalanceAccountParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateLoanStatus provides a mock function with given fields: ctx, arg
+func (_m *Store) UpdateLoanStatus(ctx context.Context, arg db.UpdateLoanStatusParams) (db.Loan, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 db.Loan
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateLoanStatusParams) (db.Loan, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateLoanStatusParams) db.Loan); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.Loan)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.UpdateLoanStatusParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateUserRole provides a mock function with given fields: ctx, arg
+func (_m *Store) UpdateUserRole(ctx context.Context, arg db.UpdateUserRoleParams) (db.User, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 db.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateUserRoleParams) (db.User, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateUserRoleParams) db.User); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.UpdateUserRoleParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WithdrawCashTx provides a mock function with given fields: ctx, arg
+func (_m *Store) WithdrawCashTx(ctx context.Context, arg db.CashTxParams) (db.CashTxResult, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 db.CashTxResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CashTxParams) (db.CashTxResult, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CashTxParams) db.CashTxResult); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.CashTxResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CashTxParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WithdrawTermDepositTx provides a mock function with given fields: ctx, id
+func (_m *Store) WithdrawTermDepositTx(ctx context.Context, id int64) (db.TermDepositClosure, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 db.TermDepositClosure
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.TermDepositClosure, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.TermDepositClosure); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(db.TermDepositClosure)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTNewStore interface {
 	mock.TestingT
 	Cleanup(func())
@@ -1,8 +1,8 @@
 -- name: CreateAccount :one
 INSERT INTO accounts (
     owner_id,
-    balance,
-    currency
+    currency,
+    account_type
 ) VALUES (
     $1,
     $2,
@@ -24,6 +24,20 @@ ORDER BY id
 LIMIT $1
 OFFSET $2;
 
+-- name: FetchAccountsByOwner :many
+SELECT * FROM accounts
+WHERE owner_id = $1
+    OR id IN (SELECT account_id FROM account_holders WHERE user_id = $1)
+ORDER BY id
+LIMIT $2
+OFFSET $3;
+
+-- name: UpdateAccountStatus :one
+UPDATE accounts
+SET status = $2
+WHERE id = $1
+RETURNING *;
+
 -- name: UpdateBalanceAccount :one
 UPDATE accounts
 SET balance = $2
@@ -36,5 +50,17 @@ SET balance = balance + sqlc.arg(amount)
 WHERE id = sqlc.arg(id)
 RETURNING *;
 
--- name: DeleteAccount :exec
-DELETE FROM accounts WHERE id = $1;
\ No newline at end of file
+-- name: CloseAccount :one
+UPDATE accounts
+SET status = 'closed', closed_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: GetHouseAccount :one
+SELECT * FROM accounts
+WHERE account_type = $1 AND currency = $2
+LIMIT 1;
+
+-- name: GetAccountByNumber :one
+SELECT * FROM accounts
+WHERE number = $1 LIMIT 1;
@@ -0,0 +1,32 @@
+-- name: CreateBeneficiary :one
+INSERT INTO beneficiaries (
+    owner_id,
+    nickname,
+    account_id,
+    currency,
+    cooling_off_until
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: GetBeneficiary :one
+SELECT * FROM beneficiaries
+WHERE id = $1 LIMIT 1;
+
+-- name: GetCoolingOffBeneficiaryForUpdate :one
+SELECT * FROM beneficiaries
+WHERE owner_id = sqlc.arg(owner_id)
+    AND account_id = sqlc.arg(account_id)
+    AND cooling_off_until > sqlc.arg(now)
+LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListBeneficiaries :many
+SELECT * FROM beneficiaries
+WHERE owner_id = $1
+ORDER BY nickname;
+
+-- name: DeleteBeneficiary :one
+DELETE FROM beneficiaries
+WHERE id = $1
+RETURNING *;
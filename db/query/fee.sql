@@ -0,0 +1,31 @@
+-- name: CreateFeeSchedule :one
+INSERT INTO fee_schedules (
+    currency,
+    account_type,
+    kind,
+    flat_amount,
+    rate_bps,
+    min_fee,
+    max_fee
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+) RETURNING *;
+
+-- name: GetFeeSchedule :one
+SELECT * FROM fee_schedules
+WHERE currency = $1 AND account_type = $2 LIMIT 1;
+
+-- name: CreateFeeScheduleTier :one
+INSERT INTO fee_schedule_tiers (
+    schedule_id,
+    up_to,
+    flat_amount,
+    rate_bps
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
+
+-- name: ListFeeScheduleTiers :many
+SELECT * FROM fee_schedule_tiers
+WHERE schedule_id = $1
+ORDER BY up_to = 0, up_to;
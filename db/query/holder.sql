@@ -0,0 +1,54 @@
+-- name: CreateAccountHolder :one
+INSERT INTO account_holders (
+    account_id,
+    user_id,
+    can_initiate,
+    can_approve,
+    spend_limit
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: GetAccountHolder :one
+SELECT * FROM account_holders
+WHERE account_id = $1 AND user_id = $2 LIMIT 1;
+
+-- name: ListAccountHolders :many
+SELECT * FROM account_holders
+WHERE account_id = $1
+ORDER BY created_at, user_id;
+
+-- name: DeleteAccountHolder :one
+DELETE FROM account_holders
+WHERE account_id = $1 AND user_id = $2
+RETURNING *;
+
+-- name: CreateTransferApproval :one
+INSERT INTO transfer_approvals (
+    from_account_id,
+    to_account_id,
+    amount,
+    requested_by
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
+
+-- name: GetTransferApproval :one
+SELECT * FROM transfer_approvals
+WHERE id = $1 LIMIT 1;
+
+-- name: GetTransferApprovalForUpdate :one
+SELECT * FROM transfer_approvals
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListPendingTransferApprovals :many
+SELECT * FROM transfer_approvals
+WHERE from_account_id = $1 AND status = 'pending'
+ORDER BY id;
+
+-- name: DecideTransferApproval :one
+UPDATE transfer_approvals
+SET status = $2, decided_by = $3, transfer_id = $4, decided_at = now()
+WHERE id = $1
+RETURNING *;
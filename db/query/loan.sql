@@ -0,0 +1,74 @@
+-- name: CreateLoan :one
+INSERT INTO loans (
+    account_id,
+    currency,
+    principal,
+    rate_bps,
+    term,
+    frequency,
+    amortization,
+    late_fee,
+    disbursement_id
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+) RETURNING *;
+
+-- name: GetLoan :one
+SELECT * FROM loans
+WHERE id = $1 LIMIT 1;
+
+-- name: GetLoanForUpdate :one
+SELECT * FROM loans
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: UpdateLoanStatus :one
+UPDATE loans
+SET status = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: CreateLoanInstallment :one
+INSERT INTO loan_installments (
+    loan_id,
+    number,
+    due_date,
+    principal,
+    interest
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: ListLoanInstallments :many
+SELECT * FROM loan_installments
+WHERE loan_id = $1
+ORDER BY number;
+
+-- name: ListDueLoanInstallments :many
+SELECT * FROM loan_installments
+WHERE due_date <= $1 AND status <> 'paid'
+ORDER BY due_date, loan_id, number;
+
+-- name: GetLoanInstallmentForUpdate :one
+SELECT * FROM loan_installments
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: MarkLoanInstallmentOverdue :one
+UPDATE loan_installments
+SET status = 'overdue',
+    late_fee = late_fee + sqlc.arg(late_fee)
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: MarkLoanInstallmentPaid :one
+UPDATE loan_installments
+SET status = 'paid',
+    entry_id = $2,
+    paid_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: CountUnpaidLoanInstallments :one
+SELECT COUNT(*) FROM loan_installments
+WHERE loan_id = $1 AND status <> 'paid';
@@ -0,0 +1,32 @@
+-- name: CreatePaymentRequest :one
+INSERT INTO payment_requests (
+    requester_id,
+    payer_id,
+    to_account_id,
+    amount,
+    currency,
+    memo,
+    expires_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+) RETURNING *;
+
+-- name: GetPaymentRequest :one
+SELECT * FROM payment_requests
+WHERE id = $1 LIMIT 1;
+
+-- name: GetPaymentRequestForUpdate :one
+SELECT * FROM payment_requests
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListPaymentRequests :many
+SELECT * FROM payment_requests
+WHERE requester_id = $1 OR payer_id = $1
+ORDER BY id DESC;
+
+-- name: DecidePaymentRequest :one
+UPDATE payment_requests
+SET status = $2, transfer_id = $3, decided_at = now()
+WHERE id = $1
+RETURNING *;
@@ -0,0 +1,60 @@
+-- name: CreateTellerTill :one
+INSERT INTO teller_tills (
+    teller_id,
+    currency,
+    business_date,
+    opening_cash,
+    expected_cash
+) VALUES (
+    $1, $2, $3, $4, $4
+) RETURNING *;
+
+-- name: GetTellerTill :one
+SELECT * FROM teller_tills
+WHERE id = $1 LIMIT 1;
+
+-- name: GetTellerTillForUpdate :one
+SELECT * FROM teller_tills
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: GetOpenTellerTillForUpdate :one
+SELECT * FROM teller_tills
+WHERE teller_id = $1
+    AND currency = $2
+    AND business_date = $3
+    AND status = 'open'
+LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: AddTellerTillCash :one
+UPDATE teller_tills
+SET expected_cash = expected_cash + sqlc.arg(amount)
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: CloseTellerTill :one
+UPDATE teller_tills
+SET status = 'closed',
+    counted_cash = sqlc.arg(counted_cash),
+    difference = sqlc.arg(counted_cash) - expected_cash,
+    closed_at = now()
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: CreateCashTransaction :one
+INSERT INTO cash_transactions (
+    till_id,
+    account_id,
+    kind,
+    amount,
+    balance_after,
+    entry_id
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: ListCashTransactionsByTill :many
+SELECT * FROM cash_transactions
+WHERE till_id = $1
+ORDER BY id;
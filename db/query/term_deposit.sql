@@ -0,0 +1,58 @@
+-- name: CreateTermDepositRate :one
+INSERT INTO term_deposit_rates (
+    currency,
+    term_months,
+    rate_bps,
+    day_count,
+    penalty_bps
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: GetTermDepositRate :one
+SELECT * FROM term_deposit_rates
+WHERE currency = $1 AND term_months = $2 LIMIT 1;
+
+-- name: ListTermDepositRates :many
+SELECT * FROM term_deposit_rates
+ORDER BY currency, term_months;
+
+-- name: CreateTermDeposit :one
+INSERT INTO term_deposits (
+    account_id,
+    payout_account_id,
+    currency,
+    principal,
+    rate_bps,
+    day_count,
+    penalty_bps,
+    term_months,
+    start_date,
+    maturity_date,
+    on_maturity,
+    renewed_from_id
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
+) RETURNING *;
+
+-- name: GetTermDeposit :one
+SELECT * FROM term_deposits
+WHERE id = $1 LIMIT 1;
+
+-- name: GetTermDepositForUpdate :one
+SELECT * FROM term_deposits
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListMaturingTermDeposits :many
+SELECT * FROM term_deposits
+WHERE maturity_date <= $1 AND status = 'active'
+ORDER BY maturity_date, id;
+
+-- name: CloseTermDeposit :one
+UPDATE term_deposits
+SET status = $2,
+    interest_paid = $3,
+    closed_at = now()
+WHERE id = $1
+RETURNING *;
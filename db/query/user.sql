@@ -15,10 +15,27 @@ INSERT INTO users (
 SELECT * FROM users
 WHERE id = $1 LIMIT 1;
 
+-- name: GetUserForUpdate :one
+SELECT * FROM users
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
 -- name: GetUserByUsername :one
 SELECT * FROM users
 WHERE username = $1 LIMIT 1;
 
 -- name: GetUserByEmail :one
 SELECT * FROM users
-WHERE email = $1 LIMIT 1;
\ No newline at end of file
+WHERE email = $1 LIMIT 1;
+
+-- name: FetchUsers :many
+SELECT * FROM users
+ORDER BY created_at
+LIMIT $1
+OFFSET $2;
+
+-- name: UpdateUserRole :one
+UPDATE users
+SET role = $2
+WHERE id = $1
+RETURNING *;
\ No newline at end of file
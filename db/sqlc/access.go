@@ -0,0 +1,95 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/flukis/simplebank/util"
+	"github.com/google/uuid"
+)
+
+// AccountPermission is a set of things a user may do with an account
+type AccountPermission uint8
+
+const (
+	PermView AccountPermission = 1 << iota
+	PermInitiate
+	PermApprove
+	// managing the account itself: closing it, opening pots and choosing its holders
+	PermManage
+
+	PermAll = PermView | PermInitiate | PermApprove | PermManage
+)
+
+// AccountAccess is what a user may do with an account, as decided by
+// GetAccountAccess for both the REST and the gRPC API
+type AccountAccess struct {
+	Permissions AccountPermission
+	// largest transfer that may be made without approval, 0 for no limit
+	SpendLimit int64
+}
+
+func (a AccountAccess) Can(need AccountPermission) bool {
+	return a.Permissions&need == need
+}
+
+// NeedsApproval reports whether a transfer of amount is above the spend limit
+func (a AccountAccess) NeedsApproval(amount int64) bool {
+	return a.SpendLimit > 0 && amount > a.SpendLimit
+}
+
+// GetAccountAccess returns what the user with userID and role may do with
+// account. The owner may do anything, other users get the rights they were
+// given as a holder of the account, if any. Staff may only read it; they
+// act on accounts through the teller and admin endpoints.
+func GetAccountAccess(ctx context.Context, q Querier, account Account, userID uuid.UUID, role string) (AccountAccess, error) {
+	if userID == account.OwnerID {
+		return AccountAccess{Permissions: PermAll}, nil
+	}
+
+	holder, err := q.GetAccountHolder(ctx, GetAccountHolderParams{
+		AccountID: account.ID,
+		UserID:    userID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			if util.IsStaffRole(role) {
+				return AccountAccess{Permissions: PermView}, nil
+			}
+			return AccountAccess{}, nil
+		}
+		return AccountAccess{}, err
+	}
+
+	access := AccountAccess{
+		Permissions: PermView,
+		SpendLimit:  holder.SpendLimit,
+	}
+	if holder.CanInitiate {
+		access.Permissions |= PermInitiate
+	}
+	if holder.CanApprove {
+		access.Permissions |= PermApprove
+	}
+	return access, nil
+}
+
+// CanOpenAccountFor reports whether the user with userID and role may open
+// an account for the user with ownerID: for themselves, or for a customer at
+// the branch as a teller or admin. Staff don't move money out of the
+// accounts they open, that goes through the teller and admin endpoints.
+func CanOpenAccountFor(userID uuid.UUID, role string, ownerID uuid.UUID) bool {
+	return userID == ownerID || role == util.RoleTeller || role == util.RoleAdmin
+}
+
+// GetAccountByCheckedNumber returns the account with number, as written by
+// a customer for both the REST and the gRPC API. Its check digits are
+// verified first, so a mistyped number is reported as
+// util.ErrAccountNumberCheck rather than as not found.
+func GetAccountByCheckedNumber(ctx context.Context, q Querier, number string) (Account, error) {
+	number = util.NormalizeAccountNumber(number)
+	if err := util.ValidateAccountNumber(number); err != nil {
+		return Account{}, err
+	}
+	return q.GetAccountByNumber(ctx, number)
+}
@@ -0,0 +1,66 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flukis/simplebank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAccountAccess(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+
+	newUser := func() User {
+		user, err := store.CreateUser(ctx, CreateUserParams{
+			Username:       util.GenRandomOwner(),
+			HashedPassword: "secret",
+			FullName:       util.GenRandomOwner(),
+			Email:          util.GenRandomEmail(),
+		})
+		require.NoError(t, err)
+		return user
+	}
+	owner := newUser()
+	account, err := store.CreateAccount(ctx, CreateAccountParams{
+		OwnerID:     owner.ID,
+		Currency:    "USD",
+		AccountType: AccountTypeCurrent,
+	})
+	require.NoError(t, err)
+
+	holder := newUser()
+	_, err = store.CreateAccountHolder(ctx, CreateAccountHolderParams{
+		AccountID:   account.ID,
+		UserID:      holder.ID,
+		CanInitiate: true,
+		SpendLimit:  100,
+	})
+	require.NoError(t, err)
+
+	access, err := GetAccountAccess(ctx, store, account, owner.ID, util.RoleCustomer)
+	require.NoError(t, err)
+	require.True(t, access.Can(PermAll))
+	require.False(t, access.NeedsApproval(1000))
+
+	access, err = GetAccountAccess(ctx, store, account, holder.ID, util.RoleCustomer)
+	require.NoError(t, err)
+	require.True(t, access.Can(PermView|PermInitiate))
+	require.False(t, access.Can(PermApprove))
+	require.False(t, access.Can(PermManage))
+	require.False(t, access.NeedsApproval(100))
+	require.True(t, access.NeedsApproval(101))
+
+	// staff read any account but don't move money out of it
+	for _, role := range []string{util.RoleTeller, util.RoleAdmin, util.RoleAuditor} {
+		access, err = GetAccountAccess(ctx, store, account, newUser().ID, role)
+		require.NoError(t, err)
+		require.True(t, access.Can(PermView))
+		require.False(t, access.Can(PermInitiate))
+	}
+
+	access, err = GetAccountAccess(ctx, store, account, newUser().ID, util.RoleCustomer)
+	require.NoError(t, err)
+	require.False(t, access.Can(PermView))
+}
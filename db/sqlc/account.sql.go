@@ -15,7 +15,7 @@ const addBalanceAccount = `-- name: AddBalanceAccount :one
 UPDATE accounts
 SET balance = balance + $1
 WHERE id = $2
-RETURNING id, owner_id, balance, currency, created_at
+RETURNING id, owner_id, balance, currency, created_at, tier, account_type, status, closed_at, number
 `
 
 type AddBalanceAccountParams struct {
@@ -32,6 +32,36 @@ func (q *Queries) AddBalanceAccount(ctx context.Context, arg AddBalanceAccountPa
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.Tier,
+		&i.AccountType,
+		&i.Status,
+		&i.ClosedAt,
+		&i.Number,
+	)
+	return i, err
+}
+
+const closeAccount = `-- name: CloseAccount :one
+UPDATE accounts
+SET status = 'closed', closed_at = now()
+WHERE id = $1
+RETURNING id, owner_id, balance, currency, created_at, tier, account_type, status, closed_at, number
+`
+
+func (q *Queries) CloseAccount(ctx context.Context, id int64) (Account, error) {
+	row := q.queryRow(ctx, q.closeAccountStmt, closeAccount, id)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.OwnerID,
+		&i.Balance,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.Tier,
+		&i.AccountType,
+		&i.Status,
+		&i.ClosedAt,
+		&i.Number,
 	)
 	return i, err
 }
@@ -39,23 +69,23 @@ func (q *Queries) AddBalanceAccount(ctx context.Context, arg AddBalanceAccountPa
 const createAccount = `-- name: CreateAccount :one
 INSERT INTO accounts (
     owner_id,
-    balance,
-    currency
+    currency,
+    account_type
 ) VALUES (
     $1,
     $2,
     $3
-) RETURNING id, owner_id, balance, currency, created_at
+) RETURNING id, owner_id, balance, currency, created_at, tier, account_type, status, closed_at, number
 `
 
 type CreateAccountParams struct {
-	OwnerID  uuid.UUID `json:"owner_id"`
-	Balance  int64     `json:"balance"`
-	Currency string    `json:"currency"`
+	OwnerID     uuid.UUID `json:"owner_id"`
+	Currency    string    `json:"currency"`
+	AccountType string    `json:"account_type"`
 }
 
 func (q *Queries) CreateAccount(ctx context.Context, arg CreateAccountParams) (Account, error) {
-	row := q.queryRow(ctx, q.createAccountStmt, createAccount, arg.OwnerID, arg.Balance, arg.Currency)
+	row := q.queryRow(ctx, q.createAccountStmt, createAccount, arg.OwnerID, arg.Currency, arg.AccountType)
 	var i Account
 	err := row.Scan(
 		&i.ID,
@@ -63,21 +93,17 @@ func (q *Queries) CreateAccount(ctx context.Context, arg CreateAccountParams) (A
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.Tier,
+		&i.AccountType,
+		&i.Status,
+		&i.ClosedAt,
+		&i.Number,
 	)
 	return i, err
 }
 
-const deleteAccount = `-- name: DeleteAccount :exec
-DELETE FROM accounts WHERE id = $1
-`
-
-func (q *Queries) DeleteAccount(ctx context.Context, id int64) error {
-	_, err := q.exec(ctx, q.deleteAccountStmt, deleteAccount, id)
-	return err
-}
-
 const fetchAccounts = `-- name: FetchAccounts :many
-SELECT id, owner_id, balance, currency, created_at FROM accounts
+SELECT id, owner_id, balance, currency, created_at, tier, account_type, status, closed_at, number FROM accounts
 ORDER BY id
 LIMIT $1
 OFFSET $2
@@ -103,6 +129,60 @@ func (q *Queries) FetchAccounts(ctx context.Context, arg FetchAccountsParams) ([
 			&i.Balance,
 			&i.Currency,
 			&i.CreatedAt,
+			&i.Tier,
+			&i.AccountType,
+			&i.Status,
+			&i.ClosedAt,
+			&i.Number,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const fetchAccountsByOwner = `-- name: FetchAccountsByOwner :many
+SELECT id, owner_id, balance, currency, created_at, tier, account_type, status, closed_at, number FROM accounts
+WHERE owner_id = $1
+    OR id IN (SELECT account_id FROM account_holders WHERE user_id = $1)
+ORDER BY id
+LIMIT $2
+OFFSET $3
+`
+
+type FetchAccountsByOwnerParams struct {
+	OwnerID uuid.UUID `json:"owner_id"`
+	Limit   int32     `json:"limit"`
+	Offset  int32     `json:"offset"`
+}
+
+func (q *Queries) FetchAccountsByOwner(ctx context.Context, arg FetchAccountsByOwnerParams) ([]Account, error) {
+	rows, err := q.query(ctx, q.fetchAccountsByOwnerStmt, fetchAccountsByOwner, arg.OwnerID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Account{}
+	for rows.Next() {
+		var i Account
+		if err := rows.Scan(
+			&i.ID,
+			&i.OwnerID,
+			&i.Balance,
+			&i.Currency,
+			&i.CreatedAt,
+			&i.Tier,
+			&i.AccountType,
+			&i.Status,
+			&i.ClosedAt,
+			&i.Number,
 		); err != nil {
 			return nil, err
 		}
@@ -118,7 +198,7 @@ func (q *Queries) FetchAccounts(ctx context.Context, arg FetchAccountsParams) ([
 }
 
 const getAccount = `-- name: GetAccount :one
-SELECT id, owner_id, balance, currency, created_at FROM accounts
+SELECT id, owner_id, balance, currency, created_at, tier, account_type, status, closed_at, number FROM accounts
 WHERE id = $1 LIMIT 1
 `
 
@@ -131,12 +211,40 @@ func (q *Queries) GetAccount(ctx context.Context, id int64) (Account, error) {
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.Tier,
+		&i.AccountType,
+		&i.Status,
+		&i.ClosedAt,
+		&i.Number,
+	)
+	return i, err
+}
+
+const getAccountByNumber = `-- name: GetAccountByNumber :one
+SELECT id, owner_id, balance, currency, created_at, tier, account_type, status, closed_at, number FROM accounts
+WHERE number = $1 LIMIT 1
+`
+
+func (q *Queries) GetAccountByNumber(ctx context.Context, number string) (Account, error) {
+	row := q.queryRow(ctx, q.getAccountByNumberStmt, getAccountByNumber, number)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.OwnerID,
+		&i.Balance,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.Tier,
+		&i.AccountType,
+		&i.Status,
+		&i.ClosedAt,
+		&i.Number,
 	)
 	return i, err
 }
 
 const getAccountForUpdate = `-- name: GetAccountForUpdate :one
-SELECT id, owner_id, balance, currency, created_at FROM accounts
+SELECT id, owner_id, balance, currency, created_at, tier, account_type, status, closed_at, number FROM accounts
 WHERE id = $1 LIMIT 1
 FOR NO KEY UPDATE
 `
@@ -150,6 +258,70 @@ func (q *Queries) GetAccountForUpdate(ctx context.Context, id int64) (Account, e
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.Tier,
+		&i.AccountType,
+		&i.Status,
+		&i.ClosedAt,
+		&i.Number,
+	)
+	return i, err
+}
+
+const getHouseAccount = `-- name: GetHouseAccount :one
+SELECT id, owner_id, balance, currency, created_at, tier, account_type, status, closed_at, number FROM accounts
+WHERE account_type = $1 AND currency = $2
+LIMIT 1
+`
+
+type GetHouseAccountParams struct {
+	AccountType string `json:"account_type"`
+	Currency    string `json:"currency"`
+}
+
+func (q *Queries) GetHouseAccount(ctx context.Context, arg GetHouseAccountParams) (Account, error) {
+	row := q.queryRow(ctx, q.getHouseAccountStmt, getHouseAccount, arg.AccountType, arg.Currency)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.OwnerID,
+		&i.Balance,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.Tier,
+		&i.AccountType,
+		&i.Status,
+		&i.ClosedAt,
+		&i.Number,
+	)
+	return i, err
+}
+
+const updateAccountStatus = `-- name: UpdateAccountStatus :one
+UPDATE accounts
+SET status = $2
+WHERE id = $1
+RETURNING id, owner_id, balance, currency, created_at, tier, account_type, status, closed_at, number
+`
+
+type UpdateAccountStatusParams struct {
+	ID     int64  `json:"id"`
+	Status string `json:"status"`
+}
+
+func (q *Queries) UpdateAccountStatus(ctx context.Context, arg UpdateAccountStatusParams) (Account, error) {
+	row := q.queryRow(ctx, q.updateAccountStatusStmt, updateAccountStatus, arg.ID, arg.Status)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.OwnerID,
+		&i.Balance,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.Tier,
+		&i.AccountType,
+		&i.Status,
+		&i.ClosedAt,
+		&i.Number,
 	)
 	return i, err
 }
@@ -158,7 +330,7 @@ const updateBalanceAccount = `-- name: UpdateBalanceAccount :one
 UPDATE accounts
 SET balance = $2
 WHERE id = $1
-RETURNING id, owner_id, balance, currency, created_at
+RETURNING id, owner_id, balance, currency, created_at, tier, account_type, status, closed_at, number
 `
 
 type UpdateBalanceAccountParams struct {
@@ -175,6 +347,11 @@ func (q *Queries) UpdateBalanceAccount(ctx context.Context, arg UpdateBalanceAcc
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.Tier,
+		&i.AccountType,
+		&i.Status,
+		&i.ClosedAt,
+		&i.Number,
 	)
 	return i, err
 }
@@ -0,0 +1,183 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const (
+	AccountStatusActive  = "active"
+	AccountStatusFrozen  = "frozen"
+	AccountStatusDormant = "dormant"
+	AccountStatusClosed  = "closed"
+)
+
+// accountTransitions lists, for every status, the statuses it can be
+// reached from. Frozen accounts must be unfrozen before they are closed and
+// closed accounts never change again.
+var accountTransitions = map[string][]string{
+	AccountStatusActive:  {AccountStatusFrozen, AccountStatusDormant},
+	AccountStatusFrozen:  {AccountStatusActive, AccountStatusDormant},
+	AccountStatusDormant: {AccountStatusActive},
+	AccountStatusClosed:  {AccountStatusActive, AccountStatusDormant},
+}
+
+// AccountStatusError is returned when an operation is refused because of
+// the status of one of the accounts involved.
+type AccountStatusError struct {
+	AccountID int64  `json:"account_id"`
+	Status    string `json:"status"`
+}
+
+func (e *AccountStatusError) Error() string {
+	return fmt.Sprintf("account %d is %s", e.AccountID, e.Status)
+}
+
+// AccountTransitionError is returned when an account cannot move from its
+// current status to the requested one.
+type AccountTransitionError struct {
+	AccountID int64  `json:"account_id"`
+	From      string `json:"from"`
+	To        string `json:"to"`
+}
+
+func (e *AccountTransitionError) Error() string {
+	return fmt.Sprintf("account %d cannot go from %s to %s", e.AccountID, e.From, e.To)
+}
+
+// AccountBalanceError is returned when closing an account that still holds
+// money and no account to sweep it to was given.
+type AccountBalanceError struct {
+	AccountID int64 `json:"account_id"`
+	Balance   int64 `json:"balance"`
+}
+
+func (e *AccountBalanceError) Error() string {
+	return fmt.Sprintf("account %d has a non-zero balance of %d", e.AccountID, e.Balance)
+}
+
+// checkAccountActive refuses postings on accounts that are not active
+func checkAccountActive(account Account) error {
+	if account.Status != AccountStatusActive {
+		return &AccountStatusError{
+			AccountID: account.ID,
+			Status:    account.Status,
+		}
+	}
+	return nil
+}
+
+func checkAccountTransition(account Account, status string) error {
+	for _, from := range accountTransitions[status] {
+		if account.Status == from {
+			return nil
+		}
+	}
+	return &AccountTransitionError{
+		AccountID: account.ID,
+		From:      account.Status,
+		To:        status,
+	}
+}
+
+// SetAccountStatusTx moves an account to a new status, other than closed,
+// if the transition is allowed from its current one.
+func (s *SQLStore) SetAccountStatusTx(ctx context.Context, id int64, status string) (Account, error) {
+	var account Account
+
+	err := s.execTx(ctx, func(q Querier) error {
+		if status == AccountStatusClosed {
+			return fmt.Errorf("use CloseAccountTx to close account %d", id)
+		}
+
+		current, err := q.GetAccountForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err = checkAccountTransition(current, status); err != nil {
+			return err
+		}
+
+		account, err = q.UpdateAccountStatus(ctx, UpdateAccountStatusParams{
+			ID:     id,
+			Status: status,
+		})
+		return err
+	})
+
+	return account, err
+}
+
+type CloseAccountTxParams struct {
+	AccountID int64 `json:"account_id"`
+	// SweepToAccountID receives the remaining balance, 0 requires the
+	// account to be empty already
+	SweepToAccountID int64 `json:"sweep_to_account_id"`
+}
+
+type CloseAccountTxResult struct {
+	Account  Account                 `json:"account"`
+	Interest *InterestCapitalization `json:"interest,omitempty"`
+	Sweep    *TransferTxResult       `json:"sweep,omitempty"`
+}
+
+// CloseAccountTx closes an account, first paying out interest accrued on it
+// and sweeping any positive balance to another account. The sweep is an
+// internal transfer, so it is neither limited nor charged. Closed accounts
+// are kept with their entries.
+func (s *SQLStore) CloseAccountTx(ctx context.Context, arg CloseAccountTxParams) (CloseAccountTxResult, error) {
+	var result CloseAccountTxResult
+
+	err := s.execTx(ctx, func(q Querier) error {
+		result = CloseAccountTxResult{}
+
+		account, err := lockSourceAccount(ctx, q, arg.AccountID)
+		if err != nil {
+			return err
+		}
+		if err = checkAccountTransition(account, AccountStatusClosed); err != nil {
+			return err
+		}
+
+		pots, err := q.CountOpenPots(ctx, account.ID)
+		if err != nil {
+			return err
+		}
+		if pots > 0 {
+			return ErrAccountHasPots
+		}
+
+		result.Interest, err = capitalizeInterest(ctx, q, account, startOfDay(time.Now()))
+		if err != nil {
+			return err
+		}
+		if result.Interest != nil {
+			account.Balance += result.Interest.Amount
+		}
+
+		if account.Balance != 0 {
+			if account.Balance < 0 || arg.SweepToAccountID == 0 {
+				return &AccountBalanceError{
+					AccountID: account.ID,
+					Balance:   account.Balance,
+				}
+			}
+
+			sweep, err := transfer(ctx, q, TransferTxParams{
+				FromAccountID: account.ID,
+				ToAccountID:   arg.SweepToAccountID,
+				Amount:        account.Balance,
+			}, true)
+			if err != nil {
+				return err
+			}
+			result.Sweep = &sweep
+		}
+
+		result.Account, err = q.CloseAccount(ctx, account.ID)
+		return err
+	})
+
+	return result, err
+}
@@ -0,0 +1,113 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckAccountTransition(t *testing.T) {
+	testCases := []struct {
+		from, to string
+		ok       bool
+	}{
+		{AccountStatusActive, AccountStatusFrozen, true},
+		{AccountStatusFrozen, AccountStatusActive, true},
+		{AccountStatusDormant, AccountStatusActive, true},
+		{AccountStatusActive, AccountStatusDormant, true},
+		{AccountStatusActive, AccountStatusClosed, true},
+		{AccountStatusDormant, AccountStatusClosed, true},
+		{AccountStatusFrozen, AccountStatusClosed, false},
+		{AccountStatusDormant, AccountStatusFrozen, true},
+		{AccountStatusFrozen, AccountStatusDormant, false},
+		{AccountStatusClosed, AccountStatusActive, false},
+		{AccountStatusClosed, AccountStatusFrozen, false},
+		{AccountStatusActive, AccountStatusActive, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.from+"_"+tc.to, func(t *testing.T) {
+			err := checkAccountTransition(Account{ID: 1, Status: tc.from}, tc.to)
+			if tc.ok {
+				require.NoError(t, err)
+				return
+			}
+
+			var transitionErr *AccountTransitionError
+			require.ErrorAs(t, err, &transitionErr)
+			require.Equal(t, tc.from, transitionErr.From)
+			require.Equal(t, tc.to, transitionErr.To)
+		})
+	}
+}
+
+func TestSetAccountStatusTx(t *testing.T) {
+	store := NewStore(testDB)
+	account1 := createDummyAccount(t)
+
+	account2, err := store.SetAccountStatusTx(context.Background(), account1.ID, AccountStatusFrozen)
+	require.NoError(t, err)
+	require.Equal(t, AccountStatusFrozen, account2.Status)
+
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   createDummyAccount(t).ID,
+		Amount:        1,
+	})
+	var statusErr *AccountStatusError
+	require.ErrorAs(t, err, &statusErr)
+
+	account3, err := store.SetAccountStatusTx(context.Background(), account1.ID, AccountStatusActive)
+	require.NoError(t, err)
+	require.Equal(t, AccountStatusActive, account3.Status)
+}
+
+func TestCloseAccountTx(t *testing.T) {
+	store := NewStore(testDB)
+	account1 := createDummyAccount(t)
+
+	user := createDummyUser(t)
+	account2, err := testQueries.CreateAccount(context.Background(), CreateAccountParams{
+		OwnerID:     user.ID,
+		Currency:    account1.Currency,
+		AccountType: AccountTypeCurrent,
+	})
+	require.NoError(t, err)
+
+	// money left on the account and nowhere to sweep it
+	_, err = store.CloseAccountTx(context.Background(), CloseAccountTxParams{AccountID: account1.ID})
+	var balanceErr *AccountBalanceError
+	require.ErrorAs(t, err, &balanceErr)
+	require.Equal(t, account1.Balance, balanceErr.Balance)
+
+	result, err := store.CloseAccountTx(context.Background(), CloseAccountTxParams{
+		AccountID:        account1.ID,
+		SweepToAccountID: account2.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, AccountStatusClosed, result.Account.Status)
+	require.Zero(t, result.Account.Balance)
+	require.True(t, result.Account.ClosedAt.Valid)
+	require.NotNil(t, result.Sweep)
+	require.Equal(t, account1.Balance, result.Sweep.Transfer.Amount)
+	require.Equal(t, account1.Balance, result.Sweep.ToAccount.Balance)
+	require.Zero(t, result.Sweep.Fee.Amount)
+
+	// the closed account is kept and accepts no more postings
+	closed, err := testQueries.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, AccountStatusClosed, closed.Status)
+
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account2.ID,
+		ToAccountID:   account1.ID,
+		Amount:        1,
+	})
+	var statusErr *AccountStatusError
+	require.ErrorAs(t, err, &statusErr)
+
+	_, err = store.CloseAccountTx(context.Background(), CloseAccountTxParams{AccountID: account1.ID})
+	var transitionErr *AccountTransitionError
+	require.ErrorAs(t, err, &transitionErr)
+}
@@ -12,9 +12,9 @@ import (
 func createDummyAccount(t *testing.T) Account {
 	user := createDummyUser(t)
 	args := CreateAccountParams{
-		OwnerID:  user.ID,
-		Balance:  util.GenRandomMoney(),
-		Currency: util.GenRandomCurrency(),
+		OwnerID:     user.ID,
+		Currency:    util.GenRandomCurrency(),
+		AccountType: AccountTypeCurrent,
 	}
 
 	account, err := testQueries.CreateAccount(context.Background(), args)
@@ -22,12 +22,25 @@ func createDummyAccount(t *testing.T) Account {
 	require.NoError(t, err)
 	require.NotEmpty(t, account)
 
-	require.Equal(t, account.Balance, args.Balance)
+	require.Zero(t, account.Balance)
 	require.Equal(t, account.OwnerID, args.OwnerID)
 	require.Equal(t, account.Currency, args.Currency)
 
 	require.NotZero(t, account.ID)
 	require.NotZero(t, account.CreatedAt)
+	require.NoError(t, util.ValidateAccountNumber(account.Number))
+
+	return fundDummyAccount(t, account)
+}
+
+// fundDummyAccount gives a test account some money to move around
+func fundDummyAccount(t *testing.T, account Account) Account {
+	account, err := testQueries.UpdateBalanceAccount(context.Background(), UpdateBalanceAccountParams{
+		ID:      account.ID,
+		Balance: util.GenRandomNum(100000, 1000000),
+	})
+	require.NoError(t, err)
+	require.NotZero(t, account.Balance)
 
 	return account
 }
@@ -49,6 +62,14 @@ func TestGetAccount(t *testing.T) {
 	require.WithinDuration(t, account1.CreatedAt, account2.CreatedAt, time.Second)
 }
 
+func TestGetAccountByNumber(t *testing.T) {
+	account1 := createDummyAccount(t)
+	account2, err := testQueries.GetAccountByNumber(context.Background(), account1.Number)
+
+	require.NoError(t, err)
+	require.Equal(t, account1.ID, account2.ID)
+}
+
 func TestUpdateAccount(t *testing.T) {
 	account1 := createDummyAccount(t)
 
@@ -87,3 +108,29 @@ func TestFetchAccounts(t *testing.T) {
 		require.NotEmpty(t, account)
 	}
 }
+
+func TestFetchAccountsByOwner(t *testing.T) {
+	account1 := createDummyAccount(t)
+	createDummyAccount(t)
+
+	accounts, err := testQueries.FetchAccountsByOwner(context.Background(), FetchAccountsByOwnerParams{
+		OwnerID: account1.OwnerID,
+		Limit:   5,
+		Offset:  0,
+	})
+	require.NoError(t, err)
+	require.Len(t, accounts, 1)
+	require.Equal(t, account1.ID, accounts[0].ID)
+}
+
+func TestUpdateAccountStatus(t *testing.T) {
+	account1 := createDummyAccount(t)
+	require.Equal(t, AccountStatusActive, account1.Status)
+
+	account2, err := testQueries.UpdateAccountStatus(context.Background(), UpdateAccountStatusParams{
+		ID:     account1.ID,
+		Status: AccountStatusFrozen,
+	})
+	require.NoError(t, err)
+	require.Equal(t, AccountStatusFrozen, account2.Status)
+}
@@ -0,0 +1,72 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const LimitNewPayee = "new_payee"
+
+// CheckCoolingOff returns a TransferLimitError when amount, on top of what
+// was already paid to the beneficiary since it was saved, is above the new
+// payee limit of limit while the beneficiary is still cooling off. A limit
+// of 0 is unlimited.
+func (b Beneficiary) CheckCoolingOff(limit TransferLimit, paid, amount int64, now time.Time) error {
+	if !now.Before(b.CoolingOffUntil) {
+		return nil
+	}
+	if limit.NewPayeeMaxPerTransfer == 0 || paid+amount <= limit.NewPayeeMaxPerTransfer {
+		return nil
+	}
+
+	resetsAt := b.CoolingOffUntil
+	return &TransferLimitError{
+		Limit:    LimitNewPayee,
+		Scope:    LimitScopeAccount,
+		Max:      limit.NewPayeeMaxPerTransfer,
+		Used:     paid,
+		ResetsAt: &resetsAt,
+	}
+}
+
+// checkCoolingOff must run inside the transfer transaction, after the
+// source account has been locked with lockSourceAccount. When the owner of
+// from saved to as a beneficiary that is still cooling off, the payments to
+// it are capped by the new payee limit however the destination was named.
+// The beneficiary stays locked until the transfer commits, so concurrent
+// payments to it are counted against the same limit.
+func checkCoolingOff(ctx context.Context, q Querier, from, to Account, amount int64, now time.Time) error {
+	beneficiary, err := q.GetCoolingOffBeneficiaryForUpdate(ctx, GetCoolingOffBeneficiaryForUpdateParams{
+		OwnerID:   from.OwnerID,
+		AccountID: to.ID,
+		Now:       now,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		return err
+	}
+
+	limit, err := q.GetTransferLimit(ctx, GetTransferLimitParams{
+		Tier:     from.Tier,
+		Currency: from.Currency,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		return err
+	}
+
+	paid, err := q.GetPayeeTransferTotal(ctx, GetPayeeTransferTotalParams{
+		OwnerID:     from.OwnerID,
+		ToAccountID: to.ID,
+		Since:       beneficiary.CreatedAt,
+	})
+	if err != nil {
+		return err
+	}
+	return beneficiary.CheckCoolingOff(limit, paid, amount, now)
+}
@@ -0,0 +1,73 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/flukis/simplebank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBeneficiaryCheckCoolingOff(t *testing.T) {
+	now := time.Date(2023, time.March, 15, 10, 30, 0, 0, time.UTC)
+	limit := TransferLimit{NewPayeeMaxPerTransfer: 1000}
+	cooling := Beneficiary{CoolingOffUntil: now.Add(time.Hour)}
+	cooled := Beneficiary{CoolingOffUntil: now}
+
+	require.NoError(t, cooling.CheckCoolingOff(limit, 0, 1000, now))
+	require.NoError(t, cooling.CheckCoolingOff(limit, 400, 600, now))
+	require.NoError(t, cooled.CheckCoolingOff(limit, 0, 1001, now))
+	require.NoError(t, cooling.CheckCoolingOff(TransferLimit{}, 0, 1001, now))
+
+	err := cooling.CheckCoolingOff(limit, 0, 1001, now)
+	var limitErr *TransferLimitError
+	require.ErrorAs(t, err, &limitErr)
+	require.Equal(t, LimitNewPayee, limitErr.Limit)
+	require.Equal(t, int64(1000), limitErr.Max)
+	require.Equal(t, int64(0), limitErr.Used)
+
+	// payments made earlier in the cooling-off count against the limit
+	err = cooling.CheckCoolingOff(limit, 600, 401, now)
+	require.ErrorAs(t, err, &limitErr)
+	require.Equal(t, int64(600), limitErr.Used)
+	require.Equal(t, cooling.CoolingOffUntil, *limitErr.ResetsAt)
+}
+
+func TestBeneficiaries(t *testing.T) {
+	user := createDummyUser(t)
+	account := createDummyAccount(t)
+
+	beneficiary, err := testQueries.CreateBeneficiary(context.Background(), CreateBeneficiaryParams{
+		OwnerID:         user.ID,
+		Nickname:        util.GenRandomString(8),
+		AccountID:       account.ID,
+		Currency:        account.Currency,
+		CoolingOffUntil: time.Now().Add(24 * time.Hour),
+	})
+	require.NoError(t, err)
+	require.Equal(t, user.ID, beneficiary.OwnerID)
+	require.Equal(t, account.ID, beneficiary.AccountID)
+
+	beneficiaries, err := testQueries.ListBeneficiaries(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.Len(t, beneficiaries, 1)
+	require.Equal(t, beneficiary.ID, beneficiaries[0].ID)
+
+	// the same account cannot be saved twice
+	_, err = testQueries.CreateBeneficiary(context.Background(), CreateBeneficiaryParams{
+		OwnerID:         user.ID,
+		Nickname:        util.GenRandomString(8),
+		AccountID:       account.ID,
+		Currency:        account.Currency,
+		CoolingOffUntil: time.Now(),
+	})
+	require.Error(t, err)
+
+	_, err = testQueries.DeleteBeneficiary(context.Background(), beneficiary.ID)
+	require.NoError(t, err)
+
+	beneficiaries, err = testQueries.ListBeneficiaries(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.Empty(t, beneficiaries)
+}
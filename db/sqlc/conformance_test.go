@@ -0,0 +1,25 @@
+package db_test
+
+import (
+	"context"
+	"testing"
+
+	db "github.com/flukis/simplebank/db/sqlc"
+	"github.com/flukis/simplebank/db/storetest"
+	"github.com/flukis/simplebank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLStoreConformance(t *testing.T) {
+	conf, err := util.LoadConfig("../..")
+	require.NoError(t, err)
+
+	conn, pool, err := db.OpenDB(context.Background(), conf)
+	require.NoError(t, err)
+	defer pool.Close()
+	defer conn.Close()
+
+	storetest.Run(t, func(t *testing.T) db.Store {
+		return db.NewStore(conn)
+	})
+}
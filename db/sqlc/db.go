@@ -27,42 +27,231 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.addBalanceAccountStmt, err = db.PrepareContext(ctx, addBalanceAccount); err != nil {
 		return nil, fmt.Errorf("error preparing query AddBalanceAccount: %w", err)
 	}
+	if q.addTellerTillCashStmt, err = db.PrepareContext(ctx, addTellerTillCash); err != nil {
+		return nil, fmt.Errorf("error preparing query AddTellerTillCash: %w", err)
+	}
+	if q.closeAccountStmt, err = db.PrepareContext(ctx, closeAccount); err != nil {
+		return nil, fmt.Errorf("error preparing query CloseAccount: %w", err)
+	}
+	if q.closeTellerTillStmt, err = db.PrepareContext(ctx, closeTellerTill); err != nil {
+		return nil, fmt.Errorf("error preparing query CloseTellerTill: %w", err)
+	}
+	if q.closeTermDepositStmt, err = db.PrepareContext(ctx, closeTermDeposit); err != nil {
+		return nil, fmt.Errorf("error preparing query CloseTermDeposit: %w", err)
+	}
+	if q.countOpenPotsStmt, err = db.PrepareContext(ctx, countOpenPots); err != nil {
+		return nil, fmt.Errorf("error preparing query CountOpenPots: %w", err)
+	}
+	if q.countUnpaidLoanInstallmentsStmt, err = db.PrepareContext(ctx, countUnpaidLoanInstallments); err != nil {
+		return nil, fmt.Errorf("error preparing query CountUnpaidLoanInstallments: %w", err)
+	}
 	if q.createAccountStmt, err = db.PrepareContext(ctx, createAccount); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateAccount: %w", err)
 	}
+	if q.createAccountHolderStmt, err = db.PrepareContext(ctx, createAccountHolder); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateAccountHolder: %w", err)
+	}
+	if q.createBeneficiaryStmt, err = db.PrepareContext(ctx, createBeneficiary); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateBeneficiary: %w", err)
+	}
+	if q.createCashTransactionStmt, err = db.PrepareContext(ctx, createCashTransaction); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateCashTransaction: %w", err)
+	}
 	if q.createEntryStmt, err = db.PrepareContext(ctx, createEntry); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateEntry: %w", err)
 	}
+	if q.createFeeScheduleStmt, err = db.PrepareContext(ctx, createFeeSchedule); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateFeeSchedule: %w", err)
+	}
+	if q.createFeeScheduleTierStmt, err = db.PrepareContext(ctx, createFeeScheduleTier); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateFeeScheduleTier: %w", err)
+	}
+	if q.createGLAccountStmt, err = db.PrepareContext(ctx, createGLAccount); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateGLAccount: %w", err)
+	}
+	if q.createIdempotencyKeyStmt, err = db.PrepareContext(ctx, createIdempotencyKey); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateIdempotencyKey: %w", err)
+	}
+	if q.createInterestAccrualStmt, err = db.PrepareContext(ctx, createInterestAccrual); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateInterestAccrual: %w", err)
+	}
+	if q.createInterestCapitalizationStmt, err = db.PrepareContext(ctx, createInterestCapitalization); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateInterestCapitalization: %w", err)
+	}
+	if q.createInterestRateStmt, err = db.PrepareContext(ctx, createInterestRate); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateInterestRate: %w", err)
+	}
+	if q.createJournalEntryStmt, err = db.PrepareContext(ctx, createJournalEntry); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateJournalEntry: %w", err)
+	}
+	if q.createJournalLineStmt, err = db.PrepareContext(ctx, createJournalLine); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateJournalLine: %w", err)
+	}
+	if q.createLoanStmt, err = db.PrepareContext(ctx, createLoan); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateLoan: %w", err)
+	}
+	if q.createLoanInstallmentStmt, err = db.PrepareContext(ctx, createLoanInstallment); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateLoanInstallment: %w", err)
+	}
+	if q.createPaymentRequestStmt, err = db.PrepareContext(ctx, createPaymentRequest); err != nil {
+		return nil, fmt.Errorf("error preparing query CreatePaymentRequest: %w", err)
+	}
+	if q.createPotStmt, err = db.PrepareContext(ctx, createPot); err != nil {
+		return nil, fmt.Errorf("error preparing query CreatePot: %w", err)
+	}
+	if q.createTellerTillStmt, err = db.PrepareContext(ctx, createTellerTill); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateTellerTill: %w", err)
+	}
+	if q.createTermDepositStmt, err = db.PrepareContext(ctx, createTermDeposit); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateTermDeposit: %w", err)
+	}
+	if q.createTermDepositRateStmt, err = db.PrepareContext(ctx, createTermDepositRate); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateTermDepositRate: %w", err)
+	}
 	if q.createTransferStmt, err = db.PrepareContext(ctx, createTransfer); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateTransfer: %w", err)
 	}
+	if q.createTransferApprovalStmt, err = db.PrepareContext(ctx, createTransferApproval); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateTransferApproval: %w", err)
+	}
 	if q.createUserStmt, err = db.PrepareContext(ctx, createUser); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateUser: %w", err)
 	}
-	if q.deleteAccountStmt, err = db.PrepareContext(ctx, deleteAccount); err != nil {
-		return nil, fmt.Errorf("error preparing query DeleteAccount: %w", err)
+	if q.decidePaymentRequestStmt, err = db.PrepareContext(ctx, decidePaymentRequest); err != nil {
+		return nil, fmt.Errorf("error preparing query DecidePaymentRequest: %w", err)
+	}
+	if q.decideTransferApprovalStmt, err = db.PrepareContext(ctx, decideTransferApproval); err != nil {
+		return nil, fmt.Errorf("error preparing query DecideTransferApproval: %w", err)
+	}
+	if q.deleteAccountHolderStmt, err = db.PrepareContext(ctx, deleteAccountHolder); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteAccountHolder: %w", err)
+	}
+	if q.deleteBeneficiaryStmt, err = db.PrepareContext(ctx, deleteBeneficiary); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteBeneficiary: %w", err)
+	}
+	if q.deleteIdempotencyKeyStmt, err = db.PrepareContext(ctx, deleteIdempotencyKey); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteIdempotencyKey: %w", err)
+	}
+	if q.deleteIdempotencyKeysBeforeStmt, err = db.PrepareContext(ctx, deleteIdempotencyKeysBefore); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteIdempotencyKeysBefore: %w", err)
 	}
 	if q.fetchAccountsStmt, err = db.PrepareContext(ctx, fetchAccounts); err != nil {
 		return nil, fmt.Errorf("error preparing query FetchAccounts: %w", err)
 	}
+	if q.fetchAccountsByOwnerStmt, err = db.PrepareContext(ctx, fetchAccountsByOwner); err != nil {
+		return nil, fmt.Errorf("error preparing query FetchAccountsByOwner: %w", err)
+	}
 	if q.fetchEntriesStmt, err = db.PrepareContext(ctx, fetchEntries); err != nil {
 		return nil, fmt.Errorf("error preparing query FetchEntries: %w", err)
 	}
 	if q.fetchTransferStmt, err = db.PrepareContext(ctx, fetchTransfer); err != nil {
 		return nil, fmt.Errorf("error preparing query FetchTransfer: %w", err)
 	}
+	if q.fetchUsersStmt, err = db.PrepareContext(ctx, fetchUsers); err != nil {
+		return nil, fmt.Errorf("error preparing query FetchUsers: %w", err)
+	}
 	if q.getAccountStmt, err = db.PrepareContext(ctx, getAccount); err != nil {
 		return nil, fmt.Errorf("error preparing query GetAccount: %w", err)
 	}
+	if q.getAccountByNumberStmt, err = db.PrepareContext(ctx, getAccountByNumber); err != nil {
+		return nil, fmt.Errorf("error preparing query GetAccountByNumber: %w", err)
+	}
 	if q.getAccountForUpdateStmt, err = db.PrepareContext(ctx, getAccountForUpdate); err != nil {
 		return nil, fmt.Errorf("error preparing query GetAccountForUpdate: %w", err)
 	}
+	if q.getAccountHolderStmt, err = db.PrepareContext(ctx, getAccountHolder); err != nil {
+		return nil, fmt.Errorf("error preparing query GetAccountHolder: %w", err)
+	}
+	if q.getAccountTransferUsageStmt, err = db.PrepareContext(ctx, getAccountTransferUsage); err != nil {
+		return nil, fmt.Errorf("error preparing query GetAccountTransferUsage: %w", err)
+	}
+	if q.getBeneficiaryStmt, err = db.PrepareContext(ctx, getBeneficiary); err != nil {
+		return nil, fmt.Errorf("error preparing query GetBeneficiary: %w", err)
+	}
+	if q.getCoolingOffBeneficiaryForUpdateStmt, err = db.PrepareContext(ctx, getCoolingOffBeneficiaryForUpdate); err != nil {
+		return nil, fmt.Errorf("error preparing query GetCoolingOffBeneficiaryForUpdate: %w", err)
+	}
 	if q.getEntryStmt, err = db.PrepareContext(ctx, getEntry); err != nil {
 		return nil, fmt.Errorf("error preparing query GetEntry: %w", err)
 	}
+	if q.getFeeScheduleStmt, err = db.PrepareContext(ctx, getFeeSchedule); err != nil {
+		return nil, fmt.Errorf("error preparing query GetFeeSchedule: %w", err)
+	}
+	if q.getGLAccountForAccountStmt, err = db.PrepareContext(ctx, getGLAccountForAccount); err != nil {
+		return nil, fmt.Errorf("error preparing query GetGLAccountForAccount: %w", err)
+	}
+	if q.getHouseAccountStmt, err = db.PrepareContext(ctx, getHouseAccount); err != nil {
+		return nil, fmt.Errorf("error preparing query GetHouseAccount: %w", err)
+	}
+	if q.getIdempotencyKeyStmt, err = db.PrepareContext(ctx, getIdempotencyKey); err != nil {
+		return nil, fmt.Errorf("error preparing query GetIdempotencyKey: %w", err)
+	}
+	if q.getLastInterestCapitalizationStmt, err = db.PrepareContext(ctx, getLastInterestCapitalization); err != nil {
+		return nil, fmt.Errorf("error preparing query GetLastInterestCapitalization: %w", err)
+	}
+	if q.getLoanStmt, err = db.PrepareContext(ctx, getLoan); err != nil {
+		return nil, fmt.Errorf("error preparing query GetLoan: %w", err)
+	}
+	if q.getLoanForUpdateStmt, err = db.PrepareContext(ctx, getLoanForUpdate); err != nil {
+		return nil, fmt.Errorf("error preparing query GetLoanForUpdate: %w", err)
+	}
+	if q.getLoanInstallmentForUpdateStmt, err = db.PrepareContext(ctx, getLoanInstallmentForUpdate); err != nil {
+		return nil, fmt.Errorf("error preparing query GetLoanInstallmentForUpdate: %w", err)
+	}
+	if q.getOpenTellerTillForUpdateStmt, err = db.PrepareContext(ctx, getOpenTellerTillForUpdate); err != nil {
+		return nil, fmt.Errorf("error preparing query GetOpenTellerTillForUpdate: %w", err)
+	}
+	if q.getOwnerTransferUsageStmt, err = db.PrepareContext(ctx, getOwnerTransferUsage); err != nil {
+		return nil, fmt.Errorf("error preparing query GetOwnerTransferUsage: %w", err)
+	}
+	if q.getPayeeTransferTotalStmt, err = db.PrepareContext(ctx, getPayeeTransferTotal); err != nil {
+		return nil, fmt.Errorf("error preparing query GetPayeeTransferTotal: %w", err)
+	}
+	if q.getPaymentRequestStmt, err = db.PrepareContext(ctx, getPaymentRequest); err != nil {
+		return nil, fmt.Errorf("error preparing query GetPaymentRequest: %w", err)
+	}
+	if q.getPaymentRequestForUpdateStmt, err = db.PrepareContext(ctx, getPaymentRequestForUpdate); err != nil {
+		return nil, fmt.Errorf("error preparing query GetPaymentRequestForUpdate: %w", err)
+	}
+	if q.getPendingInterestStmt, err = db.PrepareContext(ctx, getPendingInterest); err != nil {
+		return nil, fmt.Errorf("error preparing query GetPendingInterest: %w", err)
+	}
+	if q.getPotStmt, err = db.PrepareContext(ctx, getPot); err != nil {
+		return nil, fmt.Errorf("error preparing query GetPot: %w", err)
+	}
+	if q.getRoundUpPotStmt, err = db.PrepareContext(ctx, getRoundUpPot); err != nil {
+		return nil, fmt.Errorf("error preparing query GetRoundUpPot: %w", err)
+	}
+	if q.getTellerTillStmt, err = db.PrepareContext(ctx, getTellerTill); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTellerTill: %w", err)
+	}
+	if q.getTellerTillForUpdateStmt, err = db.PrepareContext(ctx, getTellerTillForUpdate); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTellerTillForUpdate: %w", err)
+	}
+	if q.getTermDepositStmt, err = db.PrepareContext(ctx, getTermDeposit); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTermDeposit: %w", err)
+	}
+	if q.getTermDepositForUpdateStmt, err = db.PrepareContext(ctx, getTermDepositForUpdate); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTermDepositForUpdate: %w", err)
+	}
+	if q.getTermDepositRateStmt, err = db.PrepareContext(ctx, getTermDepositRate); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTermDepositRate: %w", err)
+	}
 	if q.getTransferStmt, err = db.PrepareContext(ctx, getTransfer); err != nil {
 		return nil, fmt.Errorf("error preparing query GetTransfer: %w", err)
 	}
+	if q.getTransferApprovalStmt, err = db.PrepareContext(ctx, getTransferApproval); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTransferApproval: %w", err)
+	}
+	if q.getTransferApprovalForUpdateStmt, err = db.PrepareContext(ctx, getTransferApprovalForUpdate); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTransferApprovalForUpdate: %w", err)
+	}
+	if q.getTransferLimitStmt, err = db.PrepareContext(ctx, getTransferLimit); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTransferLimit: %w", err)
+	}
+	if q.getTrialBalanceStmt, err = db.PrepareContext(ctx, getTrialBalance); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTrialBalance: %w", err)
+	}
 	if q.getUserStmt, err = db.PrepareContext(ctx, getUser); err != nil {
 		return nil, fmt.Errorf("error preparing query GetUser: %w", err)
 	}
@@ -72,9 +261,87 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.getUserByUsernameStmt, err = db.PrepareContext(ctx, getUserByUsername); err != nil {
 		return nil, fmt.Errorf("error preparing query GetUserByUsername: %w", err)
 	}
+	if q.getUserForUpdateStmt, err = db.PrepareContext(ctx, getUserForUpdate); err != nil {
+		return nil, fmt.Errorf("error preparing query GetUserForUpdate: %w", err)
+	}
+	if q.listAccountHoldersStmt, err = db.PrepareContext(ctx, listAccountHolders); err != nil {
+		return nil, fmt.Errorf("error preparing query ListAccountHolders: %w", err)
+	}
+	if q.listAccountsWithPendingInterestStmt, err = db.PrepareContext(ctx, listAccountsWithPendingInterest); err != nil {
+		return nil, fmt.Errorf("error preparing query ListAccountsWithPendingInterest: %w", err)
+	}
+	if q.listBeneficiariesStmt, err = db.PrepareContext(ctx, listBeneficiaries); err != nil {
+		return nil, fmt.Errorf("error preparing query ListBeneficiaries: %w", err)
+	}
+	if q.listCashTransactionsByTillStmt, err = db.PrepareContext(ctx, listCashTransactionsByTill); err != nil {
+		return nil, fmt.Errorf("error preparing query ListCashTransactionsByTill: %w", err)
+	}
+	if q.listDueLoanInstallmentsStmt, err = db.PrepareContext(ctx, listDueLoanInstallments); err != nil {
+		return nil, fmt.Errorf("error preparing query ListDueLoanInstallments: %w", err)
+	}
+	if q.listFeeScheduleTiersStmt, err = db.PrepareContext(ctx, listFeeScheduleTiers); err != nil {
+		return nil, fmt.Errorf("error preparing query ListFeeScheduleTiers: %w", err)
+	}
+	if q.listGLAccountsStmt, err = db.PrepareContext(ctx, listGLAccounts); err != nil {
+		return nil, fmt.Errorf("error preparing query ListGLAccounts: %w", err)
+	}
+	if q.listInterestAccrualsStmt, err = db.PrepareContext(ctx, listInterestAccruals); err != nil {
+		return nil, fmt.Errorf("error preparing query ListInterestAccruals: %w", err)
+	}
+	if q.listInterestBearingAccountsStmt, err = db.PrepareContext(ctx, listInterestBearingAccounts); err != nil {
+		return nil, fmt.Errorf("error preparing query ListInterestBearingAccounts: %w", err)
+	}
+	if q.listJournalEntriesByReferenceStmt, err = db.PrepareContext(ctx, listJournalEntriesByReference); err != nil {
+		return nil, fmt.Errorf("error preparing query ListJournalEntriesByReference: %w", err)
+	}
+	if q.listJournalLinesStmt, err = db.PrepareContext(ctx, listJournalLines); err != nil {
+		return nil, fmt.Errorf("error preparing query ListJournalLines: %w", err)
+	}
+	if q.listLoanInstallmentsStmt, err = db.PrepareContext(ctx, listLoanInstallments); err != nil {
+		return nil, fmt.Errorf("error preparing query ListLoanInstallments: %w", err)
+	}
+	if q.listMaturingTermDepositsStmt, err = db.PrepareContext(ctx, listMaturingTermDeposits); err != nil {
+		return nil, fmt.Errorf("error preparing query ListMaturingTermDeposits: %w", err)
+	}
+	if q.listPaymentRequestsStmt, err = db.PrepareContext(ctx, listPaymentRequests); err != nil {
+		return nil, fmt.Errorf("error preparing query ListPaymentRequests: %w", err)
+	}
+	if q.listPendingTransferApprovalsStmt, err = db.PrepareContext(ctx, listPendingTransferApprovals); err != nil {
+		return nil, fmt.Errorf("error preparing query ListPendingTransferApprovals: %w", err)
+	}
+	if q.listPotsByParentStmt, err = db.PrepareContext(ctx, listPotsByParent); err != nil {
+		return nil, fmt.Errorf("error preparing query ListPotsByParent: %w", err)
+	}
+	if q.listTermDepositRatesStmt, err = db.PrepareContext(ctx, listTermDepositRates); err != nil {
+		return nil, fmt.Errorf("error preparing query ListTermDepositRates: %w", err)
+	}
+	if q.markInterestAccrualsCapitalizedStmt, err = db.PrepareContext(ctx, markInterestAccrualsCapitalized); err != nil {
+		return nil, fmt.Errorf("error preparing query MarkInterestAccrualsCapitalized: %w", err)
+	}
+	if q.markLoanInstallmentOverdueStmt, err = db.PrepareContext(ctx, markLoanInstallmentOverdue); err != nil {
+		return nil, fmt.Errorf("error preparing query MarkLoanInstallmentOverdue: %w", err)
+	}
+	if q.markLoanInstallmentPaidStmt, err = db.PrepareContext(ctx, markLoanInstallmentPaid); err != nil {
+		return nil, fmt.Errorf("error preparing query MarkLoanInstallmentPaid: %w", err)
+	}
+	if q.saveIdempotentResponseStmt, err = db.PrepareContext(ctx, saveIdempotentResponse); err != nil {
+		return nil, fmt.Errorf("error preparing query SaveIdempotentResponse: %w", err)
+	}
+	if q.takeOverIdempotencyKeyStmt, err = db.PrepareContext(ctx, takeOverIdempotencyKey); err != nil {
+		return nil, fmt.Errorf("error preparing query TakeOverIdempotencyKey: %w", err)
+	}
+	if q.updateAccountStatusStmt, err = db.PrepareContext(ctx, updateAccountStatus); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateAccountStatus: %w", err)
+	}
 	if q.updateBalanceAccountStmt, err = db.PrepareContext(ctx, updateBalanceAccount); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateBalanceAccount: %w", err)
 	}
+	if q.updateLoanStatusStmt, err = db.PrepareContext(ctx, updateLoanStatus); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateLoanStatus: %w", err)
+	}
+	if q.updateUserRoleStmt, err = db.PrepareContext(ctx, updateUserRole); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateUserRole: %w", err)
+	}
 	return &q, nil
 }
 
@@ -85,29 +352,184 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing addBalanceAccountStmt: %w", cerr)
 		}
 	}
+	if q.addTellerTillCashStmt != nil {
+		if cerr := q.addTellerTillCashStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing addTellerTillCashStmt: %w", cerr)
+		}
+	}
+	if q.closeAccountStmt != nil {
+		if cerr := q.closeAccountStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing closeAccountStmt: %w", cerr)
+		}
+	}
+	if q.closeTellerTillStmt != nil {
+		if cerr := q.closeTellerTillStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing closeTellerTillStmt: %w", cerr)
+		}
+	}
+	if q.closeTermDepositStmt != nil {
+		if cerr := q.closeTermDepositStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing closeTermDepositStmt: %w", cerr)
+		}
+	}
+	if q.countOpenPotsStmt != nil {
+		if cerr := q.countOpenPotsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countOpenPotsStmt: %w", cerr)
+		}
+	}
+	if q.countUnpaidLoanInstallmentsStmt != nil {
+		if cerr := q.countUnpaidLoanInstallmentsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countUnpaidLoanInstallmentsStmt: %w", cerr)
+		}
+	}
 	if q.createAccountStmt != nil {
 		if cerr := q.createAccountStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createAccountStmt: %w", cerr)
 		}
 	}
+	if q.createAccountHolderStmt != nil {
+		if cerr := q.createAccountHolderStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createAccountHolderStmt: %w", cerr)
+		}
+	}
+	if q.createBeneficiaryStmt != nil {
+		if cerr := q.createBeneficiaryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createBeneficiaryStmt: %w", cerr)
+		}
+	}
+	if q.createCashTransactionStmt != nil {
+		if cerr := q.createCashTransactionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createCashTransactionStmt: %w", cerr)
+		}
+	}
 	if q.createEntryStmt != nil {
 		if cerr := q.createEntryStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createEntryStmt: %w", cerr)
 		}
 	}
+	if q.createFeeScheduleStmt != nil {
+		if cerr := q.createFeeScheduleStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createFeeScheduleStmt: %w", cerr)
+		}
+	}
+	if q.createFeeScheduleTierStmt != nil {
+		if cerr := q.createFeeScheduleTierStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createFeeScheduleTierStmt: %w", cerr)
+		}
+	}
+	if q.createGLAccountStmt != nil {
+		if cerr := q.createGLAccountStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createGLAccountStmt: %w", cerr)
+		}
+	}
+	if q.createIdempotencyKeyStmt != nil {
+		if cerr := q.createIdempotencyKeyStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createIdempotencyKeyStmt: %w", cerr)
+		}
+	}
+	if q.createInterestAccrualStmt != nil {
+		if cerr := q.createInterestAccrualStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createInterestAccrualStmt: %w", cerr)
+		}
+	}
+	if q.createInterestCapitalizationStmt != nil {
+		if cerr := q.createInterestCapitalizationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createInterestCapitalizationStmt: %w", cerr)
+		}
+	}
+	if q.createInterestRateStmt != nil {
+		if cerr := q.createInterestRateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createInterestRateStmt: %w", cerr)
+		}
+	}
+	if q.createJournalEntryStmt != nil {
+		if cerr := q.createJournalEntryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createJournalEntryStmt: %w", cerr)
+		}
+	}
+	if q.createJournalLineStmt != nil {
+		if cerr := q.createJournalLineStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createJournalLineStmt: %w", cerr)
+		}
+	}
+	if q.createLoanStmt != nil {
+		if cerr := q.createLoanStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createLoanStmt: %w", cerr)
+		}
+	}
+	if q.createLoanInstallmentStmt != nil {
+		if cerr := q.createLoanInstallmentStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createLoanInstallmentStmt: %w", cerr)
+		}
+	}
+	if q.createPaymentRequestStmt != nil {
+		if cerr := q.createPaymentRequestStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createPaymentRequestStmt: %w", cerr)
+		}
+	}
+	if q.createPotStmt != nil {
+		if cerr := q.createPotStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createPotStmt: %w", cerr)
+		}
+	}
+	if q.createTellerTillStmt != nil {
+		if cerr := q.createTellerTillStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createTellerTillStmt: %w", cerr)
+		}
+	}
+	if q.createTermDepositStmt != nil {
+		if cerr := q.createTermDepositStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createTermDepositStmt: %w", cerr)
+		}
+	}
+	if q.createTermDepositRateStmt != nil {
+		if cerr := q.createTermDepositRateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createTermDepositRateStmt: %w", cerr)
+		}
+	}
 	if q.createTransferStmt != nil {
 		if cerr := q.createTransferStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createTransferStmt: %w", cerr)
 		}
 	}
+	if q.createTransferApprovalStmt != nil {
+		if cerr := q.createTransferApprovalStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createTransferApprovalStmt: %w", cerr)
+		}
+	}
 	if q.createUserStmt != nil {
 		if cerr := q.createUserStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createUserStmt: %w", cerr)
 		}
 	}
-	if q.deleteAccountStmt != nil {
-		if cerr := q.deleteAccountStmt.Close(); cerr != nil {
-			err = fmt.Errorf("error closing deleteAccountStmt: %w", cerr)
+	if q.decidePaymentRequestStmt != nil {
+		if cerr := q.decidePaymentRequestStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing decidePaymentRequestStmt: %w", cerr)
+		}
+	}
+	if q.decideTransferApprovalStmt != nil {
+		if cerr := q.decideTransferApprovalStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing decideTransferApprovalStmt: %w", cerr)
+		}
+	}
+	if q.deleteAccountHolderStmt != nil {
+		if cerr := q.deleteAccountHolderStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteAccountHolderStmt: %w", cerr)
+		}
+	}
+	if q.deleteBeneficiaryStmt != nil {
+		if cerr := q.deleteBeneficiaryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteBeneficiaryStmt: %w", cerr)
+		}
+	}
+	if q.deleteIdempotencyKeyStmt != nil {
+		if cerr := q.deleteIdempotencyKeyStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteIdempotencyKeyStmt: %w", cerr)
+		}
+	}
+	if q.deleteIdempotencyKeysBeforeStmt != nil {
+		if cerr := q.deleteIdempotencyKeysBeforeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteIdempotencyKeysBeforeStmt: %w", cerr)
 		}
 	}
 	if q.fetchAccountsStmt != nil {
@@ -115,6 +537,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing fetchAccountsStmt: %w", cerr)
 		}
 	}
+	if q.fetchAccountsByOwnerStmt != nil {
+		if cerr := q.fetchAccountsByOwnerStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing fetchAccountsByOwnerStmt: %w", cerr)
+		}
+	}
 	if q.fetchEntriesStmt != nil {
 		if cerr := q.fetchEntriesStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing fetchEntriesStmt: %w", cerr)
@@ -125,26 +552,181 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing fetchTransferStmt: %w", cerr)
 		}
 	}
+	if q.fetchUsersStmt != nil {
+		if cerr := q.fetchUsersStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing fetchUsersStmt: %w", cerr)
+		}
+	}
 	if q.getAccountStmt != nil {
 		if cerr := q.getAccountStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getAccountStmt: %w", cerr)
 		}
 	}
+	if q.getAccountByNumberStmt != nil {
+		if cerr := q.getAccountByNumberStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getAccountByNumberStmt: %w", cerr)
+		}
+	}
 	if q.getAccountForUpdateStmt != nil {
 		if cerr := q.getAccountForUpdateStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getAccountForUpdateStmt: %w", cerr)
 		}
 	}
+	if q.getAccountHolderStmt != nil {
+		if cerr := q.getAccountHolderStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getAccountHolderStmt: %w", cerr)
+		}
+	}
+	if q.getAccountTransferUsageStmt != nil {
+		if cerr := q.getAccountTransferUsageStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getAccountTransferUsageStmt: %w", cerr)
+		}
+	}
+	if q.getBeneficiaryStmt != nil {
+		if cerr := q.getBeneficiaryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getBeneficiaryStmt: %w", cerr)
+		}
+	}
+	if q.getCoolingOffBeneficiaryForUpdateStmt != nil {
+		if cerr := q.getCoolingOffBeneficiaryForUpdateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getCoolingOffBeneficiaryForUpdateStmt: %w", cerr)
+		}
+	}
 	if q.getEntryStmt != nil {
 		if cerr := q.getEntryStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getEntryStmt: %w", cerr)
 		}
 	}
+	if q.getFeeScheduleStmt != nil {
+		if cerr := q.getFeeScheduleStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getFeeScheduleStmt: %w", cerr)
+		}
+	}
+	if q.getGLAccountForAccountStmt != nil {
+		if cerr := q.getGLAccountForAccountStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getGLAccountForAccountStmt: %w", cerr)
+		}
+	}
+	if q.getHouseAccountStmt != nil {
+		if cerr := q.getHouseAccountStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getHouseAccountStmt: %w", cerr)
+		}
+	}
+	if q.getIdempotencyKeyStmt != nil {
+		if cerr := q.getIdempotencyKeyStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getIdempotencyKeyStmt: %w", cerr)
+		}
+	}
+	if q.getLastInterestCapitalizationStmt != nil {
+		if cerr := q.getLastInterestCapitalizationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getLastInterestCapitalizationStmt: %w", cerr)
+		}
+	}
+	if q.getLoanStmt != nil {
+		if cerr := q.getLoanStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getLoanStmt: %w", cerr)
+		}
+	}
+	if q.getLoanForUpdateStmt != nil {
+		if cerr := q.getLoanForUpdateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getLoanForUpdateStmt: %w", cerr)
+		}
+	}
+	if q.getLoanInstallmentForUpdateStmt != nil {
+		if cerr := q.getLoanInstallmentForUpdateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getLoanInstallmentForUpdateStmt: %w", cerr)
+		}
+	}
+	if q.getOpenTellerTillForUpdateStmt != nil {
+		if cerr := q.getOpenTellerTillForUpdateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getOpenTellerTillForUpdateStmt: %w", cerr)
+		}
+	}
+	if q.getOwnerTransferUsageStmt != nil {
+		if cerr := q.getOwnerTransferUsageStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getOwnerTransferUsageStmt: %w", cerr)
+		}
+	}
+	if q.getPayeeTransferTotalStmt != nil {
+		if cerr := q.getPayeeTransferTotalStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getPayeeTransferTotalStmt: %w", cerr)
+		}
+	}
+	if q.getPaymentRequestStmt != nil {
+		if cerr := q.getPaymentRequestStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getPaymentRequestStmt: %w", cerr)
+		}
+	}
+	if q.getPaymentRequestForUpdateStmt != nil {
+		if cerr := q.getPaymentRequestForUpdateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getPaymentRequestForUpdateStmt: %w", cerr)
+		}
+	}
+	if q.getPendingInterestStmt != nil {
+		if cerr := q.getPendingInterestStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getPendingInterestStmt: %w", cerr)
+		}
+	}
+	if q.getPotStmt != nil {
+		if cerr := q.getPotStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getPotStmt: %w", cerr)
+		}
+	}
+	if q.getRoundUpPotStmt != nil {
+		if cerr := q.getRoundUpPotStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getRoundUpPotStmt: %w", cerr)
+		}
+	}
+	if q.getTellerTillStmt != nil {
+		if cerr := q.getTellerTillStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTellerTillStmt: %w", cerr)
+		}
+	}
+	if q.getTellerTillForUpdateStmt != nil {
+		if cerr := q.getTellerTillForUpdateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTellerTillForUpdateStmt: %w", cerr)
+		}
+	}
+	if q.getTermDepositStmt != nil {
+		if cerr := q.getTermDepositStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTermDepositStmt: %w", cerr)
+		}
+	}
+	if q.getTermDepositForUpdateStmt != nil {
+		if cerr := q.getTermDepositForUpdateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTermDepositForUpdateStmt: %w", cerr)
+		}
+	}
+	if q.getTermDepositRateStmt != nil {
+		if cerr := q.getTermDepositRateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTermDepositRateStmt: %w", cerr)
+		}
+	}
 	if q.getTransferStmt != nil {
 		if cerr := q.getTransferStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getTransferStmt: %w", cerr)
 		}
 	}
+	if q.getTransferApprovalStmt != nil {
+		if cerr := q.getTransferApprovalStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTransferApprovalStmt: %w", cerr)
+		}
+	}
+	if q.getTransferApprovalForUpdateStmt != nil {
+		if cerr := q.getTransferApprovalForUpdateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTransferApprovalForUpdateStmt: %w", cerr)
+		}
+	}
+	if q.getTransferLimitStmt != nil {
+		if cerr := q.getTransferLimitStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTransferLimitStmt: %w", cerr)
+		}
+	}
+	if q.getTrialBalanceStmt != nil {
+		if cerr := q.getTrialBalanceStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTrialBalanceStmt: %w", cerr)
+		}
+	}
 	if q.getUserStmt != nil {
 		if cerr := q.getUserStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getUserStmt: %w", cerr)
@@ -160,11 +742,141 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getUserByUsernameStmt: %w", cerr)
 		}
 	}
+	if q.getUserForUpdateStmt != nil {
+		if cerr := q.getUserForUpdateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getUserForUpdateStmt: %w", cerr)
+		}
+	}
+	if q.listAccountHoldersStmt != nil {
+		if cerr := q.listAccountHoldersStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listAccountHoldersStmt: %w", cerr)
+		}
+	}
+	if q.listAccountsWithPendingInterestStmt != nil {
+		if cerr := q.listAccountsWithPendingInterestStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listAccountsWithPendingInterestStmt: %w", cerr)
+		}
+	}
+	if q.listBeneficiariesStmt != nil {
+		if cerr := q.listBeneficiariesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listBeneficiariesStmt: %w", cerr)
+		}
+	}
+	if q.listCashTransactionsByTillStmt != nil {
+		if cerr := q.listCashTransactionsByTillStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listCashTransactionsByTillStmt: %w", cerr)
+		}
+	}
+	if q.listDueLoanInstallmentsStmt != nil {
+		if cerr := q.listDueLoanInstallmentsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listDueLoanInstallmentsStmt: %w", cerr)
+		}
+	}
+	if q.listFeeScheduleTiersStmt != nil {
+		if cerr := q.listFeeScheduleTiersStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listFeeScheduleTiersStmt: %w", cerr)
+		}
+	}
+	if q.listGLAccountsStmt != nil {
+		if cerr := q.listGLAccountsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listGLAccountsStmt: %w", cerr)
+		}
+	}
+	if q.listInterestAccrualsStmt != nil {
+		if cerr := q.listInterestAccrualsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listInterestAccrualsStmt: %w", cerr)
+		}
+	}
+	if q.listInterestBearingAccountsStmt != nil {
+		if cerr := q.listInterestBearingAccountsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listInterestBearingAccountsStmt: %w", cerr)
+		}
+	}
+	if q.listJournalEntriesByReferenceStmt != nil {
+		if cerr := q.listJournalEntriesByReferenceStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listJournalEntriesByReferenceStmt: %w", cerr)
+		}
+	}
+	if q.listJournalLinesStmt != nil {
+		if cerr := q.listJournalLinesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listJournalLinesStmt: %w", cerr)
+		}
+	}
+	if q.listLoanInstallmentsStmt != nil {
+		if cerr := q.listLoanInstallmentsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listLoanInstallmentsStmt: %w", cerr)
+		}
+	}
+	if q.listMaturingTermDepositsStmt != nil {
+		if cerr := q.listMaturingTermDepositsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listMaturingTermDepositsStmt: %w", cerr)
+		}
+	}
+	if q.listPaymentRequestsStmt != nil {
+		if cerr := q.listPaymentRequestsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listPaymentRequestsStmt: %w", cerr)
+		}
+	}
+	if q.listPendingTransferApprovalsStmt != nil {
+		if cerr := q.listPendingTransferApprovalsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listPendingTransferApprovalsStmt: %w", cerr)
+		}
+	}
+	if q.listPotsByParentStmt != nil {
+		if cerr := q.listPotsByParentStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listPotsByParentStmt: %w", cerr)
+		}
+	}
+	if q.listTermDepositRatesStmt != nil {
+		if cerr := q.listTermDepositRatesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listTermDepositRatesStmt: %w", cerr)
+		}
+	}
+	if q.markInterestAccrualsCapitalizedStmt != nil {
+		if cerr := q.markInterestAccrualsCapitalizedStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing markInterestAccrualsCapitalizedStmt: %w", cerr)
+		}
+	}
+	if q.markLoanInstallmentOverdueStmt != nil {
+		if cerr := q.markLoanInstallmentOverdueStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing markLoanInstallmentOverdueStmt: %w", cerr)
+		}
+	}
+	if q.markLoanInstallmentPaidStmt != nil {
+		if cerr := q.markLoanInstallmentPaidStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing markLoanInstallmentPaidStmt: %w", cerr)
+		}
+	}
+	if q.saveIdempotentResponseStmt != nil {
+		if cerr := q.saveIdempotentResponseStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing saveIdempotentResponseStmt: %w", cerr)
+		}
+	}
+	if q.takeOverIdempotencyKeyStmt != nil {
+		if cerr := q.takeOverIdempotencyKeyStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing takeOverIdempotencyKeyStmt: %w", cerr)
+		}
+	}
+	if q.updateAccountStatusStmt != nil {
+		if cerr := q.updateAccountStatusStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateAccountStatusStmt: %w", cerr)
+		}
+	}
 	if q.updateBalanceAccountStmt != nil {
 		if cerr := q.updateBalanceAccountStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing updateBalanceAccountStmt: %w", cerr)
 		}
 	}
+	if q.updateLoanStatusStmt != nil {
+		if cerr := q.updateLoanStatusStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateLoanStatusStmt: %w", cerr)
+		}
+	}
+	if q.updateUserRoleStmt != nil {
+		if cerr := q.updateUserRoleStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateUserRoleStmt: %w", cerr)
+		}
+	}
 	return err
 }
 
@@ -202,47 +914,225 @@ func (q *Queries) queryRow(ctx context.Context, stmt *sql.Stmt, query string, ar
 }
 
 type Queries struct {
-	db                       DBTX
-	tx                       *sql.Tx
-	addBalanceAccountStmt    *sql.Stmt
-	createAccountStmt        *sql.Stmt
-	createEntryStmt          *sql.Stmt
-	createTransferStmt       *sql.Stmt
-	createUserStmt           *sql.Stmt
-	deleteAccountStmt        *sql.Stmt
-	fetchAccountsStmt        *sql.Stmt
-	fetchEntriesStmt         *sql.Stmt
-	fetchTransferStmt        *sql.Stmt
-	getAccountStmt           *sql.Stmt
-	getAccountForUpdateStmt  *sql.Stmt
-	getEntryStmt             *sql.Stmt
-	getTransferStmt          *sql.Stmt
-	getUserStmt              *sql.Stmt
-	getUserByEmailStmt       *sql.Stmt
-	getUserByUsernameStmt    *sql.Stmt
-	updateBalanceAccountStmt *sql.Stmt
+	db                                    DBTX
+	tx                                    *sql.Tx
+	addBalanceAccountStmt                 *sql.Stmt
+	addTellerTillCashStmt                 *sql.Stmt
+	closeAccountStmt                      *sql.Stmt
+	closeTellerTillStmt                   *sql.Stmt
+	closeTermDepositStmt                  *sql.Stmt
+	countOpenPotsStmt                     *sql.Stmt
+	countUnpaidLoanInstallmentsStmt       *sql.Stmt
+	createAccountStmt                     *sql.Stmt
+	createAccountHolderStmt               *sql.Stmt
+	createBeneficiaryStmt                 *sql.Stmt
+	createCashTransactionStmt             *sql.Stmt
+	createEntryStmt                       *sql.Stmt
+	createFeeScheduleStmt                 *sql.Stmt
+	createFeeScheduleTierStmt             *sql.Stmt
+	createGLAccountStmt                   *sql.Stmt
+	createIdempotencyKeyStmt              *sql.Stmt
+	createInterestAccrualStmt             *sql.Stmt
+	createInterestCapitalizationStmt      *sql.Stmt
+	createInterestRateStmt                *sql.Stmt
+	createJournalEntryStmt                *sql.Stmt
+	createJournalLineStmt                 *sql.Stmt
+	createLoanStmt                        *sql.Stmt
+	createLoanInstallmentStmt             *sql.Stmt
+	createPaymentRequestStmt              *sql.Stmt
+	createPotStmt                         *sql.Stmt
+	createTellerTillStmt                  *sql.Stmt
+	createTermDepositStmt                 *sql.Stmt
+	createTermDepositRateStmt             *sql.Stmt
+	createTransferStmt                    *sql.Stmt
+	createTransferApprovalStmt            *sql.Stmt
+	createUserStmt                        *sql.Stmt
+	decidePaymentRequestStmt              *sql.Stmt
+	decideTransferApprovalStmt            *sql.Stmt
+	deleteAccountHolderStmt               *sql.Stmt
+	deleteBeneficiaryStmt                 *sql.Stmt
+	deleteIdempotencyKeyStmt              *sql.Stmt
+	deleteIdempotencyKeysBeforeStmt       *sql.Stmt
+	fetchAccountsStmt                     *sql.Stmt
+	fetchAccountsByOwnerStmt              *sql.Stmt
+	fetchEntriesStmt                      *sql.Stmt
+	fetchTransferStmt                     *sql.Stmt
+	fetchUsersStmt                        *sql.Stmt
+	getAccountStmt                        *sql.Stmt
+	getAccountByNumberStmt                *sql.Stmt
+	getAccountForUpdateStmt               *sql.Stmt
+	getAccountHolderStmt                  *sql.Stmt
+	getAccountTransferUsageStmt           *sql.Stmt
+	getBeneficiaryStmt                    *sql.Stmt
+	getCoolingOffBeneficiaryForUpdateStmt *sql.Stmt
+	getEntryStmt                          *sql.Stmt
+	getFeeScheduleStmt                    *sql.Stmt
+	getGLAccountForAccountStmt            *sql.Stmt
+	getHouseAccountStmt                   *sql.Stmt
+	getIdempotencyKeyStmt                 *sql.Stmt
+	getLastInterestCapitalizationStmt     *sql.Stmt
+	getLoanStmt                           *sql.Stmt
+	getLoanForUpdateStmt                  *sql.Stmt
+	getLoanInstallmentForUpdateStmt       *sql.Stmt
+	getOpenTellerTillForUpdateStmt        *sql.Stmt
+	getOwnerTransferUsageStmt             *sql.Stmt
+	getPayeeTransferTotalStmt             *sql.Stmt
+	getPaymentRequestStmt                 *sql.Stmt
+	getPaymentRequestForUpdateStmt        *sql.Stmt
+	getPendingInterestStmt                *sql.Stmt
+	getPotStmt                            *sql.Stmt
+	getRoundUpPotStmt                     *sql.Stmt
+	getTellerTillStmt                     *sql.Stmt
+	getTellerTillForUpdateStmt            *sql.Stmt
+	getTermDepositStmt                    *sql.Stmt
+	getTermDepositForUpdateStmt           *sql.Stmt
+	getTermDepositRateStmt                *sql.Stmt
+	getTransferStmt                       *sql.Stmt
+	getTransferApprovalStmt               *sql.Stmt
+	getTransferApprovalForUpdateStmt      *sql.Stmt
+	getTransferLimitStmt                  *sql.Stmt
+	getTrialBalanceStmt                   *sql.Stmt
+	getUserStmt                           *sql.Stmt
+	getUserByEmailStmt                    *sql.Stmt
+	getUserByUsernameStmt                 *sql.Stmt
+	getUserForUpdateStmt                  *sql.Stmt
+	listAccountHoldersStmt                *sql.Stmt
+	listAccountsWithPendingInterestStmt   *sql.Stmt
+	listBeneficiariesStmt                 *sql.Stmt
+	listCashTransactionsByTillStmt        *sql.Stmt
+	listDueLoanInstallmentsStmt           *sql.Stmt
+	listFeeScheduleTiersStmt              *sql.Stmt
+	listGLAccountsStmt                    *sql.Stmt
+	listInterestAccrualsStmt              *sql.Stmt
+	listInterestBearingAccountsStmt       *sql.Stmt
+	listJournalEntriesByReferenceStmt     *sql.Stmt
+	listJournalLinesStmt                  *sql.Stmt
+	listLoanInstallmentsStmt              *sql.Stmt
+	listMaturingTermDepositsStmt          *sql.Stmt
+	listPaymentRequestsStmt               *sql.Stmt
+	listPendingTransferApprovalsStmt      *sql.Stmt
+	listPotsByParentStmt                  *sql.Stmt
+	listTermDepositRatesStmt              *sql.Stmt
+	markInterestAccrualsCapitalizedStmt   *sql.Stmt
+	markLoanInstallmentOverdueStmt        *sql.Stmt
+	markLoanInstallmentPaidStmt           *sql.Stmt
+	saveIdempotentResponseStmt            *sql.Stmt
+	takeOverIdempotencyKeyStmt            *sql.Stmt
+	updateAccountStatusStmt               *sql.Stmt
+	updateBalanceAccountStmt              *sql.Stmt
+	updateLoanStatusStmt                  *sql.Stmt
+	updateUserRoleStmt                    *sql.Stmt
 }
 
 func (q *Queries) WithTx(tx *sql.Tx) *Queries {
 	return &Queries{
-		db:                       tx,
-		tx:                       tx,
-		addBalanceAccountStmt:    q.addBalanceAccountStmt,
-		createAccountStmt:        q.createAccountStmt,
-		createEntryStmt:          q.createEntryStmt,
-		createTransferStmt:       q.createTransferStmt,
-		createUserStmt:           q.createUserStmt,
-		deleteAccountStmt:        q.deleteAccountStmt,
-		fetchAccountsStmt:        q.fetchAccountsStmt,
-		fetchEntriesStmt:         q.fetchEntriesStmt,
-		fetchTransferStmt:        q.fetchTransferStmt,
-		getAccountStmt:           q.getAccountStmt,
-		getAccountForUpdateStmt:  q.getAccountForUpdateStmt,
-		getEntryStmt:             q.getEntryStmt,
-		getTransferStmt:          q.getTransferStmt,
-		getUserStmt:              q.getUserStmt,
-		getUserByEmailStmt:       q.getUserByEmailStmt,
-		getUserByUsernameStmt:    q.getUserByUsernameStmt,
-		updateBalanceAccountStmt: q.updateBalanceAccountStmt,
+		db:                                    tx,
+		tx:                                    tx,
+		addBalanceAccountStmt:                 q.addBalanceAccountStmt,
+		addTellerTillCashStmt:                 q.addTellerTillCashStmt,
+		closeAccountStmt:                      q.closeAccountStmt,
+		closeTellerTillStmt:                   q.closeTellerTillStmt,
+		closeTermDepositStmt:                  q.closeTermDepositStmt,
+		countOpenPotsStmt:                     q.countOpenPotsStmt,
+		countUnpaidLoanInstallmentsStmt:       q.countUnpaidLoanInstallmentsStmt,
+		createAccountStmt:                     q.createAccountStmt,
+		createAccountHolderStmt:               q.createAccountHolderStmt,
+		createBeneficiaryStmt:                 q.createBeneficiaryStmt,
+		createCashTransactionStmt:             q.createCashTransactionStmt,
+		createEntryStmt:                       q.createEntryStmt,
+		createFeeScheduleStmt:                 q.createFeeScheduleStmt,
+		createFeeScheduleTierStmt:             q.createFeeScheduleTierStmt,
+		createGLAccountStmt:                   q.createGLAccountStmt,
+		createIdempotencyKeyStmt:              q.createIdempotencyKeyStmt,
+		createInterestAccrualStmt:             q.createInterestAccrualStmt,
+		createInterestCapitalizationStmt:      q.createInterestCapitalizationStmt,
+		createInterestRateStmt:                q.createInterestRateStmt,
+		createJournalEntryStmt:                q.createJournalEntryStmt,
+		createJournalLineStmt:                 q.createJournalLineStmt,
+		createLoanStmt:                        q.createLoanStmt,
+		createLoanInstallmentStmt:             q.createLoanInstallmentStmt,
+		createPaymentRequestStmt:              q.createPaymentRequestStmt,
+		createPotStmt:                         q.createPotStmt,
+		createTellerTillStmt:                  q.createTellerTillStmt,
+		createTermDepositStmt:                 q.createTermDepositStmt,
+		createTermDepositRateStmt:             q.createTermDepositRateStmt,
+		createTransferStmt:                    q.createTransferStmt,
+		createTransferApprovalStmt:            q.createTransferApprovalStmt,
+		createUserStmt:                        q.createUserStmt,
+		decidePaymentRequestStmt:              q.decidePaymentRequestStmt,
+		decideTransferApprovalStmt:            q.decideTransferApprovalStmt,
+		deleteAccountHolderStmt:               q.deleteAccountHolderStmt,
+		deleteBeneficiaryStmt:                 q.deleteBeneficiaryStmt,
+		deleteIdempotencyKeyStmt:              q.deleteIdempotencyKeyStmt,
+		deleteIdempotencyKeysBeforeStmt:       q.deleteIdempotencyKeysBeforeStmt,
+		fetchAccountsStmt:                     q.fetchAccountsStmt,
+		fetchAccountsByOwnerStmt:              q.fetchAccountsByOwnerStmt,
+		fetchEntriesStmt:                      q.fetchEntriesStmt,
+		fetchTransferStmt:                     q.fetchTransferStmt,
+		fetchUsersStmt:                        q.fetchUsersStmt,
+		getAccountStmt:                        q.getAccountStmt,
+		getAccountByNumberStmt:                q.getAccountByNumberStmt,
+		getAccountForUpdateStmt:               q.getAccountForUpdateStmt,
+		getAccountHolderStmt:                  q.getAccountHolderStmt,
+		getAccountTransferUsageStmt:           q.getAccountTransferUsageStmt,
+		getBeneficiaryStmt:                    q.getBeneficiaryStmt,
+		getCoolingOffBeneficiaryForUpdateStmt: q.getCoolingOffBeneficiaryForUpdateStmt,
+		getEntryStmt:                          q.getEntryStmt,
+		getFeeScheduleStmt:                    q.getFeeScheduleStmt,
+		getGLAccountForAccountStmt:            q.getGLAccountForAccountStmt,
+		getHouseAccountStmt:                   q.getHouseAccountStmt,
+		getIdempotencyKeyStmt:                 q.getIdempotencyKeyStmt,
+		getLastInterestCapitalizationStmt:     q.getLastInterestCapitalizationStmt,
+		getLoanStmt:                           q.getLoanStmt,
+		getLoanForUpdateStmt:                  q.getLoanForUpdateStmt,
+		getLoanInstallmentForUpdateStmt:       q.getLoanInstallmentForUpdateStmt,
+		getOpenTellerTillForUpdateStmt:        q.getOpenTellerTillForUpdateStmt,
+		getOwnerTransferUsageStmt:             q.getOwnerTransferUsageStmt,
+		getPayeeTransferTotalStmt:             q.getPayeeTransferTotalStmt,
+		getPaymentRequestStmt:                 q.getPaymentRequestStmt,
+		getPaymentRequestForUpdateStmt:        q.getPaymentRequestForUpdateStmt,
+		getPendingInterestStmt:                q.getPendingInterestStmt,
+		getPotStmt:                            q.getPotStmt,
+		getRoundUpPotStmt:                     q.getRoundUpPotStmt,
+		getTellerTillStmt:                     q.getTellerTillStmt,
+		getTellerTillForUpdateStmt:            q.getTellerTillForUpdateStmt,
+		getTermDepositStmt:                    q.getTermDepositStmt,
+		getTermDepositForUpdateStmt:           q.getTermDepositForUpdateStmt,
+		getTermDepositRateStmt:                q.getTermDepositRateStmt,
+		getTransferStmt:                       q.getTransferStmt,
+		getTransferApprovalStmt:               q.getTransferApprovalStmt,
+		getTransferApprovalForUpdateStmt:      q.getTransferApprovalForUpdateStmt,
+		getTransferLimitStmt:                  q.getTransferLimitStmt,
+		getTrialBalanceStmt:                   q.getTrialBalanceStmt,
+		getUserStmt:                           q.getUserStmt,
+		getUserByEmailStmt:                    q.getUserByEmailStmt,
+		getUserByUsernameStmt:                 q.getUserByUsernameStmt,
+		getUserForUpdateStmt:                  q.getUserForUpdateStmt,
+		listAccountHoldersStmt:                q.listAccountHoldersStmt,
+		listAccountsWithPendingInterestStmt:   q.listAccountsWithPendingInterestStmt,
+		listBeneficiariesStmt:                 q.listBeneficiariesStmt,
+		listCashTransactionsByTillStmt:        q.listCashTransactionsByTillStmt,
+		listDueLoanInstallmentsStmt:           q.listDueLoanInstallmentsStmt,
+		listFeeScheduleTiersStmt:              q.listFeeScheduleTiersStmt,
+		listGLAccountsStmt:                    q.listGLAccountsStmt,
+		listInterestAccrualsStmt:              q.listInterestAccrualsStmt,
+		listInterestBearingAccountsStmt:       q.listInterestBearingAccountsStmt,
+		listJournalEntriesByReferenceStmt:     q.listJournalEntriesByReferenceStmt,
+		listJournalLinesStmt:                  q.listJournalLinesStmt,
+		listLoanInstallmentsStmt:              q.listLoanInstallmentsStmt,
+		listMaturingTermDepositsStmt:          q.listMaturingTermDepositsStmt,
+		listPaymentRequestsStmt:               q.listPaymentRequestsStmt,
+		listPendingTransferApprovalsStmt:      q.listPendingTransferApprovalsStmt,
+		listPotsByParentStmt:                  q.listPotsByParentStmt,
+		listTermDepositRatesStmt:              q.listTermDepositRatesStmt,
+		markInterestAccrualsCapitalizedStmt:   q.markInterestAccrualsCapitalizedStmt,
+		markLoanInstallmentOverdueStmt:        q.markLoanInstallmentOverdueStmt,
+		markLoanInstallmentPaidStmt:           q.markLoanInstallmentPaidStmt,
+		saveIdempotentResponseStmt:            q.saveIdempotentResponseStmt,
+		takeOverIdempotencyKeyStmt:            q.takeOverIdempotencyKeyStmt,
+		updateAccountStatusStmt:               q.updateAccountStatusStmt,
+		updateBalanceAccountStmt:              q.updateBalanceAccountStmt,
+		updateLoanStatusStmt:                  q.updateLoanStatusStmt,
+		updateUserRoleStmt:                    q.updateUserRoleStmt,
 	}
 }
@@ -0,0 +1,72 @@
+package db
+
+import (
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// SQLSTATE codes of the Postgres errors the stores tell apart
+const (
+	UniqueViolation      = "23505"
+	ForeignKeyViolation  = "23503"
+	CheckViolation       = "23514"
+	SerializationFailure = "40001"
+	DeadlockDetected     = "40P01"
+)
+
+// Errors of broken constraints. Every store wraps the error of its backend
+// in one of them, so callers match them with errors.Is whatever keeps the
+// data. The error of the backend can still be had with errors.As.
+var (
+	ErrUniqueViolation     = errors.New("unique violation")
+	ErrForeignKeyViolation = errors.New("foreign key violation")
+	ErrCheckViolation      = errors.New("check violation")
+)
+
+// constraintErrors are the errors of the SQLSTATE codes they stand for
+var constraintErrors = map[string]error{
+	UniqueViolation:     ErrUniqueViolation,
+	ForeignKeyViolation: ErrForeignKeyViolation,
+	CheckViolation:      ErrCheckViolation,
+}
+
+// constraintError is an error of the backend that is one of
+// constraintErrors
+type constraintError struct {
+	kind error
+	err  error
+}
+
+func (e *constraintError) Error() string {
+	return e.err.Error()
+}
+
+func (e *constraintError) Unwrap() error {
+	return e.err
+}
+
+func (e *constraintError) Is(target error) bool {
+	return target == e.kind
+}
+
+// mapError wraps the Postgres errors of broken constraints in their
+// constraintErrors. It is the one place backends' errors are mapped:
+// memory and SQLite report theirs as Postgres would.
+func mapError(err error) error {
+	kind, ok := constraintErrors[errorCode(err)]
+	if !ok || errors.Is(err, kind) {
+		return err
+	}
+	return &constraintError{kind: kind, err: err}
+}
+
+// errorCode returns the SQLSTATE of the Postgres error err wraps, or "" when
+// it doesn't come from Postgres
+func errorCode(err error) string {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code
+	}
+	return ""
+}
@@ -0,0 +1,543 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// errorQueries runs the queries of a backend and wraps the errors callers
+// tell apart in ErrUniqueViolation and the others, so they are matched with
+// errors.Is whatever the backend
+type errorQueries struct {
+	queries Querier
+}
+
+func (q errorQueries) AddBalanceAccount(ctx context.Context, arg AddBalanceAccountParams) (Account, error) {
+	row, err := q.queries.AddBalanceAccount(ctx, arg)
+	return row, mapError(err)
+}
+
+func (q errorQueries) AddTellerTillCash(ctx context.Context, arg AddTellerTillCashParams) (TellerTill, error) {
+	row, err := q.queries.AddTellerTillCash(ctx, arg)
+	return row, mapError(err)
+}
+
+func (q errorQueries) CloseAccount(ctx context.Context, id int64) (Account, error) {
+	row, err := q.queries.CloseAccount(ctx, id)
+	return row, mapError(err)
+}
+
+func (q errorQueries) CloseTellerTill(ctx context.Context, arg CloseTellerTillParams) (TellerTill, error) {
+	row, err := q.queries.CloseTellerTill(ctx, arg)
+	return row, mapError(err)
+}
+
+func (q errorQueries) CloseTermDeposit(ctx context.Context, arg CloseTermDepositParams) (TermDeposit, error) {
+	row, err := q.queries.CloseTermDeposit(ctx, arg)
+	return row, mapError(err)
+}
+
+func (q errorQueries) CountOpenPots(ctx context.Context, parentAccountID int64) (int64, error) {
+	row, err := q.queries.CountOpenPots(ctx, parentAccountID)
+	return row, mapError(err)
+}
+
+func (q errorQueries) CountUnpaidLoanInstallments(ctx context.Context, loanID int64) (int64, error) {
+	row, err := q.queries.CountUnpaidLoanInstallments(ctx, loanID)
+	return row, mapError(err)
+}
+
+func (q errorQueries) CreateAccount(ctx context.Context, arg CreateAccountParams) (Account, error) {
+	row, err := q.queries.CreateAccount(ctx, arg)
+	return row, mapError(err)
+}
+
+func (q errorQueries) CreateAccountHolder(ctx context.Context, arg CreateAccountHolderParams) (AccountHolder, error) {
+	row, err := q.queries.CreateAccountHolder(ctx, arg)
+	return row, mapError(err)
+}
+
+func (q errorQueries) CreateBeneficiary(ctx context.Context, arg CreateBeneficiaryParams) (Beneficiary, error) {
+	row, err := q.queries.CreateBeneficiary(ctx, arg)
+	return row, mapError(err)
+}
+
+func (q errorQueries) CreateCashTransaction(ctx context.Context, arg CreateCashTransactionParams) (CashTransaction, error) {
+	row, err := q.queries.CreateCashTransaction(ctx, arg)
+	return row, mapError(err)
+}
+
+func (q errorQueries) CreateEntry(ctx context.Context, arg CreateEntryParams) (Entry, error) {
+	row, err := q.queries.CreateEntry(ctx, arg)
+	return row, mapError(err)
+}
+
+func (q errorQueries) CreateFeeSchedule(ctx context.Context, arg CreateFeeScheduleParams) (FeeSchedule, error) {
+	row, err := q.queries.CreateFeeSchedule(ctx, arg)
+	return row, mapError(err)
+}
+
+func (q errorQueries) CreateFeeScheduleTier(ctx context.Context, arg CreateFeeScheduleTierParams) (FeeScheduleTier, error) {
+	row, err := q.queries.CreateFeeScheduleTier(ctx, arg)
+	return row, mapError(err)
+}
+
+func (q errorQueries) CreateGLAccount(ctx context.Context, arg CreateGLAccountParams) (GlAccount, error) {
+	row, err := q.queries.CreateGLAccount(ctx, arg)
+	return row, mapError(err)
+}
+
+func (q errorQueries) CreateIdempotencyKey(ctx context.Context, arg CreateIdempotencyKeyParams) (IdempotencyKey, error) {
+	row, err := q.queries.CreateIdempotencyKey(ctx, arg)
+	return row, mapError(err)
+}
+
+func (q errorQueries) CreateInterestAccrual(ctx context.Context, arg CreateInterestAccrualParams) (InterestAccrual, error) {
+	row, err := q.queries.CreateInterestAccrual(ctx, arg)
+	return row, mapError(err)
+}
+
+func (q errorQueries) CreateInterestCapitalization(ctx context.Context, arg CreateInterestCapitalizationParams) (InterestCapitalization, error) {
+	row, err := q.queries.CreateInterestCapitalization(ctx, arg)
+	return row, mapError(err)
+}
+
+func (q errorQueries) CreateInterestRate(ctx context.Context, arg CreateInterestRateParams) (InterestRate, error) {
+	row, err := q.queries.CreateInterestRate(ctx, arg)
+	return row, mapError(err)
+}
+
+func (q errorQueries) CreateJournalEntry(ctx context.Context, reference string) (JournalEntry, error) {
+	row, err := q.queries.CreateJournalEntry(ctx, reference)
+	return row, mapError(err)
+}
+
+func (q errorQueries) CreateJournalLine(ctx context.Context, arg CreateJournalLineParams) (JournalLine, error) {
+	row, err := q.queries.CreateJournalLine(ctx, arg)
+	return row, mapError(err)
+}
+
+func (q errorQueries) CreateLoan(ctx context.Context, arg CreateLoanParams) (Loan, error) {
+	row, err := q.queries.CreateLoan(ctx, arg)
+	return row, mapError(err)
+}
+
+func (q errorQueries) CreateLoanInstallment(ctx context.Context, arg CreateLoanInstallmentParams) (LoanInstallment, error) {
+	row, err := q.queries.CreateLoanInstallment(ctx, arg)
+	return row, mapError(err)
+}
+
+func (q errorQueries) CreatePaymentRequest(ctx context.Context, arg CreatePaymentRequestParams) (PaymentRequest, error) {
+	row, err := q.queries.CreatePaymentRequest(ctx, arg)
+	return row, mapError(err)
+}
+
+func (q errorQueries) CreatePot(ctx context.Context, arg CreatePotParams) (Pot, error) {
+	row, err := q.queries.CreatePot(ctx, arg)
+	return row, mapError(err)
+}
+
+func (q errorQueries) CreateTellerTill(ctx context.Context, arg CreateTellerTillParams) (TellerTill, error) {
+	row, err := q.queries.CreateTellerTill(ctx, arg)
+	return row, mapError(err)
+}
+
+func (q errorQueries) CreateTermDeposit(ctx context.Context, arg CreateTermDepositParams) (TermDeposit, error) {
+	row, err := q.queries.CreateTermDeposit(ctx, arg)
+	return row, mapError(err)
+}
+
+func (q errorQueries) CreateTermDepositRate(ctx context.Context, arg CreateTermDepositRateParams) (TermDepositRate, error) {
+	row, err := q.queries.CreateTermDepositRate(ctx, arg)
+	return row, mapError(err)
+}
+
+func (q errorQueries) CreateTransfer(ctx context.Context, arg CreateTransferParams) (Transfer, error) {
+	row, err := q.queries.CreateTransfer(ctx, arg)
+	return row, mapError(err)
+}
+
+func (q errorQueries) CreateTransferApproval(ctx context.Context, arg CreateTransferApprovalParams) (TransferApproval, error) {
+	row, err := q.queries.CreateTransferApproval(ctx, arg)
+	return row, mapError(err)
+}
+
+func (q errorQueries) CreateUser(ctx context.Context, arg CreateUserParams) (User, error) {
+	row, err := q.queries.CreateUser(ctx, arg)
+	return row, mapError(err)
+}
+
+func (q errorQueries) DecidePaymentRequest(ctx context.Context, arg DecidePaymentRequestParams) (PaymentRequest, error) {
+	row, err := q.queries.DecidePaymentRequest(ctx, arg)
+	return row, mapError(err)
+}
+
+func (q errorQueries) DecideTransferApproval(ctx context.Context, arg DecideTransferApprovalParams) (TransferApproval, error) {
+	row, err := q.queries.DecideTransferApproval(ctx, arg)
+	return row, mapError(err)
+}
+
+func (q errorQueries) DeleteAccountHolder(ctx context.Context, arg DeleteAccountHolderParams) (AccountHolder, error) {
+	row, err := q.queries.DeleteAccountHolder(ctx, arg)
+	return row, mapError(err)
+}
+
+func (q errorQueries) DeleteBeneficiary(ctx context.Context, id int64) (Beneficiary, error) {
+	row, err := q.queries.DeleteBeneficiary(ctx, id)
+	return row, mapError(err)
+}
+
+func (q errorQueries) DeleteIdempotencyKey(ctx context.Context, arg DeleteIdempotencyKeyParams) error {
+	return mapError(q.queries.DeleteIdempotencyKey(ctx, arg))
+}
+
+func (q errorQueries) DeleteIdempotencyKeysBefore(ctx context.Context, before time.Time) (int64, error) {
+	row, err := q.queries.DeleteIdempotencyKeysBefore(ctx, before)
+	return row, mapError(err)
+}
+
+func (q errorQueries) FetchAccounts(ctx context.Context, arg FetchAccountsParams) ([]Account, error) {
+	row, err := q.queries.FetchAccounts(ctx, arg)
+	return row, mapError(err)
+}
+
+func (q errorQueries) FetchAccountsByOwner(ctx context.Context, arg FetchAccountsByOwnerParams) ([]Account, error) {
+	row, err := q.queries.FetchAccountsByOwner(ctx, arg)
+	return row, mapError(err)
+}
+
+func (q errorQueries) FetchEntries(ctx context.Context, arg FetchEntriesParams) ([]Entry, error) {
+	row, err := q.queries.FetchEntries(ctx, arg)
+	return row, mapError(err)
+}
+
+func (q errorQueries) FetchTransfer(ctx context.Context, arg FetchTransferParams) ([]Transfer, error) {
+	row, err := q.queries.FetchTransfer(ctx, arg)
+	return row, mapError(err)
+}
+
+func (q errorQueries) FetchUsers(ctx context.Context, arg FetchUsersParams) ([]User, error) {
+	row, err := q.queries.FetchUsers(ctx, arg)
+	return row, mapError(err)
+}
+
+func (q errorQueries) GetAccount(ctx context.Context, id int64) (Account, error) {
+	row, err := q.queries.GetAccount(ctx, id)
+	return row, mapError(err)
+}
+
+func (q errorQueries) GetAccountByNumber(ctx context.Context, number string) (Account, error) {
+	row, err := q.queries.GetAccountByNumber(ctx, number)
+	return row, mapError(err)
+}
+
+func (q errorQueries) GetAccountForUpdate(ctx context.Context, id int64) (Account, error) {
+	row, err := q.queries.GetAccountForUpdate(ctx, id)
+	return row, mapError(err)
+}
+
+func (q errorQueries) GetAccountHolder(ctx context.Context, arg GetAccountHolderParams) (AccountHolder, error) {
+	row, err := q.queries.GetAccountHolder(ctx, arg)
+	return row, mapError(err)
+}
+
+func (q errorQueries) GetAccountTransferUsage(ctx context.Context, arg GetAccountTransferUsageParams) (GetAccountTransferUsageRow, error) {
+	row, err := q.queries.GetAccountTransferUsage(ctx, arg)
+	return row, mapError(err)
+}
+
+func (q errorQueries) GetBeneficiary(ctx context.Context, id int64) (Beneficiary, error) {
+	row, err := q.queries.GetBeneficiary(ctx, id)
+	return row, mapError(err)
+}
+
+func (q errorQueries) GetCoolingOffBeneficiaryForUpdate(ctx context.Context, arg GetCoolingOffBeneficiaryForUpdateParams) (Beneficiary, error) {
+	row, err := q.queries.GetCoolingOffBeneficiaryForUpdate(ctx, arg)
+	return row, mapError(err)
+}
+
+func (q errorQueries) GetEntry(ctx context.Context, id int64) (Entry, error) {
+	row, err := q.queries.GetEntry(ctx, id)
+	return row, mapError(err)
+}
+
+func (q errorQueries) GetFeeSchedule(ctx context.Context, arg GetFeeScheduleParams) (FeeSchedule, error) {
+	row, err := q.queries.GetFeeSchedule(ctx, arg)
+	return row, mapError(err)
+}
+
+func (q errorQueries) GetGLAccountForAccount(ctx context.Context, id int64) (GetGLAccountForAccountRow, error) {
+	row, err := q.queries.GetGLAccountForAccount(ctx, id)
+	return row, mapError(err)
+}
+
+func (q errorQueries) GetHouseAccount(ctx context.Context, arg GetHouseAccountParams) (Account, error) {
+	row, err := q.queries.GetHouseAccount(ctx, arg)
+	return row, mapError(err)
+}
+
+func (q errorQueries) GetIdempotencyKey(ctx context.Context, arg GetIdempotencyKeyParams) (IdempotencyKey, error) {
+	row, err := q.queries.GetIdempotencyKey(ctx, arg)
+	return row, mapError(err)
+}
+
+func (q errorQueries) GetLastInterestCapitalization(ctx context.Context, accountID int64) (InterestCapitalization, error) {
+	row, err := q.queries.GetLastInterestCapitalization(ctx, accountID)
+	return row, mapError(err)
+}
+
+func (q errorQueries) GetLoan(ctx context.Context, id int64) (Loan, error) {
+	row, err := q.queries.GetLoan(ctx, id)
+	return row, mapError(err)
+}
+
+func (q errorQueries) GetLoanForUpdate(ctx context.Context, id int64) (Loan, error) {
+	row, err := q.queries.GetLoanForUpdate(ctx, id)
+	return row, mapError(err)
+}
+
+func (q errorQueries) GetLoanInstallmentForUpdate(ctx context.Context, id int64) (LoanInstallment, error) {
+	row, err := q.queries.GetLoanInstallmentForUpdate(ctx, id)
+	return row, mapError(err)
+}
+
+func (q errorQueries) GetOpenTellerTillForUpdate(ctx context.Context, arg GetOpenTellerTillForUpdateParams) (TellerTill, error) {
+	row, err := q.queries.GetOpenTellerTillForUpdate(ctx, arg)
+	return row, mapError(err)
+}
+
+func (q errorQueries) GetOwnerTransferUsage(ctx context.Context, arg GetOwnerTransferUsageParams) (GetOwnerTransferUsageRow, error) {
+	row, err := q.queries.GetOwnerTransferUsage(ctx, arg)
+	return row, mapError(err)
+}
+
+func (q errorQueries) GetPayeeTransferTotal(ctx context.Context, arg GetPayeeTransferTotalParams) (int64, error) {
+	row, err := q.queries.GetPayeeTransferTotal(ctx, arg)
+	return row, mapError(err)
+}
+
+func (q errorQueries) GetPaymentRequest(ctx context.Context, id int64) (PaymentRequest, error) {
+	row, err := q.queries.GetPaymentRequest(ctx, id)
+	return row, mapError(err)
+}
+
+func (q errorQueries) GetPaymentRequestForUpdate(ctx context.Context, id int64) (PaymentRequest, error) {
+	row, err := q.queries.GetPaymentRequestForUpdate(ctx, id)
+	return row, mapError(err)
+}
+
+func (q errorQueries) GetPendingInterest(ctx context.Context, arg GetPendingInterestParams) (GetPendingInterestRow, error) {
+	row, err := q.queries.GetPendingInterest(ctx, arg)
+	return row, mapError(err)
+}
+
+func (q errorQueries) GetPot(ctx context.Context, accountID int64) (Pot, error) {
+	row, err := q.queries.GetPot(ctx, accountID)
+	return row, mapError(err)
+}
+
+func (q errorQueries) GetRoundUpPot(ctx context.Context, parentAccountID int64) (Pot, error) {
+	row, err := q.queries.GetRoundUpPot(ctx, parentAccountID)
+	return row, mapError(err)
+}
+
+func (q errorQueries) GetTellerTill(ctx context.Context, id int64) (TellerTill, error) {
+	row, err := q.queries.GetTellerTill(ctx, id)
+	return row, mapError(err)
+}
+
+func (q errorQueries) GetTellerTillForUpdate(ctx context.Context, id int64) (TellerTill, error) {
+	row, err := q.queries.GetTellerTillForUpdate(ctx, id)
+	return row, mapError(err)
+}
+
+func (q errorQueries) GetTermDeposit(ctx context.Context, id int64) (TermDeposit, error) {
+	row, err := q.queries.GetTermDeposit(ctx, id)
+	return row, mapError(err)
+}
+
+func (q errorQueries) GetTermDepositForUpdate(ctx context.Context, id int64) (TermDeposit, error) {
+	row, err := q.queries.GetTermDepositForUpdate(ctx, id)
+	return row, mapError(err)
+}
+
+func (q errorQueries) GetTermDepositRate(ctx context.Context, arg GetTermDepositRateParams) (TermDepositRate, error) {
+	row, err := q.queries.GetTermDepositRate(ctx, arg)
+	return row, mapError(err)
+}
+
+func (q errorQueries) GetTransfer(ctx context.Context, id int64) (Transfer, error) {
+	row, err := q.queries.GetTransfer(ctx, id)
+	return row, mapError(err)
+}
+
+func (q errorQueries) GetTransferApproval(ctx context.Context, id int64) (TransferApproval, error) {
+	row, err := q.queries.GetTransferApproval(ctx, id)
+	return row, mapError(err)
+}
+
+func (q errorQueries) GetTransferApprovalForUpdate(ctx context.Context, id int64) (TransferApproval, error) {
+	row, err := q.queries.GetTransferApprovalForUpdate(ctx, id)
+	return row, mapError(err)
+}
+
+func (q errorQueries) GetTransferLimit(ctx context.Context, arg GetTransferLimitParams) (TransferLimit, error) {
+	row, err := q.queries.GetTransferLimit(ctx, arg)
+	return row, mapError(err)
+}
+
+func (q errorQueries) GetTrialBalance(ctx context.Context, asOf time.Time) ([]GetTrialBalanceRow, error) {
+	row, err := q.queries.GetTrialBalance(ctx, asOf)
+	return row, mapError(err)
+}
+
+func (q errorQueries) GetUser(ctx context.Context, id uuid.UUID) (User, error) {
+	row, err := q.queries.GetUser(ctx, id)
+	return row, mapError(err)
+}
+
+func (q errorQueries) GetUserByEmail(ctx context.Context, email string) (User, error) {
+	row, err := q.queries.GetUserByEmail(ctx, email)
+	return row, mapError(err)
+}
+
+func (q errorQueries) GetUserByUsername(ctx context.Context, username string) (User, error) {
+	row, err := q.queries.GetUserByUsername(ctx, username)
+	return row, mapError(err)
+}
+
+func (q errorQueries) GetUserForUpdate(ctx context.Context, id uuid.UUID) (User, error) {
+	row, err := q.queries.GetUserForUpdate(ctx, id)
+	return row, mapError(err)
+}
+
+func (q errorQueries) ListAccountHolders(ctx context.Context, accountID int64) ([]AccountHolder, error) {
+	row, err := q.queries.ListAccountHolders(ctx, accountID)
+	return row, mapError(err)
+}
+
+func (q errorQueries) ListAccountsWithPendingInterest(ctx context.Context, periodEnd time.Time) ([]int64, error) {
+	row, err := q.queries.ListAccountsWithPendingInterest(ctx, periodEnd)
+	return row, mapError(err)
+}
+
+func (q errorQueries) ListBeneficiaries(ctx context.Context, ownerID uuid.UUID) ([]Beneficiary, error) {
+	row, err := q.queries.ListBeneficiaries(ctx, ownerID)
+	return row, mapError(err)
+}
+
+func (q errorQueries) ListCashTransactionsByTill(ctx context.Context, tillID int64) ([]CashTransaction, error) {
+	row, err := q.queries.ListCashTransactionsByTill(ctx, tillID)
+	return row, mapError(err)
+}
+
+func (q errorQueries) ListDueLoanInstallments(ctx context.Context, dueDate time.Time) ([]LoanInstallment, error) {
+	row, err := q.queries.ListDueLoanInstallments(ctx, dueDate)
+	return row, mapError(err)
+}
+
+func (q errorQueries) ListFeeScheduleTiers(ctx context.Context, scheduleID int64) ([]FeeScheduleTier, error) {
+	row, err := q.queries.ListFeeScheduleTiers(ctx, scheduleID)
+	return row, mapError(err)
+}
+
+func (q errorQueries) ListGLAccounts(ctx context.Context) ([]GlAccount, error) {
+	row, err := q.queries.ListGLAccounts(ctx)
+	return row, mapError(err)
+}
+
+func (q errorQueries) ListInterestAccruals(ctx context.Context, accountID int64) ([]InterestAccrual, error) {
+	row, err := q.queries.ListInterestAccruals(ctx, accountID)
+	return row, mapError(err)
+}
+
+func (q errorQueries) ListInterestBearingAccounts(ctx context.Context, asOf time.Time) ([]ListInterestBearingAccountsRow, error) {
+	row, err := q.queries.ListInterestBearingAccounts(ctx, asOf)
+	return row, mapError(err)
+}
+
+func (q errorQueries) ListJournalEntriesByReference(ctx context.Context, reference string) ([]JournalEntry, error) {
+	row, err := q.queries.ListJournalEntriesByReference(ctx, reference)
+	return row, mapError(err)
+}
+
+func (q errorQueries) ListJournalLines(ctx context.Context, journalEntryID int64) ([]JournalLine, error) {
+	row, err := q.queries.ListJournalLines(ctx, journalEntryID)
+	return row, mapError(err)
+}
+
+func (q errorQueries) ListLoanInstallments(ctx context.Context, loanID int64) ([]LoanInstallment, error) {
+	row, err := q.queries.ListLoanInstallments(ctx, loanID)
+	return row, mapError(err)
+}
+
+func (q errorQueries) ListMaturingTermDeposits(ctx context.Context, maturityDate time.Time) ([]TermDeposit, error) {
+	row, err := q.queries.ListMaturingTermDeposits(ctx, maturityDate)
+	return row, mapError(err)
+}
+
+func (q errorQueries) ListPaymentRequests(ctx context.Context, requesterID uuid.UUID) ([]PaymentRequest, error) {
+	row, err := q.queries.ListPaymentRequests(ctx, requesterID)
+	return row, mapError(err)
+}
+
+func (q errorQueries) ListPendingTransferApprovals(ctx context.Context, fromAccountID int64) ([]TransferApproval, error) {
+	row, err := q.queries.ListPendingTransferApprovals(ctx, fromAccountID)
+	return row, mapError(err)
+}
+
+func (q errorQueries) ListPotsByParent(ctx context.Context, parentAccountID int64) ([]ListPotsByParentRow, error) {
+	row, err := q.queries.ListPotsByParent(ctx, parentAccountID)
+	return row, mapError(err)
+}
+
+func (q errorQueries) ListTermDepositRates(ctx context.Context) ([]TermDepositRate, error) {
+	row, err := q.queries.ListTermDepositRates(ctx)
+	return row, mapError(err)
+}
+
+func (q errorQueries) MarkInterestAccrualsCapitalized(ctx context.Context, arg MarkInterestAccrualsCapitalizedParams) error {
+	return mapError(q.queries.MarkInterestAccrualsCapitalized(ctx, arg))
+}
+
+func (q errorQueries) MarkLoanInstallmentOverdue(ctx context.Context, arg MarkLoanInstallmentOverdueParams) (LoanInstallment, error) {
+	row, err := q.queries.MarkLoanInstallmentOverdue(ctx, arg)
+	return row, mapError(err)
+}
+
+func (q errorQueries) MarkLoanInstallmentPaid(ctx context.Context, arg MarkLoanInstallmentPaidParams) (LoanInstallment, error) {
+	row, err := q.queries.MarkLoanInstallmentPaid(ctx, arg)
+	return row, mapError(err)
+}
+
+func (q errorQueries) SaveIdempotentResponse(ctx context.Context, arg SaveIdempotentResponseParams) (IdempotencyKey, error) {
+	row, err := q.queries.SaveIdempotentResponse(ctx, arg)
+	return row, mapError(err)
+}
+
+func (q errorQueries) TakeOverIdempotencyKey(ctx context.Context, arg TakeOverIdempotencyKeyParams) (IdempotencyKey, error) {
+	row, err := q.queries.TakeOverIdempotencyKey(ctx, arg)
+	return row, mapError(err)
+}
+
+func (q errorQueries) UpdateAccountStatus(ctx context.Context, arg UpdateAccountStatusParams) (Account, error) {
+	row, err := q.queries.UpdateAccountStatus(ctx, arg)
+	return row, mapError(err)
+}
+
+func (q errorQueries) UpdateBalanceAccount(ctx context.Context, arg UpdateBalanceAccountParams) (Account, error) {
+	row, err := q.queries.UpdateBalanceAccount(ctx, arg)
+	return row, mapError(err)
+}
+
+func (q errorQueries) UpdateLoanStatus(ctx context.Context, arg UpdateLoanStatusParams) (Loan, error) {
+	row, err := q.queries.UpdateLoanStatus(ctx, arg)
+	return row, mapError(err)
+}
+
+func (q errorQueries) UpdateUserRole(ctx context.Context, arg UpdateUserRoleParams) (User, error) {
+	row, err := q.queries.UpdateUserRole(ctx, arg)
+	return row, mapError(err)
+}
@@ -0,0 +1,39 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorCode(t *testing.T) {
+	err := fmt.Errorf("create user: %w", &pgconn.PgError{Code: UniqueViolation})
+	require.Equal(t, UniqueViolation, errorCode(err))
+	require.Empty(t, errorCode(sql.ErrNoRows))
+	require.Empty(t, errorCode(nil))
+}
+
+func TestMapError(t *testing.T) {
+	pgErr := &pgconn.PgError{Code: ForeignKeyViolation, ConstraintName: "accounts_owner_id_fkey"}
+	err := mapError(fmt.Errorf("create account: %w", pgErr))
+	require.ErrorIs(t, err, ErrForeignKeyViolation)
+	require.False(t, errors.Is(err, ErrUniqueViolation))
+
+	// the error of the backend is kept
+	var got *pgconn.PgError
+	require.ErrorAs(t, err, &got)
+	require.Equal(t, "accounts_owner_id_fkey", got.ConstraintName)
+
+	// mapped once only
+	require.Same(t, err, mapError(err))
+
+	// other errors are left alone
+	serialization := &pgconn.PgError{Code: SerializationFailure}
+	require.Equal(t, error(serialization), mapError(serialization))
+	require.Equal(t, sql.ErrNoRows, mapError(sql.ErrNoRows))
+	require.NoError(t, mapError(nil))
+}
@@ -0,0 +1,138 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+const (
+	FeeKindFlat       = "flat"
+	FeeKindPercentage = "percentage"
+	FeeKindTiered     = "tiered"
+
+	AccountTypeCurrent      = "current"
+	AccountTypeHouseRevenue = "house_revenue"
+)
+
+// TransferFee is the fee breakdown of a transfer. Amount is Flat plus
+// Variable, moved by Adjustment when the schedule's min or max cap applies.
+type TransferFee struct {
+	ScheduleID     int64  `json:"schedule_id,omitempty"`
+	Kind           string `json:"kind,omitempty"`
+	Flat           int64  `json:"flat"`
+	Variable       int64  `json:"variable"`
+	Adjustment     int64  `json:"adjustment"`
+	Amount         int64  `json:"amount"`
+	HouseAccountID int64  `json:"house_account_id,omitempty"`
+	FromEntry      *Entry `json:"from_entry,omitempty"`
+	HouseEntry     *Entry `json:"house_entry,omitempty"`
+}
+
+// percentOf returns bps basis points of amount, rounded half up
+func percentOf(amount, bps int64) int64 {
+	return (amount*bps + 5000) / 10000
+}
+
+func (s FeeSchedule) compute(tiers []FeeScheduleTier, amount int64) TransferFee {
+	fee := TransferFee{
+		ScheduleID: s.ID,
+		Kind:       s.Kind,
+	}
+
+	switch s.Kind {
+	case FeeKindFlat:
+		fee.Flat = s.FlatAmount
+	case FeeKindPercentage:
+		fee.Variable = percentOf(amount, s.RateBps)
+	case FeeKindTiered:
+		// tiers are ordered by their upper bound, the unbounded tier last
+		for _, tier := range tiers {
+			if tier.UpTo == 0 || amount <= tier.UpTo {
+				fee.Flat = tier.FlatAmount
+				fee.Variable = percentOf(amount, tier.RateBps)
+				break
+			}
+		}
+	}
+
+	total := fee.Flat + fee.Variable
+	if s.MinFee > 0 && total < s.MinFee {
+		total = s.MinFee
+	}
+	if s.MaxFee > 0 && total > s.MaxFee {
+		total = s.MaxFee
+	}
+	fee.Adjustment = total - fee.Flat - fee.Variable
+	fee.Amount = total
+
+	return fee
+}
+
+// computeTransferFee looks up the fee schedule for the currency and type of
+// the source account. Accounts without a schedule are not charged.
+func computeTransferFee(ctx context.Context, q Querier, from Account, amount int64) (TransferFee, error) {
+	schedule, err := q.GetFeeSchedule(ctx, GetFeeScheduleParams{
+		Currency:    from.Currency,
+		AccountType: from.AccountType,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return TransferFee{}, nil
+		}
+		return TransferFee{}, err
+	}
+
+	var tiers []FeeScheduleTier
+	if schedule.Kind == FeeKindTiered {
+		tiers, err = q.ListFeeScheduleTiers(ctx, schedule.ID)
+		if err != nil {
+			return TransferFee{}, err
+		}
+	}
+
+	return schedule.compute(tiers, amount), nil
+}
+
+// postTransferFee books the fee as a separate pair of entries, debiting the
+// source account and crediting the house revenue account of its currency.
+func postTransferFee(ctx context.Context, q Querier, from Account, fee *TransferFee) (Account, error) {
+	house, err := q.GetHouseAccount(ctx, GetHouseAccountParams{
+		AccountType: AccountTypeHouseRevenue,
+		Currency:    from.Currency,
+	})
+	if err != nil {
+		return Account{}, fmt.Errorf("cannot find house revenue account for %s: %w", from.Currency, err)
+	}
+	fee.HouseAccountID = house.ID
+
+	fromEntry, err := q.CreateEntry(ctx, CreateEntryParams{
+		AccountID: from.ID,
+		Amount:    -fee.Amount,
+	})
+	if err != nil {
+		return Account{}, err
+	}
+	fee.FromEntry = &fromEntry
+
+	houseEntry, err := q.CreateEntry(ctx, CreateEntryParams{
+		AccountID: house.ID,
+		Amount:    fee.Amount,
+	})
+	if err != nil {
+		return Account{}, err
+	}
+	fee.HouseEntry = &houseEntry
+
+	if _, err = q.AddBalanceAccount(ctx, AddBalanceAccountParams{
+		ID:     house.ID,
+		Amount: fee.Amount,
+	}); err != nil {
+		return Account{}, err
+	}
+
+	return q.AddBalanceAccount(ctx, AddBalanceAccountParams{
+		ID:     from.ID,
+		Amount: -fee.Amount,
+	})
+}
@@ -0,0 +1,72 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeeScheduleCompute(t *testing.T) {
+	tiers := []FeeScheduleTier{
+		{UpTo: 10000, FlatAmount: 50},
+		{UpTo: 100000, FlatAmount: 100, RateBps: 50},
+		{UpTo: 0, RateBps: 25},
+	}
+
+	testCases := []struct {
+		name     string
+		schedule FeeSchedule
+		amount   int64
+		expected TransferFee
+	}{
+		{
+			name:     "Flat",
+			schedule: FeeSchedule{Kind: FeeKindFlat, FlatAmount: 250},
+			amount:   100000,
+			expected: TransferFee{Kind: FeeKindFlat, Flat: 250, Amount: 250},
+		},
+		{
+			name:     "PercentageRoundsHalfUp",
+			schedule: FeeSchedule{Kind: FeeKindPercentage, RateBps: 150},
+			amount:   12345,
+			expected: TransferFee{Kind: FeeKindPercentage, Variable: 185, Amount: 185},
+		},
+		{
+			name:     "PercentageMinCap",
+			schedule: FeeSchedule{Kind: FeeKindPercentage, RateBps: 100, MinFee: 50},
+			amount:   1000,
+			expected: TransferFee{Kind: FeeKindPercentage, Variable: 10, Adjustment: 40, Amount: 50},
+		},
+		{
+			name:     "PercentageMaxCap",
+			schedule: FeeSchedule{Kind: FeeKindPercentage, RateBps: 100, MaxFee: 500},
+			amount:   1000000,
+			expected: TransferFee{Kind: FeeKindPercentage, Variable: 10000, Adjustment: -9500, Amount: 500},
+		},
+		{
+			name:     "TieredFirstTier",
+			schedule: FeeSchedule{Kind: FeeKindTiered},
+			amount:   10000,
+			expected: TransferFee{Kind: FeeKindTiered, Flat: 50, Amount: 50},
+		},
+		{
+			name:     "TieredMiddleTier",
+			schedule: FeeSchedule{Kind: FeeKindTiered},
+			amount:   50000,
+			expected: TransferFee{Kind: FeeKindTiered, Flat: 100, Variable: 250, Amount: 350},
+		},
+		{
+			name:     "TieredUnboundedTier",
+			schedule: FeeSchedule{Kind: FeeKindTiered, MaxFee: 1000},
+			amount:   1000000,
+			expected: TransferFee{Kind: FeeKindTiered, Variable: 2500, Adjustment: -1500, Amount: 1000},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fee := tc.schedule.compute(tiers, tc.amount)
+			require.Equal(t, tc.expected, fee)
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+const (
+	AccountTypeBusiness = "business"
+
+	ApprovalStatusPending  = "pending"
+	ApprovalStatusApproved = "approved"
+	ApprovalStatusRejected = "rejected"
+)
+
+var (
+	ErrApprovalDecided = errors.New("the transfer has already been approved or rejected")
+	ErrSelfApproval    = errors.New("a transfer cannot be approved by the holder who made it")
+)
+
+type DecideTransferApprovalTxParams struct {
+	ID        int64     `json:"id"`
+	DecidedBy uuid.UUID `json:"decided_by"`
+}
+
+type ApproveTransferTxResult struct {
+	Approval TransferApproval `json:"approval"`
+	Transfer TransferTxResult `json:"transfer"`
+}
+
+// ApproveTransferTx makes a transfer waiting for approval. The transfer is
+// checked and charged like any other at the time it is approved.
+func (s *SQLStore) ApproveTransferTx(ctx context.Context, arg DecideTransferApprovalTxParams) (ApproveTransferTxResult, error) {
+	var result ApproveTransferTxResult
+
+	err := s.execTxIsolation(ctx, sql.LevelSerializable, func(q Querier) error {
+		approval, err := pendingTransferApproval(ctx, q, arg.ID)
+		if err != nil {
+			return err
+		}
+		if approval.RequestedBy == arg.DecidedBy {
+			return ErrSelfApproval
+		}
+
+		result.Transfer, err = transfer(ctx, q, TransferTxParams{
+			FromAccountID: approval.FromAccountID,
+			ToAccountID:   approval.ToAccountID,
+			Amount:        approval.Amount,
+		}, false)
+		if err != nil {
+			return err
+		}
+
+		result.Approval, err = q.DecideTransferApproval(ctx, DecideTransferApprovalParams{
+			ID:         approval.ID,
+			Status:     ApprovalStatusApproved,
+			DecidedBy:  uuid.NullUUID{UUID: arg.DecidedBy, Valid: true},
+			TransferID: sql.NullInt64{Int64: result.Transfer.Transfer.ID, Valid: true},
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// RejectTransferTx turns down a transfer waiting for approval, nothing is
+// moved
+func (s *SQLStore) RejectTransferTx(ctx context.Context, arg DecideTransferApprovalTxParams) (TransferApproval, error) {
+	var result TransferApproval
+
+	err := s.execTx(ctx, func(q Querier) error {
+		approval, err := pendingTransferApproval(ctx, q, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		result, err = q.DecideTransferApproval(ctx, DecideTransferApprovalParams{
+			ID:        approval.ID,
+			Status:    ApprovalStatusRejected,
+			DecidedBy: uuid.NullUUID{UUID: arg.DecidedBy, Valid: true},
+		})
+		return err
+	})
+
+	return result, err
+}
+
+func pendingTransferApproval(ctx context.Context, q Querier, id int64) (TransferApproval, error) {
+	approval, err := q.GetTransferApprovalForUpdate(ctx, id)
+	if err != nil {
+		return approval, err
+	}
+	if approval.Status != ApprovalStatusPending {
+		return approval, ErrApprovalDecided
+	}
+	return approval, nil
+}
@@ -0,0 +1,136 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createDummyHolder(t *testing.T, account Account, spendLimit int64) AccountHolder {
+	user := createDummyUser(t)
+
+	holder, err := testQueries.CreateAccountHolder(context.Background(), CreateAccountHolderParams{
+		AccountID:   account.ID,
+		UserID:      user.ID,
+		CanInitiate: true,
+		CanApprove:  spendLimit == 0,
+		SpendLimit:  spendLimit,
+	})
+	require.NoError(t, err)
+	require.Equal(t, account.ID, holder.AccountID)
+	require.Equal(t, user.ID, holder.UserID)
+	require.Equal(t, spendLimit, holder.SpendLimit)
+
+	return holder
+}
+
+func TestAccountHolders(t *testing.T) {
+	account := createDummyAccount(t)
+	holder := createDummyHolder(t, account, 0)
+
+	got, err := testQueries.GetAccountHolder(context.Background(), GetAccountHolderParams{
+		AccountID: account.ID,
+		UserID:    holder.UserID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, holder, got)
+
+	holders, err := testQueries.ListAccountHolders(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Len(t, holders, 1)
+
+	// held accounts are listed with the holder's own accounts
+	accounts, err := testQueries.FetchAccountsByOwner(context.Background(), FetchAccountsByOwnerParams{
+		OwnerID: holder.UserID,
+		Limit:   5,
+		Offset:  0,
+	})
+	require.NoError(t, err)
+	require.Len(t, accounts, 1)
+	require.Equal(t, account.ID, accounts[0].ID)
+
+	_, err = testQueries.DeleteAccountHolder(context.Background(), DeleteAccountHolderParams{
+		AccountID: account.ID,
+		UserID:    holder.UserID,
+	})
+	require.NoError(t, err)
+
+	_, err = testQueries.GetAccountHolder(context.Background(), GetAccountHolderParams{
+		AccountID: account.ID,
+		UserID:    holder.UserID,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestApproveTransferTx(t *testing.T) {
+	store := NewStore(testDB)
+	account := createDummyAccount(t)
+	to := createDummyAccount(t)
+	employee := createDummyHolder(t, account, 10)
+	manager := createDummyHolder(t, account, 0)
+
+	approval, err := testQueries.CreateTransferApproval(context.Background(), CreateTransferApprovalParams{
+		FromAccountID: account.ID,
+		ToAccountID:   to.ID,
+		Amount:        11,
+		RequestedBy:   employee.UserID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, ApprovalStatusPending, approval.Status)
+
+	pending, err := testQueries.ListPendingTransferApprovals(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+
+	_, err = store.ApproveTransferTx(context.Background(), DecideTransferApprovalTxParams{
+		ID:        approval.ID,
+		DecidedBy: employee.UserID,
+	})
+	require.ErrorIs(t, err, ErrSelfApproval)
+
+	result, err := store.ApproveTransferTx(context.Background(), DecideTransferApprovalTxParams{
+		ID:        approval.ID,
+		DecidedBy: manager.UserID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, ApprovalStatusApproved, result.Approval.Status)
+	require.Equal(t, manager.UserID, result.Approval.DecidedBy.UUID)
+	require.Equal(t, result.Transfer.Transfer.ID, result.Approval.TransferID.Int64)
+	require.Equal(t, approval.Amount, result.Transfer.Transfer.Amount)
+	require.Equal(t, account.Balance-approval.Amount-result.Transfer.Fee.Amount, result.Transfer.FromAccount.Balance)
+
+	_, err = store.RejectTransferTx(context.Background(), DecideTransferApprovalTxParams{
+		ID:        approval.ID,
+		DecidedBy: manager.UserID,
+	})
+	require.ErrorIs(t, err, ErrApprovalDecided)
+}
+
+func TestRejectTransferTx(t *testing.T) {
+	store := NewStore(testDB)
+	account := createDummyAccount(t)
+	to := createDummyAccount(t)
+	employee := createDummyHolder(t, account, 10)
+
+	approval, err := testQueries.CreateTransferApproval(context.Background(), CreateTransferApprovalParams{
+		FromAccountID: account.ID,
+		ToAccountID:   to.ID,
+		Amount:        11,
+		RequestedBy:   employee.UserID,
+	})
+	require.NoError(t, err)
+
+	rejected, err := store.RejectTransferTx(context.Background(), DecideTransferApprovalTxParams{
+		ID:        approval.ID,
+		DecidedBy: account.OwnerID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, ApprovalStatusRejected, rejected.Status)
+	require.False(t, rejected.TransferID.Valid)
+
+	unchanged, err := testQueries.GetAccount(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Equal(t, account.Balance, unchanged.Balance)
+}
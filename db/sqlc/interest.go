@@ -0,0 +1,221 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/flukis/simplebank/util"
+)
+
+const (
+	AccountTypeSavings              = "savings"
+	AccountTypeHouseInterestExpense = "house_interest_expense"
+
+	// accruals are kept in millionths of the smallest currency unit so that
+	// daily interest on small balances is not lost to rounding
+	microsPerUnit = 1000000
+)
+
+// accrueMicros returns the interest earned by balance at an annual rate of
+// rateBps over days/basis of a year, in micros and rounded half up.
+func accrueMicros(balance, rateBps, days, basis int64) int64 {
+	n := new(big.Int).Mul(big.NewInt(balance), big.NewInt(rateBps))
+	n.Mul(n, big.NewInt(days))
+	n.Mul(n, big.NewInt(microsPerUnit))
+
+	d := big.NewInt(10000 * basis)
+	n.Add(n, new(big.Int).Quo(d, big.NewInt(2)))
+	return n.Quo(n, d).Int64()
+}
+
+// AccrueInterest records one day of interest for every interest bearing
+// account, based on its closing balance of that day. Accounts already
+// accrued for the date are skipped, so the same date can be run again.
+func (s *SQLStore) AccrueInterest(ctx context.Context, date time.Time) ([]InterestAccrual, error) {
+	day := startOfDay(date)
+	next := day.AddDate(0, 0, 1)
+
+	accounts, err := s.ListInterestBearingAccounts(ctx, next)
+	if err != nil {
+		return nil, err
+	}
+
+	accruals := []InterestAccrual{}
+	for _, account := range accounts {
+		if account.Balance <= 0 {
+			continue
+		}
+
+		days, basis, err := util.DayCount(account.DayCount, day, next)
+		if err != nil {
+			return accruals, err
+		}
+
+		accrual, err := s.CreateInterestAccrual(ctx, CreateInterestAccrualParams{
+			AccountID:    account.ID,
+			AccrualDate:  day,
+			Balance:      account.Balance,
+			RateBps:      account.RateBps,
+			DayCount:     account.DayCount,
+			AmountMicros: accrueMicros(account.Balance, account.RateBps, days, basis),
+		})
+		if err != nil {
+			if err == sql.ErrNoRows {
+				// accrued by an earlier run
+				continue
+			}
+			return accruals, err
+		}
+		accruals = append(accruals, accrual)
+	}
+
+	return accruals, nil
+}
+
+// CapitalizeInterest posts the interest accrued up to periodEnd, usually the
+// last day of a month, to every account that has some pending. Each account
+// is capitalized in its own transaction and only once.
+func (s *SQLStore) CapitalizeInterest(ctx context.Context, periodEnd time.Time) ([]InterestCapitalization, error) {
+	periodEnd = startOfDay(periodEnd)
+
+	ids, err := s.ListAccountsWithPendingInterest(ctx, periodEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	capitalizations := []InterestCapitalization{}
+	for _, id := range ids {
+		var capitalization *InterestCapitalization
+
+		err := s.execTx(ctx, func(q Querier) error {
+			account, err := q.GetAccountForUpdate(ctx, id)
+			if err != nil {
+				return err
+			}
+
+			capitalization, err = capitalizeInterest(ctx, q, account, periodEnd)
+			return err
+		})
+		if err != nil {
+			return capitalizations, err
+		}
+
+		if capitalization != nil {
+			capitalizations = append(capitalizations, *capitalization)
+		}
+	}
+
+	return capitalizations, nil
+}
+
+// capitalizeInterest must run inside a transaction holding the account lock.
+// Whole units are posted and the remaining micros are carried to the next
+// period. It returns nil when nothing is pending.
+func capitalizeInterest(ctx context.Context, q Querier, account Account, periodEnd time.Time) (*InterestCapitalization, error) {
+	pending, err := q.GetPendingInterest(ctx, GetPendingInterestParams{
+		AccountID: account.ID,
+		PeriodEnd: periodEnd,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if pending.Count == 0 {
+		return nil, nil
+	}
+
+	var carry int64
+	last, err := q.GetLastInterestCapitalization(ctx, account.ID)
+	if err == nil {
+		carry = last.CarryMicros
+	} else if err != sql.ErrNoRows {
+		return nil, err
+	}
+
+	total := pending.TotalMicros + carry
+	arg := CreateInterestCapitalizationParams{
+		AccountID:     account.ID,
+		PeriodEnd:     periodEnd,
+		AccruedMicros: pending.TotalMicros,
+		Amount:        total / microsPerUnit,
+		CarryMicros:   total % microsPerUnit,
+	}
+
+	var houseEntry, entry Entry
+	if arg.Amount > 0 {
+		houseEntry, entry, err = postInterest(ctx, q, account, arg.Amount)
+		if err != nil {
+			return nil, err
+		}
+		arg.EntryID = sql.NullInt64{Int64: entry.ID, Valid: true}
+	}
+
+	capitalization, err := q.CreateInterestCapitalization(ctx, arg)
+	if err != nil {
+		return nil, err
+	}
+
+	if arg.Amount > 0 {
+		reference := fmt.Sprintf("interest:%d", capitalization.ID)
+		if _, err = bookEntries(ctx, q, reference, houseEntry, entry); err != nil {
+			return nil, err
+		}
+	}
+
+	err = q.MarkInterestAccrualsCapitalized(ctx, MarkInterestAccrualsCapitalizedParams{
+		CapitalizationID: sql.NullInt64{Int64: capitalization.ID, Valid: true},
+		AccountID:        account.ID,
+		PeriodEnd:        periodEnd,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &capitalization, nil
+}
+
+// postInterest pays interest from the house interest expense account of the
+// currency and returns the entries debiting the house and crediting the account.
+func postInterest(ctx context.Context, q Querier, account Account, amount int64) (Entry, Entry, error) {
+	house, err := q.GetHouseAccount(ctx, GetHouseAccountParams{
+		AccountType: AccountTypeHouseInterestExpense,
+		Currency:    account.Currency,
+	})
+	if err != nil {
+		return Entry{}, Entry{}, fmt.Errorf("cannot find house interest expense account for %s: %w", account.Currency, err)
+	}
+
+	houseEntry, err := q.CreateEntry(ctx, CreateEntryParams{
+		AccountID: house.ID,
+		Amount:    -amount,
+	})
+	if err != nil {
+		return Entry{}, Entry{}, err
+	}
+
+	entry, err := q.CreateEntry(ctx, CreateEntryParams{
+		AccountID: account.ID,
+		Amount:    amount,
+	})
+	if err != nil {
+		return Entry{}, Entry{}, err
+	}
+
+	if _, err = q.AddBalanceAccount(ctx, AddBalanceAccountParams{
+		ID:     house.ID,
+		Amount: -amount,
+	}); err != nil {
+		return Entry{}, Entry{}, err
+	}
+
+	if _, err = q.AddBalanceAccount(ctx, AddBalanceAccountParams{
+		ID:     account.ID,
+		Amount: amount,
+	}); err != nil {
+		return Entry{}, Entry{}, err
+	}
+
+	return houseEntry, entry, nil
+}
@@ -0,0 +1,91 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/flukis/simplebank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccrueMicros(t *testing.T) {
+	// 3.65% a year on 1000.00 is 0.10 a day under ACT/365
+	require.Equal(t, int64(10*microsPerUnit), accrueMicros(100000, 365, 1, 365))
+	require.Equal(t, int64(6849), accrueMicros(100, 250, 1, 365))
+	require.Equal(t, int64(0), accrueMicros(1, 1, 1, 360))
+	require.Equal(t, int64(0), accrueMicros(100000, 300, 0, 360))
+	// large balances must not overflow
+	require.Equal(t, int64(136986301369863014), accrueMicros(5e15, 100, 1, 365))
+}
+
+func createDummySavingsAccount(t *testing.T) Account {
+	user := createDummyUser(t)
+	account, err := testQueries.CreateAccount(context.Background(), CreateAccountParams{
+		OwnerID:     user.ID,
+		Currency:    "USD",
+		AccountType: AccountTypeSavings,
+	})
+	require.NoError(t, err)
+	require.Equal(t, AccountTypeSavings, account.AccountType)
+
+	return fundDummyAccount(t, account)
+}
+
+func findAccrual(accruals []InterestAccrual, accountID int64) *InterestAccrual {
+	for i := range accruals {
+		if accruals[i].AccountID == accountID {
+			return &accruals[i]
+		}
+	}
+	return nil
+}
+
+func TestAccrueAndCapitalizeInterest(t *testing.T) {
+	store := NewStore(testDB)
+	account := createDummySavingsAccount(t)
+	today := startOfDay(time.Now())
+
+	accruals, err := store.AccrueInterest(context.Background(), today)
+	require.NoError(t, err)
+	accrual := findAccrual(accruals, account.ID)
+	require.NotNil(t, accrual)
+	require.Equal(t, account.Balance, accrual.Balance)
+	require.Equal(t, util.DayCountACT365, accrual.DayCount)
+	require.Equal(t, accrueMicros(account.Balance, accrual.RateBps, 1, 365), accrual.AmountMicros)
+
+	// running the same date again does not accrue twice
+	accruals, err = store.AccrueInterest(context.Background(), today)
+	require.NoError(t, err)
+	require.Nil(t, findAccrual(accruals, account.ID))
+
+	capitalizations, err := store.CapitalizeInterest(context.Background(), today)
+	require.NoError(t, err)
+
+	var capitalization *InterestCapitalization
+	for i := range capitalizations {
+		if capitalizations[i].AccountID == account.ID {
+			capitalization = &capitalizations[i]
+		}
+	}
+	require.NotNil(t, capitalization)
+	require.Equal(t, accrual.AmountMicros, capitalization.AccruedMicros)
+	require.Equal(t, accrual.AmountMicros/microsPerUnit, capitalization.Amount)
+	require.Equal(t, accrual.AmountMicros%microsPerUnit, capitalization.CarryMicros)
+
+	updated, err := testQueries.GetAccount(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Equal(t, account.Balance+capitalization.Amount, updated.Balance)
+
+	stored, err := testQueries.ListInterestAccruals(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Len(t, stored, 1)
+	require.Equal(t, capitalization.ID, stored[0].CapitalizationID.Int64)
+
+	// and capitalizing again posts nothing
+	capitalizations, err = store.CapitalizeInterest(context.Background(), today)
+	require.NoError(t, err)
+	for _, c := range capitalizations {
+		require.NotEqual(t, account.ID, c.AccountID)
+	}
+}
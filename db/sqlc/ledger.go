@@ -0,0 +1,175 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+)
+
+const (
+	GLTypeAsset     = "asset"
+	GLTypeLiability = "liability"
+	GLTypeEquity    = "equity"
+	GLTypeIncome    = "income"
+	GLTypeExpense   = "expense"
+)
+
+// UnbalancedJournalError is returned when the lines of a journal entry do
+// not sum to zero in one of their currencies.
+type UnbalancedJournalError struct {
+	Reference string `json:"reference"`
+	Currency  string `json:"currency"`
+	Sum       int64  `json:"sum"`
+}
+
+func (e *UnbalancedJournalError) Error() string {
+	return fmt.Sprintf("journal %s is unbalanced by %d %s", e.Reference, e.Sum, e.Currency)
+}
+
+func checkJournalBalanced(reference string, lines []CreateJournalLineParams) error {
+	sums := map[string]int64{}
+	for _, line := range lines {
+		sums[line.Currency] += line.Amount
+	}
+
+	currencies := make([]string, 0, len(sums))
+	for currency := range sums {
+		currencies = append(currencies, currency)
+	}
+	sort.Strings(currencies)
+
+	for _, currency := range currencies {
+		if sums[currency] != 0 {
+			return &UnbalancedJournalError{
+				Reference: reference,
+				Currency:  currency,
+				Sum:       sums[currency],
+			}
+		}
+	}
+	return nil
+}
+
+// postJournal writes a journal entry with its lines. The JournalEntryID of
+// the lines is filled in here.
+func postJournal(ctx context.Context, q Querier, reference string, lines []CreateJournalLineParams) (JournalEntry, error) {
+	if err := checkJournalBalanced(reference, lines); err != nil {
+		return JournalEntry{}, err
+	}
+
+	journal, err := q.CreateJournalEntry(ctx, reference)
+	if err != nil {
+		return JournalEntry{}, err
+	}
+
+	for _, line := range lines {
+		line.JournalEntryID = journal.ID
+		if _, err = q.CreateJournalLine(ctx, line); err != nil {
+			return JournalEntry{}, err
+		}
+	}
+
+	return journal, nil
+}
+
+// bookEntries books a balanced set of account entries to the general ledger,
+// each on the GL account mapped to the type of its account. Account balances
+// are what the bank owes the holder, so crediting an account with an entry
+// of X is a GL credit, a journal line of -X.
+func bookEntries(ctx context.Context, q Querier, reference string, entries ...Entry) (JournalEntry, error) {
+	lines := make([]CreateJournalLineParams, len(entries))
+	for i, entry := range entries {
+		gl, err := q.GetGLAccountForAccount(ctx, entry.AccountID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return JournalEntry{}, fmt.Errorf("no GL account mapped for account %d", entry.AccountID)
+			}
+			return JournalEntry{}, err
+		}
+
+		lines[i] = CreateJournalLineParams{
+			GlAccountID: gl.GlAccountID,
+			AccountID:   sql.NullInt64{Int64: entry.AccountID, Valid: true},
+			Currency:    gl.Currency,
+			Amount:      -entry.Amount,
+		}
+	}
+
+	return postJournal(ctx, q, reference, lines)
+}
+
+type TrialBalanceLine struct {
+	GlAccountID int64  `json:"gl_account_id"`
+	Code        string `json:"code"`
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Debit       int64  `json:"debit"`
+	Credit      int64  `json:"credit"`
+}
+
+type TrialBalanceCurrency struct {
+	Currency    string             `json:"currency"`
+	Lines       []TrialBalanceLine `json:"lines"`
+	TotalDebit  int64              `json:"total_debit"`
+	TotalCredit int64              `json:"total_credit"`
+	Balanced    bool               `json:"balanced"`
+}
+
+type TrialBalance struct {
+	AsOf       time.Time              `json:"as_of"`
+	Currencies []TrialBalanceCurrency `json:"currencies"`
+}
+
+// buildTrialBalance groups the GL balances per currency, rows must be
+// ordered by currency
+func buildTrialBalance(asOf time.Time, rows []GetTrialBalanceRow) TrialBalance {
+	report := TrialBalance{
+		AsOf:       asOf,
+		Currencies: []TrialBalanceCurrency{},
+	}
+
+	for _, row := range rows {
+		n := len(report.Currencies)
+		if n == 0 || report.Currencies[n-1].Currency != row.Currency {
+			report.Currencies = append(report.Currencies, TrialBalanceCurrency{
+				Currency: row.Currency,
+				Lines:    []TrialBalanceLine{},
+			})
+			n++
+		}
+		currency := &report.Currencies[n-1]
+
+		line := TrialBalanceLine{
+			GlAccountID: row.GlAccountID,
+			Code:        row.Code,
+			Name:        row.Name,
+			Type:        row.Type,
+		}
+		if row.Balance > 0 {
+			line.Debit = row.Balance
+		} else {
+			line.Credit = -row.Balance
+		}
+		currency.Lines = append(currency.Lines, line)
+		currency.TotalDebit += line.Debit
+		currency.TotalCredit += line.Credit
+	}
+
+	for i := range report.Currencies {
+		report.Currencies[i].Balanced = report.Currencies[i].TotalDebit == report.Currencies[i].TotalCredit
+	}
+
+	return report
+}
+
+// TrialBalance reports the balance of every GL account, per currency, from
+// the journal entries posted before asOf.
+func (s *SQLStore) TrialBalance(ctx context.Context, asOf time.Time) (TrialBalance, error) {
+	rows, err := s.GetTrialBalance(ctx, asOf)
+	if err != nil {
+		return TrialBalance{}, err
+	}
+	return buildTrialBalance(asOf, rows), nil
+}
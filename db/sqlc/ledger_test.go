@@ -0,0 +1,107 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckJournalBalanced(t *testing.T) {
+	balanced := []CreateJournalLineParams{
+		{GlAccountID: 1, Currency: "USD", Amount: 100},
+		{GlAccountID: 2, Currency: "USD", Amount: -100},
+		{GlAccountID: 1, Currency: "EUR", Amount: -5},
+		{GlAccountID: 3, Currency: "EUR", Amount: 5},
+	}
+	require.NoError(t, checkJournalBalanced("test", balanced))
+
+	// balanced overall but not per currency
+	unbalanced := []CreateJournalLineParams{
+		{GlAccountID: 1, Currency: "USD", Amount: 100},
+		{GlAccountID: 2, Currency: "EUR", Amount: -100},
+	}
+	err := checkJournalBalanced("test", unbalanced)
+	var journalErr *UnbalancedJournalError
+	require.ErrorAs(t, err, &journalErr)
+	require.Equal(t, "EUR", journalErr.Currency)
+	require.Equal(t, int64(-100), journalErr.Sum)
+}
+
+func TestBuildTrialBalance(t *testing.T) {
+	asOf := time.Date(2023, time.April, 1, 0, 0, 0, 0, time.UTC)
+	rows := []GetTrialBalanceRow{
+		{GlAccountID: 2, Code: "2100", Type: GLTypeLiability, Currency: "EUR", Balance: -500},
+		{GlAccountID: 4, Code: "3900", Type: GLTypeEquity, Currency: "EUR", Balance: 500},
+		{GlAccountID: 2, Code: "2100", Type: GLTypeLiability, Currency: "USD", Balance: -1000},
+		{GlAccountID: 4, Code: "3900", Type: GLTypeEquity, Currency: "USD", Balance: 990},
+		{GlAccountID: 5, Code: "4100", Type: GLTypeIncome, Currency: "USD", Balance: -10},
+		{GlAccountID: 6, Code: "5100", Type: GLTypeExpense, Currency: "USD", Balance: 20},
+	}
+
+	report := buildTrialBalance(asOf, rows)
+	require.Equal(t, asOf, report.AsOf)
+	require.Len(t, report.Currencies, 2)
+
+	eur := report.Currencies[0]
+	require.Equal(t, "EUR", eur.Currency)
+	require.Len(t, eur.Lines, 2)
+	require.Equal(t, int64(500), eur.TotalDebit)
+	require.Equal(t, int64(500), eur.TotalCredit)
+	require.True(t, eur.Balanced)
+
+	usd := report.Currencies[1]
+	require.Equal(t, "USD", usd.Currency)
+	require.Len(t, usd.Lines, 4)
+	require.Equal(t, int64(1000), usd.Lines[0].Credit)
+	require.Equal(t, int64(990), usd.Lines[1].Debit)
+	require.Equal(t, int64(1010), usd.TotalDebit)
+	require.Equal(t, int64(1010), usd.TotalCredit)
+	require.True(t, usd.Balanced)
+
+	usdOff := buildTrialBalance(asOf, rows[2:4])
+	require.False(t, usdOff.Currencies[0].Balanced)
+}
+
+func TestTransferTxBooksJournal(t *testing.T) {
+	store := NewStore(testDB)
+	account1 := createDummyAccount(t)
+
+	user := createDummyUser(t)
+	account2, err := testQueries.CreateAccount(context.Background(), CreateAccountParams{
+		OwnerID:     user.ID,
+		Currency:    account1.Currency,
+		AccountType: AccountTypeCurrent,
+	})
+	require.NoError(t, err)
+
+	result, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+
+	journals, err := testQueries.ListJournalEntriesByReference(context.Background(), fmt.Sprintf("transfer:%d", result.Transfer.ID))
+	require.NoError(t, err)
+	require.Len(t, journals, 1)
+
+	lines, err := testQueries.ListJournalLines(context.Background(), journals[0].ID)
+	require.NoError(t, err)
+	require.Len(t, lines, 2)
+
+	// debit the payer's liability, credit the payee's
+	require.Equal(t, account1.ID, lines[0].AccountID.Int64)
+	require.Equal(t, int64(10), lines[0].Amount)
+	require.Equal(t, account2.ID, lines[1].AccountID.Int64)
+	require.Equal(t, int64(-10), lines[1].Amount)
+	require.Equal(t, account1.Currency, lines[0].Currency)
+
+	report, err := store.TrialBalance(context.Background(), time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	for _, currency := range report.Currencies {
+		require.True(t, currency.Balanced, currency.Currency)
+	}
+}
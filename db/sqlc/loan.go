@@ -0,0 +1,302 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/flukis/simplebank/util"
+)
+
+const (
+	AccountTypeHouseLoans        = "house_loans"
+	AccountTypeHouseLoanInterest = "house_loan_interest"
+
+	LoanStatusActive  = "active"
+	LoanStatusPaidOff = "paid_off"
+
+	InstallmentPending = "pending"
+	InstallmentOverdue = "overdue"
+	InstallmentPaid    = "paid"
+)
+
+// LoanBalance is what is left to pay on a loan. Arrears are the unpaid
+// installments that are past due, late fees included.
+type LoanBalance struct {
+	Principal           int64 `json:"principal"`
+	Interest            int64 `json:"interest"`
+	LateFees            int64 `json:"late_fees"`
+	Total               int64 `json:"total"`
+	Arrears             int64 `json:"arrears"`
+	OverdueInstallments int   `json:"overdue_installments"`
+}
+
+func (i LoanInstallment) amountDue() int64 {
+	return i.Principal + i.Interest + i.LateFee
+}
+
+// OutstandingLoanBalance sums up the unpaid installments of a loan
+func OutstandingLoanBalance(installments []LoanInstallment) LoanBalance {
+	var balance LoanBalance
+	for _, installment := range installments {
+		if installment.Status == InstallmentPaid {
+			continue
+		}
+		balance.Principal += installment.Principal
+		balance.Interest += installment.Interest
+		balance.LateFees += installment.LateFee
+		balance.Total += installment.amountDue()
+		if installment.Status == InstallmentOverdue {
+			balance.Arrears += installment.amountDue()
+			balance.OverdueInstallments++
+		}
+	}
+	return balance
+}
+
+type CreateLoanTxParams struct {
+	AccountID    int64  `json:"account_id"`
+	Principal    int64  `json:"principal"`
+	RateBps      int64  `json:"rate_bps"`
+	Term         int32  `json:"term"`
+	Frequency    string `json:"frequency"`
+	Amortization string `json:"amortization"`
+	LateFee      int64  `json:"late_fee"`
+}
+
+type CreateLoanTxResult struct {
+	Loan         Loan              `json:"loan"`
+	Installments []LoanInstallment `json:"installments"`
+	Disbursement TransferTxResult  `json:"disbursement"`
+}
+
+// CreateLoanTx books a loan with its amortization schedule, starting today,
+// and pays the principal from the house loans account of the currency into
+// the borrower's account.
+func (s *SQLStore) CreateLoanTx(ctx context.Context, arg CreateLoanTxParams) (CreateLoanTxResult, error) {
+	var result CreateLoanTxResult
+
+	err := s.execTx(ctx, func(q Querier) error {
+		account, err := q.GetAccount(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+		if err = checkAccountActive(account); err != nil {
+			return err
+		}
+
+		schedule, err := util.Amortize(arg.Principal, arg.RateBps, arg.Term, arg.Frequency, arg.Amortization, startOfDay(time.Now()))
+		if err != nil {
+			return err
+		}
+
+		house, err := q.GetHouseAccount(ctx, GetHouseAccountParams{
+			AccountType: AccountTypeHouseLoans,
+			Currency:    account.Currency,
+		})
+		if err != nil {
+			return fmt.Errorf("cannot find house loans account for %s: %w", account.Currency, err)
+		}
+
+		result.Disbursement, err = transfer(ctx, q, TransferTxParams{
+			FromAccountID: house.ID,
+			ToAccountID:   account.ID,
+			Amount:        arg.Principal,
+		}, true)
+		if err != nil {
+			return err
+		}
+
+		result.Loan, err = q.CreateLoan(ctx, CreateLoanParams{
+			AccountID:      account.ID,
+			Currency:       account.Currency,
+			Principal:      arg.Principal,
+			RateBps:        arg.RateBps,
+			Term:           arg.Term,
+			Frequency:      arg.Frequency,
+			Amortization:   arg.Amortization,
+			LateFee:        arg.LateFee,
+			DisbursementID: result.Disbursement.Transfer.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Installments = make([]LoanInstallment, 0, len(schedule))
+		for _, line := range schedule {
+			installment, err := q.CreateLoanInstallment(ctx, CreateLoanInstallmentParams{
+				LoanID:    result.Loan.ID,
+				Number:    line.Number,
+				DueDate:   line.DueDate,
+				Principal: line.Principal,
+				Interest:  line.Interest,
+			})
+			if err != nil {
+				return err
+			}
+			result.Installments = append(result.Installments, installment)
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+type LoanCollectionResult struct {
+	Collected []LoanInstallment `json:"collected"`
+	Overdue   []LoanInstallment `json:"overdue"`
+}
+
+// CollectLoanInstallments debits the borrower for every unpaid installment
+// due on or before date, each in its own transaction. An installment the
+// account cannot pay in full is left in arrears and charged the loan's late
+// fee the first time; it is tried again on the next run.
+func (s *SQLStore) CollectLoanInstallments(ctx context.Context, date time.Time) (LoanCollectionResult, error) {
+	result := LoanCollectionResult{
+		Collected: []LoanInstallment{},
+		Overdue:   []LoanInstallment{},
+	}
+
+	due, err := s.ListDueLoanInstallments(ctx, startOfDay(date))
+	if err != nil {
+		return result, err
+	}
+
+	for _, installment := range due {
+		var collected bool
+
+		err := s.execTx(ctx, func(q Querier) error {
+			collected = false
+
+			loan, err := q.GetLoanForUpdate(ctx, installment.LoanID)
+			if err != nil {
+				return err
+			}
+
+			installment, err = q.GetLoanInstallmentForUpdate(ctx, installment.ID)
+			if err != nil {
+				return err
+			}
+			if installment.Status == InstallmentPaid {
+				return nil
+			}
+
+			account, err := q.GetAccountForUpdate(ctx, loan.AccountID)
+			if err != nil {
+				return err
+			}
+
+			if checkAccountActive(account) != nil || account.Balance < installment.amountDue() {
+				if installment.Status == InstallmentPending {
+					installment, err = q.MarkLoanInstallmentOverdue(ctx, MarkLoanInstallmentOverdueParams{
+						LateFee: loan.LateFee,
+						ID:      installment.ID,
+					})
+				}
+				return err
+			}
+
+			installment, err = collectInstallment(ctx, q, loan, account, installment)
+			if err != nil {
+				return err
+			}
+			collected = true
+
+			unpaid, err := q.CountUnpaidLoanInstallments(ctx, loan.ID)
+			if err != nil {
+				return err
+			}
+			if unpaid == 0 {
+				_, err = q.UpdateLoanStatus(ctx, UpdateLoanStatusParams{
+					ID:     loan.ID,
+					Status: LoanStatusPaidOff,
+				})
+			}
+			return err
+		})
+		if err != nil {
+			return result, err
+		}
+
+		if collected {
+			result.Collected = append(result.Collected, installment)
+		} else if installment.Status == InstallmentOverdue {
+			result.Overdue = append(result.Overdue, installment)
+		}
+	}
+
+	return result, nil
+}
+
+// collectInstallment debits the borrower for the whole installment and
+// credits the principal back to house loans, the interest to loan interest
+// income and any late fee to house revenue.
+func collectInstallment(ctx context.Context, q Querier, loan Loan, account Account, installment LoanInstallment) (LoanInstallment, error) {
+	entry, err := q.CreateEntry(ctx, CreateEntryParams{
+		AccountID: account.ID,
+		Amount:    -installment.amountDue(),
+	})
+	if err != nil {
+		return installment, err
+	}
+
+	if _, err = q.AddBalanceAccount(ctx, AddBalanceAccountParams{
+		ID:     account.ID,
+		Amount: -installment.amountDue(),
+	}); err != nil {
+		return installment, err
+	}
+
+	entries := []Entry{entry}
+	credits := []struct {
+		accountType string
+		amount      int64
+	}{
+		{AccountTypeHouseLoans, installment.Principal},
+		{AccountTypeHouseLoanInterest, installment.Interest},
+		{AccountTypeHouseRevenue, installment.LateFee},
+	}
+	for _, credit := range credits {
+		if credit.amount == 0 {
+			continue
+		}
+
+		house, err := q.GetHouseAccount(ctx, GetHouseAccountParams{
+			AccountType: credit.accountType,
+			Currency:    loan.Currency,
+		})
+		if err != nil {
+			return installment, fmt.Errorf("cannot find %s account for %s: %w", credit.accountType, loan.Currency, err)
+		}
+
+		houseEntry, err := q.CreateEntry(ctx, CreateEntryParams{
+			AccountID: house.ID,
+			Amount:    credit.amount,
+		})
+		if err != nil {
+			return installment, err
+		}
+		entries = append(entries, houseEntry)
+
+		if _, err = q.AddBalanceAccount(ctx, AddBalanceAccountParams{
+			ID:     house.ID,
+			Amount: credit.amount,
+		}); err != nil {
+			return installment, err
+		}
+	}
+
+	installment, err = q.MarkLoanInstallmentPaid(ctx, MarkLoanInstallmentPaidParams{
+		ID:      installment.ID,
+		EntryID: sql.NullInt64{Int64: entry.ID, Valid: true},
+	})
+	if err != nil {
+		return installment, err
+	}
+
+	reference := fmt.Sprintf("loan-installment:%d", installment.ID)
+	_, err = bookEntries(ctx, q, reference, entries...)
+	return installment, err
+}
@@ -0,0 +1,116 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/flukis/simplebank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutstandingLoanBalance(t *testing.T) {
+	installments := []LoanInstallment{
+		{Principal: 100, Interest: 10, Status: InstallmentPaid},
+		{Principal: 100, Interest: 8, LateFee: 5, Status: InstallmentOverdue},
+		{Principal: 100, Interest: 6, Status: InstallmentPending},
+	}
+
+	require.Equal(t, LoanBalance{
+		Principal:           200,
+		Interest:            14,
+		LateFees:            5,
+		Total:               219,
+		Arrears:             113,
+		OverdueInstallments: 1,
+	}, OutstandingLoanBalance(installments))
+}
+
+func createDummyLoan(t *testing.T, account Account) CreateLoanTxResult {
+	store := NewStore(testDB)
+
+	result, err := store.CreateLoanTx(context.Background(), CreateLoanTxParams{
+		AccountID:    account.ID,
+		Principal:    100000,
+		RateBps:      1200,
+		Term:         2,
+		Frequency:    util.FrequencyMonthly,
+		Amortization: util.AmortizationAnnuity,
+		LateFee:      500,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, account.ID, result.Loan.AccountID)
+	require.Equal(t, LoanStatusActive, result.Loan.Status)
+	require.Equal(t, result.Disbursement.Transfer.ID, result.Loan.DisbursementID)
+	require.Equal(t, account.ID, result.Disbursement.ToAccount.ID)
+	require.Equal(t, AccountTypeHouseLoans, result.Disbursement.FromAccount.AccountType)
+	require.Equal(t, account.Balance+100000, result.Disbursement.ToAccount.Balance)
+
+	require.Len(t, result.Installments, 2)
+	require.Equal(t, int64(100000), OutstandingLoanBalance(result.Installments).Principal)
+
+	return result
+}
+
+func TestCollectLoanInstallments(t *testing.T) {
+	store := NewStore(testDB)
+
+	payer, err := testQueries.UpdateBalanceAccount(context.Background(), UpdateBalanceAccountParams{
+		ID:      createDummyAccount(t).ID,
+		Balance: 200000,
+	})
+	require.NoError(t, err)
+	paid := createDummyLoan(t, payer)
+
+	defaulter := createDummyAccount(t)
+	unpaid := createDummyLoan(t, defaulter)
+	_, err = testQueries.UpdateBalanceAccount(context.Background(), UpdateBalanceAccountParams{
+		ID:      defaulter.ID,
+		Balance: 0,
+	})
+	require.NoError(t, err)
+
+	lastDue := paid.Installments[1].DueDate
+	_, err = store.CollectLoanInstallments(context.Background(), lastDue)
+	require.NoError(t, err)
+
+	loan, err := testQueries.GetLoan(context.Background(), paid.Loan.ID)
+	require.NoError(t, err)
+	require.Equal(t, LoanStatusPaidOff, loan.Status)
+
+	installments, err := testQueries.ListLoanInstallments(context.Background(), paid.Loan.ID)
+	require.NoError(t, err)
+	var total int64
+	for _, installment := range installments {
+		require.Equal(t, InstallmentPaid, installment.Status)
+		require.True(t, installment.EntryID.Valid)
+		require.True(t, installment.PaidAt.Valid)
+		total += installment.amountDue()
+
+		journals, err := testQueries.ListJournalEntriesByReference(context.Background(), fmt.Sprintf("loan-installment:%d", installment.ID))
+		require.NoError(t, err)
+		require.Len(t, journals, 1)
+	}
+	require.Zero(t, OutstandingLoanBalance(installments).Total)
+
+	account, err := testQueries.GetAccount(context.Background(), payer.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(300000)-total, account.Balance)
+
+	// the defaulter is in arrears with a late fee on each installment
+	installments, err = testQueries.ListLoanInstallments(context.Background(), unpaid.Loan.ID)
+	require.NoError(t, err)
+	balance := OutstandingLoanBalance(installments)
+	require.Equal(t, 2, balance.OverdueInstallments)
+	require.Equal(t, int64(1000), balance.LateFees)
+	require.Equal(t, balance.Total, balance.Arrears)
+
+	// the late fee is charged only once per installment
+	_, err = store.CollectLoanInstallments(context.Background(), lastDue)
+	require.NoError(t, err)
+
+	installments, err = testQueries.ListLoanInstallments(context.Background(), unpaid.Loan.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1000), OutstandingLoanBalance(installments).LateFees)
+}
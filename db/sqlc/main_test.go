@@ -1,13 +1,13 @@
 package db
 
 import (
+	"context"
 	"database/sql"
 	"log"
 	"os"
 	"testing"
 
 	"github.com/flukis/simplebank/util"
-	_ "github.com/lib/pq"
 )
 
 var testQueries *Queries
@@ -19,7 +19,7 @@ func TestMain(m *testing.M) {
 	if err != nil {
 		log.Fatal(err)
 	}
-	testDB, err = sql.Open(conf.DBDriver, conf.DBSource)
+	testDB, _, err = OpenDB(context.Background(), conf)
 	if err != nil {
 		log.Fatal("cannot connect to db: ", err)
 	}
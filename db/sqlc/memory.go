@@ -0,0 +1,450 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/flukis/simplebank/util"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// houseOwnerID owns the accounts of the bank itself, see the
+// add_transfer_fees migration
+var houseOwnerID = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+
+type limitKey struct{ tier, currency string }
+
+type rateKey struct{ accountType, currency string }
+
+type termRateKey struct {
+	currency   string
+	termMonths int32
+}
+
+type holderKey struct {
+	accountID int64
+	userID    uuid.UUID
+}
+
+type requestKey struct {
+	userID uuid.UUID
+	key    string
+}
+
+// memoryDB holds the tables of the memory store. One mutex guards all of
+// them: a statement holds it while it runs and a transaction from its first
+// statement to its commit, so transactions never interleave and behave as
+// serializable ones that are never aborted.
+type memoryDB struct {
+	mu sync.Mutex
+	// last id handed out per table, like sequences not rolled back
+	sequences map[string]int64
+
+	users                   map[uuid.UUID]User
+	accounts                map[int64]Account
+	entries                 map[int64]Entry
+	transfers               map[int64]Transfer
+	transferLimits          map[limitKey]TransferLimit
+	feeSchedules            map[int64]FeeSchedule
+	feeScheduleTiers        map[int64]FeeScheduleTier
+	interestRates           map[rateKey]InterestRate
+	interestAccruals        map[int64]InterestAccrual
+	interestCapitalizations map[int64]InterestCapitalization
+	glAccounts              map[int64]GlAccount
+	glAccountMappings       map[string]GlAccountMapping
+	journalEntries          map[int64]JournalEntry
+	journalLines            map[int64]JournalLine
+	tellerTills             map[int64]TellerTill
+	cashTransactions        map[int64]CashTransaction
+	loans                   map[int64]Loan
+	loanInstallments        map[int64]LoanInstallment
+	termDepositRates        map[termRateKey]TermDepositRate
+	termDeposits            map[int64]TermDeposit
+	pots                    map[int64]Pot
+	accountHolders          map[holderKey]AccountHolder
+	transferApprovals       map[int64]TransferApproval
+	beneficiaries           map[int64]Beneficiary
+	paymentRequests         map[int64]PaymentRequest
+	idempotencyKeys         map[requestKey]IdempotencyKey
+}
+
+// NewMemoryStore returns a store keeping everything in memory, seeded with
+// the rows the migrations insert. It enforces the same unique, foreign key
+// and check constraints as Postgres and reports them with the same errors,
+// for development and tests without a database. Nothing survives the
+// process.
+func NewMemoryStore() Store {
+	db := newMemoryDB()
+	return &SQLStore{
+		Querier: errorQueries{&memoryQueries{db: db}},
+		tx:      db,
+		retry:   DefaultTxRetryPolicy,
+	}
+}
+
+func newMemoryDB() *memoryDB {
+	db := &memoryDB{
+		sequences:               map[string]int64{},
+		users:                   map[uuid.UUID]User{},
+		accounts:                map[int64]Account{},
+		entries:                 map[int64]Entry{},
+		transfers:               map[int64]Transfer{},
+		transferLimits:          map[limitKey]TransferLimit{},
+		feeSchedules:            map[int64]FeeSchedule{},
+		feeScheduleTiers:        map[int64]FeeScheduleTier{},
+		interestRates:           map[rateKey]InterestRate{},
+		interestAccruals:        map[int64]InterestAccrual{},
+		interestCapitalizations: map[int64]InterestCapitalization{},
+		glAccounts:              map[int64]GlAccount{},
+		glAccountMappings:       map[string]GlAccountMapping{},
+		journalEntries:          map[int64]JournalEntry{},
+		journalLines:            map[int64]JournalLine{},
+		tellerTills:             map[int64]TellerTill{},
+		cashTransactions:        map[int64]CashTransaction{},
+		loans:                   map[int64]Loan{},
+		loanInstallments:        map[int64]LoanInstallment{},
+		termDepositRates:        map[termRateKey]TermDepositRate{},
+		termDeposits:            map[int64]TermDeposit{},
+		pots:                    map[int64]Pot{},
+		accountHolders:          map[holderKey]AccountHolder{},
+		transferApprovals:       map[int64]TransferApproval{},
+		beneficiaries:           map[int64]Beneficiary{},
+		paymentRequests:         map[int64]PaymentRequest{},
+		idempotencyKeys:         map[requestKey]IdempotencyKey{},
+	}
+	db.seed()
+	return db
+}
+
+// seed inserts the rows of the migrations
+func (db *memoryDB) seed() {
+	q := &memoryQueries{db: db}
+	now := q.now()
+
+	db.users[houseOwnerID] = User{
+		ID:                houseOwnerID,
+		Username:          "simplebank",
+		HashedPassword:    "!",
+		FullName:          "Simplebank House",
+		Email:             "house@simplebank.internal",
+		PasswordChangedAt: time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC),
+		CreatedAt:         now,
+		Role:              util.RoleCustomer,
+	}
+
+	currencies := []string{"USD", "EUR", "IDR"}
+	houseTypes := [][]string{
+		{AccountTypeHouseRevenue},
+		{AccountTypeHouseInterestExpense},
+		{AccountTypeHouseCash},
+		{AccountTypeHouseLoans, AccountTypeHouseLoanInterest},
+		{AccountTypeHouseTermDeposits},
+	}
+	for _, types := range houseTypes {
+		for _, accountType := range types {
+			for _, currency := range currencies {
+				q.CreateAccount(context.Background(), CreateAccountParams{
+					OwnerID:     houseOwnerID,
+					Currency:    currency,
+					AccountType: accountType,
+				})
+			}
+		}
+	}
+
+	limits := []TransferLimit{
+		{Tier: "standard", Currency: "USD", MaxPerTransfer: 1000000, AccountDailyAmount: 2500000, AccountMonthlyAmount: 25000000, AccountDailyCount: 100, UserDailyAmount: 5000000, UserMonthlyAmount: 50000000, UserDailyCount: 200},
+		{Tier: "standard", Currency: "EUR", MaxPerTransfer: 1000000, AccountDailyAmount: 2500000, AccountMonthlyAmount: 25000000, AccountDailyCount: 100, UserDailyAmount: 5000000, UserMonthlyAmount: 50000000, UserDailyCount: 200},
+		{Tier: "standard", Currency: "IDR", MaxPerTransfer: 1000000000, AccountDailyAmount: 2500000000, AccountMonthlyAmount: 25000000000, AccountDailyCount: 100, UserDailyAmount: 5000000000, UserMonthlyAmount: 50000000000, UserDailyCount: 200},
+		{Tier: "premium", Currency: "USD", MaxPerTransfer: 10000000, AccountDailyAmount: 25000000, AccountMonthlyAmount: 250000000, AccountDailyCount: 500, UserDailyAmount: 50000000, UserMonthlyAmount: 500000000, UserDailyCount: 1000},
+		{Tier: "premium", Currency: "EUR", MaxPerTransfer: 10000000, AccountDailyAmount: 25000000, AccountMonthlyAmount: 250000000, AccountDailyCount: 500, UserDailyAmount: 50000000, UserMonthlyAmount: 500000000, UserDailyCount: 1000},
+		{Tier: "premium", Currency: "IDR", MaxPerTransfer: 10000000000, AccountDailyAmount: 25000000000, AccountMonthlyAmount: 250000000000, AccountDailyCount: 500, UserDailyAmount: 50000000000, UserMonthlyAmount: 500000000000, UserDailyCount: 1000},
+	}
+	for _, limit := range limits {
+		limit.NewPayeeMaxPerTransfer = limit.MaxPerTransfer / 10
+		limit.CreatedAt = now
+		db.transferLimits[limitKey{limit.Tier, limit.Currency}] = limit
+	}
+
+	for _, rate := range []CreateInterestRateParams{
+		{AccountType: AccountTypeSavings, Currency: "USD", RateBps: 250, DayCount: util.DayCountACT365},
+		{AccountType: AccountTypeSavings, Currency: "EUR", RateBps: 150, DayCount: util.DayCountACT360},
+		{AccountType: AccountTypeSavings, Currency: "IDR", RateBps: 300, DayCount: util.DayCount30360},
+	} {
+		q.CreateInterestRate(context.Background(), rate)
+	}
+
+	gl := map[string]int64{}
+	for _, account := range []CreateGLAccountParams{
+		{Code: "1000", Name: "Cash and cash equivalents", Type: "asset"},
+		{Code: "2100", Name: "Customer current accounts", Type: "liability"},
+		{Code: "2200", Name: "Customer savings accounts", Type: "liability"},
+		{Code: "3900", Name: "Opening balance equity", Type: "equity"},
+		{Code: "4100", Name: "Fee income", Type: "income"},
+		{Code: "5100", Name: "Interest expense", Type: "expense"},
+		{Code: "1200", Name: "Loans receivable", Type: "asset"},
+		{Code: "4200", Name: "Loan interest income", Type: "income"},
+		{Code: "2300", Name: "Customer term deposits", Type: "liability"},
+	} {
+		created, _ := q.CreateGLAccount(context.Background(), account)
+		gl[created.Code] = created.ID
+	}
+	for accountType, code := range map[string]string{
+		AccountTypeCurrent:              "2100",
+		AccountTypeSavings:              "2200",
+		AccountTypeHouseRevenue:         "4100",
+		AccountTypeHouseInterestExpense: "5100",
+		AccountTypeHouseCash:            "1000",
+		AccountTypeHouseLoans:           "1200",
+		AccountTypeHouseLoanInterest:    "4200",
+		AccountTypeHouseTermDeposits:    "2300",
+		AccountTypePot:                  "2200",
+		AccountTypeBusiness:             "2100",
+	} {
+		db.glAccountMappings[accountType] = GlAccountMapping{AccountType: accountType, GlAccountID: gl[code]}
+	}
+	q.CreateJournalEntry(context.Background(), "opening-balances")
+
+	for _, rate := range []CreateTermDepositRateParams{
+		{Currency: "USD", TermMonths: 3, RateBps: 350, DayCount: util.DayCountACT365, PenaltyBps: 200},
+		{Currency: "USD", TermMonths: 6, RateBps: 400, DayCount: util.DayCountACT365, PenaltyBps: 200},
+		{Currency: "USD", TermMonths: 12, RateBps: 450, DayCount: util.DayCountACT365, PenaltyBps: 250},
+		{Currency: "EUR", TermMonths: 3, RateBps: 250, DayCount: util.DayCountACT360, PenaltyBps: 150},
+		{Currency: "EUR", TermMonths: 6, RateBps: 300, DayCount: util.DayCountACT360, PenaltyBps: 150},
+		{Currency: "EUR", TermMonths: 12, RateBps: 350, DayCount: util.DayCountACT360, PenaltyBps: 200},
+		{Currency: "IDR", TermMonths: 3, RateBps: 500, DayCount: util.DayCount30360, PenaltyBps: 300},
+		{Currency: "IDR", TermMonths: 6, RateBps: 550, DayCount: util.DayCount30360, PenaltyBps: 300},
+		{Currency: "IDR", TermMonths: 12, RateBps: 600, DayCount: util.DayCount30360, PenaltyBps: 350},
+	} {
+		q.CreateTermDepositRate(context.Background(), rate)
+	}
+}
+
+// memoryTx is a transaction of the memory store. Statements write straight
+// to the tables and log how to undo the write, which a rollback replays
+// backwards.
+type memoryTx struct {
+	now  time.Time
+	undo []func()
+}
+
+func (db *memoryDB) runTx(ctx context.Context, level sql.IsolationLevel, fn func(Querier) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	tx := &memoryTx{now: memoryNow()}
+	committed := false
+	defer func() {
+		if !committed {
+			for i := len(tx.undo) - 1; i >= 0; i-- {
+				tx.undo[i]()
+			}
+		}
+	}()
+
+	if err := fn(&memoryQueries{db: db, tx: tx}); err != nil {
+		return err
+	}
+	committed = true
+	return nil
+}
+
+// memoryQueries runs the queries of db/query on a memoryDB, inside tx when
+// it is set and as a statement of its own otherwise
+type memoryQueries struct {
+	db *memoryDB
+	tx *memoryTx
+}
+
+var _ Querier = (*memoryQueries)(nil)
+
+// lock takes the mutex for a statement run outside a transaction and
+// returns how to release it
+func (q *memoryQueries) lock() func() {
+	if q.tx != nil {
+		return func() {}
+	}
+	q.db.mu.Lock()
+	return q.db.mu.Unlock
+}
+
+// now is the start of the transaction, like now() in Postgres
+func (q *memoryQueries) now() time.Time {
+	if q.tx != nil {
+		return q.tx.now
+	}
+	return memoryNow()
+}
+
+// memoryNow has the precision of a Postgres timestamptz
+func memoryNow() time.Time {
+	return time.Now().Truncate(time.Microsecond)
+}
+
+func (q *memoryQueries) nextID(table string) int64 {
+	q.db.sequences[table]++
+	return q.db.sequences[table]
+}
+
+// newAccountNumber draws account numbers like the generate_account_number
+// function until it finds a free one
+func (q *memoryQueries) newAccountNumber() string {
+	for {
+		number, err := util.NewAccountNumber(fmt.Sprintf("%010d", rand.Int63n(10_000_000_000)))
+		if err != nil {
+			panic(err)
+		}
+		taken := false
+		for _, account := range q.db.accounts {
+			if account.Number == number {
+				taken = true
+				break
+			}
+		}
+		if !taken {
+			return number
+		}
+	}
+}
+
+// put writes a row, logging the write in the transaction
+func put[K comparable, V any](q *memoryQueries, table map[K]V, key K, row V) {
+	if q.tx != nil {
+		old, ok := table[key]
+		q.tx.undo = append(q.tx.undo, func() {
+			if ok {
+				table[key] = old
+			} else {
+				delete(table, key)
+			}
+		})
+	}
+	table[key] = row
+}
+
+// remove deletes a row and returns it
+func remove[K comparable, V any](q *memoryQueries, table map[K]V, key K) (V, error) {
+	row, ok := table[key]
+	if !ok {
+		return row, sql.ErrNoRows
+	}
+	if q.tx != nil {
+		q.tx.undo = append(q.tx.undo, func() { table[key] = row })
+	}
+	delete(table, key)
+	return row, nil
+}
+
+func get[K comparable, V any](table map[K]V, key K) (V, error) {
+	row, ok := table[key]
+	if !ok {
+		return row, sql.ErrNoRows
+	}
+	return row, nil
+}
+
+func exists[K comparable, V any](table map[K]V, key K) bool {
+	_, ok := table[key]
+	return ok
+}
+
+// update changes the row at key with fn, unless fn fails
+func update[K comparable, V any](q *memoryQueries, table map[K]V, key K, fn func(row *V) error) (V, error) {
+	row, ok := table[key]
+	if !ok {
+		return row, sql.ErrNoRows
+	}
+	if err := fn(&row); err != nil {
+		var zero V
+		return zero, err
+	}
+	put(q, table, key, row)
+	return row, nil
+}
+
+// selectRows returns the rows of a table with a bigserial key that match,
+// ordered by id
+func selectRows[V any](table map[int64]V, match func(row V) bool) []V {
+	ids := make([]int64, 0, len(table))
+	for id, row := range table {
+		if match(row) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	var rows []V
+	for _, id := range ids {
+		rows = append(rows, table[id])
+	}
+	return rows
+}
+
+// page applies LIMIT and OFFSET
+func page[V any](rows []V, limit, offset int32) []V {
+	if int(offset) >= len(rows) {
+		return nil
+	}
+	rows = rows[offset:]
+	if int(limit) < len(rows) {
+		rows = rows[:limit]
+	}
+	return rows
+}
+
+// date is the value of t in a date column
+func date(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func oneOf(value string, allowed ...string) bool {
+	for _, a := range allowed {
+		if value == a {
+			return true
+		}
+	}
+	return false
+}
+
+func uniqueViolation(table, constraint string) error {
+	return &pgconn.PgError{
+		Severity:       "ERROR",
+		Code:           UniqueViolation,
+		Message:        fmt.Sprintf("duplicate key value violates unique constraint %q", constraint),
+		TableName:      table,
+		ConstraintName: constraint,
+	}
+}
+
+// foreignKeyViolation names the constraint the way Postgres names the
+// foreign keys of the migrations
+func foreignKeyViolation(table, column string) error {
+	constraint := fmt.Sprintf("%s_%s_fkey", table, column)
+	return &pgconn.PgError{
+		Severity:       "ERROR",
+		Code:           ForeignKeyViolation,
+		Message:        fmt.Sprintf("insert or update on table %q violates foreign key constraint %q", table, constraint),
+		TableName:      table,
+		ConstraintName: constraint,
+	}
+}
+
+func checkViolation(table, constraint string) error {
+	return &pgconn.PgError{
+		Severity:       "ERROR",
+		Code:           CheckViolation,
+		Message:        fmt.Sprintf("new row for relation %q violates check constraint %q", table, constraint),
+		TableName:      table,
+		ConstraintName: constraint,
+	}
+}
@@ -0,0 +1,725 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/flukis/simplebank/util"
+	"github.com/google/uuid"
+)
+
+// Queries of the memory store on users, accounts and the money moved
+// between them
+
+func (q *memoryQueries) CreateUser(ctx context.Context, arg CreateUserParams) (User, error) {
+	defer q.lock()()
+	for _, user := range q.db.users {
+		if user.Email == arg.Email {
+			return User{}, uniqueViolation("users", "users_email_key")
+		}
+	}
+
+	user := User{
+		ID:                uuid.New(),
+		Username:          arg.Username,
+		HashedPassword:    arg.HashedPassword,
+		FullName:          arg.FullName,
+		Email:             arg.Email,
+		PasswordChangedAt: time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC),
+		CreatedAt:         q.now(),
+		Role:              util.RoleCustomer,
+	}
+	put(q, q.db.users, user.ID, user)
+	return user, nil
+}
+
+func (q *memoryQueries) GetUser(ctx context.Context, id uuid.UUID) (User, error) {
+	defer q.lock()()
+	return get(q.db.users, id)
+}
+
+func (q *memoryQueries) GetUserForUpdate(ctx context.Context, id uuid.UUID) (User, error) {
+	return q.GetUser(ctx, id)
+}
+
+func (q *memoryQueries) GetUserByUsername(ctx context.Context, username string) (User, error) {
+	defer q.lock()()
+	users := q.sortedUsers(func(user User) bool { return user.Username == username })
+	if len(users) == 0 {
+		return User{}, sql.ErrNoRows
+	}
+	return users[0], nil
+}
+
+func (q *memoryQueries) GetUserByEmail(ctx context.Context, email string) (User, error) {
+	defer q.lock()()
+	users := q.sortedUsers(func(user User) bool { return user.Email == email })
+	if len(users) == 0 {
+		return User{}, sql.ErrNoRows
+	}
+	return users[0], nil
+}
+
+func (q *memoryQueries) FetchUsers(ctx context.Context, arg FetchUsersParams) ([]User, error) {
+	defer q.lock()()
+	return page(q.sortedUsers(func(User) bool { return true }), arg.Limit, arg.Offset), nil
+}
+
+func (q *memoryQueries) UpdateUserRole(ctx context.Context, arg UpdateUserRoleParams) (User, error) {
+	defer q.lock()()
+	return update(q, q.db.users, arg.ID, func(user *User) error {
+		if !util.IsSupportedRole(arg.Role) {
+			return checkViolation("users", "users_role_check")
+		}
+		user.Role = arg.Role
+		return nil
+	})
+}
+
+// sortedUsers returns the users that match by creation time
+func (q *memoryQueries) sortedUsers(match func(user User) bool) []User {
+	var users []User
+	for _, user := range q.db.users {
+		if match(user) {
+			users = append(users, user)
+		}
+	}
+	sort.Slice(users, func(i, j int) bool {
+		if !users[i].CreatedAt.Equal(users[j].CreatedAt) {
+			return users[i].CreatedAt.Before(users[j].CreatedAt)
+		}
+		return users[i].ID.String() < users[j].ID.String()
+	})
+	return users
+}
+
+func (q *memoryQueries) CreateAccount(ctx context.Context, arg CreateAccountParams) (Account, error) {
+	defer q.lock()()
+	if !exists(q.db.users, arg.OwnerID) {
+		return Account{}, foreignKeyViolation("accounts", "owner_id")
+	}
+
+	account := Account{
+		OwnerID:     arg.OwnerID,
+		Currency:    arg.Currency,
+		CreatedAt:   q.now(),
+		Tier:        "standard",
+		AccountType: arg.AccountType,
+		Status:      AccountStatusActive,
+		Number:      q.newAccountNumber(),
+	}
+	if err := q.checkAccount(account); err != nil {
+		return Account{}, err
+	}
+	account.ID = q.nextID("accounts")
+	put(q, q.db.accounts, account.ID, account)
+	return account, nil
+}
+
+// checkAccount enforces the constraints of the accounts table on row
+func (q *memoryQueries) checkAccount(row Account) error {
+	if !oneOf(row.Status, AccountStatusActive, AccountStatusFrozen, AccountStatusDormant, AccountStatusClosed) {
+		return checkViolation("accounts", "accounts_status_check")
+	}
+
+	// owner_id_currency_type_key only covers open accounts that aren't pots
+	indexed := func(account Account) bool {
+		return account.Status != AccountStatusClosed && account.AccountType != AccountTypePot
+	}
+	for _, other := range q.db.accounts {
+		if other.ID == row.ID {
+			continue
+		}
+		if other.Number == row.Number {
+			return uniqueViolation("accounts", "accounts_number_key")
+		}
+		if indexed(row) && indexed(other) &&
+			other.OwnerID == row.OwnerID && other.Currency == row.Currency && other.AccountType == row.AccountType {
+			return uniqueViolation("accounts", "owner_id_currency_type_key")
+		}
+	}
+	return nil
+}
+
+func (q *memoryQueries) GetAccount(ctx context.Context, id int64) (Account, error) {
+	defer q.lock()()
+	return get(q.db.accounts, id)
+}
+
+func (q *memoryQueries) GetAccountForUpdate(ctx context.Context, id int64) (Account, error) {
+	return q.GetAccount(ctx, id)
+}
+
+func (q *memoryQueries) GetAccountByNumber(ctx context.Context, number string) (Account, error) {
+	defer q.lock()()
+	for _, account := range q.db.accounts {
+		if account.Number == number {
+			return account, nil
+		}
+	}
+	return Account{}, sql.ErrNoRows
+}
+
+func (q *memoryQueries) GetHouseAccount(ctx context.Context, arg GetHouseAccountParams) (Account, error) {
+	defer q.lock()()
+	accounts := selectRows(q.db.accounts, func(account Account) bool {
+		return account.AccountType == arg.AccountType && account.Currency == arg.Currency
+	})
+	if len(accounts) == 0 {
+		return Account{}, sql.ErrNoRows
+	}
+	return accounts[0], nil
+}
+
+func (q *memoryQueries) FetchAccounts(ctx context.Context, arg FetchAccountsParams) ([]Account, error) {
+	defer q.lock()()
+	accounts := selectRows(q.db.accounts, func(Account) bool { return true })
+	return page(accounts, arg.Limit, arg.Offset), nil
+}
+
+func (q *memoryQueries) FetchAccountsByOwner(ctx context.Context, arg FetchAccountsByOwnerParams) ([]Account, error) {
+	defer q.lock()()
+	accounts := selectRows(q.db.accounts, func(account Account) bool {
+		return account.OwnerID == arg.OwnerID || exists(q.db.accountHolders, holderKey{account.ID, arg.OwnerID})
+	})
+	return page(accounts, arg.Limit, arg.Offset), nil
+}
+
+func (q *memoryQueries) UpdateAccountStatus(ctx context.Context, arg UpdateAccountStatusParams) (Account, error) {
+	defer q.lock()()
+	return update(q, q.db.accounts, arg.ID, func(account *Account) error {
+		account.Status = arg.Status
+		return q.checkAccount(*account)
+	})
+}
+
+func (q *memoryQueries) UpdateBalanceAccount(ctx context.Context, arg UpdateBalanceAccountParams) (Account, error) {
+	defer q.lock()()
+	return update(q, q.db.accounts, arg.ID, func(account *Account) error {
+		account.Balance = arg.Balance
+		return nil
+	})
+}
+
+func (q *memoryQueries) AddBalanceAccount(ctx context.Context, arg AddBalanceAccountParams) (Account, error) {
+	defer q.lock()()
+	return update(q, q.db.accounts, arg.ID, func(account *Account) error {
+		account.Balance += arg.Amount
+		return nil
+	})
+}
+
+func (q *memoryQueries) CloseAccount(ctx context.Context, id int64) (Account, error) {
+	defer q.lock()()
+	return update(q, q.db.accounts, id, func(account *Account) error {
+		account.Status = AccountStatusClosed
+		account.ClosedAt = sql.NullTime{Time: q.now(), Valid: true}
+		return nil
+	})
+}
+
+func (q *memoryQueries) CreateEntry(ctx context.Context, arg CreateEntryParams) (Entry, error) {
+	defer q.lock()()
+	if !exists(q.db.accounts, arg.AccountID) {
+		return Entry{}, foreignKeyViolation("entries", "account_id")
+	}
+
+	entry := Entry{
+		ID:        q.nextID("entries"),
+		AccountID: arg.AccountID,
+		Amount:    arg.Amount,
+		CreatedAt: q.now(),
+	}
+	put(q, q.db.entries, entry.ID, entry)
+	return entry, nil
+}
+
+func (q *memoryQueries) GetEntry(ctx context.Context, id int64) (Entry, error) {
+	defer q.lock()()
+	return get(q.db.entries, id)
+}
+
+func (q *memoryQueries) FetchEntries(ctx context.Context, arg FetchEntriesParams) ([]Entry, error) {
+	defer q.lock()()
+	entries := selectRows(q.db.entries, func(entry Entry) bool { return entry.AccountID == arg.AccountID })
+	return page(entries, arg.Limit, arg.Offset), nil
+}
+
+func (q *memoryQueries) CreateTransfer(ctx context.Context, arg CreateTransferParams) (Transfer, error) {
+	defer q.lock()()
+	if !exists(q.db.accounts, arg.FromAccountID) {
+		return Transfer{}, foreignKeyViolation("transfers", "from_account_id")
+	}
+	if !exists(q.db.accounts, arg.ToAccountID) {
+		return Transfer{}, foreignKeyViolation("transfers", "to_account_id")
+	}
+
+	transfer := Transfer{
+		ID:            q.nextID("transfers"),
+		FromAccountID: arg.FromAccountID,
+		ToAccountID:   arg.ToAccountID,
+		Amount:        arg.Amount,
+		CreatedAt:     q.now(),
+		Internal:      arg.Internal,
+	}
+	put(q, q.db.transfers, transfer.ID, transfer)
+	return transfer, nil
+}
+
+func (q *memoryQueries) GetTransfer(ctx context.Context, id int64) (Transfer, error) {
+	defer q.lock()()
+	return get(q.db.transfers, id)
+}
+
+func (q *memoryQueries) FetchTransfer(ctx context.Context, arg FetchTransferParams) ([]Transfer, error) {
+	defer q.lock()()
+	transfers := selectRows(q.db.transfers, func(transfer Transfer) bool {
+		return transfer.FromAccountID == arg.FromAccountID || transfer.ToAccountID == arg.ToAccountID
+	})
+	return page(transfers, arg.Limit, arg.Offset), nil
+}
+
+func (q *memoryQueries) GetTransferLimit(ctx context.Context, arg GetTransferLimitParams) (TransferLimit, error) {
+	defer q.lock()()
+	return get(q.db.transferLimits, limitKey{arg.Tier, arg.Currency})
+}
+
+func (q *memoryQueries) GetAccountTransferUsage(ctx context.Context, arg GetAccountTransferUsageParams) (GetAccountTransferUsageRow, error) {
+	defer q.lock()()
+	var usage GetAccountTransferUsageRow
+	for _, transfer := range q.db.transfers {
+		if transfer.FromAccountID == arg.AccountID && !transfer.Internal && !transfer.CreatedAt.Before(arg.Since) {
+			usage.Count++
+			usage.Total += transfer.Amount
+		}
+	}
+	return usage, nil
+}
+
+func (q *memoryQueries) GetOwnerTransferUsage(ctx context.Context, arg GetOwnerTransferUsageParams) (GetOwnerTransferUsageRow, error) {
+	defer q.lock()()
+	var usage GetOwnerTransferUsageRow
+	for _, transfer := range q.db.transfers {
+		from := q.db.accounts[transfer.FromAccountID]
+		if from.OwnerID == arg.OwnerID && from.Currency == arg.Currency && !transfer.Internal && !transfer.CreatedAt.Before(arg.Since) {
+			usage.Count++
+			usage.Total += transfer.Amount
+		}
+	}
+	return usage, nil
+}
+
+func (q *memoryQueries) GetPayeeTransferTotal(ctx context.Context, arg GetPayeeTransferTotalParams) (int64, error) {
+	defer q.lock()()
+	var total int64
+	for _, transfer := range q.db.transfers {
+		from := q.db.accounts[transfer.FromAccountID]
+		if from.OwnerID == arg.OwnerID && transfer.ToAccountID == arg.ToAccountID && !transfer.CreatedAt.Before(arg.Since) {
+			total += transfer.Amount
+		}
+	}
+	return total, nil
+}
+
+func (q *memoryQueries) CreateAccountHolder(ctx context.Context, arg CreateAccountHolderParams) (AccountHolder, error) {
+	defer q.lock()()
+	key := holderKey{arg.AccountID, arg.UserID}
+	switch {
+	case arg.SpendLimit < 0:
+		return AccountHolder{}, checkViolation("account_holders", "account_holders_spend_limit_check")
+	case exists(q.db.accountHolders, key):
+		return AccountHolder{}, uniqueViolation("account_holders", "account_holders_pkey")
+	case !exists(q.db.accounts, arg.AccountID):
+		return AccountHolder{}, foreignKeyViolation("account_holders", "account_id")
+	case !exists(q.db.users, arg.UserID):
+		return AccountHolder{}, foreignKeyViolation("account_holders", "user_id")
+	}
+
+	holder := AccountHolder{
+		AccountID:   arg.AccountID,
+		UserID:      arg.UserID,
+		CanInitiate: arg.CanInitiate,
+		CanApprove:  arg.CanApprove,
+		SpendLimit:  arg.SpendLimit,
+		CreatedAt:   q.now(),
+	}
+	put(q, q.db.accountHolders, key, holder)
+	return holder, nil
+}
+
+func (q *memoryQueries) GetAccountHolder(ctx context.Context, arg GetAccountHolderParams) (AccountHolder, error) {
+	defer q.lock()()
+	return get(q.db.accountHolders, holderKey{arg.AccountID, arg.UserID})
+}
+
+func (q *memoryQueries) ListAccountHolders(ctx context.Context, accountID int64) ([]AccountHolder, error) {
+	defer q.lock()()
+	var holders []AccountHolder
+	for _, holder := range q.db.accountHolders {
+		if holder.AccountID == accountID {
+			holders = append(holders, holder)
+		}
+	}
+	sort.Slice(holders, func(i, j int) bool {
+		if !holders[i].CreatedAt.Equal(holders[j].CreatedAt) {
+			return holders[i].CreatedAt.Before(holders[j].CreatedAt)
+		}
+		return holders[i].UserID.String() < holders[j].UserID.String()
+	})
+	return holders, nil
+}
+
+func (q *memoryQueries) DeleteAccountHolder(ctx context.Context, arg DeleteAccountHolderParams) (AccountHolder, error) {
+	defer q.lock()()
+	return remove(q, q.db.accountHolders, holderKey{arg.AccountID, arg.UserID})
+}
+
+func (q *memoryQueries) CreateTransferApproval(ctx context.Context, arg CreateTransferApprovalParams) (TransferApproval, error) {
+	defer q.lock()()
+	switch {
+	case arg.Amount <= 0:
+		return TransferApproval{}, checkViolation("transfer_approvals", "transfer_approvals_amount_check")
+	case !exists(q.db.accounts, arg.FromAccountID):
+		return TransferApproval{}, foreignKeyViolation("transfer_approvals", "from_account_id")
+	case !exists(q.db.accounts, arg.ToAccountID):
+		return TransferApproval{}, foreignKeyViolation("transfer_approvals", "to_account_id")
+	case !exists(q.db.users, arg.RequestedBy):
+		return TransferApproval{}, foreignKeyViolation("transfer_approvals", "requested_by")
+	}
+
+	approval := TransferApproval{
+		ID:            q.nextID("transfer_approvals"),
+		FromAccountID: arg.FromAccountID,
+		ToAccountID:   arg.ToAccountID,
+		Amount:        arg.Amount,
+		RequestedBy:   arg.RequestedBy,
+		Status:        ApprovalStatusPending,
+		CreatedAt:     q.now(),
+	}
+	put(q, q.db.transferApprovals, approval.ID, approval)
+	return approval, nil
+}
+
+func (q *memoryQueries) GetTransferApproval(ctx context.Context, id int64) (TransferApproval, error) {
+	defer q.lock()()
+	return get(q.db.transferApprovals, id)
+}
+
+func (q *memoryQueries) GetTransferApprovalForUpdate(ctx context.Context, id int64) (TransferApproval, error) {
+	return q.GetTransferApproval(ctx, id)
+}
+
+func (q *memoryQueries) ListPendingTransferApprovals(ctx context.Context, fromAccountID int64) ([]TransferApproval, error) {
+	defer q.lock()()
+	return selectRows(q.db.transferApprovals, func(approval TransferApproval) bool {
+		return approval.FromAccountID == fromAccountID && approval.Status == ApprovalStatusPending
+	}), nil
+}
+
+func (q *memoryQueries) DecideTransferApproval(ctx context.Context, arg DecideTransferApprovalParams) (TransferApproval, error) {
+	defer q.lock()()
+	return update(q, q.db.transferApprovals, arg.ID, func(approval *TransferApproval) error {
+		switch {
+		case !oneOf(arg.Status, ApprovalStatusPending, ApprovalStatusApproved, ApprovalStatusRejected):
+			return checkViolation("transfer_approvals", "transfer_approvals_status_check")
+		case arg.DecidedBy.Valid && !exists(q.db.users, arg.DecidedBy.UUID):
+			return foreignKeyViolation("transfer_approvals", "decided_by")
+		case arg.TransferID.Valid && !exists(q.db.transfers, arg.TransferID.Int64):
+			return foreignKeyViolation("transfer_approvals", "transfer_id")
+		}
+		approval.Status = arg.Status
+		approval.DecidedBy = arg.DecidedBy
+		approval.TransferID = arg.TransferID
+		approval.DecidedAt = sql.NullTime{Time: q.now(), Valid: true}
+		return nil
+	})
+}
+
+func (q *memoryQueries) CreateBeneficiary(ctx context.Context, arg CreateBeneficiaryParams) (Beneficiary, error) {
+	defer q.lock()()
+	for _, other := range q.db.beneficiaries {
+		if other.OwnerID != arg.OwnerID {
+			continue
+		}
+		if other.Nickname == arg.Nickname {
+			return Beneficiary{}, uniqueViolation("beneficiaries", "owner_id_nickname_key")
+		}
+		if other.AccountID == arg.AccountID {
+			return Beneficiary{}, uniqueViolation("beneficiaries", "owner_id_account_id_key")
+		}
+	}
+	if !exists(q.db.users, arg.OwnerID) {
+		return Beneficiary{}, foreignKeyViolation("beneficiaries", "owner_id")
+	}
+	if !exists(q.db.accounts, arg.AccountID) {
+		return Beneficiary{}, foreignKeyViolation("beneficiaries", "account_id")
+	}
+
+	beneficiary := Beneficiary{
+		ID:              q.nextID("beneficiaries"),
+		OwnerID:         arg.OwnerID,
+		Nickname:        arg.Nickname,
+		AccountID:       arg.AccountID,
+		Currency:        arg.Currency,
+		CoolingOffUntil: arg.CoolingOffUntil,
+		CreatedAt:       q.now(),
+	}
+	put(q, q.db.beneficiaries, beneficiary.ID, beneficiary)
+	return beneficiary, nil
+}
+
+func (q *memoryQueries) GetBeneficiary(ctx context.Context, id int64) (Beneficiary, error) {
+	defer q.lock()()
+	return get(q.db.beneficiaries, id)
+}
+
+func (q *memoryQueries) GetCoolingOffBeneficiaryForUpdate(ctx context.Context, arg GetCoolingOffBeneficiaryForUpdateParams) (Beneficiary, error) {
+	defer q.lock()()
+	for _, beneficiary := range q.db.beneficiaries {
+		if beneficiary.OwnerID == arg.OwnerID && beneficiary.AccountID == arg.AccountID && beneficiary.CoolingOffUntil.After(arg.Now) {
+			return beneficiary, nil
+		}
+	}
+	return Beneficiary{}, sql.ErrNoRows
+}
+
+func (q *memoryQueries) ListBeneficiaries(ctx context.Context, ownerID uuid.UUID) ([]Beneficiary, error) {
+	defer q.lock()()
+	beneficiaries := selectRows(q.db.beneficiaries, func(beneficiary Beneficiary) bool {
+		return beneficiary.OwnerID == ownerID
+	})
+	sort.SliceStable(beneficiaries, func(i, j int) bool {
+		return beneficiaries[i].Nickname < beneficiaries[j].Nickname
+	})
+	return beneficiaries, nil
+}
+
+func (q *memoryQueries) DeleteBeneficiary(ctx context.Context, id int64) (Beneficiary, error) {
+	defer q.lock()()
+	return remove(q, q.db.beneficiaries, id)
+}
+
+func (q *memoryQueries) CreatePot(ctx context.Context, arg CreatePotParams) (Pot, error) {
+	defer q.lock()()
+	switch {
+	case arg.TargetAmount < 0:
+		return Pot{}, checkViolation("pots", "pots_target_amount_check")
+	case arg.RoundUpTo < 0:
+		return Pot{}, checkViolation("pots", "pots_round_up_to_check")
+	case exists(q.db.pots, arg.AccountID):
+		return Pot{}, uniqueViolation("pots", "pots_pkey")
+	}
+	for _, other := range q.db.pots {
+		if other.ParentAccountID == arg.ParentAccountID && other.Name == arg.Name {
+			return Pot{}, uniqueViolation("pots", "parent_account_id_name_key")
+		}
+	}
+	if !exists(q.db.accounts, arg.AccountID) {
+		return Pot{}, foreignKeyViolation("pots", "account_id")
+	}
+	if !exists(q.db.accounts, arg.ParentAccountID) {
+		return Pot{}, foreignKeyViolation("pots", "parent_account_id")
+	}
+
+	pot := Pot{
+		AccountID:       arg.AccountID,
+		ParentAccountID: arg.ParentAccountID,
+		Name:            arg.Name,
+		TargetAmount:    arg.TargetAmount,
+		RoundUpTo:       arg.RoundUpTo,
+		CreatedAt:       q.now(),
+	}
+	put(q, q.db.pots, pot.AccountID, pot)
+	return pot, nil
+}
+
+func (q *memoryQueries) GetPot(ctx context.Context, accountID int64) (Pot, error) {
+	defer q.lock()()
+	return get(q.db.pots, accountID)
+}
+
+func (q *memoryQueries) ListPotsByParent(ctx context.Context, parentAccountID int64) ([]ListPotsByParentRow, error) {
+	defer q.lock()()
+	pots := selectRows(q.db.pots, func(pot Pot) bool {
+		return pot.ParentAccountID == parentAccountID && q.db.accounts[pot.AccountID].Status != AccountStatusClosed
+	})
+	sort.SliceStable(pots, func(i, j int) bool { return pots[i].CreatedAt.Before(pots[j].CreatedAt) })
+
+	var rows []ListPotsByParentRow
+	for _, pot := range pots {
+		account := q.db.accounts[pot.AccountID]
+		rows = append(rows, ListPotsByParentRow{
+			AccountID:    pot.AccountID,
+			Name:         pot.Name,
+			TargetAmount: pot.TargetAmount,
+			RoundUpTo:    pot.RoundUpTo,
+			Balance:      account.Balance,
+			Status:       account.Status,
+			CreatedAt:    pot.CreatedAt,
+		})
+	}
+	return rows, nil
+}
+
+func (q *memoryQueries) GetRoundUpPot(ctx context.Context, parentAccountID int64) (Pot, error) {
+	defer q.lock()()
+	pots := selectRows(q.db.pots, func(pot Pot) bool {
+		return pot.ParentAccountID == parentAccountID && pot.RoundUpTo > 0 &&
+			q.db.accounts[pot.AccountID].Status == AccountStatusActive
+	})
+	if len(pots) == 0 {
+		return Pot{}, sql.ErrNoRows
+	}
+	return pots[0], nil
+}
+
+func (q *memoryQueries) CountOpenPots(ctx context.Context, parentAccountID int64) (int64, error) {
+	defer q.lock()()
+	var count int64
+	for _, pot := range q.db.pots {
+		if pot.ParentAccountID == parentAccountID && q.db.accounts[pot.AccountID].Status != AccountStatusClosed {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (q *memoryQueries) CreatePaymentRequest(ctx context.Context, arg CreatePaymentRequestParams) (PaymentRequest, error) {
+	defer q.lock()()
+	switch {
+	case arg.Amount <= 0:
+		return PaymentRequest{}, checkViolation("payment_requests", "payment_requests_amount_check")
+	case arg.PayerID == arg.RequesterID:
+		return PaymentRequest{}, checkViolation("payment_requests", "payment_requests_payer_check")
+	case !exists(q.db.users, arg.RequesterID):
+		return PaymentRequest{}, foreignKeyViolation("payment_requests", "requester_id")
+	case !exists(q.db.users, arg.PayerID):
+		return PaymentRequest{}, foreignKeyViolation("payment_requests", "payer_id")
+	case !exists(q.db.accounts, arg.ToAccountID):
+		return PaymentRequest{}, foreignKeyViolation("payment_requests", "to_account_id")
+	}
+
+	request := PaymentRequest{
+		ID:          q.nextID("payment_requests"),
+		RequesterID: arg.RequesterID,
+		PayerID:     arg.PayerID,
+		ToAccountID: arg.ToAccountID,
+		Amount:      arg.Amount,
+		Currency:    arg.Currency,
+		Memo:        arg.Memo,
+		Status:      PaymentRequestPending,
+		ExpiresAt:   arg.ExpiresAt,
+		CreatedAt:   q.now(),
+	}
+	put(q, q.db.paymentRequests, request.ID, request)
+	return request, nil
+}
+
+func (q *memoryQueries) GetPaymentRequest(ctx context.Context, id int64) (PaymentRequest, error) {
+	defer q.lock()()
+	return get(q.db.paymentRequests, id)
+}
+
+func (q *memoryQueries) GetPaymentRequestForUpdate(ctx context.Context, id int64) (PaymentRequest, error) {
+	return q.GetPaymentRequest(ctx, id)
+}
+
+func (q *memoryQueries) ListPaymentRequests(ctx context.Context, requesterID uuid.UUID) ([]PaymentRequest, error) {
+	defer q.lock()()
+	requests := selectRows(q.db.paymentRequests, func(request PaymentRequest) bool {
+		return request.RequesterID == requesterID || request.PayerID == requesterID
+	})
+	for i, j := 0, len(requests)-1; i < j; i, j = i+1, j-1 {
+		requests[i], requests[j] = requests[j], requests[i]
+	}
+	return requests, nil
+}
+
+func (q *memoryQueries) DecidePaymentRequest(ctx context.Context, arg DecidePaymentRequestParams) (PaymentRequest, error) {
+	defer q.lock()()
+	return update(q, q.db.paymentRequests, arg.ID, func(request *PaymentRequest) error {
+		switch {
+		case !oneOf(arg.Status, PaymentRequestPending, PaymentRequestAccepted, PaymentRequestDeclined):
+			return checkViolation("payment_requests", "payment_requests_status_check")
+		case arg.TransferID.Valid && !exists(q.db.transfers, arg.TransferID.Int64):
+			return foreignKeyViolation("payment_requests", "transfer_id")
+		}
+		request.Status = arg.Status
+		request.TransferID = arg.TransferID
+		request.DecidedAt = sql.NullTime{Time: q.now(), Valid: true}
+		return nil
+	})
+}
+
+func (q *memoryQueries) CreateIdempotencyKey(ctx context.Context, arg CreateIdempotencyKeyParams) (IdempotencyKey, error) {
+	defer q.lock()()
+	key := requestKey{arg.UserID, arg.Key}
+	switch {
+	case exists(q.db.idempotencyKeys, key):
+		return IdempotencyKey{}, uniqueViolation("idempotency_keys", "idempotency_keys_pkey")
+	case !exists(q.db.users, arg.UserID):
+		return IdempotencyKey{}, foreignKeyViolation("idempotency_keys", "user_id")
+	}
+
+	row := IdempotencyKey{
+		UserID:       arg.UserID,
+		Key:          arg.Key,
+		RequestHash:  arg.RequestHash,
+		ResponseBody: []byte{},
+		CreatedAt:    q.now(),
+	}
+	put(q, q.db.idempotencyKeys, key, row)
+	return row, nil
+}
+
+func (q *memoryQueries) GetIdempotencyKey(ctx context.Context, arg GetIdempotencyKeyParams) (IdempotencyKey, error) {
+	defer q.lock()()
+	return get(q.db.idempotencyKeys, requestKey{arg.UserID, arg.Key})
+}
+
+func (q *memoryQueries) SaveIdempotentResponse(ctx context.Context, arg SaveIdempotentResponseParams) (IdempotencyKey, error) {
+	defer q.lock()()
+	return update(q, q.db.idempotencyKeys, requestKey{arg.UserID, arg.Key}, func(row *IdempotencyKey) error {
+		row.StatusCode = arg.StatusCode
+		row.ContentType = arg.ContentType
+		row.ResponseBody = arg.ResponseBody
+		return nil
+	})
+}
+
+func (q *memoryQueries) TakeOverIdempotencyKey(ctx context.Context, arg TakeOverIdempotencyKeyParams) (IdempotencyKey, error) {
+	defer q.lock()()
+	return update(q, q.db.idempotencyKeys, requestKey{arg.UserID, arg.Key}, func(row *IdempotencyKey) error {
+		if row.StatusCode != 0 || !row.CreatedAt.Before(arg.StartedBefore) {
+			return sql.ErrNoRows
+		}
+		row.CreatedAt = q.now()
+		return nil
+	})
+}
+
+func (q *memoryQueries) DeleteIdempotencyKey(ctx context.Context, arg DeleteIdempotencyKeyParams) error {
+	defer q.lock()()
+	_, err := remove(q, q.db.idempotencyKeys, requestKey{arg.UserID, arg.Key})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	return err
+}
+
+func (q *memoryQueries) DeleteIdempotencyKeysBefore(ctx context.Context, before time.Time) (int64, error) {
+	defer q.lock()()
+	var count int64
+	for key, row := range q.db.idempotencyKeys {
+		if row.CreatedAt.Before(before) {
+			if _, err := remove(q, q.db.idempotencyKeys, key); err != nil {
+				return count, err
+			}
+			count++
+		}
+	}
+	return count, nil
+}
@@ -0,0 +1,384 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"time"
+
+	"github.com/flukis/simplebank/util"
+)
+
+// Queries of the memory store on fees, interest and the general ledger
+
+func (q *memoryQueries) CreateFeeSchedule(ctx context.Context, arg CreateFeeScheduleParams) (FeeSchedule, error) {
+	defer q.lock()()
+	if !oneOf(arg.Kind, "flat", "percentage", "tiered") {
+		return FeeSchedule{}, checkViolation("fee_schedules", "fee_schedules_kind_check")
+	}
+	for _, other := range q.db.feeSchedules {
+		if other.Currency == arg.Currency && other.AccountType == arg.AccountType {
+			return FeeSchedule{}, uniqueViolation("fee_schedules", "currency_account_type_key")
+		}
+	}
+
+	schedule := FeeSchedule{
+		ID:          q.nextID("fee_schedules"),
+		Currency:    arg.Currency,
+		AccountType: arg.AccountType,
+		Kind:        arg.Kind,
+		FlatAmount:  arg.FlatAmount,
+		RateBps:     arg.RateBps,
+		MinFee:      arg.MinFee,
+		MaxFee:      arg.MaxFee,
+		CreatedAt:   q.now(),
+	}
+	put(q, q.db.feeSchedules, schedule.ID, schedule)
+	return schedule, nil
+}
+
+func (q *memoryQueries) GetFeeSchedule(ctx context.Context, arg GetFeeScheduleParams) (FeeSchedule, error) {
+	defer q.lock()()
+	for _, schedule := range q.db.feeSchedules {
+		if schedule.Currency == arg.Currency && schedule.AccountType == arg.AccountType {
+			return schedule, nil
+		}
+	}
+	return FeeSchedule{}, sql.ErrNoRows
+}
+
+func (q *memoryQueries) CreateFeeScheduleTier(ctx context.Context, arg CreateFeeScheduleTierParams) (FeeScheduleTier, error) {
+	defer q.lock()()
+	if !exists(q.db.feeSchedules, arg.ScheduleID) {
+		return FeeScheduleTier{}, foreignKeyViolation("fee_schedule_tiers", "schedule_id")
+	}
+
+	tier := FeeScheduleTier{
+		ID:         q.nextID("fee_schedule_tiers"),
+		ScheduleID: arg.ScheduleID,
+		UpTo:       arg.UpTo,
+		FlatAmount: arg.FlatAmount,
+		RateBps:    arg.RateBps,
+	}
+	put(q, q.db.feeScheduleTiers, tier.ID, tier)
+	return tier, nil
+}
+
+func (q *memoryQueries) ListFeeScheduleTiers(ctx context.Context, scheduleID int64) ([]FeeScheduleTier, error) {
+	defer q.lock()()
+	tiers := selectRows(q.db.feeScheduleTiers, func(tier FeeScheduleTier) bool {
+		return tier.ScheduleID == scheduleID
+	})
+	// the unbounded tier comes last
+	sort.SliceStable(tiers, func(i, j int) bool {
+		if (tiers[i].UpTo == 0) != (tiers[j].UpTo == 0) {
+			return tiers[j].UpTo == 0
+		}
+		return tiers[i].UpTo < tiers[j].UpTo
+	})
+	return tiers, nil
+}
+
+func (q *memoryQueries) CreateInterestRate(ctx context.Context, arg CreateInterestRateParams) (InterestRate, error) {
+	defer q.lock()()
+	key := rateKey{arg.AccountType, arg.Currency}
+	switch {
+	case !util.IsSupportedDayCount(arg.DayCount):
+		return InterestRate{}, checkViolation("interest_rates", "interest_rates_day_count_check")
+	case exists(q.db.interestRates, key):
+		return InterestRate{}, uniqueViolation("interest_rates", "interest_rates_pkey")
+	}
+
+	rate := InterestRate{
+		AccountType: arg.AccountType,
+		Currency:    arg.Currency,
+		RateBps:     arg.RateBps,
+		DayCount:    arg.DayCount,
+		CreatedAt:   q.now(),
+	}
+	put(q, q.db.interestRates, key, rate)
+	return rate, nil
+}
+
+func (q *memoryQueries) ListInterestBearingAccounts(ctx context.Context, asOf time.Time) ([]ListInterestBearingAccountsRow, error) {
+	defer q.lock()()
+	accounts := selectRows(q.db.accounts, func(account Account) bool {
+		return account.Status != AccountStatusClosed && account.CreatedAt.Before(asOf) &&
+			exists(q.db.interestRates, rateKey{account.AccountType, account.Currency})
+	})
+
+	var rows []ListInterestBearingAccountsRow
+	for _, account := range accounts {
+		rate := q.db.interestRates[rateKey{account.AccountType, account.Currency}]
+		balance := account.Balance
+		for _, entry := range q.db.entries {
+			if entry.AccountID == account.ID && !entry.CreatedAt.Before(asOf) {
+				balance -= entry.Amount
+			}
+		}
+		rows = append(rows, ListInterestBearingAccountsRow{
+			ID:          account.ID,
+			Currency:    account.Currency,
+			AccountType: account.AccountType,
+			RateBps:     rate.RateBps,
+			DayCount:    rate.DayCount,
+			Balance:     balance,
+		})
+	}
+	return rows, nil
+}
+
+// CreateInterestAccrual returns sql.ErrNoRows when the account was already
+// accrued for the date, like ON CONFLICT DO NOTHING
+func (q *memoryQueries) CreateInterestAccrual(ctx context.Context, arg CreateInterestAccrualParams) (InterestAccrual, error) {
+	defer q.lock()()
+	day := date(arg.AccrualDate)
+	for _, other := range q.db.interestAccruals {
+		if other.AccountID == arg.AccountID && other.AccrualDate.Equal(day) {
+			return InterestAccrual{}, sql.ErrNoRows
+		}
+	}
+	if !exists(q.db.accounts, arg.AccountID) {
+		return InterestAccrual{}, foreignKeyViolation("interest_accruals", "account_id")
+	}
+
+	accrual := InterestAccrual{
+		ID:           q.nextID("interest_accruals"),
+		AccountID:    arg.AccountID,
+		AccrualDate:  day,
+		Balance:      arg.Balance,
+		RateBps:      arg.RateBps,
+		DayCount:     arg.DayCount,
+		AmountMicros: arg.AmountMicros,
+		CreatedAt:    q.now(),
+	}
+	put(q, q.db.interestAccruals, accrual.ID, accrual)
+	return accrual, nil
+}
+
+func (q *memoryQueries) ListInterestAccruals(ctx context.Context, accountID int64) ([]InterestAccrual, error) {
+	defer q.lock()()
+	accruals := selectRows(q.db.interestAccruals, func(accrual InterestAccrual) bool {
+		return accrual.AccountID == accountID
+	})
+	sort.SliceStable(accruals, func(i, j int) bool {
+		return accruals[i].AccrualDate.Before(accruals[j].AccrualDate)
+	})
+	return accruals, nil
+}
+
+// pendingAccrual reports whether accrual is still to be capitalized in the
+// period ending on periodEnd
+func pendingAccrual(accrual InterestAccrual, periodEnd time.Time) bool {
+	return !accrual.CapitalizationID.Valid && !accrual.AccrualDate.After(date(periodEnd))
+}
+
+func (q *memoryQueries) ListAccountsWithPendingInterest(ctx context.Context, periodEnd time.Time) ([]int64, error) {
+	defer q.lock()()
+	var ids []int64
+	seen := map[int64]bool{}
+	for _, accrual := range selectRows(q.db.interestAccruals, func(accrual InterestAccrual) bool {
+		return pendingAccrual(accrual, periodEnd)
+	}) {
+		if !seen[accrual.AccountID] {
+			seen[accrual.AccountID] = true
+			ids = append(ids, accrual.AccountID)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids, nil
+}
+
+func (q *memoryQueries) GetPendingInterest(ctx context.Context, arg GetPendingInterestParams) (GetPendingInterestRow, error) {
+	defer q.lock()()
+	var pending GetPendingInterestRow
+	for _, accrual := range q.db.interestAccruals {
+		if accrual.AccountID == arg.AccountID && pendingAccrual(accrual, arg.PeriodEnd) {
+			pending.Count++
+			pending.TotalMicros += accrual.AmountMicros
+		}
+	}
+	return pending, nil
+}
+
+func (q *memoryQueries) GetLastInterestCapitalization(ctx context.Context, accountID int64) (InterestCapitalization, error) {
+	defer q.lock()()
+	var last InterestCapitalization
+	found := false
+	for _, capitalization := range q.db.interestCapitalizations {
+		if capitalization.AccountID == accountID && (!found || capitalization.PeriodEnd.After(last.PeriodEnd)) {
+			last, found = capitalization, true
+		}
+	}
+	if !found {
+		return InterestCapitalization{}, sql.ErrNoRows
+	}
+	return last, nil
+}
+
+func (q *memoryQueries) CreateInterestCapitalization(ctx context.Context, arg CreateInterestCapitalizationParams) (InterestCapitalization, error) {
+	defer q.lock()()
+	periodEnd := date(arg.PeriodEnd)
+	for _, other := range q.db.interestCapitalizations {
+		if other.AccountID == arg.AccountID && other.PeriodEnd.Equal(periodEnd) {
+			return InterestCapitalization{}, uniqueViolation("interest_capitalizations", "account_id_period_end_key")
+		}
+	}
+	switch {
+	case !exists(q.db.accounts, arg.AccountID):
+		return InterestCapitalization{}, foreignKeyViolation("interest_capitalizations", "account_id")
+	case arg.EntryID.Valid && !exists(q.db.entries, arg.EntryID.Int64):
+		return InterestCapitalization{}, foreignKeyViolation("interest_capitalizations", "entry_id")
+	}
+
+	capitalization := InterestCapitalization{
+		ID:            q.nextID("interest_capitalizations"),
+		AccountID:     arg.AccountID,
+		PeriodEnd:     periodEnd,
+		AccruedMicros: arg.AccruedMicros,
+		Amount:        arg.Amount,
+		CarryMicros:   arg.CarryMicros,
+		EntryID:       arg.EntryID,
+		CreatedAt:     q.now(),
+	}
+	put(q, q.db.interestCapitalizations, capitalization.ID, capitalization)
+	return capitalization, nil
+}
+
+func (q *memoryQueries) MarkInterestAccrualsCapitalized(ctx context.Context, arg MarkInterestAccrualsCapitalizedParams) error {
+	defer q.lock()()
+	if arg.CapitalizationID.Valid && !exists(q.db.interestCapitalizations, arg.CapitalizationID.Int64) {
+		return foreignKeyViolation("interest_accruals", "capitalization_id")
+	}
+	for id, accrual := range q.db.interestAccruals {
+		if accrual.AccountID == arg.AccountID && pendingAccrual(accrual, arg.PeriodEnd) {
+			accrual.CapitalizationID = arg.CapitalizationID
+			put(q, q.db.interestAccruals, id, accrual)
+		}
+	}
+	return nil
+}
+
+func (q *memoryQueries) CreateGLAccount(ctx context.Context, arg CreateGLAccountParams) (GlAccount, error) {
+	defer q.lock()()
+	if !oneOf(arg.Type, "asset", "liability", "equity", "income", "expense") {
+		return GlAccount{}, checkViolation("gl_accounts", "gl_accounts_type_check")
+	}
+	for _, other := range q.db.glAccounts {
+		if other.Code == arg.Code {
+			return GlAccount{}, uniqueViolation("gl_accounts", "gl_accounts_code_key")
+		}
+	}
+
+	account := GlAccount{
+		ID:        q.nextID("gl_accounts"),
+		Code:      arg.Code,
+		Name:      arg.Name,
+		Type:      arg.Type,
+		CreatedAt: q.now(),
+	}
+	put(q, q.db.glAccounts, account.ID, account)
+	return account, nil
+}
+
+func (q *memoryQueries) ListGLAccounts(ctx context.Context) ([]GlAccount, error) {
+	defer q.lock()()
+	accounts := selectRows(q.db.glAccounts, func(GlAccount) bool { return true })
+	sort.Slice(accounts, func(i, j int) bool { return accounts[i].Code < accounts[j].Code })
+	return accounts, nil
+}
+
+func (q *memoryQueries) GetGLAccountForAccount(ctx context.Context, id int64) (GetGLAccountForAccountRow, error) {
+	defer q.lock()()
+	account, ok := q.db.accounts[id]
+	if !ok {
+		return GetGLAccountForAccountRow{}, sql.ErrNoRows
+	}
+	mapping, ok := q.db.glAccountMappings[account.AccountType]
+	if !ok {
+		return GetGLAccountForAccountRow{}, sql.ErrNoRows
+	}
+	return GetGLAccountForAccountRow{GlAccountID: mapping.GlAccountID, Currency: account.Currency}, nil
+}
+
+func (q *memoryQueries) CreateJournalEntry(ctx context.Context, reference string) (JournalEntry, error) {
+	defer q.lock()()
+	entry := JournalEntry{
+		ID:        q.nextID("journal_entries"),
+		Reference: reference,
+		CreatedAt: q.now(),
+	}
+	put(q, q.db.journalEntries, entry.ID, entry)
+	return entry, nil
+}
+
+func (q *memoryQueries) CreateJournalLine(ctx context.Context, arg CreateJournalLineParams) (JournalLine, error) {
+	defer q.lock()()
+	switch {
+	case !exists(q.db.journalEntries, arg.JournalEntryID):
+		return JournalLine{}, foreignKeyViolation("journal_lines", "journal_entry_id")
+	case !exists(q.db.glAccounts, arg.GlAccountID):
+		return JournalLine{}, foreignKeyViolation("journal_lines", "gl_account_id")
+	case arg.AccountID.Valid && !exists(q.db.accounts, arg.AccountID.Int64):
+		return JournalLine{}, foreignKeyViolation("journal_lines", "account_id")
+	}
+
+	line := JournalLine{
+		ID:             q.nextID("journal_lines"),
+		JournalEntryID: arg.JournalEntryID,
+		GlAccountID:    arg.GlAccountID,
+		AccountID:      arg.AccountID,
+		Currency:       arg.Currency,
+		Amount:         arg.Amount,
+	}
+	put(q, q.db.journalLines, line.ID, line)
+	return line, nil
+}
+
+func (q *memoryQueries) ListJournalEntriesByReference(ctx context.Context, reference string) ([]JournalEntry, error) {
+	defer q.lock()()
+	return selectRows(q.db.journalEntries, func(entry JournalEntry) bool {
+		return entry.Reference == reference
+	}), nil
+}
+
+func (q *memoryQueries) ListJournalLines(ctx context.Context, journalEntryID int64) ([]JournalLine, error) {
+	defer q.lock()()
+	return selectRows(q.db.journalLines, func(line JournalLine) bool {
+		return line.JournalEntryID == journalEntryID
+	}), nil
+}
+
+func (q *memoryQueries) GetTrialBalance(ctx context.Context, asOf time.Time) ([]GetTrialBalanceRow, error) {
+	defer q.lock()()
+	type group struct {
+		glAccountID int64
+		currency    string
+	}
+	balances := map[group]int64{}
+	for _, line := range q.db.journalLines {
+		if q.db.journalEntries[line.JournalEntryID].CreatedAt.Before(asOf) {
+			balances[group{line.GlAccountID, line.Currency}] += line.Amount
+		}
+	}
+
+	var rows []GetTrialBalanceRow
+	for g, balance := range balances {
+		account := q.db.glAccounts[g.glAccountID]
+		rows = append(rows, GetTrialBalanceRow{
+			GlAccountID: account.ID,
+			Code:        account.Code,
+			Name:        account.Name,
+			Type:        account.Type,
+			Currency:    g.currency,
+			Balance:     balance,
+		})
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].Currency != rows[j].Currency {
+			return rows[i].Currency < rows[j].Currency
+		}
+		return rows[i].Code < rows[j].Code
+	})
+	return rows, nil
+}
@@ -0,0 +1,369 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"time"
+
+	"github.com/flukis/simplebank/util"
+)
+
+// Queries of the memory store on teller tills, loans and term deposits
+
+func (q *memoryQueries) CreateTellerTill(ctx context.Context, arg CreateTellerTillParams) (TellerTill, error) {
+	defer q.lock()()
+	businessDate := date(arg.BusinessDate)
+	for _, other := range q.db.tellerTills {
+		if other.TellerID == arg.TellerID && other.Currency == arg.Currency && other.BusinessDate.Equal(businessDate) {
+			return TellerTill{}, uniqueViolation("teller_tills", "teller_id_currency_business_date_key")
+		}
+	}
+	if !exists(q.db.users, arg.TellerID) {
+		return TellerTill{}, foreignKeyViolation("teller_tills", "teller_id")
+	}
+
+	till := TellerTill{
+		ID:           q.nextID("teller_tills"),
+		TellerID:     arg.TellerID,
+		Currency:     arg.Currency,
+		BusinessDate: businessDate,
+		Status:       TillStatusOpen,
+		OpeningCash:  arg.OpeningCash,
+		ExpectedCash: arg.OpeningCash,
+		OpenedAt:     q.now(),
+	}
+	put(q, q.db.tellerTills, till.ID, till)
+	return till, nil
+}
+
+func (q *memoryQueries) GetTellerTill(ctx context.Context, id int64) (TellerTill, error) {
+	defer q.lock()()
+	return get(q.db.tellerTills, id)
+}
+
+func (q *memoryQueries) GetTellerTillForUpdate(ctx context.Context, id int64) (TellerTill, error) {
+	return q.GetTellerTill(ctx, id)
+}
+
+func (q *memoryQueries) GetOpenTellerTillForUpdate(ctx context.Context, arg GetOpenTellerTillForUpdateParams) (TellerTill, error) {
+	defer q.lock()()
+	tills := selectRows(q.db.tellerTills, func(till TellerTill) bool {
+		return till.TellerID == arg.TellerID && till.Currency == arg.Currency &&
+			till.BusinessDate.Equal(date(arg.BusinessDate)) && till.Status == TillStatusOpen
+	})
+	if len(tills) == 0 {
+		return TellerTill{}, sql.ErrNoRows
+	}
+	return tills[0], nil
+}
+
+func (q *memoryQueries) AddTellerTillCash(ctx context.Context, arg AddTellerTillCashParams) (TellerTill, error) {
+	defer q.lock()()
+	return update(q, q.db.tellerTills, arg.ID, func(till *TellerTill) error {
+		till.ExpectedCash += arg.Amount
+		return nil
+	})
+}
+
+func (q *memoryQueries) CloseTellerTill(ctx context.Context, arg CloseTellerTillParams) (TellerTill, error) {
+	defer q.lock()()
+	return update(q, q.db.tellerTills, arg.ID, func(till *TellerTill) error {
+		till.Status = TillStatusClosed
+		till.CountedCash = arg.CountedCash
+		till.Difference = arg.CountedCash - till.ExpectedCash
+		till.ClosedAt = sql.NullTime{Time: q.now(), Valid: true}
+		return nil
+	})
+}
+
+func (q *memoryQueries) CreateCashTransaction(ctx context.Context, arg CreateCashTransactionParams) (CashTransaction, error) {
+	defer q.lock()()
+	switch {
+	case !oneOf(arg.Kind, CashDeposit, CashWithdrawal):
+		return CashTransaction{}, checkViolation("cash_transactions", "cash_transactions_kind_check")
+	case arg.Amount <= 0:
+		return CashTransaction{}, checkViolation("cash_transactions", "cash_transactions_amount_check")
+	case !exists(q.db.tellerTills, arg.TillID):
+		return CashTransaction{}, foreignKeyViolation("cash_transactions", "till_id")
+	case !exists(q.db.accounts, arg.AccountID):
+		return CashTransaction{}, foreignKeyViolation("cash_transactions", "account_id")
+	case !exists(q.db.entries, arg.EntryID):
+		return CashTransaction{}, foreignKeyViolation("cash_transactions", "entry_id")
+	}
+
+	transaction := CashTransaction{
+		ID:           q.nextID("cash_transactions"),
+		TillID:       arg.TillID,
+		AccountID:    arg.AccountID,
+		Kind:         arg.Kind,
+		Amount:       arg.Amount,
+		BalanceAfter: arg.BalanceAfter,
+		EntryID:      arg.EntryID,
+		CreatedAt:    q.now(),
+	}
+	put(q, q.db.cashTransactions, transaction.ID, transaction)
+	return transaction, nil
+}
+
+func (q *memoryQueries) ListCashTransactionsByTill(ctx context.Context, tillID int64) ([]CashTransaction, error) {
+	defer q.lock()()
+	return selectRows(q.db.cashTransactions, func(transaction CashTransaction) bool {
+		return transaction.TillID == tillID
+	}), nil
+}
+
+func (q *memoryQueries) CreateLoan(ctx context.Context, arg CreateLoanParams) (Loan, error) {
+	defer q.lock()()
+	switch {
+	case arg.Principal <= 0:
+		return Loan{}, checkViolation("loans", "loans_principal_check")
+	case !util.IsSupportedFrequency(arg.Frequency):
+		return Loan{}, checkViolation("loans", "loans_frequency_check")
+	case !util.IsSupportedAmortization(arg.Amortization):
+		return Loan{}, checkViolation("loans", "loans_amortization_check")
+	case !exists(q.db.accounts, arg.AccountID):
+		return Loan{}, foreignKeyViolation("loans", "account_id")
+	case !exists(q.db.transfers, arg.DisbursementID):
+		return Loan{}, foreignKeyViolation("loans", "disbursement_id")
+	}
+
+	loan := Loan{
+		ID:             q.nextID("loans"),
+		AccountID:      arg.AccountID,
+		Currency:       arg.Currency,
+		Principal:      arg.Principal,
+		RateBps:        arg.RateBps,
+		Term:           arg.Term,
+		Frequency:      arg.Frequency,
+		Amortization:   arg.Amortization,
+		LateFee:        arg.LateFee,
+		Status:         LoanStatusActive,
+		DisbursementID: arg.DisbursementID,
+		CreatedAt:      q.now(),
+	}
+	put(q, q.db.loans, loan.ID, loan)
+	return loan, nil
+}
+
+func (q *memoryQueries) GetLoan(ctx context.Context, id int64) (Loan, error) {
+	defer q.lock()()
+	return get(q.db.loans, id)
+}
+
+func (q *memoryQueries) GetLoanForUpdate(ctx context.Context, id int64) (Loan, error) {
+	return q.GetLoan(ctx, id)
+}
+
+func (q *memoryQueries) UpdateLoanStatus(ctx context.Context, arg UpdateLoanStatusParams) (Loan, error) {
+	defer q.lock()()
+	return update(q, q.db.loans, arg.ID, func(loan *Loan) error {
+		if !oneOf(arg.Status, LoanStatusActive, LoanStatusPaidOff) {
+			return checkViolation("loans", "loans_status_check")
+		}
+		loan.Status = arg.Status
+		return nil
+	})
+}
+
+func (q *memoryQueries) CreateLoanInstallment(ctx context.Context, arg CreateLoanInstallmentParams) (LoanInstallment, error) {
+	defer q.lock()()
+	for _, other := range q.db.loanInstallments {
+		if other.LoanID == arg.LoanID && other.Number == arg.Number {
+			return LoanInstallment{}, uniqueViolation("loan_installments", "loan_id_number_key")
+		}
+	}
+	if !exists(q.db.loans, arg.LoanID) {
+		return LoanInstallment{}, foreignKeyViolation("loan_installments", "loan_id")
+	}
+
+	installment := LoanInstallment{
+		ID:        q.nextID("loan_installments"),
+		LoanID:    arg.LoanID,
+		Number:    arg.Number,
+		DueDate:   date(arg.DueDate),
+		Principal: arg.Principal,
+		Interest:  arg.Interest,
+		Status:    InstallmentPending,
+	}
+	put(q, q.db.loanInstallments, installment.ID, installment)
+	return installment, nil
+}
+
+func (q *memoryQueries) ListLoanInstallments(ctx context.Context, loanID int64) ([]LoanInstallment, error) {
+	defer q.lock()()
+	installments := selectRows(q.db.loanInstallments, func(installment LoanInstallment) bool {
+		return installment.LoanID == loanID
+	})
+	sort.SliceStable(installments, func(i, j int) bool {
+		return installments[i].Number < installments[j].Number
+	})
+	return installments, nil
+}
+
+func (q *memoryQueries) ListDueLoanInstallments(ctx context.Context, dueDate time.Time) ([]LoanInstallment, error) {
+	defer q.lock()()
+	installments := selectRows(q.db.loanInstallments, func(installment LoanInstallment) bool {
+		return !installment.DueDate.After(date(dueDate)) && installment.Status != InstallmentPaid
+	})
+	sort.SliceStable(installments, func(i, j int) bool {
+		a, b := installments[i], installments[j]
+		if !a.DueDate.Equal(b.DueDate) {
+			return a.DueDate.Before(b.DueDate)
+		}
+		if a.LoanID != b.LoanID {
+			return a.LoanID < b.LoanID
+		}
+		return a.Number < b.Number
+	})
+	return installments, nil
+}
+
+func (q *memoryQueries) GetLoanInstallmentForUpdate(ctx context.Context, id int64) (LoanInstallment, error) {
+	defer q.lock()()
+	return get(q.db.loanInstallments, id)
+}
+
+func (q *memoryQueries) MarkLoanInstallmentOverdue(ctx context.Context, arg MarkLoanInstallmentOverdueParams) (LoanInstallment, error) {
+	defer q.lock()()
+	return update(q, q.db.loanInstallments, arg.ID, func(installment *LoanInstallment) error {
+		installment.Status = InstallmentOverdue
+		installment.LateFee += arg.LateFee
+		return nil
+	})
+}
+
+func (q *memoryQueries) MarkLoanInstallmentPaid(ctx context.Context, arg MarkLoanInstallmentPaidParams) (LoanInstallment, error) {
+	defer q.lock()()
+	return update(q, q.db.loanInstallments, arg.ID, func(installment *LoanInstallment) error {
+		if arg.EntryID.Valid && !exists(q.db.entries, arg.EntryID.Int64) {
+			return foreignKeyViolation("loan_installments", "entry_id")
+		}
+		installment.Status = InstallmentPaid
+		installment.EntryID = arg.EntryID
+		installment.PaidAt = sql.NullTime{Time: q.now(), Valid: true}
+		return nil
+	})
+}
+
+func (q *memoryQueries) CountUnpaidLoanInstallments(ctx context.Context, loanID int64) (int64, error) {
+	defer q.lock()()
+	var count int64
+	for _, installment := range q.db.loanInstallments {
+		if installment.LoanID == loanID && installment.Status != InstallmentPaid {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (q *memoryQueries) CreateTermDepositRate(ctx context.Context, arg CreateTermDepositRateParams) (TermDepositRate, error) {
+	defer q.lock()()
+	key := termRateKey{arg.Currency, arg.TermMonths}
+	switch {
+	case !util.IsSupportedDayCount(arg.DayCount):
+		return TermDepositRate{}, checkViolation("term_deposit_rates", "term_deposit_rates_day_count_check")
+	case exists(q.db.termDepositRates, key):
+		return TermDepositRate{}, uniqueViolation("term_deposit_rates", "term_deposit_rates_pkey")
+	}
+
+	rate := TermDepositRate{
+		Currency:   arg.Currency,
+		TermMonths: arg.TermMonths,
+		RateBps:    arg.RateBps,
+		DayCount:   arg.DayCount,
+		PenaltyBps: arg.PenaltyBps,
+		CreatedAt:  q.now(),
+	}
+	put(q, q.db.termDepositRates, key, rate)
+	return rate, nil
+}
+
+func (q *memoryQueries) GetTermDepositRate(ctx context.Context, arg GetTermDepositRateParams) (TermDepositRate, error) {
+	defer q.lock()()
+	return get(q.db.termDepositRates, termRateKey{arg.Currency, arg.TermMonths})
+}
+
+func (q *memoryQueries) ListTermDepositRates(ctx context.Context) ([]TermDepositRate, error) {
+	defer q.lock()()
+	var rates []TermDepositRate
+	for _, rate := range q.db.termDepositRates {
+		rates = append(rates, rate)
+	}
+	sort.Slice(rates, func(i, j int) bool {
+		if rates[i].Currency != rates[j].Currency {
+			return rates[i].Currency < rates[j].Currency
+		}
+		return rates[i].TermMonths < rates[j].TermMonths
+	})
+	return rates, nil
+}
+
+func (q *memoryQueries) CreateTermDeposit(ctx context.Context, arg CreateTermDepositParams) (TermDeposit, error) {
+	defer q.lock()()
+	switch {
+	case arg.Principal <= 0:
+		return TermDeposit{}, checkViolation("term_deposits", "term_deposits_principal_check")
+	case !oneOf(arg.OnMaturity, OnMaturityPayout, OnMaturityRollover):
+		return TermDeposit{}, checkViolation("term_deposits", "term_deposits_on_maturity_check")
+	case !exists(q.db.accounts, arg.AccountID):
+		return TermDeposit{}, foreignKeyViolation("term_deposits", "account_id")
+	case !exists(q.db.accounts, arg.PayoutAccountID):
+		return TermDeposit{}, foreignKeyViolation("term_deposits", "payout_account_id")
+	case arg.RenewedFromID.Valid && !exists(q.db.termDeposits, arg.RenewedFromID.Int64):
+		return TermDeposit{}, foreignKeyViolation("term_deposits", "renewed_from_id")
+	}
+
+	deposit := TermDeposit{
+		ID:              q.nextID("term_deposits"),
+		AccountID:       arg.AccountID,
+		PayoutAccountID: arg.PayoutAccountID,
+		Currency:        arg.Currency,
+		Principal:       arg.Principal,
+		RateBps:         arg.RateBps,
+		DayCount:        arg.DayCount,
+		PenaltyBps:      arg.PenaltyBps,
+		TermMonths:      arg.TermMonths,
+		StartDate:       date(arg.StartDate),
+		MaturityDate:    date(arg.MaturityDate),
+		OnMaturity:      arg.OnMaturity,
+		Status:          TermDepositActive,
+		RenewedFromID:   arg.RenewedFromID,
+		CreatedAt:       q.now(),
+	}
+	put(q, q.db.termDeposits, deposit.ID, deposit)
+	return deposit, nil
+}
+
+func (q *memoryQueries) GetTermDeposit(ctx context.Context, id int64) (TermDeposit, error) {
+	defer q.lock()()
+	return get(q.db.termDeposits, id)
+}
+
+func (q *memoryQueries) GetTermDepositForUpdate(ctx context.Context, id int64) (TermDeposit, error) {
+	return q.GetTermDeposit(ctx, id)
+}
+
+func (q *memoryQueries) ListMaturingTermDeposits(ctx context.Context, maturityDate time.Time) ([]TermDeposit, error) {
+	defer q.lock()()
+	deposits := selectRows(q.db.termDeposits, func(deposit TermDeposit) bool {
+		return !deposit.MaturityDate.After(date(maturityDate)) && deposit.Status == TermDepositActive
+	})
+	sort.SliceStable(deposits, func(i, j int) bool {
+		return deposits[i].MaturityDate.Before(deposits[j].MaturityDate)
+	})
+	return deposits, nil
+}
+
+func (q *memoryQueries) CloseTermDeposit(ctx context.Context, arg CloseTermDepositParams) (TermDeposit, error) {
+	defer q.lock()()
+	return update(q, q.db.termDeposits, arg.ID, func(deposit *TermDeposit) error {
+		if !oneOf(arg.Status, TermDepositActive, TermDepositMatured, TermDepositRolledOver, TermDepositWithdrawn) {
+			return checkViolation("term_deposits", "term_deposits_status_check")
+		}
+		deposit.Status = arg.Status
+		deposit.InterestPaid = arg.InterestPaid
+		deposit.ClosedAt = sql.NullTime{Time: q.now(), Valid: true}
+		return nil
+	})
+}
@@ -5,17 +5,62 @@
 package db
 
 import (
+	"database/sql"
 	"time"
 
 	"github.com/google/uuid"
 )
 
 type Account struct {
+	ID          int64     `json:"id"`
+	OwnerID     uuid.UUID `json:"owner_id"`
+	Balance     int64     `json:"balance"`
+	Currency    string    `json:"currency"`
+	CreatedAt   time.Time `json:"created_at"`
+	Tier        string    `json:"tier"`
+	AccountType string    `json:"account_type"`
+	Status      string    `json:"status"`
+	// set when the account is closed, closed accounts are never deleted
+	ClosedAt sql.NullTime `json:"closed_at"`
+	// external account number with mod-97 check digits, shown to and accepted from users instead of the id
+	Number string `json:"number"`
+}
+
+// users other than the owner who may use an account, every holder may view it
+type AccountHolder struct {
+	AccountID int64     `json:"account_id"`
+	UserID    uuid.UUID `json:"user_id"`
+	// may move money out of the account
+	CanInitiate bool `json:"can_initiate"`
+	// may approve transfers other holders made above their spend limit
+	CanApprove bool `json:"can_approve"`
+	// largest transfer the holder may make without approval, 0 for no limit
+	SpendLimit int64     `json:"spend_limit"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// accounts a user has saved to pay to
+type Beneficiary struct {
 	ID        int64     `json:"id"`
 	OwnerID   uuid.UUID `json:"owner_id"`
-	Balance   int64     `json:"balance"`
+	Nickname  string    `json:"nickname"`
+	AccountID int64     `json:"account_id"`
 	Currency  string    `json:"currency"`
-	CreatedAt time.Time `json:"created_at"`
+	// until then payments to the beneficiary are capped by the new payee limit
+	CoolingOffUntil time.Time `json:"cooling_off_until"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+type CashTransaction struct {
+	ID        int64  `json:"id"`
+	TillID    int64  `json:"till_id"`
+	AccountID int64  `json:"account_id"`
+	Kind      string `json:"kind"`
+	Amount    int64  `json:"amount"`
+	// account balance printed on the receipt
+	BalanceAfter int64     `json:"balance_after"`
+	EntryID      int64     `json:"entry_id"`
+	CreatedAt    time.Time `json:"created_at"`
 }
 
 type Entry struct {
@@ -26,6 +71,221 @@ type Entry struct {
 	CreatedAt time.Time `json:"created_at"`
 }
 
+type FeeSchedule struct {
+	ID          int64  `json:"id"`
+	Currency    string `json:"currency"`
+	AccountType string `json:"account_type"`
+	Kind        string `json:"kind"`
+	FlatAmount  int64  `json:"flat_amount"`
+	// basis points of the transferred amount
+	RateBps int64 `json:"rate_bps"`
+	MinFee  int64 `json:"min_fee"`
+	// 0 means no cap
+	MaxFee    int64     `json:"max_fee"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type FeeScheduleTier struct {
+	ID         int64 `json:"id"`
+	ScheduleID int64 `json:"schedule_id"`
+	// inclusive upper bound of the transfer amount, 0 means no bound
+	UpTo       int64 `json:"up_to"`
+	FlatAmount int64 `json:"flat_amount"`
+	RateBps    int64 `json:"rate_bps"`
+}
+
+type GlAccount struct {
+	ID        int64     `json:"id"`
+	Code      string    `json:"code"`
+	Name      string    `json:"name"`
+	Type      string    `json:"type"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// GL account every account of a type is booked to
+type GlAccountMapping struct {
+	AccountType string `json:"account_type"`
+	GlAccountID int64  `json:"gl_account_id"`
+}
+
+// the responses to requests sent with an Idempotency-Key header, replayed when the request is sent again
+type IdempotencyKey struct {
+	UserID uuid.UUID `json:"user_id"`
+	Key    string    `json:"key"`
+	// of the method, path and body, a key may not be reused for another request
+	RequestHash string `json:"request_hash"`
+	// 0 while the first request is still being handled
+	StatusCode   int32     `json:"status_code"`
+	ContentType  string    `json:"content_type"`
+	ResponseBody []byte    `json:"response_body"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type InterestAccrual struct {
+	ID          int64     `json:"id"`
+	AccountID   int64     `json:"account_id"`
+	AccrualDate time.Time `json:"accrual_date"`
+	Balance     int64     `json:"balance"`
+	RateBps     int64     `json:"rate_bps"`
+	DayCount    string    `json:"day_count"`
+	// millionths of the smallest currency unit
+	AmountMicros     int64         `json:"amount_micros"`
+	CapitalizationID sql.NullInt64 `json:"capitalization_id"`
+	CreatedAt        time.Time     `json:"created_at"`
+}
+
+type InterestCapitalization struct {
+	ID            int64     `json:"id"`
+	AccountID     int64     `json:"account_id"`
+	PeriodEnd     time.Time `json:"period_end"`
+	AccruedMicros int64     `json:"accrued_micros"`
+	Amount        int64     `json:"amount"`
+	// accrued interest too small to post, carried to the next period
+	CarryMicros int64         `json:"carry_micros"`
+	EntryID     sql.NullInt64 `json:"entry_id"`
+	CreatedAt   time.Time     `json:"created_at"`
+}
+
+type InterestRate struct {
+	AccountType string `json:"account_type"`
+	Currency    string `json:"currency"`
+	// annual rate in basis points
+	RateBps   int64     `json:"rate_bps"`
+	DayCount  string    `json:"day_count"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type JournalEntry struct {
+	ID int64 `json:"id"`
+	// what caused the posting, e.g. transfer:42
+	Reference string    `json:"reference"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type JournalLine struct {
+	ID             int64 `json:"id"`
+	JournalEntryID int64 `json:"journal_entry_id"`
+	GlAccountID    int64 `json:"gl_account_id"`
+	// customer or house account the line was posted for, if any
+	AccountID sql.NullInt64 `json:"account_id"`
+	Currency  string        `json:"currency"`
+	// debits are positive, credits negative, lines of an entry sum to zero per currency
+	Amount int64 `json:"amount"`
+}
+
+type Loan struct {
+	ID int64 `json:"id"`
+	// borrower account the loan is paid into and collected from
+	AccountID int64  `json:"account_id"`
+	Currency  string `json:"currency"`
+	Principal int64  `json:"principal"`
+	// annual rate in basis points
+	RateBps      int64  `json:"rate_bps"`
+	Term         int32  `json:"term"`
+	Frequency    string `json:"frequency"`
+	Amortization string `json:"amortization"`
+	// charged once on every installment that cannot be collected on its due date
+	LateFee int64  `json:"late_fee"`
+	Status  string `json:"status"`
+	// transfer that paid the principal to the borrower
+	DisbursementID int64     `json:"disbursement_id"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type LoanInstallment struct {
+	ID        int64     `json:"id"`
+	LoanID    int64     `json:"loan_id"`
+	Number    int32     `json:"number"`
+	DueDate   time.Time `json:"due_date"`
+	Principal int64     `json:"principal"`
+	Interest  int64     `json:"interest"`
+	LateFee   int64     `json:"late_fee"`
+	Status    string    `json:"status"`
+	// debit of the borrower account that paid the installment
+	EntryID sql.NullInt64 `json:"entry_id"`
+	PaidAt  sql.NullTime  `json:"paid_at"`
+}
+
+// money a user asked another user to pay into one of their accounts
+type PaymentRequest struct {
+	ID          int64     `json:"id"`
+	RequesterID uuid.UUID `json:"requester_id"`
+	PayerID     uuid.UUID `json:"payer_id"`
+	ToAccountID int64     `json:"to_account_id"`
+	Amount      int64     `json:"amount"`
+	Currency    string    `json:"currency"`
+	Memo        string    `json:"memo"`
+	Status      string    `json:"status"`
+	// the transfer that paid the request once it is accepted
+	TransferID sql.NullInt64 `json:"transfer_id"`
+	// pending requests can no longer be accepted after this
+	ExpiresAt time.Time    `json:"expires_at"`
+	CreatedAt time.Time    `json:"created_at"`
+	DecidedAt sql.NullTime `json:"decided_at"`
+}
+
+// named sub-accounts of a main account, each backed by an account of type pot
+type Pot struct {
+	AccountID       int64  `json:"account_id"`
+	ParentAccountID int64  `json:"parent_account_id"`
+	Name            string `json:"name"`
+	// amount the owner is saving up to, 0 when there is none
+	TargetAmount int64 `json:"target_amount"`
+	// outgoing transfers of the parent are rounded up to a multiple of this and the difference moved into the pot, 0 to disable
+	RoundUpTo int64     `json:"round_up_to"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type TellerTill struct {
+	ID           int64     `json:"id"`
+	TellerID     uuid.UUID `json:"teller_id"`
+	Currency     string    `json:"currency"`
+	BusinessDate time.Time `json:"business_date"`
+	Status       string    `json:"status"`
+	OpeningCash  int64     `json:"opening_cash"`
+	// opening cash plus deposits minus withdrawals
+	ExpectedCash int64 `json:"expected_cash"`
+	CountedCash  int64 `json:"counted_cash"`
+	// counted minus expected cash when the till is closed, negative when short
+	Difference int64        `json:"difference"`
+	OpenedAt   time.Time    `json:"opened_at"`
+	ClosedAt   sql.NullTime `json:"closed_at"`
+}
+
+type TermDeposit struct {
+	ID int64 `json:"id"`
+	// current account the deposit was funded from
+	AccountID int64 `json:"account_id"`
+	// account principal and interest are paid to
+	PayoutAccountID int64     `json:"payout_account_id"`
+	Currency        string    `json:"currency"`
+	Principal       int64     `json:"principal"`
+	RateBps         int64     `json:"rate_bps"`
+	DayCount        string    `json:"day_count"`
+	PenaltyBps      int64     `json:"penalty_bps"`
+	TermMonths      int32     `json:"term_months"`
+	StartDate       time.Time `json:"start_date"`
+	MaturityDate    time.Time `json:"maturity_date"`
+	OnMaturity      string    `json:"on_maturity"`
+	Status          string    `json:"status"`
+	InterestPaid    int64     `json:"interest_paid"`
+	// deposit that was rolled over into this one
+	RenewedFromID sql.NullInt64 `json:"renewed_from_id"`
+	CreatedAt     time.Time     `json:"created_at"`
+	ClosedAt      sql.NullTime  `json:"closed_at"`
+}
+
+type TermDepositRate struct {
+	Currency   string `json:"currency"`
+	TermMonths int32  `json:"term_months"`
+	// annual rate in basis points
+	RateBps  int64  `json:"rate_bps"`
+	DayCount string `json:"day_count"`
+	// taken off the rate when a deposit is withdrawn before maturity
+	PenaltyBps int64     `json:"penalty_bps"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
 type Transfer struct {
 	ID            int64 `json:"id"`
 	FromAccountID int64 `json:"from_account_id"`
@@ -33,6 +293,37 @@ type Transfer struct {
 	// must be positive
 	Amount    int64     `json:"amount"`
 	CreatedAt time.Time `json:"created_at"`
+	// made by the bank itself, e.g. pot moves, round-ups, sweeps and loan disbursements, and not counted towards transfer limits
+	Internal bool `json:"internal"`
+}
+
+// transfers above the spend limit of the holder who made them, waiting for another holder
+type TransferApproval struct {
+	ID            int64         `json:"id"`
+	FromAccountID int64         `json:"from_account_id"`
+	ToAccountID   int64         `json:"to_account_id"`
+	Amount        int64         `json:"amount"`
+	RequestedBy   uuid.UUID     `json:"requested_by"`
+	Status        string        `json:"status"`
+	DecidedBy     uuid.NullUUID `json:"decided_by"`
+	TransferID    sql.NullInt64 `json:"transfer_id"`
+	CreatedAt     time.Time     `json:"created_at"`
+	DecidedAt     sql.NullTime  `json:"decided_at"`
+}
+
+// a limit of 0 means unlimited
+type TransferLimit struct {
+	Tier                   string    `json:"tier"`
+	Currency               string    `json:"currency"`
+	MaxPerTransfer         int64     `json:"max_per_transfer"`
+	AccountDailyAmount     int64     `json:"account_daily_amount"`
+	AccountMonthlyAmount   int64     `json:"account_monthly_amount"`
+	AccountDailyCount      int64     `json:"account_daily_count"`
+	UserDailyAmount        int64     `json:"user_daily_amount"`
+	UserMonthlyAmount      int64     `json:"user_monthly_amount"`
+	UserDailyCount         int64     `json:"user_daily_count"`
+	CreatedAt              time.Time `json:"created_at"`
+	NewPayeeMaxPerTransfer int64     `json:"new_payee_max_per_transfer"`
 }
 
 type User struct {
@@ -43,4 +334,5 @@ type User struct {
 	Email             string    `json:"email"`
 	PasswordChangedAt time.Time `json:"password_changed_at"`
 	CreatedAt         time.Time `json:"created_at"`
+	Role              string    `json:"role"`
 }
@@ -0,0 +1,115 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+const (
+	PaymentRequestPending  = "pending"
+	PaymentRequestAccepted = "accepted"
+	PaymentRequestDeclined = "declined"
+)
+
+var (
+	ErrPaymentRequestDecided = errors.New("the payment request has already been accepted or declined")
+	ErrPaymentRequestExpired = errors.New("the payment request has expired")
+)
+
+type AcceptPaymentRequestTxParams struct {
+	ID            int64 `json:"id"`
+	FromAccountID int64 `json:"from_account_id"`
+}
+
+type AcceptPaymentRequestTxResult struct {
+	Request  PaymentRequest   `json:"request"`
+	Transfer TransferTxResult `json:"transfer"`
+}
+
+// CreatePaymentRequestsTx creates the requests of a split bill, all of them
+// or none
+func (s *SQLStore) CreatePaymentRequestsTx(ctx context.Context, args []CreatePaymentRequestParams) ([]PaymentRequest, error) {
+	result := make([]PaymentRequest, 0, len(args))
+
+	err := s.execTx(ctx, func(q Querier) error {
+		result = result[:0]
+		for _, arg := range args {
+			request, err := q.CreatePaymentRequest(ctx, arg)
+			if err != nil {
+				return err
+			}
+			result = append(result, request)
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+// AcceptPaymentRequestTx pays a pending payment request from an account of
+// the payer, the transfer is checked and charged like any other
+func (s *SQLStore) AcceptPaymentRequestTx(ctx context.Context, arg AcceptPaymentRequestTxParams) (AcceptPaymentRequestTxResult, error) {
+	var result AcceptPaymentRequestTxResult
+
+	err := s.execTxIsolation(ctx, sql.LevelSerializable, func(q Querier) error {
+		request, err := pendingPaymentRequest(ctx, q, arg.ID)
+		if err != nil {
+			return err
+		}
+		if !request.ExpiresAt.After(time.Now()) {
+			return ErrPaymentRequestExpired
+		}
+
+		result.Transfer, err = transfer(ctx, q, TransferTxParams{
+			FromAccountID: arg.FromAccountID,
+			ToAccountID:   request.ToAccountID,
+			Amount:        request.Amount,
+		}, false)
+		if err != nil {
+			return err
+		}
+
+		result.Request, err = q.DecidePaymentRequest(ctx, DecidePaymentRequestParams{
+			ID:         request.ID,
+			Status:     PaymentRequestAccepted,
+			TransferID: sql.NullInt64{Int64: result.Transfer.Transfer.ID, Valid: true},
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// DeclinePaymentRequestTx turns down a pending payment request, expired
+// ones included
+func (s *SQLStore) DeclinePaymentRequestTx(ctx context.Context, id int64) (PaymentRequest, error) {
+	var result PaymentRequest
+
+	err := s.execTx(ctx, func(q Querier) error {
+		request, err := pendingPaymentRequest(ctx, q, id)
+		if err != nil {
+			return err
+		}
+
+		result, err = q.DecidePaymentRequest(ctx, DecidePaymentRequestParams{
+			ID:     request.ID,
+			Status: PaymentRequestDeclined,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+func pendingPaymentRequest(ctx context.Context, q Querier, id int64) (PaymentRequest, error) {
+	request, err := q.GetPaymentRequestForUpdate(ctx, id)
+	if err != nil {
+		return request, err
+	}
+	if request.Status != PaymentRequestPending {
+		return request, ErrPaymentRequestDecided
+	}
+	return request, nil
+}
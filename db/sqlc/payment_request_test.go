@@ -0,0 +1,106 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createDummyPaymentRequest(t *testing.T, to Account, expiresAt time.Time) PaymentRequest {
+	payer := createDummyUser(t)
+
+	request, err := testQueries.CreatePaymentRequest(context.Background(), CreatePaymentRequestParams{
+		RequesterID: to.OwnerID,
+		PayerID:     payer.ID,
+		ToAccountID: to.ID,
+		Amount:      25,
+		Currency:    to.Currency,
+		Memo:        "dinner",
+		ExpiresAt:   expiresAt,
+	})
+	require.NoError(t, err)
+	require.Equal(t, PaymentRequestPending, request.Status)
+	require.Equal(t, payer.ID, request.PayerID)
+	require.False(t, request.TransferID.Valid)
+
+	return request
+}
+
+func TestAcceptPaymentRequestTx(t *testing.T) {
+	store := NewStore(testDB)
+	to := createDummyAccount(t)
+	from := createDummyAccount(t)
+	request := createDummyPaymentRequest(t, to, time.Now().Add(time.Hour))
+
+	requests, err := testQueries.ListPaymentRequests(context.Background(), request.PayerID)
+	require.NoError(t, err)
+	require.Len(t, requests, 1)
+	require.Equal(t, request.ID, requests[0].ID)
+
+	result, err := store.AcceptPaymentRequestTx(context.Background(), AcceptPaymentRequestTxParams{
+		ID:            request.ID,
+		FromAccountID: from.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, PaymentRequestAccepted, result.Request.Status)
+	require.Equal(t, result.Transfer.Transfer.ID, result.Request.TransferID.Int64)
+	require.Equal(t, to.Balance+request.Amount, result.Transfer.ToAccount.Balance)
+
+	_, err = store.DeclinePaymentRequestTx(context.Background(), request.ID)
+	require.ErrorIs(t, err, ErrPaymentRequestDecided)
+}
+
+func TestDeclinePaymentRequestTx(t *testing.T) {
+	store := NewStore(testDB)
+	to := createDummyAccount(t)
+	from := createDummyAccount(t)
+	request := createDummyPaymentRequest(t, to, time.Now().Add(-time.Minute))
+
+	_, err := store.AcceptPaymentRequestTx(context.Background(), AcceptPaymentRequestTxParams{
+		ID:            request.ID,
+		FromAccountID: from.ID,
+	})
+	require.ErrorIs(t, err, ErrPaymentRequestExpired)
+
+	declined, err := store.DeclinePaymentRequestTx(context.Background(), request.ID)
+	require.NoError(t, err)
+	require.Equal(t, PaymentRequestDeclined, declined.Status)
+	require.True(t, declined.DecidedAt.Valid)
+
+	unchanged, err := testQueries.GetAccount(context.Background(), from.ID)
+	require.NoError(t, err)
+	require.Equal(t, from.Balance, unchanged.Balance)
+}
+
+func TestCreatePaymentRequestsTx(t *testing.T) {
+	store := NewStore(testDB)
+	to := createDummyAccount(t)
+	payers := []User{createDummyUser(t), createDummyUser(t)}
+
+	args := make([]CreatePaymentRequestParams, len(payers))
+	for i, payer := range payers {
+		args[i] = CreatePaymentRequestParams{
+			RequesterID: to.OwnerID,
+			PayerID:     payer.ID,
+			ToAccountID: to.ID,
+			Amount:      int64(i) + 10,
+			Currency:    to.Currency,
+			ExpiresAt:   time.Now().Add(time.Hour),
+		}
+	}
+	requests, err := store.CreatePaymentRequestsTx(context.Background(), args)
+	require.NoError(t, err)
+	require.Len(t, requests, len(payers))
+
+	// a request the database refuses leaves none behind
+	args[1].Amount = 0
+	args[0].PayerID = createDummyUser(t).ID
+	_, err = store.CreatePaymentRequestsTx(context.Background(), args)
+	require.Error(t, err)
+
+	requests, err = testQueries.ListPaymentRequests(context.Background(), args[0].PayerID)
+	require.NoError(t, err)
+	require.Empty(t, requests)
+}
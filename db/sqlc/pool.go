@@ -0,0 +1,74 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/flukis/simplebank/util"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5/stdlib"
+)
+
+// statementCacheModes are the values of DB_STATEMENT_CACHE_MODE. prepare
+// caches prepared statements, describe caches only the types of their
+// parameters and results, and none prepares nothing, for poolers like
+// PgBouncer in transaction mode.
+var statementCacheModes = map[string]pgx.QueryExecMode{
+	"":         pgx.QueryExecModeCacheStatement,
+	"prepare":  pgx.QueryExecModeCacheStatement,
+	"describe": pgx.QueryExecModeCacheDescribe,
+	"none":     pgx.QueryExecModeExec,
+}
+
+// PoolConfig returns the pool for the database of conf. Pool settings left
+// at zero keep the defaults of pgxpool.
+func PoolConfig(conf util.Config) (*pgxpool.Config, error) {
+	config, err := pgxpool.ParseConfig(conf.DBSource)
+	if err != nil {
+		return nil, err
+	}
+
+	mode, ok := statementCacheModes[conf.DBStatementCacheMode]
+	if !ok {
+		return nil, fmt.Errorf("unknown statement cache mode %q", conf.DBStatementCacheMode)
+	}
+	config.ConnConfig.DefaultQueryExecMode = mode
+
+	if conf.DBMinConns > conf.DBMaxConns && conf.DBMaxConns > 0 {
+		return nil, fmt.Errorf("min conns %d is more than max conns %d", conf.DBMinConns, conf.DBMaxConns)
+	}
+	if conf.DBMaxConns > 0 {
+		config.MaxConns = conf.DBMaxConns
+	}
+	if conf.DBMinConns > 0 {
+		config.MinConns = conf.DBMinConns
+	}
+	if conf.DBMaxConnLifetime > 0 {
+		config.MaxConnLifetime = conf.DBMaxConnLifetime
+	}
+	if conf.DBMaxConnIdleTime > 0 {
+		config.MaxConnIdleTime = conf.DBMaxConnIdleTime
+	}
+	if conf.DBHealthCheckPeriod > 0 {
+		config.HealthCheckPeriod = conf.DBHealthCheckPeriod
+	}
+	return config, nil
+}
+
+// OpenDB starts a pool on the database of conf and returns it as the
+// *sql.DB the store and the migrations run on. Close the pool after the
+// *sql.DB.
+func OpenDB(ctx context.Context, conf util.Config) (*sql.DB, *pgxpool.Pool, error) {
+	config, err := PoolConfig(conf)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pool, err := pgxpool.NewWithConfig(ctx, config)
+	if err != nil {
+		return nil, nil, err
+	}
+	return stdlib.OpenDBFromPool(pool), pool, nil
+}
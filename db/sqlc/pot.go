@@ -0,0 +1,168 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+const (
+	AccountTypePot = "pot"
+
+	PotMoveIn  = "in"
+	PotMoveOut = "out"
+)
+
+var (
+	ErrPotTransfer      = errors.New("pots can only move money to and from their parent account")
+	ErrNestedPot        = errors.New("a pot cannot have pots of its own")
+	ErrNotPotOfAccount  = errors.New("pot doesn't belong to the account")
+	ErrRoundUpPotExists = errors.New("another pot of the account already collects round-ups")
+	ErrAccountHasPots   = errors.New("the account still has open pots")
+)
+
+type CreatePotTxParams struct {
+	ParentAccountID int64  `json:"parent_account_id"`
+	Name            string `json:"name"`
+	TargetAmount    int64  `json:"target_amount"`
+	RoundUpTo       int64  `json:"round_up_to"`
+}
+
+type CreatePotTxResult struct {
+	Pot     Pot     `json:"pot"`
+	Account Account `json:"account"`
+}
+
+// CreatePotTx opens a pot under an account. The pot is backed by an account
+// of its own in the same currency and owned by the same user.
+func (s *SQLStore) CreatePotTx(ctx context.Context, arg CreatePotTxParams) (CreatePotTxResult, error) {
+	var result CreatePotTxResult
+
+	err := s.execTx(ctx, func(q Querier) error {
+		parent, err := q.GetAccountForUpdate(ctx, arg.ParentAccountID)
+		if err != nil {
+			return err
+		}
+		if err = checkAccountActive(parent); err != nil {
+			return err
+		}
+		if parent.AccountType == AccountTypePot {
+			return ErrNestedPot
+		}
+
+		if arg.RoundUpTo > 0 {
+			pots, err := q.ListPotsByParent(ctx, parent.ID)
+			if err != nil {
+				return err
+			}
+			for _, pot := range pots {
+				if pot.RoundUpTo > 0 {
+					return ErrRoundUpPotExists
+				}
+			}
+		}
+
+		result.Account, err = q.CreateAccount(ctx, CreateAccountParams{
+			OwnerID:     parent.OwnerID,
+			Currency:    parent.Currency,
+			AccountType: AccountTypePot,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Pot, err = q.CreatePot(ctx, CreatePotParams{
+			AccountID:       result.Account.ID,
+			ParentAccountID: parent.ID,
+			Name:            arg.Name,
+			TargetAmount:    arg.TargetAmount,
+			RoundUpTo:       arg.RoundUpTo,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+type MovePotTxParams struct {
+	AccountID    int64  `json:"account_id"`
+	PotAccountID int64  `json:"pot_account_id"`
+	Amount       int64  `json:"amount"`
+	Direction    string `json:"direction"`
+}
+
+// MovePotTx moves money between an account and one of its pots, into the
+// pot for PotMoveIn and back out for PotMoveOut. Moves are internal
+// transfers, so they are instant and neither limited nor charged.
+func (s *SQLStore) MovePotTx(ctx context.Context, arg MovePotTxParams) (TransferTxResult, error) {
+	var result TransferTxResult
+
+	err := s.execTx(ctx, func(q Querier) error {
+		pot, err := q.GetPot(ctx, arg.PotAccountID)
+		if err != nil {
+			return err
+		}
+		if pot.ParentAccountID != arg.AccountID {
+			return ErrNotPotOfAccount
+		}
+
+		move := TransferTxParams{
+			FromAccountID: pot.ParentAccountID,
+			ToAccountID:   pot.AccountID,
+			Amount:        arg.Amount,
+		}
+		if arg.Direction == PotMoveOut {
+			move.FromAccountID, move.ToAccountID = move.ToAccountID, move.FromAccountID
+		}
+
+		from, err := lockSourceAccount(ctx, q, move.FromAccountID)
+		if err != nil {
+			return err
+		}
+		if err = checkAccountActive(from); err != nil {
+			return err
+		}
+		if from.Balance < arg.Amount {
+			return &InsufficientFundsError{
+				AccountID: from.ID,
+				Balance:   from.Balance,
+				Amount:    arg.Amount,
+			}
+		}
+
+		result, err = transfer(ctx, q, move, true)
+		return err
+	})
+
+	return result, err
+}
+
+// roundUpToPot moves the difference between amount and the next multiple of
+// the round-up step of the account's round-up pot into that pot. Nothing is
+// moved when the account has no such pot or cannot cover the difference.
+func roundUpToPot(ctx context.Context, q Querier, from Account, amount int64) (*TransferTxResult, error) {
+	pot, err := q.GetRoundUpPot(ctx, from.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	diff := (pot.RoundUpTo - amount%pot.RoundUpTo) % pot.RoundUpTo
+	if diff == 0 || from.Balance < diff {
+		return nil, nil
+	}
+
+	roundUp, err := transfer(ctx, q, TransferTxParams{
+		FromAccountID: from.ID,
+		ToAccountID:   pot.AccountID,
+		Amount:        diff,
+	}, true)
+	if err != nil {
+		return nil, fmt.Errorf("cannot round up into pot %d: %w", pot.AccountID, err)
+	}
+
+	return &roundUp, nil
+}
@@ -0,0 +1,139 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flukis/simplebank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createDummyPot(t *testing.T, parent Account, roundUpTo int64) CreatePotTxResult {
+	store := NewStore(testDB)
+
+	result, err := store.CreatePotTx(context.Background(), CreatePotTxParams{
+		ParentAccountID: parent.ID,
+		Name:            util.GenRandomString(8),
+		TargetAmount:    util.GenRandomNum(1, 100000),
+		RoundUpTo:       roundUpTo,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, parent.ID, result.Pot.ParentAccountID)
+	require.Equal(t, result.Account.ID, result.Pot.AccountID)
+	require.Equal(t, AccountTypePot, result.Account.AccountType)
+	require.Equal(t, parent.OwnerID, result.Account.OwnerID)
+	require.Equal(t, parent.Currency, result.Account.Currency)
+	require.Zero(t, result.Account.Balance)
+
+	return result
+}
+
+func TestMovePotTx(t *testing.T) {
+	store := NewStore(testDB)
+	parent := createDummyAccount(t)
+
+	// several pots in the same currency
+	holiday := createDummyPot(t, parent, 0)
+	createDummyPot(t, parent, 0)
+
+	_, err := store.CreatePotTx(context.Background(), CreatePotTxParams{
+		ParentAccountID: holiday.Account.ID,
+		Name:            "Nested",
+	})
+	require.ErrorIs(t, err, ErrNestedPot)
+
+	moved, err := store.MovePotTx(context.Background(), MovePotTxParams{
+		AccountID:    parent.ID,
+		PotAccountID: holiday.Account.ID,
+		Amount:       parent.Balance,
+		Direction:    PotMoveIn,
+	})
+	require.NoError(t, err)
+	require.Zero(t, moved.FromAccount.Balance)
+	require.Equal(t, parent.Balance, moved.ToAccount.Balance)
+
+	_, err = store.MovePotTx(context.Background(), MovePotTxParams{
+		AccountID:    parent.ID,
+		PotAccountID: holiday.Account.ID,
+		Amount:       parent.Balance + 1,
+		Direction:    PotMoveOut,
+	})
+	var fundsErr *InsufficientFundsError
+	require.ErrorAs(t, err, &fundsErr)
+
+	moved, err = store.MovePotTx(context.Background(), MovePotTxParams{
+		AccountID:    parent.ID,
+		PotAccountID: holiday.Account.ID,
+		Amount:       1,
+		Direction:    PotMoveOut,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), moved.ToAccount.Balance)
+
+	other := createDummyAccount(t)
+	_, err = store.MovePotTx(context.Background(), MovePotTxParams{
+		AccountID:    other.ID,
+		PotAccountID: holiday.Account.ID,
+		Amount:       1,
+		Direction:    PotMoveOut,
+	})
+	require.ErrorIs(t, err, ErrNotPotOfAccount)
+
+	// pots are only reachable through their parent
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: holiday.Account.ID,
+		ToAccountID:   other.ID,
+		Amount:        1,
+	})
+	require.ErrorIs(t, err, ErrPotTransfer)
+
+	_, err = store.CloseAccountTx(context.Background(), CloseAccountTxParams{
+		AccountID:        parent.ID,
+		SweepToAccountID: other.ID,
+	})
+	require.ErrorIs(t, err, ErrAccountHasPots)
+}
+
+func TestTransferRoundUp(t *testing.T) {
+	store := NewStore(testDB)
+	parent, err := testQueries.UpdateBalanceAccount(context.Background(), UpdateBalanceAccountParams{
+		ID:      createDummyAccount(t).ID,
+		Balance: 100000,
+	})
+	require.NoError(t, err)
+	pot := createDummyPot(t, parent, 100)
+
+	_, err = store.CreatePotTx(context.Background(), CreatePotTxParams{
+		ParentAccountID: parent.ID,
+		Name:            "Change",
+		RoundUpTo:       10,
+	})
+	require.ErrorIs(t, err, ErrRoundUpPotExists)
+
+	to := createDummyAccount(t)
+
+	result, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: parent.ID,
+		ToAccountID:   to.ID,
+		Amount:        1234,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, result.RoundUp)
+	require.Equal(t, pot.Account.ID, result.RoundUp.ToAccountID)
+	require.Equal(t, int64(66), result.RoundUp.Amount)
+	require.Equal(t, int64(100000-1234-66)-result.Fee.Amount, result.FromAccount.Balance)
+
+	got, err := testQueries.GetAccount(context.Background(), pot.Account.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(66), got.Balance)
+
+	// a round amount is not rounded
+	result, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: parent.ID,
+		ToAccountID:   to.ID,
+		Amount:        1200,
+	})
+	require.NoError(t, err)
+	require.Nil(t, result.RoundUp)
+}
@@ -6,28 +6,120 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type Querier interface {
 	AddBalanceAccount(ctx context.Context, arg AddBalanceAccountParams) (Account, error)
+	AddTellerTillCash(ctx context.Context, arg AddTellerTillCashParams) (TellerTill, error)
+	CloseAccount(ctx context.Context, id int64) (Account, error)
+	CloseTellerTill(ctx context.Context, arg CloseTellerTillParams) (TellerTill, error)
+	CloseTermDeposit(ctx context.Context, arg CloseTermDepositParams) (TermDeposit, error)
+	CountOpenPots(ctx context.Context, parentAccountID int64) (int64, error)
+	CountUnpaidLoanInstallments(ctx context.Context, loanID int64) (int64, error)
 	CreateAccount(ctx context.Context, arg CreateAccountParams) (Account, error)
+	CreateAccountHolder(ctx context.Context, arg CreateAccountHolderParams) (AccountHolder, error)
+	CreateBeneficiary(ctx context.Context, arg CreateBeneficiaryParams) (Beneficiary, error)
+	CreateCashTransaction(ctx context.Context, arg CreateCashTransactionParams) (CashTransaction, error)
 	CreateEntry(ctx context.Context, arg CreateEntryParams) (Entry, error)
+	CreateFeeSchedule(ctx context.Context, arg CreateFeeScheduleParams) (FeeSchedule, error)
+	CreateFeeScheduleTier(ctx context.Context, arg CreateFeeScheduleTierParams) (FeeScheduleTier, error)
+	CreateGLAccount(ctx context.Context, arg CreateGLAccountParams) (GlAccount, error)
+	CreateIdempotencyKey(ctx context.Context, arg CreateIdempotencyKeyParams) (IdempotencyKey, error)
+	CreateInterestAccrual(ctx context.Context, arg CreateInterestAccrualParams) (InterestAccrual, error)
+	CreateInterestCapitalization(ctx context.Context, arg CreateInterestCapitalizationParams) (InterestCapitalization, error)
+	CreateInterestRate(ctx context.Context, arg CreateInterestRateParams) (InterestRate, error)
+	CreateJournalEntry(ctx context.Context, reference string) (JournalEntry, error)
+	CreateJournalLine(ctx context.Context, arg CreateJournalLineParams) (JournalLine, error)
+	CreateLoan(ctx context.Context, arg CreateLoanParams) (Loan, error)
+	CreateLoanInstallment(ctx context.Context, arg CreateLoanInstallmentParams) (LoanInstallment, error)
+	CreatePaymentRequest(ctx context.Context, arg CreatePaymentRequestParams) (PaymentRequest, error)
+	CreatePot(ctx context.Context, arg CreatePotParams) (Pot, error)
+	CreateTellerTill(ctx context.Context, arg CreateTellerTillParams) (TellerTill, error)
+	CreateTermDeposit(ctx context.Context, arg CreateTermDepositParams) (TermDeposit, error)
+	CreateTermDepositRate(ctx context.Context, arg CreateTermDepositRateParams) (TermDepositRate, error)
 	CreateTransfer(ctx context.Context, arg CreateTransferParams) (Transfer, error)
+	CreateTransferApproval(ctx context.Context, arg CreateTransferApprovalParams) (TransferApproval, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
-	DeleteAccount(ctx context.Context, id int64) error
+	DecidePaymentRequest(ctx context.Context, arg DecidePaymentRequestParams) (PaymentRequest, error)
+	DecideTransferApproval(ctx context.Context, arg DecideTransferApprovalParams) (TransferApproval, error)
+	DeleteAccountHolder(ctx context.Context, arg DeleteAccountHolderParams) (AccountHolder, error)
+	DeleteBeneficiary(ctx context.Context, id int64) (Beneficiary, error)
+	DeleteIdempotencyKey(ctx context.Context, arg DeleteIdempotencyKeyParams) error
+	DeleteIdempotencyKeysBefore(ctx context.Context, before time.Time) (int64, error)
 	FetchAccounts(ctx context.Context, arg FetchAccountsParams) ([]Account, error)
+	FetchAccountsByOwner(ctx context.Context, arg FetchAccountsByOwnerParams) ([]Account, error)
 	FetchEntries(ctx context.Context, arg FetchEntriesParams) ([]Entry, error)
 	FetchTransfer(ctx context.Context, arg FetchTransferParams) ([]Transfer, error)
+	FetchUsers(ctx context.Context, arg FetchUsersParams) ([]User, error)
 	GetAccount(ctx context.Context, id int64) (Account, error)
+	GetAccountByNumber(ctx context.Context, number string) (Account, error)
 	GetAccountForUpdate(ctx context.Context, id int64) (Account, error)
+	GetAccountHolder(ctx context.Context, arg GetAccountHolderParams) (AccountHolder, error)
+	GetAccountTransferUsage(ctx context.Context, arg GetAccountTransferUsageParams) (GetAccountTransferUsageRow, error)
+	GetBeneficiary(ctx context.Context, id int64) (Beneficiary, error)
+	GetCoolingOffBeneficiaryForUpdate(ctx context.Context, arg GetCoolingOffBeneficiaryForUpdateParams) (Beneficiary, error)
 	GetEntry(ctx context.Context, id int64) (Entry, error)
+	GetFeeSchedule(ctx context.Context, arg GetFeeScheduleParams) (FeeSchedule, error)
+	GetGLAccountForAccount(ctx context.Context, id int64) (GetGLAccountForAccountRow, error)
+	GetHouseAccount(ctx context.Context, arg GetHouseAccountParams) (Account, error)
+	GetIdempotencyKey(ctx context.Context, arg GetIdempotencyKeyParams) (IdempotencyKey, error)
+	GetLastInterestCapitalization(ctx context.Context, accountID int64) (InterestCapitalization, error)
+	GetLoan(ctx context.Context, id int64) (Loan, error)
+	GetLoanForUpdate(ctx context.Context, id int64) (Loan, error)
+	GetLoanInstallmentForUpdate(ctx context.Context, id int64) (LoanInstallment, error)
+	GetOpenTellerTillForUpdate(ctx context.Context, arg GetOpenTellerTillForUpdateParams) (TellerTill, error)
+	GetOwnerTransferUsage(ctx context.Context, arg GetOwnerTransferUsageParams) (GetOwnerTransferUsageRow, error)
+	GetPayeeTransferTotal(ctx context.Context, arg GetPayeeTransferTotalParams) (int64, error)
+	GetPaymentRequest(ctx context.Context, id int64) (PaymentRequest, error)
+	GetPaymentRequestForUpdate(ctx context.Context, id int64) (PaymentRequest, error)
+	GetPendingInterest(ctx context.Context, arg GetPendingInterestParams) (GetPendingInterestRow, error)
+	GetPot(ctx context.Context, accountID int64) (Pot, error)
+	GetRoundUpPot(ctx context.Context, parentAccountID int64) (Pot, error)
+	GetTellerTill(ctx context.Context, id int64) (TellerTill, error)
+	GetTellerTillForUpdate(ctx context.Context, id int64) (TellerTill, error)
+	GetTermDeposit(ctx context.Context, id int64) (TermDeposit, error)
+	GetTermDepositForUpdate(ctx context.Context, id int64) (TermDeposit, error)
+	GetTermDepositRate(ctx context.Context, arg GetTermDepositRateParams) (TermDepositRate, error)
 	GetTransfer(ctx context.Context, id int64) (Transfer, error)
+	GetTransferApproval(ctx context.Context, id int64) (TransferApproval, error)
+	GetTransferApprovalForUpdate(ctx context.Context, id int64) (TransferApproval, error)
+	GetTransferLimit(ctx context.Context, arg GetTransferLimitParams) (TransferLimit, error)
+	GetTrialBalance(ctx context.Context, asOf time.Time) ([]GetTrialBalanceRow, error)
 	GetUser(ctx context.Context, id uuid.UUID) (User, error)
 	GetUserByEmail(ctx context.Context, email string) (User, error)
 	GetUserByUsername(ctx context.Context, username string) (User, error)
+	GetUserForUpdate(ctx context.Context, id uuid.UUID) (User, error)
+	ListAccountHolders(ctx context.Context, accountID int64) ([]AccountHolder, error)
+	ListAccountsWithPendingInterest(ctx context.Context, periodEnd time.Time) ([]int64, error)
+	ListBeneficiaries(ctx context.Context, ownerID uuid.UUID) ([]Beneficiary, error)
+	ListCashTransactionsByTill(ctx context.Context, tillID int64) ([]CashTransaction, error)
+	ListDueLoanInstallments(ctx context.Context, dueDate time.Time) ([]LoanInstallment, error)
+	ListFeeScheduleTiers(ctx context.Context, scheduleID int64) ([]FeeScheduleTier, error)
+	ListGLAccounts(ctx context.Context) ([]GlAccount, error)
+	ListInterestAccruals(ctx context.Context, accountID int64) ([]InterestAccrual, error)
+	// balance is the closing balance of the day before as_of, so that re-running
+	// an old date accrues on the same balance as the original run
+	ListInterestBearingAccounts(ctx context.Context, asOf time.Time) ([]ListInterestBearingAccountsRow, error)
+	ListJournalEntriesByReference(ctx context.Context, reference string) ([]JournalEntry, error)
+	ListJournalLines(ctx context.Context, journalEntryID int64) ([]JournalLine, error)
+	ListLoanInstallments(ctx context.Context, loanID int64) ([]LoanInstallment, error)
+	ListMaturingTermDeposits(ctx context.Context, maturityDate time.Time) ([]TermDeposit, error)
+	ListPaymentRequests(ctx context.Context, requesterID uuid.UUID) ([]PaymentRequest, error)
+	ListPendingTransferApprovals(ctx context.Context, fromAccountID int64) ([]TransferApproval, error)
+	ListPotsByParent(ctx context.Context, parentAccountID int64) ([]ListPotsByParentRow, error)
+	ListTermDepositRates(ctx context.Context) ([]TermDepositRate, error)
+	MarkInterestAccrualsCapitalized(ctx context.Context, arg MarkInterestAccrualsCapitalizedParams) error
+	MarkLoanInstallmentOverdue(ctx context.Context, arg MarkLoanInstallmentOverdueParams) (LoanInstallment, error)
+	MarkLoanInstallmentPaid(ctx context.Context, arg MarkLoanInstallmentPaidParams) (LoanInstallment, error)
+	SaveIdempotentResponse(ctx context.Context, arg SaveIdempotentResponseParams) (IdempotencyKey, error)
+	TakeOverIdempotencyKey(ctx context.Context, arg TakeOverIdempotencyKeyParams) (IdempotencyKey, error)
+	UpdateAccountStatus(ctx context.Context, arg UpdateAccountStatusParams) (Account, error)
 	UpdateBalanceAccount(ctx context.Context, arg UpdateBalanceAccountParams) (Account, error)
+	UpdateLoanStatus(ctx context.Context, arg UpdateLoanStatusParams) (Loan, error)
+	UpdateUserRole(ctx context.Context, arg UpdateUserRoleParams) (User, error)
 }
 
 var _ Querier = (*Queries)(nil)
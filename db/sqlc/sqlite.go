@@ -0,0 +1,184 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/flukis/simplebank/db/sqlite"
+	"github.com/flukis/simplebank/util"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/mattn/go-sqlite3"
+)
+
+// sqliteParams are added to DB_SOURCE. SQLite leaves foreign keys off unless
+// asked. Every transaction takes the write lock at BEGIN, so two transfers
+// never both read a balance before either writes it. A writer waits for the
+// lock rather than failing at once, and WAL lets reads go on meanwhile.
+const sqliteParams = "_foreign_keys=on&_txlock=immediate&_busy_timeout=5000&_journal_mode=WAL"
+
+// OpenSQLite opens the SQLite file DB_SOURCE names, e.g. simplebank.db,
+// creating it when it doesn't exist, for NewSQLiteStore and the migrations
+func OpenSQLite(ctx context.Context, conf util.Config) (*sql.DB, error) {
+	sep := "?"
+	if strings.Contains(conf.DBSource, "?") {
+		sep = "&"
+	}
+	conn, err := sql.Open("sqlite3", conf.DBSource+sep+sqliteParams)
+	if err != nil {
+		return nil, err
+	}
+	if conf.DBMaxConns > 0 {
+		conn.SetMaxOpenConns(int(conf.DBMaxConns))
+	}
+
+	if err := conn.PingContext(ctx); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return conn, nil
+}
+
+// NewSQLiteStore returns a store on a SQLite database opened with
+// OpenSQLite and migrated with migration.NewSQLite, for small deployments
+// that don't run Postgres. It breaks the same constraints as NewStore with
+// the same errors.
+func NewSQLiteStore(db *sql.DB) Store {
+	return &SQLStore{
+		Querier: errorQueries{sqliteQueries{queries: sqlite.New(db)}},
+		tx:      sqliteTxRunner{db: db},
+		retry:   DefaultTxRetryPolicy,
+	}
+}
+
+// sqliteTxRunner runs transactions on SQLite. Each holds the write lock
+// from BEGIN to COMMIT, which makes them serializable whatever level is
+// asked for.
+type sqliteTxRunner struct {
+	db *sql.DB
+}
+
+func (r sqliteTxRunner) runTx(ctx context.Context, level sql.IsolationLevel, fn func(Querier) error) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return sqliteError(err)
+	}
+
+	if err = fn(sqliteQueries{queries: sqlite.New(tx)}); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("tx error: %w, rollback error: %v", err, rbErr)
+		}
+		return err
+	}
+
+	return sqliteError(tx.Commit())
+}
+
+// sqliteUniqueKeys names the unique constraints of the schema after the
+// columns SQLite reports when one is broken
+var sqliteUniqueKeys = map[string]string{
+	"users.id":        "users_pkey",
+	"users.email":     "users_email_key",
+	"accounts.number": "accounts_number_key",
+	"accounts.owner_id, accounts.currency, accounts.account_type":              "owner_id_currency_type_key",
+	"transfer_limits.tier, transfer_limits.currency":                           "transfer_limits_pkey",
+	"fee_schedules.currency, fee_schedules.account_type":                       "currency_account_type_key",
+	"interest_rates.account_type, interest_rates.currency":                     "interest_rates_pkey",
+	"interest_accruals.account_id, interest_accruals.accrual_date":             "account_id_accrual_date_key",
+	"interest_capitalizations.account_id, interest_capitalizations.period_end": "account_id_period_end_key",
+	"gl_accounts.code":                 "gl_accounts_code_key",
+	"gl_account_mappings.account_type": "gl_account_mappings_pkey",
+	"teller_tills.teller_id, teller_tills.currency, teller_tills.business_date": "teller_id_currency_business_date_key",
+	"loan_installments.loan_id, loan_installments.number":                       "loan_id_number_key",
+	"term_deposit_rates.currency, term_deposit_rates.term_months":               "term_deposit_rates_pkey",
+	"pots.account_id":                                     "pots_pkey",
+	"pots.parent_account_id, pots.name":                   "parent_account_id_name_key",
+	"account_holders.account_id, account_holders.user_id": "account_holders_pkey",
+	"beneficiaries.owner_id, beneficiaries.nickname":      "owner_id_nickname_key",
+	"beneficiaries.owner_id, beneficiaries.account_id":    "owner_id_account_id_key",
+	"idempotency_keys.user_id, idempotency_keys.key":      "idempotency_keys_pkey",
+}
+
+// sqliteCheckTables gives the table of each check constraint, which SQLite
+// leaves out of its error
+var sqliteCheckTables = map[string]string{
+	"users_role_check":                   "users",
+	"accounts_status_check":              "accounts",
+	"fee_schedules_kind_check":           "fee_schedules",
+	"interest_rates_day_count_check":     "interest_rates",
+	"gl_accounts_type_check":             "gl_accounts",
+	"teller_tills_status_check":          "teller_tills",
+	"cash_transactions_kind_check":       "cash_transactions",
+	"cash_transactions_amount_check":     "cash_transactions",
+	"loans_principal_check":              "loans",
+	"loans_frequency_check":              "loans",
+	"loans_amortization_check":           "loans",
+	"loans_status_check":                 "loans",
+	"loan_installments_status_check":     "loan_installments",
+	"term_deposit_rates_day_count_check": "term_deposit_rates",
+	"term_deposits_principal_check":      "term_deposits",
+	"term_deposits_on_maturity_check":    "term_deposits",
+	"term_deposits_status_check":         "term_deposits",
+	"pots_target_amount_check":           "pots",
+	"pots_round_up_to_check":             "pots",
+	"account_holders_spend_limit_check":  "account_holders",
+	"transfer_approvals_amount_check":    "transfer_approvals",
+	"transfer_approvals_status_check":    "transfer_approvals",
+	"payment_requests_amount_check":      "payment_requests",
+	"payment_requests_status_check":      "payment_requests",
+	"payment_requests_payer_check":       "payment_requests",
+}
+
+// sqliteForeignKey matches the message the foreign key triggers of the
+// schema raise
+var sqliteForeignKey = regexp.MustCompile(`^insert or update on table "(\w+)" violates foreign key constraint "(\w+)"$`)
+
+// sqliteError turns the errors SQLite gives for broken constraints into the
+// Postgres ones mapError knows. Waiting too long for the write lock is
+// reported as a serialization failure, so the transaction is run again.
+func sqliteError(err error) error {
+	var sqliteErr sqlite3.Error
+	if !errors.As(err, &sqliteErr) {
+		return err
+	}
+
+	msg := sqliteErr.Error()
+	switch sqliteErr.Code {
+	case sqlite3.ErrBusy, sqlite3.ErrLocked:
+		return &pgconn.PgError{
+			Severity: "ERROR",
+			Code:     SerializationFailure,
+			Message:  msg,
+		}
+	case sqlite3.ErrConstraint:
+	default:
+		return err
+	}
+
+	switch sqliteErr.ExtendedCode {
+	case sqlite3.ErrConstraintUnique, sqlite3.ErrConstraintPrimaryKey:
+		columns := strings.TrimPrefix(msg, "UNIQUE constraint failed: ")
+		table, _, _ := strings.Cut(columns, ".")
+		return uniqueViolation(table, sqliteUniqueKeys[columns])
+	case sqlite3.ErrConstraintCheck:
+		constraint := strings.TrimPrefix(msg, "CHECK constraint failed: ")
+		return checkViolation(sqliteCheckTables[constraint], constraint)
+	case sqlite3.ErrConstraintTrigger:
+		if m := sqliteForeignKey.FindStringSubmatch(msg); m != nil {
+			table, constraint := m[1], m[2]
+			column := strings.TrimSuffix(strings.TrimPrefix(constraint, table+"_"), "_fkey")
+			return foreignKeyViolation(table, column)
+		}
+	case sqlite3.ErrConstraintForeignKey:
+		// a row that is still referenced, SQLite doesn't say by what
+		return &pgconn.PgError{
+			Severity: "ERROR",
+			Code:     ForeignKeyViolation,
+			Message:  msg,
+		}
+	}
+	return err
+}
@@ -0,0 +1,729 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/flukis/simplebank/db/sqlite"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// sqliteQueries runs the queries of db/sqlite/query and hands back the
+// types and errors of the Postgres ones, so the rest of the package can't
+// tell the backends apart. The rows of both have the same fields and are
+// converted one to the other.
+type sqliteQueries struct {
+	queries *sqlite.Queries
+}
+
+func (q sqliteQueries) AddBalanceAccount(ctx context.Context, arg AddBalanceAccountParams) (Account, error) {
+	row, err := q.queries.AddBalanceAccount(ctx, sqlite.AddBalanceAccountParams(arg))
+	return Account(row), sqliteError(err)
+}
+
+func (q sqliteQueries) AddTellerTillCash(ctx context.Context, arg AddTellerTillCashParams) (TellerTill, error) {
+	row, err := q.queries.AddTellerTillCash(ctx, sqlite.AddTellerTillCashParams(arg))
+	return TellerTill(row), sqliteError(err)
+}
+
+func (q sqliteQueries) CloseAccount(ctx context.Context, id int64) (Account, error) {
+	row, err := q.queries.CloseAccount(ctx, id)
+	return Account(row), sqliteError(err)
+}
+
+func (q sqliteQueries) CloseTellerTill(ctx context.Context, arg CloseTellerTillParams) (TellerTill, error) {
+	row, err := q.queries.CloseTellerTill(ctx, sqlite.CloseTellerTillParams(arg))
+	return TellerTill(row), sqliteError(err)
+}
+
+func (q sqliteQueries) CloseTermDeposit(ctx context.Context, arg CloseTermDepositParams) (TermDeposit, error) {
+	row, err := q.queries.CloseTermDeposit(ctx, sqlite.CloseTermDepositParams(arg))
+	return TermDeposit(row), sqliteError(err)
+}
+
+func (q sqliteQueries) CountOpenPots(ctx context.Context, parentAccountID int64) (int64, error) {
+	count, err := q.queries.CountOpenPots(ctx, parentAccountID)
+	return count, sqliteError(err)
+}
+
+func (q sqliteQueries) CountUnpaidLoanInstallments(ctx context.Context, loanID int64) (int64, error) {
+	count, err := q.queries.CountUnpaidLoanInstallments(ctx, loanID)
+	return count, sqliteError(err)
+}
+
+// CreateAccount draws the number again when it is taken, which Postgres
+// does in generate_account_number
+func (q sqliteQueries) CreateAccount(ctx context.Context, arg CreateAccountParams) (Account, error) {
+	for {
+		row, err := q.queries.CreateAccount(ctx, sqlite.CreateAccountParams(arg))
+		err = sqliteError(err)
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.ConstraintName == "accounts_number_key" {
+			continue
+		}
+		return Account(row), err
+	}
+}
+
+func (q sqliteQueries) CreateAccountHolder(ctx context.Context, arg CreateAccountHolderParams) (AccountHolder, error) {
+	row, err := q.queries.CreateAccountHolder(ctx, sqlite.CreateAccountHolderParams(arg))
+	return AccountHolder(row), sqliteError(err)
+}
+
+func (q sqliteQueries) CreateBeneficiary(ctx context.Context, arg CreateBeneficiaryParams) (Beneficiary, error) {
+	row, err := q.queries.CreateBeneficiary(ctx, sqlite.CreateBeneficiaryParams(arg))
+	return Beneficiary(row), sqliteError(err)
+}
+
+func (q sqliteQueries) CreateCashTransaction(ctx context.Context, arg CreateCashTransactionParams) (CashTransaction, error) {
+	row, err := q.queries.CreateCashTransaction(ctx, sqlite.CreateCashTransactionParams(arg))
+	return CashTransaction(row), sqliteError(err)
+}
+
+func (q sqliteQueries) CreateEntry(ctx context.Context, arg CreateEntryParams) (Entry, error) {
+	row, err := q.queries.CreateEntry(ctx, sqlite.CreateEntryParams(arg))
+	return Entry(row), sqliteError(err)
+}
+
+func (q sqliteQueries) CreateFeeSchedule(ctx context.Context, arg CreateFeeScheduleParams) (FeeSchedule, error) {
+	row, err := q.queries.CreateFeeSchedule(ctx, sqlite.CreateFeeScheduleParams(arg))
+	return FeeSchedule(row), sqliteError(err)
+}
+
+func (q sqliteQueries) CreateFeeScheduleTier(ctx context.Context, arg CreateFeeScheduleTierParams) (FeeScheduleTier, error) {
+	row, err := q.queries.CreateFeeScheduleTier(ctx, sqlite.CreateFeeScheduleTierParams(arg))
+	return FeeScheduleTier(row), sqliteError(err)
+}
+
+func (q sqliteQueries) CreateGLAccount(ctx context.Context, arg CreateGLAccountParams) (GlAccount, error) {
+	row, err := q.queries.CreateGLAccount(ctx, sqlite.CreateGLAccountParams(arg))
+	return GlAccount(row), sqliteError(err)
+}
+
+func (q sqliteQueries) CreateIdempotencyKey(ctx context.Context, arg CreateIdempotencyKeyParams) (IdempotencyKey, error) {
+	row, err := q.queries.CreateIdempotencyKey(ctx, sqlite.CreateIdempotencyKeyParams(arg))
+	return IdempotencyKey(row), sqliteError(err)
+}
+
+func (q sqliteQueries) CreateInterestAccrual(ctx context.Context, arg CreateInterestAccrualParams) (InterestAccrual, error) {
+	row, err := q.queries.CreateInterestAccrual(ctx, sqlite.CreateInterestAccrualParams(arg))
+	return InterestAccrual(row), sqliteError(err)
+}
+
+func (q sqliteQueries) CreateInterestCapitalization(ctx context.Context, arg CreateInterestCapitalizationParams) (InterestCapitalization, error) {
+	row, err := q.queries.CreateInterestCapitalization(ctx, sqlite.CreateInterestCapitalizationParams(arg))
+	return InterestCapitalization(row), sqliteError(err)
+}
+
+func (q sqliteQueries) CreateInterestRate(ctx context.Context, arg CreateInterestRateParams) (InterestRate, error) {
+	row, err := q.queries.CreateInterestRate(ctx, sqlite.CreateInterestRateParams(arg))
+	return InterestRate(row), sqliteError(err)
+}
+
+func (q sqliteQueries) CreateJournalEntry(ctx context.Context, reference string) (JournalEntry, error) {
+	row, err := q.queries.CreateJournalEntry(ctx, reference)
+	return JournalEntry(row), sqliteError(err)
+}
+
+func (q sqliteQueries) CreateJournalLine(ctx context.Context, arg CreateJournalLineParams) (JournalLine, error) {
+	row, err := q.queries.CreateJournalLine(ctx, sqlite.CreateJournalLineParams(arg))
+	return JournalLine(row), sqliteError(err)
+}
+
+func (q sqliteQueries) CreateLoan(ctx context.Context, arg CreateLoanParams) (Loan, error) {
+	row, err := q.queries.CreateLoan(ctx, sqlite.CreateLoanParams(arg))
+	return Loan(row), sqliteError(err)
+}
+
+func (q sqliteQueries) CreateLoanInstallment(ctx context.Context, arg CreateLoanInstallmentParams) (LoanInstallment, error) {
+	row, err := q.queries.CreateLoanInstallment(ctx, sqlite.CreateLoanInstallmentParams(arg))
+	return LoanInstallment(row), sqliteError(err)
+}
+
+func (q sqliteQueries) CreatePaymentRequest(ctx context.Context, arg CreatePaymentRequestParams) (PaymentRequest, error) {
+	row, err := q.queries.CreatePaymentRequest(ctx, sqlite.CreatePaymentRequestParams(arg))
+	return PaymentRequest(row), sqliteError(err)
+}
+
+func (q sqliteQueries) CreatePot(ctx context.Context, arg CreatePotParams) (Pot, error) {
+	row, err := q.queries.CreatePot(ctx, sqlite.CreatePotParams(arg))
+	return Pot(row), sqliteError(err)
+}
+
+func (q sqliteQueries) CreateTellerTill(ctx context.Context, arg CreateTellerTillParams) (TellerTill, error) {
+	row, err := q.queries.CreateTellerTill(ctx, sqlite.CreateTellerTillParams(arg))
+	return TellerTill(row), sqliteError(err)
+}
+
+func (q sqliteQueries) CreateTermDeposit(ctx context.Context, arg CreateTermDepositParams) (TermDeposit, error) {
+	row, err := q.queries.CreateTermDeposit(ctx, sqlite.CreateTermDepositParams(arg))
+	return TermDeposit(row), sqliteError(err)
+}
+
+func (q sqliteQueries) CreateTermDepositRate(ctx context.Context, arg CreateTermDepositRateParams) (TermDepositRate, error) {
+	row, err := q.queries.CreateTermDepositRate(ctx, sqlite.CreateTermDepositRateParams(arg))
+	return TermDepositRate(row), sqliteError(err)
+}
+
+func (q sqliteQueries) CreateTransfer(ctx context.Context, arg CreateTransferParams) (Transfer, error) {
+	row, err := q.queries.CreateTransfer(ctx, sqlite.CreateTransferParams(arg))
+	return Transfer(row), sqliteError(err)
+}
+
+func (q sqliteQueries) CreateTransferApproval(ctx context.Context, arg CreateTransferApprovalParams) (TransferApproval, error) {
+	row, err := q.queries.CreateTransferApproval(ctx, sqlite.CreateTransferApprovalParams(arg))
+	return TransferApproval(row), sqliteError(err)
+}
+
+func (q sqliteQueries) CreateUser(ctx context.Context, arg CreateUserParams) (User, error) {
+	row, err := q.queries.CreateUser(ctx, sqlite.CreateUserParams(arg))
+	return User(row), sqliteError(err)
+}
+
+func (q sqliteQueries) DecidePaymentRequest(ctx context.Context, arg DecidePaymentRequestParams) (PaymentRequest, error) {
+	row, err := q.queries.DecidePaymentRequest(ctx, sqlite.DecidePaymentRequestParams(arg))
+	return PaymentRequest(row), sqliteError(err)
+}
+
+func (q sqliteQueries) DecideTransferApproval(ctx context.Context, arg DecideTransferApprovalParams) (TransferApproval, error) {
+	row, err := q.queries.DecideTransferApproval(ctx, sqlite.DecideTransferApprovalParams(arg))
+	return TransferApproval(row), sqliteError(err)
+}
+
+func (q sqliteQueries) DeleteAccountHolder(ctx context.Context, arg DeleteAccountHolderParams) (AccountHolder, error) {
+	row, err := q.queries.DeleteAccountHolder(ctx, sqlite.DeleteAccountHolderParams(arg))
+	return AccountHolder(row), sqliteError(err)
+}
+
+func (q sqliteQueries) DeleteBeneficiary(ctx context.Context, id int64) (Beneficiary, error) {
+	row, err := q.queries.DeleteBeneficiary(ctx, id)
+	return Beneficiary(row), sqliteError(err)
+}
+
+func (q sqliteQueries) DeleteIdempotencyKey(ctx context.Context, arg DeleteIdempotencyKeyParams) error {
+	return sqliteError(q.queries.DeleteIdempotencyKey(ctx, sqlite.DeleteIdempotencyKeyParams(arg)))
+}
+
+func (q sqliteQueries) DeleteIdempotencyKeysBefore(ctx context.Context, before time.Time) (int64, error) {
+	count, err := q.queries.DeleteIdempotencyKeysBefore(ctx, before)
+	return count, sqliteError(err)
+}
+
+func (q sqliteQueries) FetchAccounts(ctx context.Context, arg FetchAccountsParams) ([]Account, error) {
+	rows, err := q.queries.FetchAccounts(ctx, sqlite.FetchAccountsParams{
+		Limit:  int64(arg.Limit),
+		Offset: int64(arg.Offset),
+	})
+	if err != nil {
+		return nil, sqliteError(err)
+	}
+	items := make([]Account, len(rows))
+	for i, row := range rows {
+		items[i] = Account(row)
+	}
+	return items, nil
+}
+
+func (q sqliteQueries) FetchAccountsByOwner(ctx context.Context, arg FetchAccountsByOwnerParams) ([]Account, error) {
+	rows, err := q.queries.FetchAccountsByOwner(ctx, sqlite.FetchAccountsByOwnerParams{
+		OwnerID: arg.OwnerID,
+		Limit:   int64(arg.Limit),
+		Offset:  int64(arg.Offset),
+	})
+	if err != nil {
+		return nil, sqliteError(err)
+	}
+	items := make([]Account, len(rows))
+	for i, row := range rows {
+		items[i] = Account(row)
+	}
+	return items, nil
+}
+
+func (q sqliteQueries) FetchEntries(ctx context.Context, arg FetchEntriesParams) ([]Entry, error) {
+	rows, err := q.queries.FetchEntries(ctx, sqlite.FetchEntriesParams{
+		AccountID: arg.AccountID,
+		Limit:     int64(arg.Limit),
+		Offset:    int64(arg.Offset),
+	})
+	if err != nil {
+		return nil, sqliteError(err)
+	}
+	items := make([]Entry, len(rows))
+	for i, row := range rows {
+		items[i] = Entry(row)
+	}
+	return items, nil
+}
+
+func (q sqliteQueries) FetchTransfer(ctx context.Context, arg FetchTransferParams) ([]Transfer, error) {
+	rows, err := q.queries.FetchTransfer(ctx, sqlite.FetchTransferParams{
+		FromAccountID: arg.FromAccountID,
+		ToAccountID:   arg.ToAccountID,
+		Limit:         int64(arg.Limit),
+		Offset:        int64(arg.Offset),
+	})
+	if err != nil {
+		return nil, sqliteError(err)
+	}
+	items := make([]Transfer, len(rows))
+	for i, row := range rows {
+		items[i] = Transfer(row)
+	}
+	return items, nil
+}
+
+func (q sqliteQueries) FetchUsers(ctx context.Context, arg FetchUsersParams) ([]User, error) {
+	rows, err := q.queries.FetchUsers(ctx, sqlite.FetchUsersParams{
+		Limit:  int64(arg.Limit),
+		Offset: int64(arg.Offset),
+	})
+	if err != nil {
+		return nil, sqliteError(err)
+	}
+	items := make([]User, len(rows))
+	for i, row := range rows {
+		items[i] = User(row)
+	}
+	return items, nil
+}
+
+func (q sqliteQueries) GetAccount(ctx context.Context, id int64) (Account, error) {
+	row, err := q.queries.GetAccount(ctx, id)
+	return Account(row), sqliteError(err)
+}
+
+func (q sqliteQueries) GetAccountByNumber(ctx context.Context, number string) (Account, error) {
+	row, err := q.queries.GetAccountByNumber(ctx, number)
+	return Account(row), sqliteError(err)
+}
+
+func (q sqliteQueries) GetAccountForUpdate(ctx context.Context, id int64) (Account, error) {
+	row, err := q.queries.GetAccountForUpdate(ctx, id)
+	return Account(row), sqliteError(err)
+}
+
+func (q sqliteQueries) GetAccountHolder(ctx context.Context, arg GetAccountHolderParams) (AccountHolder, error) {
+	row, err := q.queries.GetAccountHolder(ctx, sqlite.GetAccountHolderParams(arg))
+	return AccountHolder(row), sqliteError(err)
+}
+
+func (q sqliteQueries) GetAccountTransferUsage(ctx context.Context, arg GetAccountTransferUsageParams) (GetAccountTransferUsageRow, error) {
+	row, err := q.queries.GetAccountTransferUsage(ctx, sqlite.GetAccountTransferUsageParams(arg))
+	return GetAccountTransferUsageRow(row), sqliteError(err)
+}
+
+func (q sqliteQueries) GetBeneficiary(ctx context.Context, id int64) (Beneficiary, error) {
+	row, err := q.queries.GetBeneficiary(ctx, id)
+	return Beneficiary(row), sqliteError(err)
+}
+
+func (q sqliteQueries) GetCoolingOffBeneficiaryForUpdate(ctx context.Context, arg GetCoolingOffBeneficiaryForUpdateParams) (Beneficiary, error) {
+	row, err := q.queries.GetCoolingOffBeneficiaryForUpdate(ctx, sqlite.GetCoolingOffBeneficiaryForUpdateParams(arg))
+	return Beneficiary(row), sqliteError(err)
+}
+
+func (q sqliteQueries) GetEntry(ctx context.Context, id int64) (Entry, error) {
+	row, err := q.queries.GetEntry(ctx, id)
+	return Entry(row), sqliteError(err)
+}
+
+func (q sqliteQueries) GetFeeSchedule(ctx context.Context, arg GetFeeScheduleParams) (FeeSchedule, error) {
+	row, err := q.queries.GetFeeSchedule(ctx, sqlite.GetFeeScheduleParams(arg))
+	return FeeSchedule(row), sqliteError(err)
+}
+
+func (q sqliteQueries) GetGLAccountForAccount(ctx context.Context, id int64) (GetGLAccountForAccountRow, error) {
+	row, err := q.queries.GetGLAccountForAccount(ctx, id)
+	return GetGLAccountForAccountRow(row), sqliteError(err)
+}
+
+func (q sqliteQueries) GetHouseAccount(ctx context.Context, arg GetHouseAccountParams) (Account, error) {
+	row, err := q.queries.GetHouseAccount(ctx, sqlite.GetHouseAccountParams(arg))
+	return Account(row), sqliteError(err)
+}
+
+func (q sqliteQueries) GetIdempotencyKey(ctx context.Context, arg GetIdempotencyKeyParams) (IdempotencyKey, error) {
+	row, err := q.queries.GetIdempotencyKey(ctx, sqlite.GetIdempotencyKeyParams(arg))
+	return IdempotencyKey(row), sqliteError(err)
+}
+
+func (q sqliteQueries) GetLastInterestCapitalization(ctx context.Context, accountID int64) (InterestCapitalization, error) {
+	row, err := q.queries.GetLastInterestCapitalization(ctx, accountID)
+	return InterestCapitalization(row), sqliteError(err)
+}
+
+func (q sqliteQueries) GetLoan(ctx context.Context, id int64) (Loan, error) {
+	row, err := q.queries.GetLoan(ctx, id)
+	return Loan(row), sqliteError(err)
+}
+
+func (q sqliteQueries) GetLoanForUpdate(ctx context.Context, id int64) (Loan, error) {
+	row, err := q.queries.GetLoanForUpdate(ctx, id)
+	return Loan(row), sqliteError(err)
+}
+
+func (q sqliteQueries) GetLoanInstallmentForUpdate(ctx context.Context, id int64) (LoanInstallment, error) {
+	row, err := q.queries.GetLoanInstallmentForUpdate(ctx, id)
+	return LoanInstallment(row), sqliteError(err)
+}
+
+func (q sqliteQueries) GetOpenTellerTillForUpdate(ctx context.Context, arg GetOpenTellerTillForUpdateParams) (TellerTill, error) {
+	row, err := q.queries.GetOpenTellerTillForUpdate(ctx, sqlite.GetOpenTellerTillForUpdateParams(arg))
+	return TellerTill(row), sqliteError(err)
+}
+
+func (q sqliteQueries) GetOwnerTransferUsage(ctx context.Context, arg GetOwnerTransferUsageParams) (GetOwnerTransferUsageRow, error) {
+	row, err := q.queries.GetOwnerTransferUsage(ctx, sqlite.GetOwnerTransferUsageParams(arg))
+	return GetOwnerTransferUsageRow(row), sqliteError(err)
+}
+
+func (q sqliteQueries) GetPayeeTransferTotal(ctx context.Context, arg GetPayeeTransferTotalParams) (int64, error) {
+	total, err := q.queries.GetPayeeTransferTotal(ctx, sqlite.GetPayeeTransferTotalParams(arg))
+	return total, sqliteError(err)
+}
+
+func (q sqliteQueries) GetPaymentRequest(ctx context.Context, id int64) (PaymentRequest, error) {
+	row, err := q.queries.GetPaymentRequest(ctx, id)
+	return PaymentRequest(row), sqliteError(err)
+}
+
+func (q sqliteQueries) GetPaymentRequestForUpdate(ctx context.Context, id int64) (PaymentRequest, error) {
+	row, err := q.queries.GetPaymentRequestForUpdate(ctx, id)
+	return PaymentRequest(row), sqliteError(err)
+}
+
+func (q sqliteQueries) GetPendingInterest(ctx context.Context, arg GetPendingInterestParams) (GetPendingInterestRow, error) {
+	row, err := q.queries.GetPendingInterest(ctx, sqlite.GetPendingInterestParams(arg))
+	return GetPendingInterestRow(row), sqliteError(err)
+}
+
+func (q sqliteQueries) GetPot(ctx context.Context, accountID int64) (Pot, error) {
+	row, err := q.queries.GetPot(ctx, accountID)
+	return Pot(row), sqliteError(err)
+}
+
+func (q sqliteQueries) GetRoundUpPot(ctx context.Context, parentAccountID int64) (Pot, error) {
+	row, err := q.queries.GetRoundUpPot(ctx, parentAccountID)
+	return Pot(row), sqliteError(err)
+}
+
+func (q sqliteQueries) GetTellerTill(ctx context.Context, id int64) (TellerTill, error) {
+	row, err := q.queries.GetTellerTill(ctx, id)
+	return TellerTill(row), sqliteError(err)
+}
+
+func (q sqliteQueries) GetTellerTillForUpdate(ctx context.Context, id int64) (TellerTill, error) {
+	row, err := q.queries.GetTellerTillForUpdate(ctx, id)
+	return TellerTill(row), sqliteError(err)
+}
+
+func (q sqliteQueries) GetTermDeposit(ctx context.Context, id int64) (TermDeposit, error) {
+	row, err := q.queries.GetTermDeposit(ctx, id)
+	return TermDeposit(row), sqliteError(err)
+}
+
+func (q sqliteQueries) GetTermDepositForUpdate(ctx context.Context, id int64) (TermDeposit, error) {
+	row, err := q.queries.GetTermDepositForUpdate(ctx, id)
+	return TermDeposit(row), sqliteError(err)
+}
+
+func (q sqliteQueries) GetTermDepositRate(ctx context.Context, arg GetTermDepositRateParams) (TermDepositRate, error) {
+	row, err := q.queries.GetTermDepositRate(ctx, sqlite.GetTermDepositRateParams(arg))
+	return TermDepositRate(row), sqliteError(err)
+}
+
+func (q sqliteQueries) GetTransfer(ctx context.Context, id int64) (Transfer, error) {
+	row, err := q.queries.GetTransfer(ctx, id)
+	return Transfer(row), sqliteError(err)
+}
+
+func (q sqliteQueries) GetTransferApproval(ctx context.Context, id int64) (TransferApproval, error) {
+	row, err := q.queries.GetTransferApproval(ctx, id)
+	return TransferApproval(row), sqliteError(err)
+}
+
+func (q sqliteQueries) GetTransferApprovalForUpdate(ctx context.Context, id int64) (TransferApproval, error) {
+	row, err := q.queries.GetTransferApprovalForUpdate(ctx, id)
+	return TransferApproval(row), sqliteError(err)
+}
+
+func (q sqliteQueries) GetTransferLimit(ctx context.Context, arg GetTransferLimitParams) (TransferLimit, error) {
+	row, err := q.queries.GetTransferLimit(ctx, sqlite.GetTransferLimitParams(arg))
+	return TransferLimit(row), sqliteError(err)
+}
+
+func (q sqliteQueries) GetTrialBalance(ctx context.Context, asOf time.Time) ([]GetTrialBalanceRow, error) {
+	rows, err := q.queries.GetTrialBalance(ctx, asOf)
+	if err != nil {
+		return nil, sqliteError(err)
+	}
+	items := make([]GetTrialBalanceRow, len(rows))
+	for i, row := range rows {
+		items[i] = GetTrialBalanceRow(row)
+	}
+	return items, nil
+}
+
+func (q sqliteQueries) GetUser(ctx context.Context, id uuid.UUID) (User, error) {
+	row, err := q.queries.GetUser(ctx, id)
+	return User(row), sqliteError(err)
+}
+
+func (q sqliteQueries) GetUserByEmail(ctx context.Context, email string) (User, error) {
+	row, err := q.queries.GetUserByEmail(ctx, email)
+	return User(row), sqliteError(err)
+}
+
+func (q sqliteQueries) GetUserByUsername(ctx context.Context, username string) (User, error) {
+	row, err := q.queries.GetUserByUsername(ctx, username)
+	return User(row), sqliteError(err)
+}
+
+func (q sqliteQueries) GetUserForUpdate(ctx context.Context, id uuid.UUID) (User, error) {
+	row, err := q.queries.GetUserForUpdate(ctx, id)
+	return User(row), sqliteError(err)
+}
+
+func (q sqliteQueries) ListAccountHolders(ctx context.Context, accountID int64) ([]AccountHolder, error) {
+	rows, err := q.queries.ListAccountHolders(ctx, accountID)
+	if err != nil {
+		return nil, sqliteError(err)
+	}
+	items := make([]AccountHolder, len(rows))
+	for i, row := range rows {
+		items[i] = AccountHolder(row)
+	}
+	return items, nil
+}
+
+func (q sqliteQueries) ListAccountsWithPendingInterest(ctx context.Context, periodEnd time.Time) ([]int64, error) {
+	ids, err := q.queries.ListAccountsWithPendingInterest(ctx, periodEnd)
+	return ids, sqliteError(err)
+}
+
+func (q sqliteQueries) ListBeneficiaries(ctx context.Context, ownerID uuid.UUID) ([]Beneficiary, error) {
+	rows, err := q.queries.ListBeneficiaries(ctx, ownerID)
+	if err != nil {
+		return nil, sqliteError(err)
+	}
+	items := make([]Beneficiary, len(rows))
+	for i, row := range rows {
+		items[i] = Beneficiary(row)
+	}
+	return items, nil
+}
+
+func (q sqliteQueries) ListCashTransactionsByTill(ctx context.Context, tillID int64) ([]CashTransaction, error) {
+	rows, err := q.queries.ListCashTransactionsByTill(ctx, tillID)
+	if err != nil {
+		return nil, sqliteError(err)
+	}
+	items := make([]CashTransaction, len(rows))
+	for i, row := range rows {
+		items[i] = CashTransaction(row)
+	}
+	return items, nil
+}
+
+func (q sqliteQueries) ListDueLoanInstallments(ctx context.Context, dueDate time.Time) ([]LoanInstallment, error) {
+	rows, err := q.queries.ListDueLoanInstallments(ctx, dueDate)
+	if err != nil {
+		return nil, sqliteError(err)
+	}
+	items := make([]LoanInstallment, len(rows))
+	for i, row := range rows {
+		items[i] = LoanInstallment(row)
+	}
+	return items, nil
+}
+
+func (q sqliteQueries) ListFeeScheduleTiers(ctx context.Context, scheduleID int64) ([]FeeScheduleTier, error) {
+	rows, err := q.queries.ListFeeScheduleTiers(ctx, scheduleID)
+	if err != nil {
+		return nil, sqliteError(err)
+	}
+	items := make([]FeeScheduleTier, len(rows))
+	for i, row := range rows {
+		items[i] = FeeScheduleTier(row)
+	}
+	return items, nil
+}
+
+func (q sqliteQueries) ListGLAccounts(ctx context.Context) ([]GlAccount, error) {
+	rows, err := q.queries.ListGLAccounts(ctx)
+	if err != nil {
+		return nil, sqliteError(err)
+	}
+	items := make([]GlAccount, len(rows))
+	for i, row := range rows {
+		items[i] = GlAccount(row)
+	}
+	return items, nil
+}
+
+func (q sqliteQueries) ListInterestAccruals(ctx context.Context, accountID int64) ([]InterestAccrual, error) {
+	rows, err := q.queries.ListInterestAccruals(ctx, accountID)
+	if err != nil {
+		return nil, sqliteError(err)
+	}
+	items := make([]InterestAccrual, len(rows))
+	for i, row := range rows {
+		items[i] = InterestAccrual(row)
+	}
+	return items, nil
+}
+
+func (q sqliteQueries) ListInterestBearingAccounts(ctx context.Context, asOf time.Time) ([]ListInterestBearingAccountsRow, error) {
+	rows, err := q.queries.ListInterestBearingAccounts(ctx, asOf)
+	if err != nil {
+		return nil, sqliteError(err)
+	}
+	items := make([]ListInterestBearingAccountsRow, len(rows))
+	for i, row := range rows {
+		items[i] = ListInterestBearingAccountsRow(row)
+	}
+	return items, nil
+}
+
+func (q sqliteQueries) ListJournalEntriesByReference(ctx context.Context, reference string) ([]JournalEntry, error) {
+	rows, err := q.queries.ListJournalEntriesByReference(ctx, reference)
+	if err != nil {
+		return nil, sqliteError(err)
+	}
+	items := make([]JournalEntry, len(rows))
+	for i, row := range rows {
+		items[i] = JournalEntry(row)
+	}
+	return items, nil
+}
+
+func (q sqliteQueries) ListJournalLines(ctx context.Context, journalEntryID int64) ([]JournalLine, error) {
+	rows, err := q.queries.ListJournalLines(ctx, journalEntryID)
+	if err != nil {
+		return nil, sqliteError(err)
+	}
+	items := make([]JournalLine, len(rows))
+	for i, row := range rows {
+		items[i] = JournalLine(row)
+	}
+	return items, nil
+}
+
+func (q sqliteQueries) ListLoanInstallments(ctx context.Context, loanID int64) ([]LoanInstallment, error) {
+	rows, err := q.queries.ListLoanInstallments(ctx, loanID)
+	if err != nil {
+		return nil, sqliteError(err)
+	}
+	items := make([]LoanInstallment, len(rows))
+	for i, row := range rows {
+		items[i] = LoanInstallment(row)
+	}
+	return items, nil
+}
+
+func (q sqliteQueries) ListMaturingTermDeposits(ctx context.Context, maturityDate time.Time) ([]TermDeposit, error) {
+	rows, err := q.queries.ListMaturingTermDeposits(ctx, maturityDate)
+	if err != nil {
+		return nil, sqliteError(err)
+	}
+	items := make([]TermDeposit, len(rows))
+	for i, row := range rows {
+		items[i] = TermDeposit(row)
+	}
+	return items, nil
+}
+
+func (q sqliteQueries) ListPaymentRequests(ctx context.Context, requesterID uuid.UUID) ([]PaymentRequest, error) {
+	rows, err := q.queries.ListPaymentRequests(ctx, requesterID)
+	if err != nil {
+		return nil, sqliteError(err)
+	}
+	items := make([]PaymentRequest, len(rows))
+	for i, row := range rows {
+		items[i] = PaymentRequest(row)
+	}
+	return items, nil
+}
+
+func (q sqliteQueries) ListPendingTransferApprovals(ctx context.Context, fromAccountID int64) ([]TransferApproval, error) {
+	rows, err := q.queries.ListPendingTransferApprovals(ctx, fromAccountID)
+	if err != nil {
+		return nil, sqliteError(err)
+	}
+	items := make([]TransferApproval, len(rows))
+	for i, row := range rows {
+		items[i] = TransferApproval(row)
+	}
+	return items, nil
+}
+
+func (q sqliteQueries) ListPotsByParent(ctx context.Context, parentAccountID int64) ([]ListPotsByParentRow, error) {
+	rows, err := q.queries.ListPotsByParent(ctx, parentAccountID)
+	if err != nil {
+		return nil, sqliteError(err)
+	}
+	items := make([]ListPotsByParentRow, len(rows))
+	for i, row := range rows {
+		items[i] = ListPotsByParentRow(row)
+	}
+	return items, nil
+}
+
+func (q sqliteQueries) ListTermDepositRates(ctx context.Context) ([]TermDepositRate, error) {
+	rows, err := q.queries.ListTermDepositRates(ctx)
+	if err != nil {
+		return nil, sqliteError(err)
+	}
+	items := make([]TermDepositRate, len(rows))
+	for i, row := range rows {
+		items[i] = TermDepositRate(row)
+	}
+	return items, nil
+}
+
+func (q sqliteQueries) MarkInterestAccrualsCapitalized(ctx context.Context, arg MarkInterestAccrualsCapitalizedParams) error {
+	return sqliteError(q.queries.MarkInterestAccrualsCapitalized(ctx, sqlite.MarkInterestAccrualsCapitalizedParams(arg)))
+}
+
+func (q sqliteQueries) MarkLoanInstallmentOverdue(ctx context.Context, arg MarkLoanInstallmentOverdueParams) (LoanInstallment, error) {
+	row, err := q.queries.MarkLoanInstallmentOverdue(ctx, sqlite.MarkLoanInstallmentOverdueParams(arg))
+	return LoanInstallment(row), sqliteError(err)
+}
+
+func (q sqliteQueries) MarkLoanInstallmentPaid(ctx context.Context, arg MarkLoanInstallmentPaidParams) (LoanInstallment, error) {
+	row, err := q.queries.MarkLoanInstallmentPaid(ctx, sqlite.MarkLoanInstallmentPaidParams(arg))
+	return LoanInstallment(row), sqliteError(err)
+}
+
+func (q sqliteQueries) SaveIdempotentResponse(ctx context.Context, arg SaveIdempotentResponseParams) (IdempotencyKey, error) {
+	row, err := q.queries.SaveIdempotentResponse(ctx, sqlite.SaveIdempotentResponseParams(arg))
+	return IdempotencyKey(row), sqliteError(err)
+}
+
+func (q sqliteQueries) TakeOverIdempotencyKey(ctx context.Context, arg TakeOverIdempotencyKeyParams) (IdempotencyKey, error) {
+	row, err := q.queries.TakeOverIdempotencyKey(ctx, sqlite.TakeOverIdempotencyKeyParams(arg))
+	return IdempotencyKey(row), sqliteError(err)
+}
+
+func (q sqliteQueries) UpdateAccountStatus(ctx context.Context, arg UpdateAccountStatusParams) (Account, error) {
+	row, err := q.queries.UpdateAccountStatus(ctx, sqlite.UpdateAccountStatusParams(arg))
+	return Account(row), sqliteError(err)
+}
+
+func (q sqliteQueries) UpdateBalanceAccount(ctx context.Context, arg UpdateBalanceAccountParams) (Account, error) {
+	row, err := q.queries.UpdateBalanceAccount(ctx, sqlite.UpdateBalanceAccountParams(arg))
+	return Account(row), sqliteError(err)
+}
+
+func (q sqliteQueries) UpdateLoanStatus(ctx context.Context, arg UpdateLoanStatusParams) (Loan, error) {
+	row, err := q.queries.UpdateLoanStatus(ctx, sqlite.UpdateLoanStatusParams(arg))
+	return Loan(row), sqliteError(err)
+}
+
+func (q sqliteQueries) UpdateUserRole(ctx context.Context, arg UpdateUserRoleParams) (User, error) {
+	row, err := q.queries.UpdateUserRole(ctx, sqlite.UpdateUserRoleParams(arg))
+	return User(row), sqliteError(err)
+}
@@ -4,28 +4,130 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"math/rand"
+	"sync/atomic"
+	"time"
 )
 
 type Store interface {
 	TransferTx(ctx context.Context, arg TransferTxParams) (TransferTxResult, error)
+	SetAccountStatusTx(ctx context.Context, id int64, status string) (Account, error)
+	CloseAccountTx(ctx context.Context, arg CloseAccountTxParams) (CloseAccountTxResult, error)
+	AccrueInterest(ctx context.Context, date time.Time) ([]InterestAccrual, error)
+	CapitalizeInterest(ctx context.Context, periodEnd time.Time) ([]InterestCapitalization, error)
+	TrialBalance(ctx context.Context, asOf time.Time) (TrialBalance, error)
+	DepositCashTx(ctx context.Context, arg CashTxParams) (CashTxResult, error)
+	WithdrawCashTx(ctx context.Context, arg CashTxParams) (CashTxResult, error)
+	CloseTellerTillTx(ctx context.Context, id int64, countedCash int64) (TellerTill, error)
+	CreateLoanTx(ctx context.Context, arg CreateLoanTxParams) (CreateLoanTxResult, error)
+	CollectLoanInstallments(ctx context.Context, date time.Time) (LoanCollectionResult, error)
+	OpenTermDepositTx(ctx context.Context, arg OpenTermDepositTxParams) (OpenTermDepositTxResult, error)
+	MatureTermDeposits(ctx context.Context, date time.Time) ([]TermDepositClosure, error)
+	WithdrawTermDepositTx(ctx context.Context, id int64) (TermDepositClosure, error)
+	CreatePotTx(ctx context.Context, arg CreatePotTxParams) (CreatePotTxResult, error)
+	MovePotTx(ctx context.Context, arg MovePotTxParams) (TransferTxResult, error)
+	ApproveTransferTx(ctx context.Context, arg DecideTransferApprovalTxParams) (ApproveTransferTxResult, error)
+	RejectTransferTx(ctx context.Context, arg DecideTransferApprovalTxParams) (TransferApproval, error)
+	CreatePaymentRequestsTx(ctx context.Context, args []CreatePaymentRequestParams) ([]PaymentRequest, error)
+	AcceptPaymentRequestTx(ctx context.Context, arg AcceptPaymentRequestTxParams) (AcceptPaymentRequestTxResult, error)
+	DeclinePaymentRequestTx(ctx context.Context, id int64) (PaymentRequest, error)
+	TxStats() TxStats
 	Querier
 }
 
+// TxRetryPolicy bounds how often a transaction Postgres aborted with a
+// serialization failure or a deadlock is run again, and how long to wait
+// in between
+type TxRetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// DefaultTxRetryPolicy leaves room for a handful of transfers racing on the
+// same account
+var DefaultTxRetryPolicy = TxRetryPolicy{
+	MaxAttempts: 10,
+	BaseDelay:   5 * time.Millisecond,
+	MaxDelay:    200 * time.Millisecond,
+}
+
+// TxStats counts the transactions run again since the store was created
+type TxStats struct {
+	// attempts run again after a serialization failure or deadlock
+	Retries uint64 `json:"retries"`
+	// transactions that still failed after the last attempt
+	Exhausted uint64 `json:"exhausted"`
+}
+
+// txRunner runs fn in a transaction of a backend, rolled back when fn fails
+type txRunner interface {
+	runTx(ctx context.Context, level sql.IsolationLevel, fn func(Querier) error) error
+}
+
+// SQLStore carries out the business rules of the bank over the queries of a
+// backend, Postgres for NewStore or memory for NewMemoryStore
 type SQLStore struct {
-	db *sql.DB
-	*Queries
+	Querier
+	tx        txRunner
+	retry     TxRetryPolicy
+	retries   atomic.Uint64
+	exhausted atomic.Uint64
 }
 
 func NewStore(db *sql.DB) Store {
 	return &SQLStore{
-		db:      db,
-		Queries: New(db),
+		Querier: errorQueries{New(db)},
+		tx:      sqlTxRunner{db: db},
+		retry:   DefaultTxRetryPolicy,
+	}
+}
+
+func (s *SQLStore) TxStats() TxStats {
+	return TxStats{
+		Retries:   s.retries.Load(),
+		Exhausted: s.exhausted.Load(),
 	}
 }
 
 // execute database transaction
-func (s *SQLStore) execTx(ctx context.Context, fn func(*Queries) error) error {
-	tx, err := s.db.BeginTx(ctx, nil)
+func (s *SQLStore) execTx(ctx context.Context, fn func(Querier) error) error {
+	return s.execTxIsolation(ctx, sql.LevelDefault, fn)
+}
+
+// execTxIsolation runs fn in a transaction of the given isolation level.
+// When Postgres aborts it with a serialization failure or a deadlock the
+// whole of fn runs again in a new transaction, so fn must set its results
+// rather than add to them, and reset first any it only sets on some paths.
+func (s *SQLStore) execTxIsolation(ctx context.Context, level sql.IsolationLevel, fn func(Querier) error) error {
+	for attempt := 1; ; attempt++ {
+		err := s.tx.runTx(ctx, level, func(q Querier) error {
+			return fn(errorQueries{q})
+		})
+		err = mapError(err)
+		if !retryable(err) {
+			return err
+		}
+		if attempt >= s.retry.MaxAttempts {
+			s.exhausted.Add(1)
+			return err
+		}
+
+		s.retries.Add(1)
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(s.retry.backoff(attempt)):
+		}
+	}
+}
+
+type sqlTxRunner struct {
+	db *sql.DB
+}
+
+func (r sqlTxRunner) runTx(ctx context.Context, level sql.IsolationLevel, fn func(Querier) error) error {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{Isolation: level})
 	if err != nil {
 		return err
 	}
@@ -34,7 +136,7 @@ func (s *SQLStore) execTx(ctx context.Context, fn func(*Queries) error) error {
 	q := New(tx)
 	if err = fn(q); err != nil {
 		if rbErr := tx.Rollback(); rbErr != nil {
-			return fmt.Errorf("tx error: %v, rollback error: %v", err, rbErr)
+			return fmt.Errorf("tx error: %w, rollback error: %v", err, rbErr)
 		}
 		return err
 	}
@@ -42,6 +144,24 @@ func (s *SQLStore) execTx(ctx context.Context, fn func(*Queries) error) error {
 	return tx.Commit()
 }
 
+func retryable(err error) bool {
+	code := errorCode(err)
+	return code == SerializationFailure || code == DeadlockDetected
+}
+
+// backoff returns a random delay up to the base delay doubled for every
+// attempt made, so racing transactions don't retry in lockstep
+func (p TxRetryPolicy) backoff(attempt int) time.Duration {
+	limit := p.MaxDelay
+	if attempt < 32 && p.BaseDelay<<(attempt-1) < limit {
+		limit = p.BaseDelay << (attempt - 1)
+	}
+	if limit <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(limit))) + 1
+}
+
 // exec transfer from on to another
 // update account balance, transfer record, and account entries
 
@@ -52,62 +172,156 @@ type TransferTxParams struct {
 }
 
 type TransferTxResult struct {
-	Transfer    Transfer `json:"transfer"`
-	FromAccount Account  `json:"from_account"`
-	ToAccount   Account  `json:"to_account"`
-	FromEntry   Entry    `json:"from_entry"`
-	ToEntry     Entry    `json:"to_entry"`
+	Transfer    Transfer    `json:"transfer"`
+	FromAccount Account     `json:"from_account"`
+	ToAccount   Account     `json:"to_account"`
+	FromEntry   Entry       `json:"from_entry"`
+	ToEntry     Entry       `json:"to_entry"`
+	Fee         TransferFee `json:"fee"`
+	// set when the transfer was rounded up into a pot of the source account
+	RoundUp *Transfer `json:"round_up,omitempty"`
 }
 
 func (s *SQLStore) TransferTx(ctx context.Context, arg TransferTxParams) (TransferTxResult, error) {
 	var result TransferTxResult
 
-	err := s.execTx(ctx, func(q *Queries) error {
-		// create transfer
+	err := s.execTxIsolation(ctx, sql.LevelSerializable, func(q Querier) error {
 		var err error
+		result, err = transfer(ctx, q, arg, false)
+		return err
+	})
 
-		result.Transfer, err = q.CreateTransfer(ctx, CreateTransferParams(arg))
-		if err != nil {
-			return err
+	return result, err
+}
+
+// transfer moves money inside an open transaction. Internal transfers are
+// made by the bank itself, e.g. when sweeping an account being closed, and
+// skip the source status check, the velocity limits and the fee. They are
+// recorded as internal, so they don't count towards the limits either.
+func transfer(ctx context.Context, q Querier, arg TransferTxParams, internal bool) (TransferTxResult, error) {
+	var result TransferTxResult
+
+	from, err := lockSourceAccount(ctx, q, arg.FromAccountID)
+	if err != nil {
+		return result, err
+	}
+	if !internal {
+		if err = checkAccountActive(from); err != nil {
+			return result, err
 		}
+	}
 
-		// create from entry
-		result.FromEntry, err = q.CreateEntry(ctx, CreateEntryParams{
-			AccountID: arg.FromAccountID,
-			Amount:    -arg.Amount,
-		})
-		if err != nil {
-			return err
+	to, err := q.GetAccount(ctx, arg.ToAccountID)
+	if err != nil {
+		return result, err
+	}
+	if err = checkAccountActive(to); err != nil {
+		return result, err
+	}
+	if !internal && (from.AccountType == AccountTypePot || to.AccountType == AccountTypePot) {
+		return result, ErrPotTransfer
+	}
+
+	if !internal {
+		// check velocity limits before anything is written
+		now := time.Now()
+		if err = checkTransferLimits(ctx, q, from, arg.Amount, now); err != nil {
+			return result, err
+		}
+		if err = checkCoolingOff(ctx, q, from, to, arg.Amount, now); err != nil {
+			return result, err
 		}
 
-		// create to entry
-		result.ToEntry, err = q.CreateEntry(ctx, CreateEntryParams{
-			AccountID: arg.ToAccountID,
-			Amount:    arg.Amount,
-		})
+		result.Fee, err = computeTransferFee(ctx, q, from, arg.Amount)
 		if err != nil {
-			return err
+			return result, err
 		}
 
-		// get current balance and add to other
-		result.FromAccount, err = q.AddBalanceAccount(ctx, AddBalanceAccountParams{
-			ID:     arg.FromAccountID,
-			Amount: -arg.Amount,
-		})
-		if err != nil {
-			return err
+		// the fee is charged on top, so the balance must cover both
+		if from.Balance < arg.Amount+result.Fee.Amount {
+			return result, &InsufficientFundsError{
+				AccountID: from.ID,
+				Balance:   from.Balance,
+				Amount:    arg.Amount + result.Fee.Amount,
+			}
 		}
+	}
 
-		result.ToAccount, err = q.AddBalanceAccount(ctx, AddBalanceAccountParams{
-			ID:     arg.ToAccountID,
-			Amount: arg.Amount,
-		})
+	// create transfer
+	result.Transfer, err = q.CreateTransfer(ctx, CreateTransferParams{
+		FromAccountID: arg.FromAccountID,
+		ToAccountID:   arg.ToAccountID,
+		Amount:        arg.Amount,
+		Internal:      internal,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	// create from entry
+	result.FromEntry, err = q.CreateEntry(ctx, CreateEntryParams{
+		AccountID: arg.FromAccountID,
+		Amount:    -arg.Amount,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	// create to entry
+	result.ToEntry, err = q.CreateEntry(ctx, CreateEntryParams{
+		AccountID: arg.ToAccountID,
+		Amount:    arg.Amount,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	// get current balance and add to other
+	result.FromAccount, err = q.AddBalanceAccount(ctx, AddBalanceAccountParams{
+		ID:     arg.FromAccountID,
+		Amount: -arg.Amount,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	result.ToAccount, err = q.AddBalanceAccount(ctx, AddBalanceAccountParams{
+		ID:     arg.ToAccountID,
+		Amount: arg.Amount,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	reference := fmt.Sprintf("transfer:%d", result.Transfer.ID)
+	if _, err = bookEntries(ctx, q, reference, result.FromEntry, result.ToEntry); err != nil {
+		return result, err
+	}
+
+	// charge the fee, if any, on top of the transferred amount
+	if result.Fee.Amount > 0 {
+		result.FromAccount, err = postTransferFee(ctx, q, result.FromAccount, &result.Fee)
 		if err != nil {
-			return err
+			return result, err
 		}
 
-		return nil
-	})
+		reference = fmt.Sprintf("fee:%d", result.Transfer.ID)
+		if _, err = bookEntries(ctx, q, reference, *result.Fee.FromEntry, *result.Fee.HouseEntry); err != nil {
+			return result, err
+		}
+	}
 
-	return result, err
+	// put the change aside when the account has a round-up pot
+	if !internal {
+		roundUp, err := roundUpToPot(ctx, q, result.FromAccount, arg.Amount)
+		if err != nil {
+			return result, err
+		}
+		if roundUp != nil {
+			result.RoundUp = &roundUp.Transfer
+			result.FromAccount = roundUp.FromAccount
+		}
+	}
+
+	return result, nil
 }
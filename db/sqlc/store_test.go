@@ -2,8 +2,13 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"testing"
+	"time"
 
+	"github.com/flukis/simplebank/util"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/stretchr/testify/require"
 )
 
@@ -105,4 +110,228 @@ func TestTransferTx(t *testing.T) {
 	
 	require.Equal(t, account1.Balance - int64(n)*amount, updatedAccount1.Balance)
 	require.Equal(t, account2.Balance + int64(n)*amount, updatedAccount2.Balance)
-}
\ No newline at end of file
+}
+
+func TestExecTxRetry(t *testing.T) {
+	store := &SQLStore{
+		Querier: New(testDB),
+		tx:      sqlTxRunner{db: testDB},
+		retry:   TxRetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond},
+	}
+
+	// a deadlock is retried like a serialization failure
+	attempts := 0
+	err := store.execTxIsolation(context.Background(), sql.LevelSerializable, func(q Querier) error {
+		attempts++
+		switch attempts {
+		case 1:
+			return &pgconn.PgError{Code: SerializationFailure}
+		case 2:
+			return &pgconn.PgError{Code: DeadlockDetected}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 3, attempts)
+	require.Equal(t, TxStats{Retries: 2}, store.TxStats())
+
+	// the last failure is returned once the attempts run out
+	attempts = 0
+	err = store.execTx(context.Background(), func(q Querier) error {
+		attempts++
+		return &pgconn.PgError{Code: SerializationFailure}
+	})
+	require.Equal(t, SerializationFailure, errorCode(err))
+	require.Equal(t, 3, attempts)
+	require.Equal(t, TxStats{Retries: 4, Exhausted: 1}, store.TxStats())
+
+	// any other error is returned at once
+	attempts = 0
+	errOther := errors.New("other")
+	err = store.execTx(context.Background(), func(q Querier) error {
+		attempts++
+		return errOther
+	})
+	require.ErrorIs(t, err, errOther)
+	require.Equal(t, 1, attempts)
+}
+
+// flakyTxRunner aborts the first failures transactions with err once fn
+// has run in them, as Postgres does with transactions that raced. race, if
+// set, runs after every aborted one as the transaction that won.
+type flakyTxRunner struct {
+	next     txRunner
+	failures int
+	err      error
+	runs     int
+	race     func()
+}
+
+func (r *flakyTxRunner) runTx(ctx context.Context, level sql.IsolationLevel, fn func(Querier) error) error {
+	r.runs++
+	if r.runs > r.failures {
+		return r.next.runTx(ctx, level, fn)
+	}
+	err := r.next.runTx(ctx, level, func(q Querier) error {
+		if err := fn(q); err != nil {
+			return err
+		}
+		return r.err
+	})
+	if r.race != nil {
+		r.race()
+	}
+	return err
+}
+
+// createMemoryAccount opens a current account in IDR with balance for a new
+// user of store
+func createMemoryAccount(t *testing.T, store *SQLStore, balance int64) Account {
+	ctx := context.Background()
+	user, err := store.CreateUser(ctx, CreateUserParams{
+		Username:       util.GenRandomOwner(),
+		HashedPassword: "secret",
+		FullName:       util.GenRandomOwner(),
+		Email:          util.GenRandomEmail(),
+	})
+	require.NoError(t, err)
+	account, err := store.CreateAccount(ctx, CreateAccountParams{
+		OwnerID:     user.ID,
+		Currency:    "IDR",
+		AccountType: AccountTypeCurrent,
+	})
+	require.NoError(t, err)
+	account, err = store.AddBalanceAccount(ctx, AddBalanceAccountParams{ID: account.ID, Amount: balance})
+	require.NoError(t, err)
+	return account
+}
+
+func TestTransferTxRetry(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore().(*SQLStore)
+	store.retry = TxRetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}
+	runner := &flakyTxRunner{next: store.tx, err: &pgconn.PgError{Code: SerializationFailure}}
+	store.tx = runner
+
+	from := createMemoryAccount(t, store, 1000)
+	to := createMemoryAccount(t, store, 0)
+	arg := TransferTxParams{FromAccountID: from.ID, ToAccountID: to.ID, Amount: 100}
+
+	// aborted twice, committed on the third attempt
+	runner.runs, runner.failures = 0, 2
+	_, err := store.TransferTx(ctx, arg)
+	require.NoError(t, err)
+	require.Equal(t, 3, runner.runs)
+	require.Equal(t, TxStats{Retries: 2}, store.TxStats())
+
+	// the aborted attempts were rolled back, the money moved once
+	got, err := store.GetAccount(ctx, to.ID)
+	require.NoError(t, err)
+	require.EqualValues(t, 100, got.Balance)
+
+	// the failure is returned once the attempts run out
+	runner.runs, runner.failures = 0, 10
+	_, err = store.TransferTx(ctx, arg)
+	require.Equal(t, SerializationFailure, errorCode(err))
+	require.Equal(t, 3, runner.runs)
+	require.Equal(t, TxStats{Retries: 4, Exhausted: 1}, store.TxStats())
+
+	got, err = store.GetAccount(ctx, to.ID)
+	require.NoError(t, err)
+	require.EqualValues(t, 100, got.Balance)
+}
+
+// TestTxRetryResults races the batch jobs with a transaction that changes
+// what the retried attempt finds, which must not report what the aborted
+// attempt did
+func TestTxRetryResults(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore().(*SQLStore)
+	store.retry = TxRetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}
+	runner := &flakyTxRunner{next: store.tx, err: &pgconn.PgError{Code: SerializationFailure}}
+	store.tx = runner
+
+	t.Run("LoanInstallments", func(t *testing.T) {
+		account := createMemoryAccount(t, store, 0)
+		loan, err := store.CreateLoanTx(ctx, CreateLoanTxParams{
+			AccountID:    account.ID,
+			Principal:    100000,
+			RateBps:      1200,
+			Term:         2,
+			Frequency:    util.FrequencyMonthly,
+			Amortization: util.AmortizationAnnuity,
+			LateFee:      500,
+		})
+		require.NoError(t, err)
+
+		// the first attempt collects, then the money is spent before the retry
+		runner.runs, runner.failures = 0, 1
+		runner.race = func() {
+			_, err := store.UpdateBalanceAccount(ctx, UpdateBalanceAccountParams{ID: account.ID, Balance: 0})
+			require.NoError(t, err)
+		}
+		defer func() { runner.race = nil }()
+
+		result, err := store.CollectLoanInstallments(ctx, loan.Installments[0].DueDate)
+		require.NoError(t, err)
+		require.Empty(t, result.Collected)
+		require.Len(t, result.Overdue, 1)
+		require.Equal(t, InstallmentOverdue, result.Overdue[0].Status)
+	})
+
+	t.Run("TermDeposits", func(t *testing.T) {
+		account := createMemoryAccount(t, store, 100000)
+		opened, err := store.OpenTermDepositTx(ctx, OpenTermDepositTxParams{
+			AccountID:       account.ID,
+			PayoutAccountID: account.ID,
+			Amount:          50000,
+			TermMonths:      3,
+			OnMaturity:      OnMaturityPayout,
+		})
+		require.NoError(t, err)
+
+		// the first attempt settles, then the deposit is withdrawn before the retry
+		runner.runs, runner.failures = 0, 1
+		runner.race = func() {
+			runner.race = nil
+			_, err := store.WithdrawTermDepositTx(ctx, opened.Deposit.ID)
+			require.NoError(t, err)
+		}
+
+		closures, err := store.MatureTermDeposits(ctx, opened.Deposit.MaturityDate)
+		require.NoError(t, err)
+		require.Empty(t, closures)
+	})
+
+	t.Run("CloseAccount", func(t *testing.T) {
+		account := createMemoryAccount(t, store, 1000)
+		sweepTo := createMemoryAccount(t, store, 0)
+
+		// the first attempt sweeps, then the balance is taken out before the retry
+		runner.runs, runner.failures = 0, 1
+		runner.race = func() {
+			_, err := store.UpdateBalanceAccount(ctx, UpdateBalanceAccountParams{ID: account.ID, Balance: 0})
+			require.NoError(t, err)
+		}
+		defer func() { runner.race = nil }()
+
+		result, err := store.CloseAccountTx(ctx, CloseAccountTxParams{
+			AccountID:        account.ID,
+			SweepToAccountID: sweepTo.ID,
+		})
+		require.NoError(t, err)
+		require.Nil(t, result.Sweep)
+		require.Equal(t, AccountStatusClosed, result.Account.Status)
+	})
+}
+
+func TestTxRetryPolicyBackoff(t *testing.T) {
+	policy := TxRetryPolicy{BaseDelay: 10 * time.Millisecond, MaxDelay: 50 * time.Millisecond}
+
+	for i := 0; i < 100; i++ {
+		require.LessOrEqual(t, policy.backoff(1), 10*time.Millisecond)
+		require.LessOrEqual(t, policy.backoff(3), 40*time.Millisecond)
+		require.LessOrEqual(t, policy.backoff(64), 50*time.Millisecond)
+		require.Positive(t, policy.backoff(64))
+	}
+}
@@ -0,0 +1,192 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	AccountTypeHouseCash = "house_cash"
+
+	CashDeposit    = "deposit"
+	CashWithdrawal = "withdrawal"
+
+	TillStatusOpen   = "open"
+	TillStatusClosed = "closed"
+)
+
+var (
+	ErrTillNotOpen          = errors.New("the teller has no open till in this currency today")
+	ErrInsufficientTillCash = errors.New("not enough cash in the till")
+)
+
+// InsufficientFundsError is returned when a debit would take an account
+// below zero.
+type InsufficientFundsError struct {
+	AccountID int64 `json:"account_id"`
+	Balance   int64 `json:"balance"`
+	Amount    int64 `json:"amount"`
+}
+
+func (e *InsufficientFundsError) Error() string {
+	return fmt.Sprintf("account %d has a balance of %d, cannot debit %d", e.AccountID, e.Balance, e.Amount)
+}
+
+type CashTxParams struct {
+	TellerID  uuid.UUID `json:"teller_id"`
+	AccountID int64     `json:"account_id"`
+	Amount    int64     `json:"amount"`
+}
+
+type CashTxResult struct {
+	Receipt CashTransaction `json:"receipt"`
+	Account Account         `json:"account"`
+	Till    TellerTill      `json:"till"`
+}
+
+// DepositCashTx credits an account with cash handed to a teller
+func (s *SQLStore) DepositCashTx(ctx context.Context, arg CashTxParams) (CashTxResult, error) {
+	return s.cashTx(ctx, CashDeposit, arg)
+}
+
+// WithdrawCashTx debits an account for cash paid out by a teller
+func (s *SQLStore) WithdrawCashTx(ctx context.Context, arg CashTxParams) (CashTxResult, error) {
+	return s.cashTx(ctx, CashWithdrawal, arg)
+}
+
+// cashTx moves money between the account and the vault of its currency and
+// records the receipt on the teller's open till for today.
+func (s *SQLStore) cashTx(ctx context.Context, kind string, arg CashTxParams) (CashTxResult, error) {
+	var result CashTxResult
+
+	err := s.execTx(ctx, func(q Querier) error {
+		account, err := q.GetAccountForUpdate(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+		if err = checkAccountActive(account); err != nil {
+			return err
+		}
+
+		till, err := q.GetOpenTellerTillForUpdate(ctx, GetOpenTellerTillForUpdateParams{
+			TellerID:     arg.TellerID,
+			Currency:     account.Currency,
+			BusinessDate: startOfDay(time.Now()),
+		})
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return ErrTillNotOpen
+			}
+			return err
+		}
+
+		amount := arg.Amount
+		if kind == CashWithdrawal {
+			if account.Balance < arg.Amount {
+				return &InsufficientFundsError{
+					AccountID: account.ID,
+					Balance:   account.Balance,
+					Amount:    arg.Amount,
+				}
+			}
+			if till.ExpectedCash < arg.Amount {
+				return ErrInsufficientTillCash
+			}
+			amount = -arg.Amount
+		}
+
+		vault, err := q.GetHouseAccount(ctx, GetHouseAccountParams{
+			AccountType: AccountTypeHouseCash,
+			Currency:    account.Currency,
+		})
+		if err != nil {
+			return fmt.Errorf("cannot find vault account for %s: %w", account.Currency, err)
+		}
+
+		vaultEntry, err := q.CreateEntry(ctx, CreateEntryParams{
+			AccountID: vault.ID,
+			Amount:    -amount,
+		})
+		if err != nil {
+			return err
+		}
+
+		entry, err := q.CreateEntry(ctx, CreateEntryParams{
+			AccountID: account.ID,
+			Amount:    amount,
+		})
+		if err != nil {
+			return err
+		}
+
+		if _, err = q.AddBalanceAccount(ctx, AddBalanceAccountParams{
+			ID:     vault.ID,
+			Amount: -amount,
+		}); err != nil {
+			return err
+		}
+
+		result.Account, err = q.AddBalanceAccount(ctx, AddBalanceAccountParams{
+			ID:     account.ID,
+			Amount: amount,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Till, err = q.AddTellerTillCash(ctx, AddTellerTillCashParams{
+			ID:     till.ID,
+			Amount: amount,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Receipt, err = q.CreateCashTransaction(ctx, CreateCashTransactionParams{
+			TillID:       till.ID,
+			AccountID:    account.ID,
+			Kind:         kind,
+			Amount:       arg.Amount,
+			BalanceAfter: result.Account.Balance,
+			EntryID:      entry.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		reference := fmt.Sprintf("cash:%d", result.Receipt.ID)
+		_, err = bookEntries(ctx, q, reference, vaultEntry, entry)
+		return err
+	})
+
+	return result, err
+}
+
+// CloseTellerTillTx closes a till with the cash the teller counted. The
+// difference to the expected cash is recorded on the till for review.
+func (s *SQLStore) CloseTellerTillTx(ctx context.Context, id int64, countedCash int64) (TellerTill, error) {
+	var till TellerTill
+
+	err := s.execTx(ctx, func(q Querier) error {
+		current, err := q.GetTellerTillForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if current.Status != TillStatusOpen {
+			return ErrTillNotOpen
+		}
+
+		till, err = q.CloseTellerTill(ctx, CloseTellerTillParams{
+			ID:          id,
+			CountedCash: countedCash,
+		})
+		return err
+	})
+
+	return till, err
+}
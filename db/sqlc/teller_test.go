@@ -0,0 +1,72 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCashTx(t *testing.T) {
+	store := NewStore(testDB)
+	account := createDummyAccount(t)
+	teller := createDummyUser(t)
+
+	cashTx := CashTxParams{
+		TellerID:  teller.ID,
+		AccountID: account.ID,
+		Amount:    500,
+	}
+
+	// no till open yet
+	_, err := store.DepositCashTx(context.Background(), cashTx)
+	require.ErrorIs(t, err, ErrTillNotOpen)
+
+	till, err := testQueries.CreateTellerTill(context.Background(), CreateTellerTillParams{
+		TellerID:     teller.ID,
+		Currency:     account.Currency,
+		BusinessDate: startOfDay(time.Now()),
+		OpeningCash:  1000,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1000), till.ExpectedCash)
+
+	deposit, err := store.DepositCashTx(context.Background(), cashTx)
+	require.NoError(t, err)
+	require.Equal(t, account.Balance+500, deposit.Account.Balance)
+	require.Equal(t, deposit.Account.Balance, deposit.Receipt.BalanceAfter)
+	require.Equal(t, CashDeposit, deposit.Receipt.Kind)
+	require.Equal(t, int64(1500), deposit.Till.ExpectedCash)
+
+	journals, err := testQueries.ListJournalEntriesByReference(context.Background(), fmt.Sprintf("cash:%d", deposit.Receipt.ID))
+	require.NoError(t, err)
+	require.Len(t, journals, 1)
+
+	// more than the account holds
+	cashTx.Amount = deposit.Account.Balance + 1
+	_, err = store.WithdrawCashTx(context.Background(), cashTx)
+	var fundsErr *InsufficientFundsError
+	require.ErrorAs(t, err, &fundsErr)
+
+	cashTx.Amount = 200
+	withdrawal, err := store.WithdrawCashTx(context.Background(), cashTx)
+	require.NoError(t, err)
+	require.Equal(t, deposit.Account.Balance-200, withdrawal.Account.Balance)
+	require.Equal(t, int64(1300), withdrawal.Till.ExpectedCash)
+
+	receipts, err := testQueries.ListCashTransactionsByTill(context.Background(), till.ID)
+	require.NoError(t, err)
+	require.Len(t, receipts, 2)
+
+	// the teller counts 10 short
+	closed, err := store.CloseTellerTillTx(context.Background(), till.ID, 1290)
+	require.NoError(t, err)
+	require.Equal(t, TillStatusClosed, closed.Status)
+	require.Equal(t, int64(-10), closed.Difference)
+	require.True(t, closed.ClosedAt.Valid)
+
+	_, err = store.DepositCashTx(context.Background(), cashTx)
+	require.ErrorIs(t, err, ErrTillNotOpen)
+}
@@ -0,0 +1,319 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/flukis/simplebank/util"
+)
+
+const (
+	AccountTypeHouseTermDeposits = "house_term_deposits"
+
+	OnMaturityPayout   = "payout"
+	OnMaturityRollover = "rollover"
+
+	TermDepositActive     = "active"
+	TermDepositMatured    = "matured"
+	TermDepositRolledOver = "rolled_over"
+	TermDepositWithdrawn  = "withdrawn"
+)
+
+var (
+	ErrTermNotOffered    = errors.New("no term deposit is offered for this currency and term")
+	ErrTermDepositClosed = errors.New("term deposit is no longer active")
+)
+
+// termDepositInterest returns the simple interest earned by the deposit at
+// rateBps from its start date to end, rounded half up to whole units.
+func termDepositInterest(deposit TermDeposit, rateBps int64, end time.Time) (int64, error) {
+	if rateBps <= 0 {
+		return 0, nil
+	}
+
+	days, basis, err := util.DayCount(deposit.DayCount, deposit.StartDate, end)
+	if err != nil {
+		return 0, err
+	}
+
+	micros := accrueMicros(deposit.Principal, rateBps, days, basis)
+	return (micros + microsPerUnit/2) / microsPerUnit, nil
+}
+
+type OpenTermDepositTxParams struct {
+	AccountID       int64  `json:"account_id"`
+	PayoutAccountID int64  `json:"payout_account_id"`
+	Amount          int64  `json:"amount"`
+	TermMonths      int32  `json:"term_months"`
+	OnMaturity      string `json:"on_maturity"`
+}
+
+type OpenTermDepositTxResult struct {
+	Deposit  TermDeposit      `json:"deposit"`
+	Transfer TransferTxResult `json:"transfer"`
+}
+
+// OpenTermDepositTx locks funds from an account in a term deposit at the
+// rate currently offered for its currency and term.
+func (s *SQLStore) OpenTermDepositTx(ctx context.Context, arg OpenTermDepositTxParams) (OpenTermDepositTxResult, error) {
+	var result OpenTermDepositTxResult
+
+	err := s.execTx(ctx, func(q Querier) error {
+		from, err := lockSourceAccount(ctx, q, arg.AccountID)
+		if err != nil {
+			return err
+		}
+		if err = checkAccountActive(from); err != nil {
+			return err
+		}
+		if from.Balance < arg.Amount {
+			return &InsufficientFundsError{
+				AccountID: from.ID,
+				Balance:   from.Balance,
+				Amount:    arg.Amount,
+			}
+		}
+
+		rate, err := q.GetTermDepositRate(ctx, GetTermDepositRateParams{
+			Currency:   from.Currency,
+			TermMonths: arg.TermMonths,
+		})
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return ErrTermNotOffered
+			}
+			return err
+		}
+
+		house, err := q.GetHouseAccount(ctx, GetHouseAccountParams{
+			AccountType: AccountTypeHouseTermDeposits,
+			Currency:    from.Currency,
+		})
+		if err != nil {
+			return fmt.Errorf("cannot find house term deposits account for %s: %w", from.Currency, err)
+		}
+
+		result.Transfer, err = transfer(ctx, q, TransferTxParams{
+			FromAccountID: from.ID,
+			ToAccountID:   house.ID,
+			Amount:        arg.Amount,
+		}, true)
+		if err != nil {
+			return err
+		}
+
+		start := startOfDay(time.Now())
+		result.Deposit, err = q.CreateTermDeposit(ctx, CreateTermDepositParams{
+			AccountID:       from.ID,
+			PayoutAccountID: arg.PayoutAccountID,
+			Currency:        from.Currency,
+			Principal:       arg.Amount,
+			RateBps:         rate.RateBps,
+			DayCount:        rate.DayCount,
+			PenaltyBps:      rate.PenaltyBps,
+			TermMonths:      rate.TermMonths,
+			StartDate:       start,
+			MaturityDate:    util.AddMonths(start, int(rate.TermMonths)),
+			OnMaturity:      arg.OnMaturity,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// TermDepositClosure is what happened to a deposit when it matured or was
+// withdrawn: the interest paid and either the payout of principal and
+// interest or the deposit it was renewed into.
+type TermDepositClosure struct {
+	Deposit  TermDeposit       `json:"deposit"`
+	Interest int64             `json:"interest"`
+	Payout   *TransferTxResult `json:"payout,omitempty"`
+	Renewal  *TermDeposit      `json:"renewal,omitempty"`
+}
+
+// MatureTermDeposits settles every active deposit maturing on or before
+// date, each in its own transaction. Deposits whose payout account is not
+// active are left as they are and tried again on the next run.
+func (s *SQLStore) MatureTermDeposits(ctx context.Context, date time.Time) ([]TermDepositClosure, error) {
+	deposits, err := s.ListMaturingTermDeposits(ctx, startOfDay(date))
+	if err != nil {
+		return nil, err
+	}
+
+	closures := []TermDepositClosure{}
+	for _, deposit := range deposits {
+		var closure *TermDepositClosure
+
+		err := s.execTx(ctx, func(q Querier) error {
+			closure = nil
+
+			deposit, err := q.GetTermDepositForUpdate(ctx, deposit.ID)
+			if err != nil {
+				return err
+			}
+			if deposit.Status != TermDepositActive {
+				return nil
+			}
+
+			interest, err := termDepositInterest(deposit, deposit.RateBps, deposit.MaturityDate)
+			if err != nil {
+				return err
+			}
+
+			status := TermDepositMatured
+			if deposit.OnMaturity == OnMaturityRollover {
+				status = TermDepositRolledOver
+			}
+
+			closure, err = settleTermDeposit(ctx, q, deposit, status, interest)
+			return err
+		})
+		if err != nil {
+			var statusErr *AccountStatusError
+			if errors.As(err, &statusErr) {
+				continue
+			}
+			return closures, err
+		}
+
+		if closure != nil {
+			closures = append(closures, *closure)
+		}
+	}
+
+	return closures, nil
+}
+
+// WithdrawTermDepositTx pays out a deposit before it matures. Interest is
+// paid for the days held at the deposit's rate less its penalty, and never
+// below zero, so the principal is always returned in full. A deposit that
+// has already reached maturity is paid out in full.
+func (s *SQLStore) WithdrawTermDepositTx(ctx context.Context, id int64) (TermDepositClosure, error) {
+	var closure *TermDepositClosure
+
+	err := s.execTx(ctx, func(q Querier) error {
+		deposit, err := q.GetTermDepositForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if deposit.Status != TermDepositActive {
+			return ErrTermDepositClosed
+		}
+
+		end, rate, status := deposit.MaturityDate, deposit.RateBps, TermDepositMatured
+		if today := startOfDay(time.Now()); today.Before(deposit.MaturityDate) {
+			end, rate, status = today, deposit.RateBps-deposit.PenaltyBps, TermDepositWithdrawn
+		}
+
+		interest, err := termDepositInterest(deposit, rate, end)
+		if err != nil {
+			return err
+		}
+
+		closure, err = settleTermDeposit(ctx, q, deposit, status, interest)
+		return err
+	})
+	if err != nil {
+		return TermDepositClosure{}, err
+	}
+
+	return *closure, nil
+}
+
+// settleTermDeposit pays the interest and either pays out the deposit or, when
+// status is rolled_over, renews principal and interest for the same term at
+// the rate offered today.
+func settleTermDeposit(ctx context.Context, q Querier, deposit TermDeposit, status string, interest int64) (*TermDepositClosure, error) {
+	closure := &TermDepositClosure{
+		Interest: interest,
+	}
+
+	house, err := q.GetHouseAccount(ctx, GetHouseAccountParams{
+		AccountType: AccountTypeHouseTermDeposits,
+		Currency:    deposit.Currency,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot find house term deposits account for %s: %w", deposit.Currency, err)
+	}
+
+	// interest is credited where the money goes next
+	creditTo := house
+	if status != TermDepositRolledOver {
+		creditTo, err = q.GetAccount(ctx, deposit.PayoutAccountID)
+		if err != nil {
+			return nil, err
+		}
+		if err = checkAccountActive(creditTo); err != nil {
+			return nil, err
+		}
+	}
+
+	if interest > 0 {
+		houseEntry, entry, err := postInterest(ctx, q, creditTo, interest)
+		if err != nil {
+			return nil, err
+		}
+
+		reference := fmt.Sprintf("term-deposit-interest:%d", deposit.ID)
+		if _, err = bookEntries(ctx, q, reference, houseEntry, entry); err != nil {
+			return nil, err
+		}
+	}
+
+	if status == TermDepositRolledOver {
+		rateBps, dayCount, penaltyBps := deposit.RateBps, deposit.DayCount, deposit.PenaltyBps
+		rate, err := q.GetTermDepositRate(ctx, GetTermDepositRateParams{
+			Currency:   deposit.Currency,
+			TermMonths: deposit.TermMonths,
+		})
+		if err == nil {
+			rateBps, dayCount, penaltyBps = rate.RateBps, rate.DayCount, rate.PenaltyBps
+		} else if err != sql.ErrNoRows {
+			return nil, err
+		}
+
+		renewal, err := q.CreateTermDeposit(ctx, CreateTermDepositParams{
+			AccountID:       deposit.AccountID,
+			PayoutAccountID: deposit.PayoutAccountID,
+			Currency:        deposit.Currency,
+			Principal:       deposit.Principal + interest,
+			RateBps:         rateBps,
+			DayCount:        dayCount,
+			PenaltyBps:      penaltyBps,
+			TermMonths:      deposit.TermMonths,
+			StartDate:       deposit.MaturityDate,
+			MaturityDate:    util.AddMonths(deposit.MaturityDate, int(deposit.TermMonths)),
+			OnMaturity:      deposit.OnMaturity,
+			RenewedFromID:   sql.NullInt64{Int64: deposit.ID, Valid: true},
+		})
+		if err != nil {
+			return nil, err
+		}
+		closure.Renewal = &renewal
+	} else {
+		payout, err := transfer(ctx, q, TransferTxParams{
+			FromAccountID: house.ID,
+			ToAccountID:   deposit.PayoutAccountID,
+			Amount:        deposit.Principal,
+		}, true)
+		if err != nil {
+			return nil, err
+		}
+		closure.Payout = &payout
+	}
+
+	closure.Deposit, err = q.CloseTermDeposit(ctx, CloseTermDepositParams{
+		ID:           deposit.ID,
+		Status:       status,
+		InterestPaid: interest,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return closure, nil
+}
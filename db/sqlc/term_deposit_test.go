@@ -0,0 +1,130 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/flukis/simplebank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTermDepositInterest(t *testing.T) {
+	deposit := TermDeposit{
+		Principal: 1000000,
+		DayCount:  util.DayCountACT365,
+		StartDate: time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC),
+	}
+	maturity := util.AddMonths(deposit.StartDate, 12)
+
+	interest, err := termDepositInterest(deposit, 450, maturity)
+	require.NoError(t, err)
+	require.Equal(t, int64(45000), interest)
+
+	// 4.5% less a 2.5% penalty for the 182 days held
+	interest, err = termDepositInterest(deposit, 450-250, time.Date(2023, time.July, 2, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Equal(t, int64(9973), interest)
+
+	interest, err = termDepositInterest(deposit, -50, maturity)
+	require.NoError(t, err)
+	require.Zero(t, interest)
+}
+
+func TestTermDepositTx(t *testing.T) {
+	store := NewStore(testDB)
+	account := createDummyAccount(t)
+	amount := account.Balance / 2
+
+	// more than the account holds
+	_, err := store.OpenTermDepositTx(context.Background(), OpenTermDepositTxParams{
+		AccountID:       account.ID,
+		PayoutAccountID: account.ID,
+		Amount:          account.Balance + 1,
+		TermMonths:      3,
+		OnMaturity:      OnMaturityPayout,
+	})
+	var fundsErr *InsufficientFundsError
+	require.ErrorAs(t, err, &fundsErr)
+
+	_, err = store.OpenTermDepositTx(context.Background(), OpenTermDepositTxParams{
+		AccountID:       account.ID,
+		PayoutAccountID: account.ID,
+		Amount:          1,
+		TermMonths:      7,
+		OnMaturity:      OnMaturityPayout,
+	})
+	require.ErrorIs(t, err, ErrTermNotOffered)
+
+	opened, err := store.OpenTermDepositTx(context.Background(), OpenTermDepositTxParams{
+		AccountID:       account.ID,
+		PayoutAccountID: account.ID,
+		Amount:          amount,
+		TermMonths:      3,
+		OnMaturity:      OnMaturityPayout,
+	})
+	require.NoError(t, err)
+	require.Equal(t, account.Balance-amount, opened.Transfer.FromAccount.Balance)
+	require.Equal(t, TermDepositActive, opened.Deposit.Status)
+	require.Equal(t, util.AddMonths(opened.Deposit.StartDate, 3), opened.Deposit.MaturityDate)
+
+	// withdrawn on the day it was opened: no interest, principal back in full
+	closure, err := store.WithdrawTermDepositTx(context.Background(), opened.Deposit.ID)
+	require.NoError(t, err)
+	require.Equal(t, TermDepositWithdrawn, closure.Deposit.Status)
+	require.Zero(t, closure.Interest)
+	require.NotNil(t, closure.Payout)
+	require.Equal(t, account.Balance, closure.Payout.ToAccount.Balance)
+
+	_, err = store.WithdrawTermDepositTx(context.Background(), opened.Deposit.ID)
+	require.ErrorIs(t, err, ErrTermDepositClosed)
+}
+
+func TestMatureTermDeposits(t *testing.T) {
+	store := NewStore(testDB)
+	account := createDummyAccount(t)
+
+	payout, err := store.OpenTermDepositTx(context.Background(), OpenTermDepositTxParams{
+		AccountID:       account.ID,
+		PayoutAccountID: account.ID,
+		Amount:          account.Balance / 2,
+		TermMonths:      3,
+		OnMaturity:      OnMaturityPayout,
+	})
+	require.NoError(t, err)
+
+	rollover, err := store.OpenTermDepositTx(context.Background(), OpenTermDepositTxParams{
+		AccountID:       account.ID,
+		PayoutAccountID: account.ID,
+		Amount:          account.Balance / 4,
+		TermMonths:      3,
+		OnMaturity:      OnMaturityRollover,
+	})
+	require.NoError(t, err)
+
+	_, err = store.MatureTermDeposits(context.Background(), payout.Deposit.MaturityDate)
+	require.NoError(t, err)
+
+	matured, err := testQueries.GetTermDeposit(context.Background(), payout.Deposit.ID)
+	require.NoError(t, err)
+	require.Equal(t, TermDepositMatured, matured.Status)
+	require.True(t, matured.ClosedAt.Valid)
+
+	rolled, err := testQueries.GetTermDeposit(context.Background(), rollover.Deposit.ID)
+	require.NoError(t, err)
+	require.Equal(t, TermDepositRolledOver, rolled.Status)
+
+	// the payout deposit and its interest are back on the account, the
+	// rolled over one is still locked
+	got, err := testQueries.GetAccount(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Equal(t, account.Balance-rollover.Deposit.Principal+matured.InterestPaid, got.Balance)
+
+	// running again does not settle anything twice
+	closures, err := store.MatureTermDeposits(context.Background(), payout.Deposit.MaturityDate)
+	require.NoError(t, err)
+	for _, closure := range closures {
+		require.NotEqual(t, payout.Deposit.ID, closure.Deposit.ID)
+		require.NotEqual(t, rollover.Deposit.ID, closure.Deposit.ID)
+	}
+}
@@ -0,0 +1,186 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+const (
+	LimitPerTransfer   = "per_transfer"
+	LimitDailyAmount   = "daily_amount"
+	LimitMonthlyAmount = "monthly_amount"
+	LimitDailyCount    = "daily_count"
+
+	LimitScopeAccount = "account"
+	LimitScopeUser    = "user"
+)
+
+// TransferLimitError is returned by TransferTx when a transfer would exceed
+// one of the velocity limits configured for the account tier.
+type TransferLimitError struct {
+	Limit    string     `json:"limit"`
+	Scope    string     `json:"scope"`
+	Max      int64      `json:"max"`
+	Used     int64      `json:"used"`
+	ResetsAt *time.Time `json:"resets_at,omitempty"`
+}
+
+func (e *TransferLimitError) Error() string {
+	msg := fmt.Sprintf("%s %s limit of %d exceeded", e.Scope, e.Limit, e.Max)
+	if e.ResetsAt != nil {
+		msg = fmt.Sprintf("%s, resets at %s", msg, e.ResetsAt.Format(time.RFC3339))
+	}
+	return msg
+}
+
+type transferUsage struct {
+	dailyCount   int64
+	dailyTotal   int64
+	monthlyTotal int64
+}
+
+// startOfDay and startOfMonth return the UTC window boundaries used for limits
+func startOfDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func startOfMonth(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// check compares the usage of one scope, including the amount about to be
+// transferred, against the configured limits. A limit of 0 is unlimited.
+func (l TransferLimit) check(scope string, usage transferUsage, amount int64, now time.Time) error {
+	dailyMax, monthlyMax, countMax := l.AccountDailyAmount, l.AccountMonthlyAmount, l.AccountDailyCount
+	if scope == LimitScopeUser {
+		dailyMax, monthlyMax, countMax = l.UserDailyAmount, l.UserMonthlyAmount, l.UserDailyCount
+	}
+
+	dayReset := startOfDay(now).AddDate(0, 0, 1)
+	monthReset := startOfMonth(now).AddDate(0, 1, 0)
+
+	if scope == LimitScopeAccount && l.MaxPerTransfer > 0 && amount > l.MaxPerTransfer {
+		return &TransferLimitError{
+			Limit: LimitPerTransfer,
+			Scope: scope,
+			Max:   l.MaxPerTransfer,
+			Used:  amount,
+		}
+	}
+
+	if countMax > 0 && usage.dailyCount+1 > countMax {
+		return &TransferLimitError{
+			Limit:    LimitDailyCount,
+			Scope:    scope,
+			Max:      countMax,
+			Used:     usage.dailyCount,
+			ResetsAt: &dayReset,
+		}
+	}
+
+	if dailyMax > 0 && usage.dailyTotal+amount > dailyMax {
+		return &TransferLimitError{
+			Limit:    LimitDailyAmount,
+			Scope:    scope,
+			Max:      dailyMax,
+			Used:     usage.dailyTotal,
+			ResetsAt: &dayReset,
+		}
+	}
+
+	if monthlyMax > 0 && usage.monthlyTotal+amount > monthlyMax {
+		return &TransferLimitError{
+			Limit:    LimitMonthlyAmount,
+			Scope:    scope,
+			Max:      monthlyMax,
+			Used:     usage.monthlyTotal,
+			ResetsAt: &monthReset,
+		}
+	}
+
+	return nil
+}
+
+// lockSourceAccount locks the owner and then the source account so that
+// concurrent transfers from the same account or user are serialized and
+// cannot bypass the limits checked inside the transaction.
+func lockSourceAccount(ctx context.Context, q Querier, id int64) (Account, error) {
+	from, err := q.GetAccount(ctx, id)
+	if err != nil {
+		return Account{}, err
+	}
+
+	if _, err = q.GetUserForUpdate(ctx, from.OwnerID); err != nil {
+		return Account{}, err
+	}
+
+	return q.GetAccountForUpdate(ctx, id)
+}
+
+// checkTransferLimits must run inside the transfer transaction, after the
+// source account has been locked with lockSourceAccount.
+func checkTransferLimits(ctx context.Context, q Querier, from Account, amount int64, now time.Time) error {
+	limit, err := q.GetTransferLimit(ctx, GetTransferLimitParams{
+		Tier:     from.Tier,
+		Currency: from.Currency,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		return err
+	}
+
+	day, month := startOfDay(now), startOfMonth(now)
+
+	// account scope
+	accountDaily, err := q.GetAccountTransferUsage(ctx, GetAccountTransferUsageParams{
+		AccountID: from.ID,
+		Since:     day,
+	})
+	if err != nil {
+		return err
+	}
+	accountMonthly, err := q.GetAccountTransferUsage(ctx, GetAccountTransferUsageParams{
+		AccountID: from.ID,
+		Since:     month,
+	})
+	if err != nil {
+		return err
+	}
+	err = limit.check(LimitScopeAccount, transferUsage{
+		dailyCount:   accountDaily.Count,
+		dailyTotal:   accountDaily.Total,
+		monthlyTotal: accountMonthly.Total,
+	}, amount, now)
+	if err != nil {
+		return err
+	}
+
+	// user scope, across every account of the owner in this currency
+	userDaily, err := q.GetOwnerTransferUsage(ctx, GetOwnerTransferUsageParams{
+		OwnerID:  from.OwnerID,
+		Currency: from.Currency,
+		Since:    day,
+	})
+	if err != nil {
+		return err
+	}
+	userMonthly, err := q.GetOwnerTransferUsage(ctx, GetOwnerTransferUsageParams{
+		OwnerID:  from.OwnerID,
+		Currency: from.Currency,
+		Since:    month,
+	})
+	if err != nil {
+		return err
+	}
+	return limit.check(LimitScopeUser, transferUsage{
+		dailyCount:   userDaily.Count,
+		dailyTotal:   userDaily.Total,
+		monthlyTotal: userMonthly.Total,
+	}, amount, now)
+}
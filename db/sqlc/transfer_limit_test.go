@@ -0,0 +1,130 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransferLimitCheck(t *testing.T) {
+	now := time.Date(2023, time.March, 15, 10, 30, 0, 0, time.UTC)
+	limit := TransferLimit{
+		MaxPerTransfer:       1000,
+		AccountDailyAmount:   2000,
+		AccountMonthlyAmount: 5000,
+		AccountDailyCount:    3,
+		UserDailyAmount:      3000,
+		UserMonthlyAmount:    0,
+		UserDailyCount:       0,
+	}
+
+	testCases := []struct {
+		name     string
+		scope    string
+		usage    transferUsage
+		amount   int64
+		limit    string
+		resetsAt time.Time
+	}{
+		{
+			name:   "WithinLimits",
+			scope:  LimitScopeAccount,
+			usage:  transferUsage{dailyCount: 1, dailyTotal: 500, monthlyTotal: 1000},
+			amount: 500,
+		},
+		{
+			name:   "PerTransfer",
+			scope:  LimitScopeAccount,
+			amount: 1001,
+			limit:  LimitPerTransfer,
+		},
+		{
+			name:     "DailyCount",
+			scope:    LimitScopeAccount,
+			usage:    transferUsage{dailyCount: 3, dailyTotal: 300, monthlyTotal: 300},
+			amount:   100,
+			limit:    LimitDailyCount,
+			resetsAt: time.Date(2023, time.March, 16, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "DailyAmount",
+			scope:    LimitScopeAccount,
+			usage:    transferUsage{dailyCount: 2, dailyTotal: 1500, monthlyTotal: 1500},
+			amount:   600,
+			limit:    LimitDailyAmount,
+			resetsAt: time.Date(2023, time.March, 16, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "MonthlyAmount",
+			scope:    LimitScopeAccount,
+			usage:    transferUsage{monthlyTotal: 4800},
+			amount:   300,
+			limit:    LimitMonthlyAmount,
+			resetsAt: time.Date(2023, time.April, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:   "UserUnlimitedCountAndMonth",
+			scope:  LimitScopeUser,
+			usage:  transferUsage{dailyCount: 100, dailyTotal: 1000, monthlyTotal: 100000},
+			amount: 2000,
+		},
+		{
+			name:     "UserDailyAmount",
+			scope:    LimitScopeUser,
+			usage:    transferUsage{dailyTotal: 2500},
+			amount:   600,
+			limit:    LimitDailyAmount,
+			resetsAt: time.Date(2023, time.March, 16, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := limit.check(tc.scope, tc.usage, tc.amount, now)
+			if tc.limit == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			limitErr, ok := err.(*TransferLimitError)
+			require.True(t, ok)
+			require.Equal(t, tc.limit, limitErr.Limit)
+			require.Equal(t, tc.scope, limitErr.Scope)
+			if tc.resetsAt.IsZero() {
+				require.Nil(t, limitErr.ResetsAt)
+			} else {
+				require.Equal(t, tc.resetsAt, *limitErr.ResetsAt)
+			}
+		})
+	}
+}
+
+func TestTransferTxPerTransferLimit(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createDummyAccount(t)
+	account2 := createDummyAccount(t)
+
+	limit, err := testQueries.GetTransferLimit(context.Background(), GetTransferLimitParams{
+		Tier:     account1.Tier,
+		Currency: account1.Currency,
+	})
+	require.NoError(t, err)
+
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        limit.MaxPerTransfer + 1,
+	})
+	require.Error(t, err)
+
+	limitErr, ok := err.(*TransferLimitError)
+	require.True(t, ok)
+	require.Equal(t, LimitPerTransfer, limitErr.Limit)
+
+	updatedAccount1, err := testQueries.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance, updatedAccount1.Balance)
+}
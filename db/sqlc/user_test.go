@@ -51,3 +51,16 @@ func TestGetUser(t *testing.T) {
 	require.WithinDuration(t, user1.CreatedAt, user2.CreatedAt, time.Second)
 	require.WithinDuration(t, user1.PasswordChangedAt, user2.PasswordChangedAt, time.Second)
 }
+
+func TestUpdateUserRole(t *testing.T) {
+	user1 := createDummyUser(t)
+	require.Equal(t, util.RoleCustomer, user1.Role)
+
+	user2, err := testQueries.UpdateUserRole(context.Background(), UpdateUserRoleParams{
+		ID:   user1.ID,
+		Role: util.RoleTeller,
+	})
+	require.NoError(t, err)
+	require.Equal(t, user1.ID, user2.ID)
+	require.Equal(t, util.RoleTeller, user2.Role)
+}
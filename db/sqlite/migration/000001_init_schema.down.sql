@@ -0,0 +1,26 @@
+DROP TABLE IF EXISTS "idempotency_keys";
+DROP TABLE IF EXISTS "payment_requests";
+DROP TABLE IF EXISTS "beneficiaries";
+DROP TABLE IF EXISTS "transfer_approvals";
+DROP TABLE IF EXISTS "account_holders";
+DROP TABLE IF EXISTS "pots";
+DROP TABLE IF EXISTS "term_deposits";
+DROP TABLE IF EXISTS "term_deposit_rates";
+DROP TABLE IF EXISTS "loan_installments";
+DROP TABLE IF EXISTS "loans";
+DROP TABLE IF EXISTS "cash_transactions";
+DROP TABLE IF EXISTS "teller_tills";
+DROP TABLE IF EXISTS "journal_lines";
+DROP TABLE IF EXISTS "journal_entries";
+DROP TABLE IF EXISTS "gl_account_mappings";
+DROP TABLE IF EXISTS "gl_accounts";
+DROP TABLE IF EXISTS "interest_accruals";
+DROP TABLE IF EXISTS "interest_capitalizations";
+DROP TABLE IF EXISTS "interest_rates";
+DROP TABLE IF EXISTS "fee_schedule_tiers";
+DROP TABLE IF EXISTS "fee_schedules";
+DROP TABLE IF EXISTS "transfer_limits";
+DROP TABLE IF EXISTS "transfers";
+DROP TABLE IF EXISTS "entries";
+DROP TABLE IF EXISTS "accounts";
+DROP TABLE IF EXISTS "users";
@@ -0,0 +1,672 @@
+-- The schema of db/migration as of add_idempotency_keys, for SQLite.
+--
+-- Ids are AUTOINCREMENT so that, like Postgres sequences, they are never
+-- handed out twice. Timestamps are stored as UTC text with milliseconds,
+-- written by strftime in the queries, so that comparing them as text orders
+-- them in time. Dates are stored as YYYY-MM-DD. Constraints keep the names
+-- Postgres gives them, which db.NewSQLiteStore reports in its errors.
+
+CREATE TABLE "users" (
+  "id" TEXT PRIMARY KEY DEFAULT (lower(hex(randomblob(4)) || '-' || hex(randomblob(2)) || '-4' || substr(hex(randomblob(2)), 2) || '-' || substr('89ab', 1 + (abs(random() % 4)), 1) || substr(hex(randomblob(2)), 2) || '-' || hex(randomblob(6)))),
+  "username" TEXT NOT NULL,
+  "hashed_password" TEXT NOT NULL,
+  "full_name" TEXT NOT NULL,
+  "email" TEXT NOT NULL,
+  "password_changed_at" TIMESTAMP NOT NULL DEFAULT ('0001-01-01 00:00:00.000+00:00'),
+  "created_at" TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+  "role" TEXT NOT NULL DEFAULT 'customer',
+  CONSTRAINT "users_email_key" UNIQUE ("email"),
+  CONSTRAINT "users_role_check" CHECK ("role" IN ('customer', 'teller', 'admin', 'auditor'))
+);
+
+CREATE TABLE "accounts" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "owner_id" TEXT NOT NULL REFERENCES "users" ("id"),
+  "balance" INTEGER NOT NULL DEFAULT 0,
+  "currency" TEXT NOT NULL,
+  "created_at" TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+  "tier" TEXT NOT NULL DEFAULT 'standard',
+  "account_type" TEXT NOT NULL DEFAULT 'current',
+  "status" TEXT NOT NULL DEFAULT 'active',
+  -- set when the account is closed, closed accounts are never deleted
+  "closed_at" TIMESTAMP,
+  -- external account number with mod-97 check digits, drawn by CreateAccount
+  "number" TEXT NOT NULL,
+  CONSTRAINT "accounts_number_key" UNIQUE ("number"),
+  CONSTRAINT "accounts_status_check" CHECK ("status" IN ('active', 'frozen', 'dormant', 'closed'))
+);
+
+CREATE INDEX "accounts_owner_id_idx" ON "accounts" ("owner_id");
+
+-- closed accounts are kept for history and must not block opening a new
+-- one, and an owner may have any number of pots in a currency
+CREATE UNIQUE INDEX "owner_id_currency_type_key" ON "accounts" ("owner_id", "currency", "account_type") WHERE "status" <> 'closed' AND "account_type" <> 'pot';
+
+CREATE TABLE "entries" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "account_id" INTEGER NOT NULL REFERENCES "accounts" ("id"),
+  -- can be positive or negative
+  "amount" INTEGER NOT NULL,
+  "created_at" TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now'))
+);
+
+CREATE INDEX "entries_account_id_idx" ON "entries" ("account_id");
+
+CREATE TABLE "transfers" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "from_account_id" INTEGER NOT NULL REFERENCES "accounts" ("id"),
+  "to_account_id" INTEGER NOT NULL REFERENCES "accounts" ("id"),
+  -- must be positive
+  "amount" INTEGER NOT NULL,
+  "created_at" TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+  -- made by the bank itself and not counted towards transfer limits
+  "internal" BOOLEAN NOT NULL DEFAULT false
+);
+
+CREATE INDEX "transfers_to_account_id_idx" ON "transfers" ("to_account_id");
+
+CREATE INDEX "transfers_from_account_id_created_at_idx" ON "transfers" ("from_account_id", "created_at");
+
+-- a limit of 0 means unlimited
+CREATE TABLE "transfer_limits" (
+  "tier" TEXT NOT NULL,
+  "currency" TEXT NOT NULL,
+  "max_per_transfer" INTEGER NOT NULL DEFAULT 0,
+  "account_daily_amount" INTEGER NOT NULL DEFAULT 0,
+  "account_monthly_amount" INTEGER NOT NULL DEFAULT 0,
+  "account_daily_count" INTEGER NOT NULL DEFAULT 0,
+  "user_daily_amount" INTEGER NOT NULL DEFAULT 0,
+  "user_monthly_amount" INTEGER NOT NULL DEFAULT 0,
+  "user_daily_count" INTEGER NOT NULL DEFAULT 0,
+  "created_at" TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+  "new_payee_max_per_transfer" INTEGER NOT NULL DEFAULT 0,
+  PRIMARY KEY ("tier", "currency")
+);
+
+CREATE TABLE "fee_schedules" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "currency" TEXT NOT NULL,
+  "account_type" TEXT NOT NULL,
+  "kind" TEXT NOT NULL,
+  "flat_amount" INTEGER NOT NULL DEFAULT 0,
+  -- basis points of the transferred amount
+  "rate_bps" INTEGER NOT NULL DEFAULT 0,
+  "min_fee" INTEGER NOT NULL DEFAULT 0,
+  -- 0 means no cap
+  "max_fee" INTEGER NOT NULL DEFAULT 0,
+  "created_at" TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+  CONSTRAINT "fee_schedules_kind_check" CHECK ("kind" IN ('flat', 'percentage', 'tiered')),
+  CONSTRAINT "currency_account_type_key" UNIQUE ("currency", "account_type")
+);
+
+CREATE TABLE "fee_schedule_tiers" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "schedule_id" INTEGER NOT NULL REFERENCES "fee_schedules" ("id") ON DELETE CASCADE,
+  -- inclusive upper bound of the transfer amount, 0 means no bound
+  "up_to" INTEGER NOT NULL DEFAULT 0,
+  "flat_amount" INTEGER NOT NULL DEFAULT 0,
+  "rate_bps" INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE INDEX "fee_schedule_tiers_schedule_id_idx" ON "fee_schedule_tiers" ("schedule_id");
+
+CREATE TABLE "interest_rates" (
+  "account_type" TEXT NOT NULL,
+  "currency" TEXT NOT NULL,
+  -- annual rate in basis points
+  "rate_bps" INTEGER NOT NULL,
+  "day_count" TEXT NOT NULL,
+  "created_at" TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+  PRIMARY KEY ("account_type", "currency"),
+  CONSTRAINT "interest_rates_day_count_check" CHECK ("day_count" IN ('ACT/365', 'ACT/360', '30/360'))
+);
+
+CREATE TABLE "interest_capitalizations" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "account_id" INTEGER NOT NULL REFERENCES "accounts" ("id"),
+  "period_end" DATE NOT NULL,
+  "accrued_micros" INTEGER NOT NULL,
+  "amount" INTEGER NOT NULL,
+  -- accrued interest too small to post, carried to the next period
+  "carry_micros" INTEGER NOT NULL,
+  "entry_id" INTEGER REFERENCES "entries" ("id"),
+  "created_at" TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+  CONSTRAINT "account_id_period_end_key" UNIQUE ("account_id", "period_end")
+);
+
+CREATE TABLE "interest_accruals" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "account_id" INTEGER NOT NULL REFERENCES "accounts" ("id"),
+  "accrual_date" DATE NOT NULL,
+  "balance" INTEGER NOT NULL,
+  "rate_bps" INTEGER NOT NULL,
+  "day_count" TEXT NOT NULL,
+  -- millionths of the smallest currency unit
+  "amount_micros" INTEGER NOT NULL,
+  "capitalization_id" INTEGER REFERENCES "interest_capitalizations" ("id"),
+  "created_at" TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+  CONSTRAINT "account_id_accrual_date_key" UNIQUE ("account_id", "accrual_date")
+);
+
+CREATE INDEX "interest_accruals_capitalization_id_idx" ON "interest_accruals" ("capitalization_id");
+
+CREATE TABLE "gl_accounts" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "code" TEXT NOT NULL,
+  "name" TEXT NOT NULL,
+  "type" TEXT NOT NULL,
+  "created_at" TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+  CONSTRAINT "gl_accounts_code_key" UNIQUE ("code"),
+  CONSTRAINT "gl_accounts_type_check" CHECK ("type" IN ('asset', 'liability', 'equity', 'income', 'expense'))
+);
+
+-- GL account every account of a type is booked to
+CREATE TABLE "gl_account_mappings" (
+  "account_type" TEXT PRIMARY KEY,
+  "gl_account_id" INTEGER NOT NULL REFERENCES "gl_accounts" ("id")
+);
+
+CREATE TABLE "journal_entries" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  -- what caused the posting, e.g. transfer:42
+  "reference" TEXT NOT NULL,
+  "created_at" TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now'))
+);
+
+CREATE INDEX "journal_entries_reference_idx" ON "journal_entries" ("reference");
+
+CREATE TABLE "journal_lines" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "journal_entry_id" INTEGER NOT NULL REFERENCES "journal_entries" ("id"),
+  "gl_account_id" INTEGER NOT NULL REFERENCES "gl_accounts" ("id"),
+  -- customer or house account the line was posted for, if any
+  "account_id" INTEGER REFERENCES "accounts" ("id"),
+  "currency" TEXT NOT NULL,
+  -- debits are positive, credits negative, lines of an entry sum to zero per currency
+  "amount" INTEGER NOT NULL
+);
+
+CREATE INDEX "journal_lines_journal_entry_id_idx" ON "journal_lines" ("journal_entry_id");
+
+CREATE INDEX "journal_lines_gl_account_id_currency_idx" ON "journal_lines" ("gl_account_id", "currency");
+
+CREATE TABLE "teller_tills" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "teller_id" TEXT NOT NULL REFERENCES "users" ("id"),
+  "currency" TEXT NOT NULL,
+  "business_date" DATE NOT NULL,
+  "status" TEXT NOT NULL DEFAULT 'open',
+  "opening_cash" INTEGER NOT NULL,
+  -- opening cash plus deposits minus withdrawals
+  "expected_cash" INTEGER NOT NULL,
+  "counted_cash" INTEGER NOT NULL DEFAULT 0,
+  -- counted minus expected cash when the till is closed, negative when short
+  "difference" INTEGER NOT NULL DEFAULT 0,
+  "opened_at" TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+  "closed_at" TIMESTAMP,
+  CONSTRAINT "teller_tills_status_check" CHECK ("status" IN ('open', 'closed')),
+  CONSTRAINT "teller_id_currency_business_date_key" UNIQUE ("teller_id", "currency", "business_date")
+);
+
+CREATE TABLE "cash_transactions" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "till_id" INTEGER NOT NULL REFERENCES "teller_tills" ("id"),
+  "account_id" INTEGER NOT NULL REFERENCES "accounts" ("id"),
+  "kind" TEXT NOT NULL,
+  "amount" INTEGER NOT NULL,
+  -- account balance printed on the receipt
+  "balance_after" INTEGER NOT NULL,
+  "entry_id" INTEGER NOT NULL REFERENCES "entries" ("id"),
+  "created_at" TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+  CONSTRAINT "cash_transactions_kind_check" CHECK ("kind" IN ('deposit', 'withdrawal')),
+  CONSTRAINT "cash_transactions_amount_check" CHECK ("amount" > 0)
+);
+
+CREATE INDEX "cash_transactions_till_id_idx" ON "cash_transactions" ("till_id");
+
+CREATE INDEX "cash_transactions_account_id_idx" ON "cash_transactions" ("account_id");
+
+CREATE TABLE "loans" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  -- borrower account the loan is paid into and collected from
+  "account_id" INTEGER NOT NULL REFERENCES "accounts" ("id"),
+  "currency" TEXT NOT NULL,
+  "principal" INTEGER NOT NULL,
+  -- annual rate in basis points
+  "rate_bps" INTEGER NOT NULL,
+  "term" INTEGER NOT NULL,
+  "frequency" TEXT NOT NULL,
+  "amortization" TEXT NOT NULL,
+  -- charged once on every installment that cannot be collected on its due date
+  "late_fee" INTEGER NOT NULL DEFAULT 0,
+  "status" TEXT NOT NULL DEFAULT 'active',
+  -- transfer that paid the principal to the borrower
+  "disbursement_id" INTEGER NOT NULL REFERENCES "transfers" ("id"),
+  "created_at" TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+  CONSTRAINT "loans_principal_check" CHECK ("principal" > 0),
+  CONSTRAINT "loans_frequency_check" CHECK ("frequency" IN ('weekly', 'monthly')),
+  CONSTRAINT "loans_amortization_check" CHECK ("amortization" IN ('annuity', 'flat')),
+  CONSTRAINT "loans_status_check" CHECK ("status" IN ('active', 'paid_off'))
+);
+
+CREATE INDEX "loans_account_id_idx" ON "loans" ("account_id");
+
+CREATE TABLE "loan_installments" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "loan_id" INTEGER NOT NULL REFERENCES "loans" ("id"),
+  "number" INTEGER NOT NULL,
+  "due_date" DATE NOT NULL,
+  "principal" INTEGER NOT NULL,
+  "interest" INTEGER NOT NULL,
+  "late_fee" INTEGER NOT NULL DEFAULT 0,
+  "status" TEXT NOT NULL DEFAULT 'pending',
+  -- debit of the borrower account that paid the installment
+  "entry_id" INTEGER REFERENCES "entries" ("id"),
+  "paid_at" TIMESTAMP,
+  CONSTRAINT "loan_installments_status_check" CHECK ("status" IN ('pending', 'overdue', 'paid')),
+  CONSTRAINT "loan_id_number_key" UNIQUE ("loan_id", "number")
+);
+
+CREATE INDEX "loan_installments_due_date_idx" ON "loan_installments" ("due_date") WHERE "status" <> 'paid';
+
+CREATE TABLE "term_deposit_rates" (
+  "currency" TEXT NOT NULL,
+  "term_months" INTEGER NOT NULL,
+  -- annual rate in basis points
+  "rate_bps" INTEGER NOT NULL,
+  "day_count" TEXT NOT NULL,
+  -- taken off the rate when a deposit is withdrawn before maturity
+  "penalty_bps" INTEGER NOT NULL,
+  "created_at" TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+  PRIMARY KEY ("currency", "term_months"),
+  CONSTRAINT "term_deposit_rates_day_count_check" CHECK ("day_count" IN ('ACT/365', 'ACT/360', '30/360'))
+);
+
+CREATE TABLE "term_deposits" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  -- current account the deposit was funded from
+  "account_id" INTEGER NOT NULL REFERENCES "accounts" ("id"),
+  -- account principal and interest are paid to
+  "payout_account_id" INTEGER NOT NULL REFERENCES "accounts" ("id"),
+  "currency" TEXT NOT NULL,
+  "principal" INTEGER NOT NULL,
+  "rate_bps" INTEGER NOT NULL,
+  "day_count" TEXT NOT NULL,
+  "penalty_bps" INTEGER NOT NULL,
+  "term_months" INTEGER NOT NULL,
+  "start_date" DATE NOT NULL,
+  "maturity_date" DATE NOT NULL,
+  "on_maturity" TEXT NOT NULL,
+  "status" TEXT NOT NULL DEFAULT 'active',
+  "interest_paid" INTEGER NOT NULL DEFAULT 0,
+  -- deposit that was rolled over into this one
+  "renewed_from_id" INTEGER REFERENCES "term_deposits" ("id"),
+  "created_at" TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+  "closed_at" TIMESTAMP,
+  CONSTRAINT "term_deposits_principal_check" CHECK ("principal" > 0),
+  CONSTRAINT "term_deposits_on_maturity_check" CHECK ("on_maturity" IN ('payout', 'rollover')),
+  CONSTRAINT "term_deposits_status_check" CHECK ("status" IN ('active', 'matured', 'rolled_over', 'withdrawn'))
+);
+
+CREATE INDEX "term_deposits_account_id_idx" ON "term_deposits" ("account_id");
+
+CREATE INDEX "term_deposits_maturity_date_idx" ON "term_deposits" ("maturity_date") WHERE "status" = 'active';
+
+-- named sub-accounts of a main account, each backed by an account of type pot
+CREATE TABLE "pots" (
+  "account_id" INTEGER PRIMARY KEY REFERENCES "accounts" ("id"),
+  "parent_account_id" INTEGER NOT NULL REFERENCES "accounts" ("id"),
+  "name" TEXT NOT NULL,
+  -- amount the owner is saving up to, 0 when there is none
+  "target_amount" INTEGER NOT NULL DEFAULT 0,
+  -- outgoing transfers of the parent are rounded up to a multiple of this and the difference moved into the pot, 0 to disable
+  "round_up_to" INTEGER NOT NULL DEFAULT 0,
+  "created_at" TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+  CONSTRAINT "pots_target_amount_check" CHECK ("target_amount" >= 0),
+  CONSTRAINT "pots_round_up_to_check" CHECK ("round_up_to" >= 0),
+  CONSTRAINT "parent_account_id_name_key" UNIQUE ("parent_account_id", "name")
+);
+
+-- users other than the owner who may use an account, every holder may view it
+CREATE TABLE "account_holders" (
+  "account_id" INTEGER NOT NULL REFERENCES "accounts" ("id"),
+  "user_id" TEXT NOT NULL REFERENCES "users" ("id"),
+  -- may move money out of the account
+  "can_initiate" BOOLEAN NOT NULL DEFAULT false,
+  -- may approve transfers other holders made above their spend limit
+  "can_approve" BOOLEAN NOT NULL DEFAULT false,
+  -- largest transfer the holder may make without approval, 0 for no limit
+  "spend_limit" INTEGER NOT NULL DEFAULT 0,
+  "created_at" TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+  PRIMARY KEY ("account_id", "user_id"),
+  CONSTRAINT "account_holders_spend_limit_check" CHECK ("spend_limit" >= 0)
+);
+
+CREATE INDEX "account_holders_user_id_idx" ON "account_holders" ("user_id");
+
+-- transfers above the spend limit of the holder who made them, waiting for another holder
+CREATE TABLE "transfer_approvals" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "from_account_id" INTEGER NOT NULL REFERENCES "accounts" ("id"),
+  "to_account_id" INTEGER NOT NULL REFERENCES "accounts" ("id"),
+  "amount" INTEGER NOT NULL,
+  "requested_by" TEXT NOT NULL REFERENCES "users" ("id"),
+  "status" TEXT NOT NULL DEFAULT 'pending',
+  "decided_by" TEXT REFERENCES "users" ("id"),
+  "transfer_id" INTEGER REFERENCES "transfers" ("id"),
+  "created_at" TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+  "decided_at" TIMESTAMP,
+  CONSTRAINT "transfer_approvals_amount_check" CHECK ("amount" > 0),
+  CONSTRAINT "transfer_approvals_status_check" CHECK ("status" IN ('pending', 'approved', 'rejected'))
+);
+
+CREATE INDEX "transfer_approvals_from_account_id_idx" ON "transfer_approvals" ("from_account_id") WHERE "status" = 'pending';
+
+-- accounts a user has saved to pay to
+CREATE TABLE "beneficiaries" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "owner_id" TEXT NOT NULL REFERENCES "users" ("id"),
+  "nickname" TEXT NOT NULL,
+  "account_id" INTEGER NOT NULL REFERENCES "accounts" ("id"),
+  "currency" TEXT NOT NULL,
+  -- until then payments to the beneficiary are capped by the new payee limit
+  "cooling_off_until" TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+  "created_at" TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+  CONSTRAINT "owner_id_nickname_key" UNIQUE ("owner_id", "nickname"),
+  CONSTRAINT "owner_id_account_id_key" UNIQUE ("owner_id", "account_id")
+);
+
+-- money a user asked another user to pay into one of their accounts
+CREATE TABLE "payment_requests" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "requester_id" TEXT NOT NULL REFERENCES "users" ("id"),
+  "payer_id" TEXT NOT NULL REFERENCES "users" ("id"),
+  "to_account_id" INTEGER NOT NULL REFERENCES "accounts" ("id"),
+  "amount" INTEGER NOT NULL,
+  "currency" TEXT NOT NULL,
+  "memo" TEXT NOT NULL DEFAULT '',
+  "status" TEXT NOT NULL DEFAULT 'pending',
+  -- the transfer that paid the request once it is accepted
+  "transfer_id" INTEGER REFERENCES "transfers" ("id"),
+  -- pending requests can no longer be accepted after this
+  "expires_at" TIMESTAMP NOT NULL,
+  "created_at" TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+  "decided_at" TIMESTAMP,
+  CONSTRAINT "payment_requests_amount_check" CHECK ("amount" > 0),
+  CONSTRAINT "payment_requests_status_check" CHECK ("status" IN ('pending', 'accepted', 'declined')),
+  CONSTRAINT "payment_requests_payer_check" CHECK ("payer_id" <> "requester_id")
+);
+
+CREATE INDEX "payment_requests_requester_id_idx" ON "payment_requests" ("requester_id");
+
+CREATE INDEX "payment_requests_payer_id_idx" ON "payment_requests" ("payer_id");
+
+-- the responses to requests sent with an Idempotency-Key header, replayed
+-- when the request is sent again
+CREATE TABLE "idempotency_keys" (
+  "user_id" TEXT NOT NULL REFERENCES "users" ("id"),
+  "key" TEXT NOT NULL,
+  -- of the method, path and body, a key may not be reused for another request
+  "request_hash" TEXT NOT NULL,
+  -- 0 while the first request is still being handled
+  "status_code" INTEGER NOT NULL DEFAULT 0,
+  "content_type" TEXT NOT NULL DEFAULT '',
+  "response_body" BLOB NOT NULL DEFAULT x'',
+  "created_at" TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+  PRIMARY KEY ("user_id", "key")
+);
+
+-- SQLite doesn't say which foreign key a row broke. These triggers check
+-- the foreign keys the queries write before the row is, and fail with the
+-- message of Postgres, which names the constraint. The REFERENCES clauses
+-- still guard everything else.
+
+CREATE TRIGGER "accounts_fkey" BEFORE INSERT ON "accounts" BEGIN
+  SELECT RAISE(ABORT, 'insert or update on table "accounts" violates foreign key constraint "accounts_owner_id_fkey"')
+  WHERE NOT EXISTS (SELECT 1 FROM "users" WHERE "id" = NEW."owner_id");
+END;
+
+CREATE TRIGGER "entries_fkey" BEFORE INSERT ON "entries" BEGIN
+  SELECT RAISE(ABORT, 'insert or update on table "entries" violates foreign key constraint "entries_account_id_fkey"')
+  WHERE NOT EXISTS (SELECT 1 FROM "accounts" WHERE "id" = NEW."account_id");
+END;
+
+CREATE TRIGGER "transfers_fkey" BEFORE INSERT ON "transfers" BEGIN
+  SELECT RAISE(ABORT, 'insert or update on table "transfers" violates foreign key constraint "transfers_from_account_id_fkey"')
+  WHERE NOT EXISTS (SELECT 1 FROM "accounts" WHERE "id" = NEW."from_account_id");
+  SELECT RAISE(ABORT, 'insert or update on table "transfers" violates foreign key constraint "transfers_to_account_id_fkey"')
+  WHERE NOT EXISTS (SELECT 1 FROM "accounts" WHERE "id" = NEW."to_account_id");
+END;
+
+CREATE TRIGGER "fee_schedule_tiers_fkey" BEFORE INSERT ON "fee_schedule_tiers" BEGIN
+  SELECT RAISE(ABORT, 'insert or update on table "fee_schedule_tiers" violates foreign key constraint "fee_schedule_tiers_schedule_id_fkey"')
+  WHERE NOT EXISTS (SELECT 1 FROM "fee_schedules" WHERE "id" = NEW."schedule_id");
+END;
+
+CREATE TRIGGER "interest_accruals_fkey" BEFORE INSERT ON "interest_accruals" BEGIN
+  SELECT RAISE(ABORT, 'insert or update on table "interest_accruals" violates foreign key constraint "interest_accruals_account_id_fkey"')
+  WHERE NOT EXISTS (SELECT 1 FROM "accounts" WHERE "id" = NEW."account_id");
+END;
+
+CREATE TRIGGER "interest_accruals_capitalization_fkey" BEFORE UPDATE OF "capitalization_id" ON "interest_accruals" BEGIN
+  SELECT RAISE(ABORT, 'insert or update on table "interest_accruals" violates foreign key constraint "interest_accruals_capitalization_id_fkey"')
+  WHERE NEW."capitalization_id" IS NOT NULL
+    AND NOT EXISTS (SELECT 1 FROM "interest_capitalizations" WHERE "id" = NEW."capitalization_id");
+END;
+
+CREATE TRIGGER "interest_capitalizations_fkey" BEFORE INSERT ON "interest_capitalizations" BEGIN
+  SELECT RAISE(ABORT, 'insert or update on table "interest_capitalizations" violates foreign key constraint "interest_capitalizations_account_id_fkey"')
+  WHERE NOT EXISTS (SELECT 1 FROM "accounts" WHERE "id" = NEW."account_id");
+  SELECT RAISE(ABORT, 'insert or update on table "interest_capitalizations" violates foreign key constraint "interest_capitalizations_entry_id_fkey"')
+  WHERE NEW."entry_id" IS NOT NULL
+    AND NOT EXISTS (SELECT 1 FROM "entries" WHERE "id" = NEW."entry_id");
+END;
+
+CREATE TRIGGER "journal_lines_fkey" BEFORE INSERT ON "journal_lines" BEGIN
+  SELECT RAISE(ABORT, 'insert or update on table "journal_lines" violates foreign key constraint "journal_lines_journal_entry_id_fkey"')
+  WHERE NOT EXISTS (SELECT 1 FROM "journal_entries" WHERE "id" = NEW."journal_entry_id");
+  SELECT RAISE(ABORT, 'insert or update on table "journal_lines" violates foreign key constraint "journal_lines_gl_account_id_fkey"')
+  WHERE NOT EXISTS (SELECT 1 FROM "gl_accounts" WHERE "id" = NEW."gl_account_id");
+  SELECT RAISE(ABORT, 'insert or update on table "journal_lines" violates foreign key constraint "journal_lines_account_id_fkey"')
+  WHERE NEW."account_id" IS NOT NULL
+    AND NOT EXISTS (SELECT 1 FROM "accounts" WHERE "id" = NEW."account_id");
+END;
+
+CREATE TRIGGER "teller_tills_fkey" BEFORE INSERT ON "teller_tills" BEGIN
+  SELECT RAISE(ABORT, 'insert or update on table "teller_tills" violates foreign key constraint "teller_tills_teller_id_fkey"')
+  WHERE NOT EXISTS (SELECT 1 FROM "users" WHERE "id" = NEW."teller_id");
+END;
+
+CREATE TRIGGER "cash_transactions_fkey" BEFORE INSERT ON "cash_transactions" BEGIN
+  SELECT RAISE(ABORT, 'insert or update on table "cash_transactions" violates foreign key constraint "cash_transactions_till_id_fkey"')
+  WHERE NOT EXISTS (SELECT 1 FROM "teller_tills" WHERE "id" = NEW."till_id");
+  SELECT RAISE(ABORT, 'insert or update on table "cash_transactions" violates foreign key constraint "cash_transactions_account_id_fkey"')
+  WHERE NOT EXISTS (SELECT 1 FROM "accounts" WHERE "id" = NEW."account_id");
+  SELECT RAISE(ABORT, 'insert or update on table "cash_transactions" violates foreign key constraint "cash_transactions_entry_id_fkey"')
+  WHERE NOT EXISTS (SELECT 1 FROM "entries" WHERE "id" = NEW."entry_id");
+END;
+
+CREATE TRIGGER "loans_fkey" BEFORE INSERT ON "loans" BEGIN
+  SELECT RAISE(ABORT, 'insert or update on table "loans" violates foreign key constraint "loans_account_id_fkey"')
+  WHERE NOT EXISTS (SELECT 1 FROM "accounts" WHERE "id" = NEW."account_id");
+  SELECT RAISE(ABORT, 'insert or update on table "loans" violates foreign key constraint "loans_disbursement_id_fkey"')
+  WHERE NOT EXISTS (SELECT 1 FROM "transfers" WHERE "id" = NEW."disbursement_id");
+END;
+
+CREATE TRIGGER "loan_installments_fkey" BEFORE INSERT ON "loan_installments" BEGIN
+  SELECT RAISE(ABORT, 'insert or update on table "loan_installments" violates foreign key constraint "loan_installments_loan_id_fkey"')
+  WHERE NOT EXISTS (SELECT 1 FROM "loans" WHERE "id" = NEW."loan_id");
+END;
+
+CREATE TRIGGER "loan_installments_entry_fkey" BEFORE UPDATE OF "entry_id" ON "loan_installments" BEGIN
+  SELECT RAISE(ABORT, 'insert or update on table "loan_installments" violates foreign key constraint "loan_installments_entry_id_fkey"')
+  WHERE NEW."entry_id" IS NOT NULL
+    AND NOT EXISTS (SELECT 1 FROM "entries" WHERE "id" = NEW."entry_id");
+END;
+
+CREATE TRIGGER "term_deposits_fkey" BEFORE INSERT ON "term_deposits" BEGIN
+  SELECT RAISE(ABORT, 'insert or update on table "term_deposits" violates foreign key constraint "term_deposits_account_id_fkey"')
+  WHERE NOT EXISTS (SELECT 1 FROM "accounts" WHERE "id" = NEW."account_id");
+  SELECT RAISE(ABORT, 'insert or update on table "term_deposits" violates foreign key constraint "term_deposits_payout_account_id_fkey"')
+  WHERE NOT EXISTS (SELECT 1 FROM "accounts" WHERE "id" = NEW."payout_account_id");
+  SELECT RAISE(ABORT, 'insert or update on table "term_deposits" violates foreign key constraint "term_deposits_renewed_from_id_fkey"')
+  WHERE NEW."renewed_from_id" IS NOT NULL
+    AND NOT EXISTS (SELECT 1 FROM "term_deposits" WHERE "id" = NEW."renewed_from_id");
+END;
+
+CREATE TRIGGER "pots_fkey" BEFORE INSERT ON "pots" BEGIN
+  SELECT RAISE(ABORT, 'insert or update on table "pots" violates foreign key constraint "pots_account_id_fkey"')
+  WHERE NOT EXISTS (SELECT 1 FROM "accounts" WHERE "id" = NEW."account_id");
+  SELECT RAISE(ABORT, 'insert or update on table "pots" violates foreign key constraint "pots_parent_account_id_fkey"')
+  WHERE NOT EXISTS (SELECT 1 FROM "accounts" WHERE "id" = NEW."parent_account_id");
+END;
+
+CREATE TRIGGER "account_holders_fkey" BEFORE INSERT ON "account_holders" BEGIN
+  SELECT RAISE(ABORT, 'insert or update on table "account_holders" violates foreign key constraint "account_holders_account_id_fkey"')
+  WHERE NOT EXISTS (SELECT 1 FROM "accounts" WHERE "id" = NEW."account_id");
+  SELECT RAISE(ABORT, 'insert or update on table "account_holders" violates foreign key constraint "account_holders_user_id_fkey"')
+  WHERE NOT EXISTS (SELECT 1 FROM "users" WHERE "id" = NEW."user_id");
+END;
+
+CREATE TRIGGER "transfer_approvals_fkey" BEFORE INSERT ON "transfer_approvals" BEGIN
+  SELECT RAISE(ABORT, 'insert or update on table "transfer_approvals" violates foreign key constraint "transfer_approvals_from_account_id_fkey"')
+  WHERE NOT EXISTS (SELECT 1 FROM "accounts" WHERE "id" = NEW."from_account_id");
+  SELECT RAISE(ABORT, 'insert or update on table "transfer_approvals" violates foreign key constraint "transfer_approvals_to_account_id_fkey"')
+  WHERE NOT EXISTS (SELECT 1 FROM "accounts" WHERE "id" = NEW."to_account_id");
+  SELECT RAISE(ABORT, 'insert or update on table "transfer_approvals" violates foreign key constraint "transfer_approvals_requested_by_fkey"')
+  WHERE NOT EXISTS (SELECT 1 FROM "users" WHERE "id" = NEW."requested_by");
+END;
+
+CREATE TRIGGER "transfer_approvals_decision_fkey" BEFORE UPDATE OF "decided_by", "transfer_id" ON "transfer_approvals" BEGIN
+  SELECT RAISE(ABORT, 'insert or update on table "transfer_approvals" violates foreign key constraint "transfer_approvals_decided_by_fkey"')
+  WHERE NEW."decided_by" IS NOT NULL
+    AND NOT EXISTS (SELECT 1 FROM "users" WHERE "id" = NEW."decided_by");
+  SELECT RAISE(ABORT, 'insert or update on table "transfer_approvals" violates foreign key constraint "transfer_approvals_transfer_id_fkey"')
+  WHERE NEW."transfer_id" IS NOT NULL
+    AND NOT EXISTS (SELECT 1 FROM "transfers" WHERE "id" = NEW."transfer_id");
+END;
+
+CREATE TRIGGER "beneficiaries_fkey" BEFORE INSERT ON "beneficiaries" BEGIN
+  SELECT RAISE(ABORT, 'insert or update on table "beneficiaries" violates foreign key constraint "beneficiaries_owner_id_fkey"')
+  WHERE NOT EXISTS (SELECT 1 FROM "users" WHERE "id" = NEW."owner_id");
+  SELECT RAISE(ABORT, 'insert or update on table "beneficiaries" violates foreign key constraint "beneficiaries_account_id_fkey"')
+  WHERE NOT EXISTS (SELECT 1 FROM "accounts" WHERE "id" = NEW."account_id");
+END;
+
+CREATE TRIGGER "payment_requests_fkey" BEFORE INSERT ON "payment_requests" BEGIN
+  SELECT RAISE(ABORT, 'insert or update on table "payment_requests" violates foreign key constraint "payment_requests_requester_id_fkey"')
+  WHERE NOT EXISTS (SELECT 1 FROM "users" WHERE "id" = NEW."requester_id");
+  SELECT RAISE(ABORT, 'insert or update on table "payment_requests" violates foreign key constraint "payment_requests_payer_id_fkey"')
+  WHERE NOT EXISTS (SELECT 1 FROM "users" WHERE "id" = NEW."payer_id");
+  SELECT RAISE(ABORT, 'insert or update on table "payment_requests" violates foreign key constraint "payment_requests_to_account_id_fkey"')
+  WHERE NOT EXISTS (SELECT 1 FROM "accounts" WHERE "id" = NEW."to_account_id");
+END;
+
+CREATE TRIGGER "payment_requests_transfer_fkey" BEFORE UPDATE OF "transfer_id" ON "payment_requests" BEGIN
+  SELECT RAISE(ABORT, 'insert or update on table "payment_requests" violates foreign key constraint "payment_requests_transfer_id_fkey"')
+  WHERE NEW."transfer_id" IS NOT NULL
+    AND NOT EXISTS (SELECT 1 FROM "transfers" WHERE "id" = NEW."transfer_id");
+END;
+
+CREATE TRIGGER "idempotency_keys_fkey" BEFORE INSERT ON "idempotency_keys" BEGIN
+  SELECT RAISE(ABORT, 'insert or update on table "idempotency_keys" violates foreign key constraint "idempotency_keys_user_id_fkey"')
+  WHERE NOT EXISTS (SELECT 1 FROM "users" WHERE "id" = NEW."user_id");
+END;
+
+-- the rows the Postgres migrations seed
+
+INSERT INTO "transfer_limits" (
+  "tier", "currency",
+  "max_per_transfer", "account_daily_amount", "account_monthly_amount", "account_daily_count",
+  "user_daily_amount", "user_monthly_amount", "user_daily_count",
+  "new_payee_max_per_transfer"
+) VALUES
+  ('standard', 'USD', 1000000, 2500000, 25000000, 100, 5000000, 50000000, 200, 100000),
+  ('standard', 'EUR', 1000000, 2500000, 25000000, 100, 5000000, 50000000, 200, 100000),
+  ('standard', 'IDR', 1000000000, 2500000000, 25000000000, 100, 5000000000, 50000000000, 200, 100000000),
+  ('premium', 'USD', 10000000, 25000000, 250000000, 500, 50000000, 500000000, 1000, 1000000),
+  ('premium', 'EUR', 10000000, 25000000, 250000000, 500, 50000000, 500000000, 1000, 1000000),
+  ('premium', 'IDR', 10000000000, 25000000000, 250000000000, 500, 50000000000, 500000000000, 1000, 1000000000);
+
+-- the bank itself owns the house accounts fees are posted to
+INSERT INTO "users" ("id", "username", "hashed_password", "full_name", "email")
+VALUES ('00000000-0000-0000-0000-000000000001', 'simplebank', '!', 'Simplebank House', 'house@simplebank.internal');
+
+-- account numbers are drawn as in CreateAccount
+WITH "house" AS MATERIALIZED (
+  SELECT "column1" AS "account_type", "column2" AS "currency", abs(random() % 10000000000) AS "n"
+  FROM (VALUES
+    ('house_revenue', 'USD'),
+    ('house_revenue', 'EUR'),
+    ('house_revenue', 'IDR'),
+    ('house_interest_expense', 'USD'),
+    ('house_interest_expense', 'EUR'),
+    ('house_interest_expense', 'IDR'),
+    ('house_cash', 'USD'),
+    ('house_cash', 'EUR'),
+    ('house_cash', 'IDR'),
+    ('house_loans', 'USD'),
+    ('house_loans', 'EUR'),
+    ('house_loans', 'IDR'),
+    ('house_loan_interest', 'USD'),
+    ('house_loan_interest', 'EUR'),
+    ('house_loan_interest', 'IDR'),
+    ('house_term_deposits', 'USD'),
+    ('house_term_deposits', 'EUR'),
+    ('house_term_deposits', 'IDR')
+  )
+)
+INSERT INTO "accounts" ("owner_id", "currency", "account_type", "number")
+SELECT
+  '00000000-0000-0000-0000-000000000001',
+  "currency",
+  "account_type",
+  'ID' || printf('%02d', 98 - ((((28222521 % 97) * 10000000000 + "n") % 97) * 1000000 + 181300) % 97) || 'SMPL' || printf('%010d', "n")
+FROM "house";
+
+INSERT INTO "interest_rates" ("account_type", "currency", "rate_bps", "day_count") VALUES
+  ('savings', 'USD', 250, 'ACT/365'),
+  ('savings', 'EUR', 150, 'ACT/360'),
+  ('savings', 'IDR', 300, '30/360');
+
+INSERT INTO "gl_accounts" ("code", "name", "type") VALUES
+  ('1000', 'Cash and cash equivalents', 'asset'),
+  ('2100', 'Customer current accounts', 'liability'),
+  ('2200', 'Customer savings accounts', 'liability'),
+  ('3900', 'Opening balance equity', 'equity'),
+  ('4100', 'Fee income', 'income'),
+  ('5100', 'Interest expense', 'expense'),
+  ('1200', 'Loans receivable', 'asset'),
+  ('4200', 'Loan interest income', 'income'),
+  ('2300', 'Customer term deposits', 'liability');
+
+INSERT INTO "gl_account_mappings" ("account_type", "gl_account_id")
+SELECT m."column1", g."id"
+FROM (VALUES
+  ('current', '2100'),
+  ('savings', '2200'),
+  ('house_revenue', '4100'),
+  ('house_interest_expense', '5100'),
+  ('house_cash', '1000'),
+  ('house_loans', '1200'),
+  ('house_loan_interest', '4200'),
+  ('house_term_deposits', '2300'),
+  ('pot', '2200'),
+  ('business', '2100')
+) m
+JOIN "gl_accounts" g ON g."code" = m."column2";
+
+-- a new database has no balances to book against equity, the entry is kept
+-- so the ledger reads the same as one migrated on Postgres
+INSERT INTO "journal_entries" ("reference") VALUES ('opening-balances');
+
+INSERT INTO "term_deposit_rates" ("currency", "term_months", "rate_bps", "day_count", "penalty_bps") VALUES
+  ('USD', 3, 350, 'ACT/365', 200),
+  ('USD', 6, 400, 'ACT/365', 200),
+  ('USD', 12, 450, 'ACT/365', 250),
+  ('EUR', 3, 250, 'ACT/360', 150),
+  ('EUR', 6, 300, 'ACT/360', 150),
+  ('EUR', 12, 350, 'ACT/360', 200),
+  ('IDR', 3, 500, '30/360', 300),
+  ('IDR', 6, 550, '30/360', 300),
+  ('IDR', 12, 600, '30/360', 350);
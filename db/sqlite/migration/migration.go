@@ -0,0 +1,10 @@
+// Package migration embeds the schema migrations of the SQLite store, which
+// db/migration applies.
+package migration
+
+import "embed"
+
+// Files holds the migrations, named like those of db/migration
+//
+//go:embed *.sql
+var Files embed.FS
@@ -0,0 +1,74 @@
+-- name: CreateAccount :one
+-- the number is drawn like util.NewAccountNumber does, the store draws
+-- again when it clashes with accounts_number_key
+WITH drawn AS MATERIALIZED (
+    SELECT abs(random() % 10000000000) AS n
+)
+INSERT INTO accounts (
+    owner_id,
+    currency,
+    account_type,
+    number
+)
+SELECT
+    ?1,
+    ?2,
+    ?3,
+    'ID' || printf('%02d', 98 - ((((28222521 % 97) * 10000000000 + n) % 97) * 1000000 + 181300) % 97) || 'SMPL' || printf('%010d', n)
+FROM drawn
+RETURNING *;
+
+-- name: GetAccount :one
+SELECT * FROM accounts
+WHERE id = ?1 LIMIT 1;
+
+-- name: GetAccountForUpdate :one
+SELECT * FROM accounts
+WHERE id = ?1 LIMIT 1;
+
+-- name: FetchAccounts :many
+SELECT * FROM accounts
+ORDER BY id
+LIMIT ?1
+OFFSET ?2;
+
+-- name: FetchAccountsByOwner :many
+SELECT * FROM accounts
+WHERE owner_id = ?1
+    OR id IN (SELECT account_id FROM account_holders WHERE user_id = ?1)
+ORDER BY id
+LIMIT ?2
+OFFSET ?3;
+
+-- name: UpdateAccountStatus :one
+UPDATE accounts
+SET status = ?2
+WHERE id = ?1
+RETURNING *;
+
+-- name: UpdateBalanceAccount :one
+UPDATE accounts
+SET balance = ?2
+WHERE id = ?1
+RETURNING *;
+
+-- name: AddBalanceAccount :one
+UPDATE accounts
+SET balance = balance + sqlc.arg(amount)
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: CloseAccount :one
+UPDATE accounts
+SET status = 'closed', closed_at = strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')
+WHERE id = ?1
+RETURNING *;
+
+-- name: GetHouseAccount :one
+SELECT * FROM accounts
+WHERE account_type = ?1 AND currency = ?2
+LIMIT 1;
+
+-- name: GetAccountByNumber :one
+SELECT * FROM accounts
+WHERE number = ?1 LIMIT 1;
@@ -0,0 +1,16 @@
+-- name: CreateEntry :one
+INSERT INTO entries (
+    account_id, amount
+) VALUES (
+    ?1, ?2
+) RETURNING *;
+
+-- name: GetEntry :one
+SELECT * FROM entries WHERE ID = ?1 LIMIT 1;
+
+-- name: FetchEntries :many
+SELECT * FROM entries
+WHERE account_id = ?1
+ORDER BY id
+LIMIT ?2
+OFFSET ?3;
\ No newline at end of file
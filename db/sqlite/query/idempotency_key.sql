@@ -0,0 +1,34 @@
+-- name: CreateIdempotencyKey :one
+INSERT INTO idempotency_keys (
+    user_id,
+    key,
+    request_hash
+) VALUES (
+    ?1, ?2, ?3
+) RETURNING *;
+
+-- name: GetIdempotencyKey :one
+SELECT * FROM idempotency_keys
+WHERE user_id = ?1 AND key = ?2 LIMIT 1;
+
+-- name: SaveIdempotentResponse :one
+UPDATE idempotency_keys
+SET status_code = ?3, content_type = ?4, response_body = ?5
+WHERE user_id = ?1 AND key = ?2
+RETURNING *;
+
+-- name: DeleteIdempotencyKey :exec
+DELETE FROM idempotency_keys
+WHERE user_id = ?1 AND key = ?2;
+
+-- name: TakeOverIdempotencyKey :one
+UPDATE idempotency_keys
+SET created_at = strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')
+WHERE user_id = ?1 AND key = ?2
+    AND status_code = 0
+    AND created_at < strftime('%Y-%m-%d %H:%M:%f+00:00', sqlc.arg(started_before))
+RETURNING *;
+
+-- name: DeleteIdempotencyKeysBefore :execrows
+DELETE FROM idempotency_keys
+WHERE created_at < strftime('%Y-%m-%d %H:%M:%f+00:00', sqlc.arg(before));
@@ -0,0 +1,85 @@
+-- name: CreateInterestRate :one
+INSERT INTO interest_rates (
+    account_type,
+    currency,
+    rate_bps,
+    day_count
+) VALUES (
+    ?1, ?2, ?3, ?4
+) RETURNING *;
+
+-- name: ListInterestBearingAccounts :many
+-- balance is the closing balance of the day before as_of, so that re-running
+-- an old date accrues on the same balance as the original run
+SELECT
+    a.id,
+    a.currency,
+    a.account_type,
+    r.rate_bps,
+    r.day_count,
+    CAST(a.balance - COALESCE((
+        SELECT SUM(e.amount) FROM entries e
+        WHERE e.account_id = a.id AND e.created_at >= strftime('%Y-%m-%d %H:%M:%f+00:00', sqlc.arg(as_of))
+    ), 0) AS INTEGER) AS balance
+FROM accounts a
+JOIN interest_rates r ON r.account_type = a.account_type AND r.currency = a.currency
+WHERE a.status <> 'closed' AND a.created_at < strftime('%Y-%m-%d %H:%M:%f+00:00', sqlc.arg(as_of))
+ORDER BY a.id;
+
+-- name: CreateInterestAccrual :one
+INSERT INTO interest_accruals (
+    account_id,
+    accrual_date,
+    balance,
+    rate_bps,
+    day_count,
+    amount_micros
+) VALUES (
+    ?1, date(?2), ?3, ?4, ?5, ?6
+)
+ON CONFLICT (account_id, accrual_date) DO NOTHING
+RETURNING *;
+
+-- name: ListInterestAccruals :many
+SELECT * FROM interest_accruals
+WHERE account_id = ?1
+ORDER BY accrual_date;
+
+-- name: ListAccountsWithPendingInterest :many
+SELECT DISTINCT account_id FROM interest_accruals
+WHERE capitalization_id IS NULL AND accrual_date <= date(sqlc.arg(period_end))
+ORDER BY account_id;
+
+-- name: GetPendingInterest :one
+SELECT
+    COUNT(*) AS count,
+    CAST(COALESCE(SUM(amount_micros), 0) AS INTEGER) AS total_micros
+FROM interest_accruals
+WHERE account_id = sqlc.arg(account_id)
+    AND capitalization_id IS NULL
+    AND accrual_date <= date(sqlc.arg(period_end));
+
+-- name: GetLastInterestCapitalization :one
+SELECT * FROM interest_capitalizations
+WHERE account_id = ?1
+ORDER BY period_end DESC
+LIMIT 1;
+
+-- name: CreateInterestCapitalization :one
+INSERT INTO interest_capitalizations (
+    account_id,
+    period_end,
+    accrued_micros,
+    amount,
+    carry_micros,
+    entry_id
+) VALUES (
+    ?1, date(?2), ?3, ?4, ?5, ?6
+) RETURNING *;
+
+-- name: MarkInterestAccrualsCapitalized :exec
+UPDATE interest_accruals
+SET capitalization_id = sqlc.arg(capitalization_id)
+WHERE account_id = sqlc.arg(account_id)
+    AND capitalization_id IS NULL
+    AND accrual_date <= date(sqlc.arg(period_end));
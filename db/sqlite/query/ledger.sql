@@ -0,0 +1,61 @@
+-- name: CreateGLAccount :one
+INSERT INTO gl_accounts (
+    code,
+    name,
+    type
+) VALUES (
+    ?1, ?2, ?3
+) RETURNING *;
+
+-- name: ListGLAccounts :many
+SELECT * FROM gl_accounts
+ORDER BY code;
+
+-- name: GetGLAccountForAccount :one
+SELECT m.gl_account_id, a.currency
+FROM accounts a
+JOIN gl_account_mappings m ON m.account_type = a.account_type
+WHERE a.id = ?1 LIMIT 1;
+
+-- name: CreateJournalEntry :one
+INSERT INTO journal_entries (
+    reference
+) VALUES (
+    ?1
+) RETURNING *;
+
+-- name: CreateJournalLine :one
+INSERT INTO journal_lines (
+    journal_entry_id,
+    gl_account_id,
+    account_id,
+    currency,
+    amount
+) VALUES (
+    ?1, ?2, ?3, ?4, ?5
+) RETURNING *;
+
+-- name: ListJournalEntriesByReference :many
+SELECT * FROM journal_entries
+WHERE reference = ?1
+ORDER BY id;
+
+-- name: ListJournalLines :many
+SELECT * FROM journal_lines
+WHERE journal_entry_id = ?1
+ORDER BY id;
+
+-- name: GetTrialBalance :many
+SELECT
+    g.id AS gl_account_id,
+    g.code,
+    g.name,
+    g.type,
+    l.currency,
+    CAST(SUM(l.amount) AS INTEGER) AS balance
+FROM journal_lines l
+JOIN journal_entries j ON j.id = l.journal_entry_id
+JOIN gl_accounts g ON g.id = l.gl_account_id
+WHERE j.created_at < strftime('%Y-%m-%d %H:%M:%f+00:00', sqlc.arg(as_of))
+GROUP BY g.id, g.code, g.name, g.type, l.currency
+ORDER BY l.currency, g.code;
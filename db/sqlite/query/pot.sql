@@ -0,0 +1,41 @@
+-- name: CreatePot :one
+INSERT INTO pots (
+    account_id,
+    parent_account_id,
+    name,
+    target_amount,
+    round_up_to
+) VALUES (
+    ?1, ?2, ?3, ?4, ?5
+) RETURNING *;
+
+-- name: GetPot :one
+SELECT * FROM pots
+WHERE account_id = ?1 LIMIT 1;
+
+-- name: ListPotsByParent :many
+SELECT
+    p.account_id,
+    p.name,
+    p.target_amount,
+    p.round_up_to,
+    a.balance,
+    a.status,
+    p.created_at
+FROM pots p
+JOIN accounts a ON a.id = p.account_id
+WHERE p.parent_account_id = ?1 AND a.status <> 'closed'
+ORDER BY p.created_at, p.account_id;
+
+-- name: GetRoundUpPot :one
+SELECT p.* FROM pots p
+JOIN accounts a ON a.id = p.account_id
+WHERE p.parent_account_id = ?1
+    AND p.round_up_to > 0
+    AND a.status = 'active'
+LIMIT 1;
+
+-- name: CountOpenPots :one
+SELECT COUNT(*) FROM pots p
+JOIN accounts a ON a.id = p.account_id
+WHERE p.parent_account_id = ?1 AND a.status <> 'closed';
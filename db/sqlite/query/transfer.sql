@@ -0,0 +1,19 @@
+-- name: CreateTransfer :one
+INSERT INTO transfers (
+    from_account_id, to_account_id, amount, internal
+) VALUES (
+    ?1, ?2, ?3, ?4
+) RETURNING *;
+
+-- name: GetTransfer :one
+SELECT * FROM transfers WHERE id = ?1 LIMIT 1;
+
+-- name: FetchTransfer :many
+SELECT * FROM transfers
+WHERE
+    from_account_id = ?1
+    OR 
+    to_account_id = ?2
+ORDER BY id
+LIMIT ?3
+OFFSET ?4;
\ No newline at end of file
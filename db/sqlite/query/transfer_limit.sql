@@ -0,0 +1,31 @@
+-- name: GetTransferLimit :one
+SELECT * FROM transfer_limits
+WHERE tier = ?1 AND currency = ?2 LIMIT 1;
+
+-- name: GetAccountTransferUsage :one
+SELECT
+    COUNT(*) AS count,
+    CAST(COALESCE(SUM(amount), 0) AS INTEGER) AS total
+FROM transfers
+WHERE from_account_id = sqlc.arg(account_id)
+    AND NOT internal
+    AND created_at >= strftime('%Y-%m-%d %H:%M:%f+00:00', sqlc.arg(since));
+
+-- name: GetOwnerTransferUsage :one
+SELECT
+    COUNT(*) AS count,
+    CAST(COALESCE(SUM(t.amount), 0) AS INTEGER) AS total
+FROM transfers t
+JOIN accounts a ON a.id = t.from_account_id
+WHERE a.owner_id = sqlc.arg(owner_id)
+    AND a.currency = sqlc.arg(currency)
+    AND NOT t.internal
+    AND t.created_at >= strftime('%Y-%m-%d %H:%M:%f+00:00', sqlc.arg(since));
+
+-- name: GetPayeeTransferTotal :one
+SELECT CAST(COALESCE(SUM(t.amount), 0) AS INTEGER) AS total
+FROM transfers t
+JOIN accounts a ON a.id = t.from_account_id
+WHERE a.owner_id = sqlc.arg(owner_id)
+    AND t.to_account_id = sqlc.arg(to_account_id)
+    AND t.created_at >= strftime('%Y-%m-%d %H:%M:%f+00:00', sqlc.arg(since));
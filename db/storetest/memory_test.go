@@ -0,0 +1,13 @@
+package storetest
+
+import (
+	"testing"
+
+	db "github.com/flukis/simplebank/db/sqlc"
+)
+
+func TestMemoryStore(t *testing.T) {
+	Run(t, func(t *testing.T) db.Store {
+		return db.NewMemoryStore()
+	})
+}
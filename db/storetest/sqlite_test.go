@@ -0,0 +1,33 @@
+package storetest
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/flukis/simplebank/db/migration"
+	db "github.com/flukis/simplebank/db/sqlc"
+	"github.com/flukis/simplebank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLiteStore(t *testing.T) {
+	ctx := context.Background()
+	conf := util.Config{DBSource: filepath.Join(t.TempDir(), "simplebank.db")}
+
+	conn, err := db.OpenSQLite(ctx, conf)
+	require.NoError(t, err)
+	m, err := migration.NewSQLite(conn)
+	require.NoError(t, err)
+	require.NoError(t, m.Up(ctx, 0))
+	require.NoError(t, m.Close())
+
+	conn, err = db.OpenSQLite(ctx, conf)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	store := db.NewSQLiteStore(conn)
+	Run(t, func(t *testing.T) db.Store {
+		return store
+	})
+}
@@ -0,0 +1,762 @@
+// Package storetest holds the tests every db.Store must pass, so that the
+// stores behave the same to the handlers whatever they keep the data in.
+package storetest
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	db "github.com/flukis/simplebank/db/sqlc"
+	"github.com/flukis/simplebank/util"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/require"
+)
+
+// Run runs the conformance tests on the stores newStore returns. The tests
+// only look at rows they create, so the store may be shared with other
+// tests.
+func Run(t *testing.T, newStore func(t *testing.T) db.Store) {
+	tests := []struct {
+		name string
+		test func(t *testing.T, store db.Store)
+	}{
+		{"Seed", testSeed},
+		{"Users", testUsers},
+		{"Accounts", testAccounts},
+		{"NotFound", testNotFound},
+		{"ForeignKeys", testForeignKeys},
+		{"CheckConstraints", testCheckConstraints},
+		{"TransferTx", testTransferTx},
+		{"TransferTxConcurrent", testTransferTxConcurrent},
+		{"TransferTxRollback", testTransferTxRollback},
+		{"TransferTxInsufficientFunds", testTransferTxInsufficientFunds},
+		{"InternalTransferUsage", testInternalTransferUsage},
+		{"Holders", testHolders},
+		{"Beneficiaries", testBeneficiaries},
+		{"BeneficiaryCoolingOff", testBeneficiaryCoolingOff},
+		{"InterestAccrual", testInterestAccrual},
+		{"IdempotencyKeys", testIdempotencyKeys},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.test(t, newStore(t))
+		})
+	}
+}
+
+// requireViolation checks err is the error of a broken constraint, wrapping
+// the Postgres error that names it
+func requireViolation(t *testing.T, err error, kind error, constraint string) {
+	t.Helper()
+	require.ErrorIs(t, err, kind, "want a %s violation", constraint)
+	var pgErr *pgconn.PgError
+	require.True(t, errors.As(err, &pgErr), "want a %s violation, got %v", constraint, err)
+	require.Equal(t, constraint, pgErr.ConstraintName)
+}
+
+func createUser(t *testing.T, store db.Store) db.User {
+	arg := db.CreateUserParams{
+		Username:       util.GenRandomOwner(),
+		HashedPassword: "secret",
+		FullName:       util.GenRandomOwner(),
+		Email:          util.GenRandomEmail(),
+	}
+	user, err := store.CreateUser(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.Username, user.Username)
+	require.Equal(t, arg.Email, user.Email)
+	require.Equal(t, util.RoleCustomer, user.Role)
+	require.True(t, user.PasswordChangedAt.IsZero())
+	require.NotZero(t, user.CreatedAt)
+	return user
+}
+
+// createAccount opens an account of a new user holding balance
+func createAccount(t *testing.T, store db.Store, accountType string, balance int64) db.Account {
+	user := createUser(t, store)
+	account, err := store.CreateAccount(context.Background(), db.CreateAccountParams{
+		OwnerID:     user.ID,
+		Currency:    util.GenRandomCurrency(),
+		AccountType: accountType,
+	})
+	require.NoError(t, err)
+	require.Zero(t, account.Balance)
+	require.Equal(t, db.AccountStatusActive, account.Status)
+	require.Equal(t, "standard", account.Tier)
+	require.NoError(t, util.ValidateAccountNumber(account.Number))
+
+	account, err = store.UpdateBalanceAccount(context.Background(), db.UpdateBalanceAccountParams{
+		ID:      account.ID,
+		Balance: balance,
+	})
+	require.NoError(t, err)
+	return account
+}
+
+func testSeed(t *testing.T, store db.Store) {
+	ctx := context.Background()
+	for _, currency := range []string{"USD", "EUR", "IDR"} {
+		for _, accountType := range []string{
+			db.AccountTypeHouseRevenue,
+			db.AccountTypeHouseInterestExpense,
+			db.AccountTypeHouseCash,
+			db.AccountTypeHouseLoans,
+			db.AccountTypeHouseLoanInterest,
+			db.AccountTypeHouseTermDeposits,
+		} {
+			house, err := store.GetHouseAccount(ctx, db.GetHouseAccountParams{
+				AccountType: accountType,
+				Currency:    currency,
+			})
+			require.NoError(t, err, "%s %s", accountType, currency)
+			gl, err := store.GetGLAccountForAccount(ctx, house.ID)
+			require.NoError(t, err)
+			require.Equal(t, currency, gl.Currency)
+		}
+
+		limit, err := store.GetTransferLimit(ctx, db.GetTransferLimitParams{Tier: "standard", Currency: currency})
+		require.NoError(t, err)
+		require.Equal(t, limit.MaxPerTransfer/10, limit.NewPayeeMaxPerTransfer)
+
+		rate, err := store.GetTermDepositRate(ctx, db.GetTermDepositRateParams{Currency: currency, TermMonths: 12})
+		require.NoError(t, err)
+		require.True(t, util.IsSupportedDayCount(rate.DayCount))
+	}
+
+	accounts, err := store.ListGLAccounts(ctx)
+	require.NoError(t, err)
+	codes := make([]string, len(accounts))
+	for i, account := range accounts {
+		codes[i] = account.Code
+	}
+	require.Subset(t, codes, []string{"1000", "1200", "2100", "2200", "2300", "3900", "4100", "4200", "5100"})
+	require.IsIncreasing(t, codes)
+}
+
+func testUsers(t *testing.T, store db.Store) {
+	ctx := context.Background()
+	user := createUser(t, store)
+
+	got, err := store.GetUserByEmail(ctx, user.Email)
+	require.NoError(t, err)
+	require.Equal(t, user.ID, got.ID)
+	require.WithinDuration(t, user.CreatedAt, got.CreatedAt, time.Second)
+
+	// usernames may repeat, emails may not
+	_, err = store.CreateUser(ctx, db.CreateUserParams{
+		Username:       user.Username,
+		HashedPassword: "secret",
+		FullName:       user.FullName,
+		Email:          util.GenRandomEmail(),
+	})
+	require.NoError(t, err)
+	_, err = store.CreateUser(ctx, db.CreateUserParams{
+		Username:       util.GenRandomOwner(),
+		HashedPassword: "secret",
+		FullName:       user.FullName,
+		Email:          user.Email,
+	})
+	requireViolation(t, err, db.ErrUniqueViolation, "users_email_key")
+
+	updated, err := store.UpdateUserRole(ctx, db.UpdateUserRoleParams{ID: user.ID, Role: util.RoleTeller})
+	require.NoError(t, err)
+	require.Equal(t, util.RoleTeller, updated.Role)
+}
+
+func testAccounts(t *testing.T, store db.Store) {
+	ctx := context.Background()
+	account := createAccount(t, store, db.AccountTypeCurrent, 0)
+
+	got, err := store.GetAccountByNumber(ctx, account.Number)
+	require.NoError(t, err)
+	require.Equal(t, account.ID, got.ID)
+
+	// one open account of a type and currency per owner
+	arg := db.CreateAccountParams{
+		OwnerID:     account.OwnerID,
+		Currency:    account.Currency,
+		AccountType: account.AccountType,
+	}
+	_, err = store.CreateAccount(ctx, arg)
+	requireViolation(t, err, db.ErrUniqueViolation, "owner_id_currency_type_key")
+
+	// but any number of pots
+	for i := 0; i < 2; i++ {
+		_, err = store.CreateAccount(ctx, db.CreateAccountParams{
+			OwnerID:     account.OwnerID,
+			Currency:    account.Currency,
+			AccountType: db.AccountTypePot,
+		})
+		require.NoError(t, err)
+	}
+
+	// and a new one once the old one is closed
+	closed, err := store.CloseAccount(ctx, account.ID)
+	require.NoError(t, err)
+	require.Equal(t, db.AccountStatusClosed, closed.Status)
+	require.True(t, closed.ClosedAt.Valid)
+	reopened, err := store.CreateAccount(ctx, arg)
+	require.NoError(t, err)
+	require.NotEqual(t, account.Number, reopened.Number)
+
+	// which the closed one can't come back next to
+	_, err = store.UpdateAccountStatus(ctx, db.UpdateAccountStatusParams{ID: account.ID, Status: db.AccountStatusActive})
+	requireViolation(t, err, db.ErrUniqueViolation, "owner_id_currency_type_key")
+
+	accounts, err := store.FetchAccountsByOwner(ctx, db.FetchAccountsByOwnerParams{
+		OwnerID: account.OwnerID,
+		Limit:   10,
+	})
+	require.NoError(t, err)
+	require.Len(t, accounts, 4)
+	for i := 1; i < len(accounts); i++ {
+		require.Less(t, accounts[i-1].ID, accounts[i].ID)
+	}
+
+	page, err := store.FetchAccountsByOwner(ctx, db.FetchAccountsByOwnerParams{
+		OwnerID: account.OwnerID,
+		Limit:   2,
+		Offset:  1,
+	})
+	require.NoError(t, err)
+	require.Equal(t, accounts[1:3], page)
+}
+
+func testNotFound(t *testing.T, store db.Store) {
+	ctx := context.Background()
+
+	_, err := store.GetUser(ctx, uuid.New())
+	require.ErrorIs(t, err, sql.ErrNoRows)
+	_, err = store.GetAccount(ctx, -1)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+	_, err = store.GetAccountByNumber(ctx, "ID00SMPL0000000000")
+	require.ErrorIs(t, err, sql.ErrNoRows)
+	_, err = store.AddBalanceAccount(ctx, db.AddBalanceAccountParams{ID: -1, Amount: 10})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+	_, err = store.DeleteBeneficiary(ctx, -1)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	// lists are empty rather than missing
+	entries, err := store.FetchEntries(ctx, db.FetchEntriesParams{AccountID: -1, Limit: 5})
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
+
+func testForeignKeys(t *testing.T, store db.Store) {
+	ctx := context.Background()
+	account := createAccount(t, store, db.AccountTypeCurrent, 0)
+
+	_, err := store.CreateAccount(ctx, db.CreateAccountParams{
+		OwnerID:     uuid.New(),
+		Currency:    "USD",
+		AccountType: db.AccountTypeCurrent,
+	})
+	requireViolation(t, err, db.ErrForeignKeyViolation, "accounts_owner_id_fkey")
+
+	_, err = store.CreateEntry(ctx, db.CreateEntryParams{AccountID: -1, Amount: 10})
+	requireViolation(t, err, db.ErrForeignKeyViolation, "entries_account_id_fkey")
+
+	_, err = store.CreateTransfer(ctx, db.CreateTransferParams{
+		FromAccountID: account.ID,
+		ToAccountID:   -1,
+		Amount:        10,
+	})
+	requireViolation(t, err, db.ErrForeignKeyViolation, "transfers_to_account_id_fkey")
+}
+
+func testCheckConstraints(t *testing.T, store db.Store) {
+	ctx := context.Background()
+	account := createAccount(t, store, db.AccountTypeCurrent, 0)
+
+	_, err := store.UpdateAccountStatus(ctx, db.UpdateAccountStatusParams{ID: account.ID, Status: "lost"})
+	requireViolation(t, err, db.ErrCheckViolation, "accounts_status_check")
+
+	_, err = store.UpdateUserRole(ctx, db.UpdateUserRoleParams{ID: account.OwnerID, Role: "owner"})
+	requireViolation(t, err, db.ErrCheckViolation, "users_role_check")
+
+	_, err = store.CreatePaymentRequest(ctx, db.CreatePaymentRequestParams{
+		RequesterID: account.OwnerID,
+		PayerID:     account.OwnerID,
+		ToAccountID: account.ID,
+		Amount:      10,
+		Currency:    account.Currency,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	})
+	requireViolation(t, err, db.ErrCheckViolation, "payment_requests_payer_check")
+}
+
+func testTransferTx(t *testing.T, store db.Store) {
+	from := createAccount(t, store, db.AccountTypeCurrent, 1000)
+	to := createAccount(t, store, db.AccountTypeCurrent, 0)
+	to = sameCurrency(t, store, to, from.Currency)
+
+	result, err := store.TransferTx(context.Background(), db.TransferTxParams{
+		FromAccountID: from.ID,
+		ToAccountID:   to.ID,
+		Amount:        300,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(300), result.Transfer.Amount)
+	require.Equal(t, int64(-300), result.FromEntry.Amount)
+	require.Equal(t, int64(300), result.ToEntry.Amount)
+	require.Equal(t, from.Balance-300-result.Fee.Amount, result.FromAccount.Balance)
+	require.Equal(t, int64(300), result.ToAccount.Balance)
+
+	entry, err := store.GetEntry(context.Background(), result.ToEntry.ID)
+	require.NoError(t, err)
+	require.Equal(t, result.ToEntry, entry)
+
+	journals, err := store.ListJournalEntriesByReference(context.Background(), fmt.Sprintf("transfer:%d", result.Transfer.ID))
+	require.NoError(t, err)
+	require.Len(t, journals, 1)
+	lines, err := store.ListJournalLines(context.Background(), journals[0].ID)
+	require.NoError(t, err)
+	require.Len(t, lines, 2)
+	require.Zero(t, lines[0].Amount+lines[1].Amount)
+}
+
+// testTransferTxConcurrent moves money both ways between two accounts at
+// once, which must neither deadlock nor lose an update
+func testTransferTxConcurrent(t *testing.T, store db.Store) {
+	account1 := createAccount(t, store, db.AccountTypeCurrent, 10000)
+	account2 := createAccount(t, store, db.AccountTypeCurrent, 10000)
+	account2 = sameCurrency(t, store, account2, account1.Currency)
+
+	n := 10
+	amount := int64(10)
+	errs := make(chan error, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		from, to := account1.ID, account2.ID
+		if i%2 == 1 {
+			from, to = to, from
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := store.TransferTx(context.Background(), db.TransferTxParams{
+				FromAccountID: from,
+				ToAccountID:   to,
+				Amount:        amount,
+			})
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	updated1, err := store.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	updated2, err := store.GetAccount(context.Background(), account2.ID)
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance+account2.Balance, updated1.Balance+updated2.Balance)
+
+	transfers, err := store.FetchTransfer(context.Background(), db.FetchTransferParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account1.ID,
+		Limit:         int32(n + 1),
+	})
+	require.NoError(t, err)
+	require.Len(t, transfers, n)
+}
+
+// testTransferTxInsufficientFunds sends more than the source account holds,
+// which must be refused without writing anything
+func testTransferTxInsufficientFunds(t *testing.T, store db.Store) {
+	from := createAccount(t, store, db.AccountTypeCurrent, 0)
+	to := createAccount(t, store, db.AccountTypeCurrent, 0)
+	to = sameCurrency(t, store, to, from.Currency)
+
+	_, err := store.TransferTx(context.Background(), db.TransferTxParams{
+		FromAccountID: from.ID,
+		ToAccountID:   to.ID,
+		Amount:        500000,
+	})
+	var insufficient *db.InsufficientFundsError
+	require.ErrorAs(t, err, &insufficient)
+	require.Equal(t, from.ID, insufficient.AccountID)
+	require.Zero(t, insufficient.Balance)
+
+	for _, account := range []db.Account{from, to} {
+		got, err := store.GetAccount(context.Background(), account.ID)
+		require.NoError(t, err)
+		require.Zero(t, got.Balance)
+	}
+
+	transfers, err := store.FetchTransfer(context.Background(), db.FetchTransferParams{
+		FromAccountID: from.ID,
+		ToAccountID:   from.ID,
+		Limit:         5,
+	})
+	require.NoError(t, err)
+	require.Empty(t, transfers)
+}
+
+// testInternalTransferUsage moves money into a pot and has a transfer rounded
+// up into it, of which only the transfer counts towards the limits
+func testInternalTransferUsage(t *testing.T, store db.Store) {
+	ctx := context.Background()
+	since := time.Now().Add(-time.Minute)
+	from := createAccount(t, store, db.AccountTypeCurrent, 100000)
+	to := createAccount(t, store, db.AccountTypeCurrent, 0)
+	to = sameCurrency(t, store, to, from.Currency)
+
+	pot, err := store.CreatePotTx(ctx, db.CreatePotTxParams{
+		ParentAccountID: from.ID,
+		Name:            "Change",
+		RoundUpTo:       100,
+	})
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		_, err = store.MovePotTx(ctx, db.MovePotTxParams{
+			AccountID:    from.ID,
+			PotAccountID: pot.Account.ID,
+			Amount:       1,
+			Direction:    db.PotMoveIn,
+		})
+		require.NoError(t, err)
+	}
+
+	result, err := store.TransferTx(ctx, db.TransferTxParams{
+		FromAccountID: from.ID,
+		ToAccountID:   to.ID,
+		Amount:        1234,
+	})
+	require.NoError(t, err)
+	require.False(t, result.Transfer.Internal)
+	require.NotNil(t, result.RoundUp)
+	require.True(t, result.RoundUp.Internal)
+
+	usage, err := store.GetAccountTransferUsage(ctx, db.GetAccountTransferUsageParams{
+		AccountID: from.ID,
+		Since:     since,
+	})
+	require.NoError(t, err)
+	require.EqualValues(t, 1, usage.Count)
+	require.EqualValues(t, 1234, usage.Total)
+
+	ownerUsage, err := store.GetOwnerTransferUsage(ctx, db.GetOwnerTransferUsageParams{
+		OwnerID:  from.OwnerID,
+		Currency: from.Currency,
+		Since:    since,
+	})
+	require.NoError(t, err)
+	require.EqualValues(t, 1, ownerUsage.Count)
+	require.EqualValues(t, 1234, ownerUsage.Total)
+}
+
+// testTransferTxRollback fails a transfer after its rows are written, when
+// booking it finds no GL account for the destination, and checks none of
+// them is left
+func testTransferTxRollback(t *testing.T, store db.Store) {
+	from := createAccount(t, store, db.AccountTypeCurrent, 1000)
+	to := createAccount(t, store, "unmapped", 0)
+	to = sameCurrency(t, store, to, from.Currency)
+
+	_, err := store.TransferTx(context.Background(), db.TransferTxParams{
+		FromAccountID: from.ID,
+		ToAccountID:   to.ID,
+		Amount:        300,
+	})
+	require.Error(t, err)
+
+	for _, account := range []db.Account{from, to} {
+		got, err := store.GetAccount(context.Background(), account.ID)
+		require.NoError(t, err)
+		require.Equal(t, account.Balance, got.Balance)
+
+		entries, err := store.FetchEntries(context.Background(), db.FetchEntriesParams{AccountID: account.ID, Limit: 5})
+		require.NoError(t, err)
+		require.Empty(t, entries)
+	}
+
+	transfers, err := store.FetchTransfer(context.Background(), db.FetchTransferParams{
+		FromAccountID: from.ID,
+		ToAccountID:   from.ID,
+		Limit:         5,
+	})
+	require.NoError(t, err)
+	require.Empty(t, transfers)
+}
+
+func testHolders(t *testing.T, store db.Store) {
+	ctx := context.Background()
+	account := createAccount(t, store, db.AccountTypeBusiness, 0)
+	user := createUser(t, store)
+
+	arg := db.CreateAccountHolderParams{
+		AccountID:   account.ID,
+		UserID:      user.ID,
+		CanInitiate: true,
+		SpendLimit:  100,
+	}
+	holder, err := store.CreateAccountHolder(ctx, arg)
+	require.NoError(t, err)
+	_, err = store.CreateAccountHolder(ctx, arg)
+	requireViolation(t, err, db.ErrUniqueViolation, "account_holders_pkey")
+
+	// held accounts are listed with the holder's own
+	accounts, err := store.FetchAccountsByOwner(ctx, db.FetchAccountsByOwnerParams{OwnerID: user.ID, Limit: 5})
+	require.NoError(t, err)
+	require.Len(t, accounts, 1)
+	require.Equal(t, account.ID, accounts[0].ID)
+
+	deleted, err := store.DeleteAccountHolder(ctx, db.DeleteAccountHolderParams{AccountID: account.ID, UserID: user.ID})
+	require.NoError(t, err)
+	require.Equal(t, holder.UserID, deleted.UserID)
+	_, err = store.GetAccountHolder(ctx, db.GetAccountHolderParams{AccountID: account.ID, UserID: user.ID})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func testBeneficiaries(t *testing.T, store db.Store) {
+	ctx := context.Background()
+	owner := createUser(t, store)
+	account1 := createAccount(t, store, db.AccountTypeCurrent, 0)
+	account2 := createAccount(t, store, db.AccountTypeCurrent, 0)
+
+	create := func(nickname string, account db.Account) (db.Beneficiary, error) {
+		return store.CreateBeneficiary(ctx, db.CreateBeneficiaryParams{
+			OwnerID:         owner.ID,
+			Nickname:        nickname,
+			AccountID:       account.ID,
+			Currency:        account.Currency,
+			CoolingOffUntil: time.Now(),
+		})
+	}
+	_, err := create("zed", account1)
+	require.NoError(t, err)
+	_, err = create("zed", account2)
+	requireViolation(t, err, db.ErrUniqueViolation, "owner_id_nickname_key")
+	_, err = create("amy", account1)
+	requireViolation(t, err, db.ErrUniqueViolation, "owner_id_account_id_key")
+	_, err = create("amy", account2)
+	require.NoError(t, err)
+
+	beneficiaries, err := store.ListBeneficiaries(ctx, owner.ID)
+	require.NoError(t, err)
+	require.Len(t, beneficiaries, 2)
+	require.Equal(t, "amy", beneficiaries[0].Nickname)
+	require.Equal(t, "zed", beneficiaries[1].Nickname)
+}
+
+// testBeneficiaryCoolingOff pays a payee saved by the owner of the source
+// account, named by account id, while it is cooling off. The payments
+// share the new payee limit, also when they are made at once.
+func testBeneficiaryCoolingOff(t *testing.T, store db.Store) {
+	ctx := context.Background()
+	from := createAccount(t, store, db.AccountTypeCurrent, 0)
+	limit, err := store.GetTransferLimit(ctx, db.GetTransferLimitParams{Tier: from.Tier, Currency: from.Currency})
+	require.NoError(t, err)
+	newPayeeMax := limit.NewPayeeMaxPerTransfer
+	require.NotZero(t, newPayeeMax)
+	from, err = store.UpdateBalanceAccount(ctx, db.UpdateBalanceAccountParams{ID: from.ID, Balance: 10 * newPayeeMax})
+	require.NoError(t, err)
+
+	save := func() db.Account {
+		to := createAccount(t, store, db.AccountTypeCurrent, 0)
+		to = sameCurrency(t, store, to, from.Currency)
+		_, err := store.CreateBeneficiary(ctx, db.CreateBeneficiaryParams{
+			OwnerID:         from.OwnerID,
+			Nickname:        util.GenRandomString(8),
+			AccountID:       to.ID,
+			Currency:        to.Currency,
+			CoolingOffUntil: time.Now().Add(time.Hour),
+		})
+		require.NoError(t, err)
+		return to
+	}
+	pay := func(to db.Account, amount int64) error {
+		_, err := store.TransferTx(ctx, db.TransferTxParams{
+			FromAccountID: from.ID,
+			ToAccountID:   to.ID,
+			Amount:        amount,
+		})
+		return err
+	}
+	requireNewPayeeLimit := func(err error) {
+		var limitErr *db.TransferLimitError
+		require.ErrorAs(t, err, &limitErr)
+		require.Equal(t, db.LimitNewPayee, limitErr.Limit)
+		require.Equal(t, newPayeeMax, limitErr.Max)
+	}
+
+	to := save()
+	requireNewPayeeLimit(pay(to, newPayeeMax+1))
+	require.NoError(t, pay(to, newPayeeMax/2))
+	requireNewPayeeLimit(pay(to, newPayeeMax-newPayeeMax/2+1))
+	require.NoError(t, pay(to, newPayeeMax-newPayeeMax/2))
+
+	// an account nobody saved is not capped
+	other := createAccount(t, store, db.AccountTypeCurrent, 0)
+	other = sameCurrency(t, store, other, from.Currency)
+	require.NoError(t, pay(other, newPayeeMax+1))
+
+	// of two payments that each fit but not together, one goes through
+	to = save()
+	errs := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			errs <- pay(to, newPayeeMax/2+1)
+		}()
+	}
+	var failed int
+	for i := 0; i < 2; i++ {
+		if err := <-errs; err != nil {
+			requireNewPayeeLimit(err)
+			failed++
+		}
+	}
+	require.Equal(t, 1, failed)
+}
+
+func testIdempotencyKeys(t *testing.T, store db.Store) {
+	ctx := context.Background()
+	user := createUser(t, store)
+	key := db.GetIdempotencyKeyParams{UserID: user.ID, Key: uuid.NewString()}
+
+	created, err := store.CreateIdempotencyKey(ctx, db.CreateIdempotencyKeyParams{
+		UserID:      key.UserID,
+		Key:         key.Key,
+		RequestHash: "hash",
+	})
+	require.NoError(t, err)
+	require.Zero(t, created.StatusCode)
+	require.Empty(t, created.ResponseBody)
+
+	_, err = store.CreateIdempotencyKey(ctx, db.CreateIdempotencyKeyParams{
+		UserID:      key.UserID,
+		Key:         key.Key,
+		RequestHash: "other",
+	})
+	requireViolation(t, err, db.ErrUniqueViolation, "idempotency_keys_pkey")
+
+	_, err = store.CreateIdempotencyKey(ctx, db.CreateIdempotencyKeyParams{
+		UserID:      uuid.New(),
+		Key:         key.Key,
+		RequestHash: "hash",
+	})
+	requireViolation(t, err, db.ErrForeignKeyViolation, "idempotency_keys_user_id_fkey")
+
+	// an unanswered key is only taken over once it was claimed long enough ago
+	_, err = store.TakeOverIdempotencyKey(ctx, db.TakeOverIdempotencyKeyParams{
+		UserID:        key.UserID,
+		Key:           key.Key,
+		StartedBefore: created.CreatedAt,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+	taken, err := store.TakeOverIdempotencyKey(ctx, db.TakeOverIdempotencyKeyParams{
+		UserID:        key.UserID,
+		Key:           key.Key,
+		StartedBefore: created.CreatedAt.Add(time.Second),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "hash", taken.RequestHash)
+	require.False(t, taken.CreatedAt.Before(created.CreatedAt))
+
+	saved, err := store.SaveIdempotentResponse(ctx, db.SaveIdempotentResponseParams{
+		UserID:       key.UserID,
+		Key:          key.Key,
+		StatusCode:   201,
+		ContentType:  "application/json",
+		ResponseBody: []byte(`{"id":1}`),
+	})
+	require.NoError(t, err)
+
+	got, err := store.GetIdempotencyKey(ctx, key)
+	require.NoError(t, err)
+	require.Equal(t, "hash", got.RequestHash)
+	require.EqualValues(t, 201, got.StatusCode)
+	require.Equal(t, "application/json", got.ContentType)
+	require.Equal(t, saved.ResponseBody, got.ResponseBody)
+
+	// an answered key is never taken over
+	_, err = store.TakeOverIdempotencyKey(ctx, db.TakeOverIdempotencyKeyParams{
+		UserID:        key.UserID,
+		Key:           key.Key,
+		StartedBefore: time.Now().Add(time.Hour),
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	require.NoError(t, store.DeleteIdempotencyKey(ctx, db.DeleteIdempotencyKeyParams(key)))
+	_, err = store.GetIdempotencyKey(ctx, key)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	// expired keys are swept, answered or not
+	expired, err := store.CreateIdempotencyKey(ctx, db.CreateIdempotencyKeyParams{
+		UserID:      user.ID,
+		Key:         uuid.NewString(),
+		RequestHash: "hash",
+	})
+	require.NoError(t, err)
+	_, err = store.DeleteIdempotencyKeysBefore(ctx, expired.CreatedAt)
+	require.NoError(t, err)
+	_, err = store.GetIdempotencyKey(ctx, db.GetIdempotencyKeyParams{UserID: user.ID, Key: expired.Key})
+	require.NoError(t, err)
+
+	deleted, err := store.DeleteIdempotencyKeysBefore(ctx, expired.CreatedAt.Add(time.Second))
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, deleted, int64(1))
+	_, err = store.GetIdempotencyKey(ctx, db.GetIdempotencyKeyParams{UserID: user.ID, Key: expired.Key})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func testInterestAccrual(t *testing.T, store db.Store) {
+	ctx := context.Background()
+	account := createAccount(t, store, db.AccountTypeSavings, 1000)
+	day := time.Date(2023, 3, 14, 15, 0, 0, 0, time.UTC)
+
+	arg := db.CreateInterestAccrualParams{
+		AccountID:    account.ID,
+		AccrualDate:  day,
+		Balance:      account.Balance,
+		RateBps:      250,
+		DayCount:     util.DayCountACT365,
+		AmountMicros: 68493,
+	}
+	accrual, err := store.CreateInterestAccrual(ctx, arg)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2023, 3, 14, 0, 0, 0, 0, time.UTC), accrual.AccrualDate.UTC())
+
+	// a date already accrued is skipped
+	arg.AccrualDate = day.Add(time.Hour)
+	_, err = store.CreateInterestAccrual(ctx, arg)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	pending, err := store.GetPendingInterest(ctx, db.GetPendingInterestParams{AccountID: account.ID, PeriodEnd: day})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), pending.Count)
+	require.Equal(t, arg.AmountMicros, pending.TotalMicros)
+
+	pending, err = store.GetPendingInterest(ctx, db.GetPendingInterestParams{AccountID: account.ID, PeriodEnd: day.AddDate(0, 0, -1)})
+	require.NoError(t, err)
+	require.Zero(t, pending.Count)
+}
+
+// sameCurrency returns account in currency, opening a new account of its
+// owner when it is in another one
+func sameCurrency(t *testing.T, store db.Store, account db.Account, currency string) db.Account {
+	if account.Currency == currency {
+		return account
+	}
+	moved, err := store.CreateAccount(context.Background(), db.CreateAccountParams{
+		OwnerID:     account.OwnerID,
+		Currency:    currency,
+		AccountType: account.AccountType,
+	})
+	require.NoError(t, err)
+	moved, err = store.UpdateBalanceAccount(context.Background(), db.UpdateBalanceAccountParams{
+		ID:      moved.ID,
+		Balance: account.Balance,
+	})
+	require.NoError(t, err)
+	return moved
+}
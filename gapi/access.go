@@ -0,0 +1,50 @@
+package gapi
+
+import (
+	"context"
+
+	db "github.com/flukis/simplebank/db/sqlc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// accountAccess returns what the authenticated user may do with account,
+// decided as for the REST API
+func (s *Server) accountAccess(ctx context.Context, account db.Account) (db.AccountAccess, error) {
+	payload := authPayload(ctx)
+	access, err := db.GetAccountAccess(ctx, s.store, account, payload.UserID, payload.Role)
+	if err != nil {
+		return access, storeError(err)
+	}
+	return access, nil
+}
+
+// canViewAccount checks that the authenticated user may read account, as
+// its owner, a member of staff or one of its holders
+func (s *Server) canViewAccount(ctx context.Context, account db.Account) error {
+	access, err := s.accountAccess(ctx, account)
+	if err != nil {
+		return err
+	}
+	if !access.Can(db.PermView) {
+		return status.Error(codes.PermissionDenied, errAccountNotOwned.Error())
+	}
+	return nil
+}
+
+// canMoveMoney checks that the authenticated user may move amount out of
+// account without approval. Transfers above a holder's spend limit need to
+// be made through the REST API, which files them for approval.
+func (s *Server) canMoveMoney(ctx context.Context, account db.Account, amount int64) error {
+	access, err := s.accountAccess(ctx, account)
+	if err != nil {
+		return err
+	}
+	if !access.Can(db.PermInitiate) {
+		return status.Error(codes.PermissionDenied, errAccountNotOwned.Error())
+	}
+	if access.NeedsApproval(amount) {
+		return status.Error(codes.PermissionDenied, "the amount is above the spend limit of the authenticated user on this account")
+	}
+	return nil
+}
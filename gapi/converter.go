@@ -0,0 +1,42 @@
+package gapi
+
+import (
+	db "github.com/flukis/simplebank/db/sqlc"
+	"github.com/flukis/simplebank/pb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func convertUser(user db.User) *pb.User {
+	return &pb.User{
+		Id:        user.ID.String(),
+		Username:  user.Username,
+		FullName:  user.FullName,
+		Email:     user.Email,
+		Role:      user.Role,
+		CreatedAt: timestamppb.New(user.CreatedAt),
+	}
+}
+
+func convertAccount(account db.Account) *pb.Account {
+	return &pb.Account{
+		Id:          account.ID,
+		OwnerId:     account.OwnerID.String(),
+		Balance:     account.Balance,
+		Currency:    account.Currency,
+		Tier:        account.Tier,
+		AccountType: account.AccountType,
+		Status:      account.Status,
+		Number:      account.Number,
+		CreatedAt:   timestamppb.New(account.CreatedAt),
+	}
+}
+
+func convertTransfer(transfer db.Transfer) *pb.Transfer {
+	return &pb.Transfer{
+		Id:            transfer.ID,
+		FromAccountId: transfer.FromAccountID,
+		ToAccountId:   transfer.ToAccountID,
+		Amount:        transfer.Amount,
+		CreatedAt:     timestamppb.New(transfer.CreatedAt),
+	}
+}
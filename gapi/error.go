@@ -0,0 +1,45 @@
+package gapi
+
+import (
+	"database/sql"
+	"errors"
+
+	db "github.com/flukis/simplebank/db/sqlc"
+	"github.com/flukis/simplebank/util"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var errAccountNotOwned = errors.New("account doesn't belong to the authenticated user")
+
+// storeError maps an error of the store to the status the REST API answers
+// it with: missing rows are not found, malformed account numbers are
+// invalid, broken business rules and unique constraints are refused,
+// anything else is internal
+func storeError(err error) error {
+	if err == sql.ErrNoRows {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	switch {
+	case errors.Is(err, db.ErrUniqueViolation):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, db.ErrForeignKeyViolation):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, util.ErrAccountNumberFormat), errors.Is(err, util.ErrAccountNumberCheck):
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	var (
+		statusErr *db.AccountStatusError
+		limitErr  *db.TransferLimitError
+		fundsErr  *db.InsufficientFundsError
+	)
+	if errors.As(err, &statusErr) || errors.As(err, &limitErr) || errors.As(err, &fundsErr) || errors.Is(err, db.ErrPotTransfer) {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+func invalidArgument(err error) error {
+	return status.Error(codes.InvalidArgument, err.Error())
+}
@@ -0,0 +1,69 @@
+package gapi
+
+import (
+	"context"
+	"strings"
+
+	"github.com/flukis/simplebank/pb"
+	"github.com/flukis/simplebank/util"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const authorizationHeader = "authorization"
+
+type payloadKey struct{}
+
+// publicMethods can be called without an access token
+var publicMethods = map[string]bool{
+	pb.SimpleBank_CreateUser_FullMethodName: true,
+	pb.SimpleBank_LoginUser_FullMethodName:  true,
+}
+
+// AuthInterceptor verifies the bearer token in the authorization metadata
+// of every call but the public ones, and hands its payload to the method
+func (s *Server) AuthInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if publicMethods[info.FullMethod] {
+		return handler(ctx, req)
+	}
+
+	payload, err := s.authorize(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return handler(context.WithValue(ctx, payloadKey{}, payload), req)
+}
+
+func (s *Server) authorize(ctx context.Context) (*util.Payload, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "missing metadata")
+	}
+
+	values := md.Get(authorizationHeader)
+	if len(values) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "missing authorization header")
+	}
+
+	fields := strings.Fields(values[0])
+	if len(fields) < 2 || strings.ToLower(fields[0]) != "bearer" {
+		return nil, status.Error(codes.Unauthenticated, "invalid authorization header format")
+	}
+
+	payload, err := s.tokenMaker.VerifyToken(fields[1])
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid access token: %s", err)
+	}
+	return payload, nil
+}
+
+// authPayload returns the payload AuthInterceptor verified
+func authPayload(ctx context.Context) *util.Payload {
+	payload, ok := ctx.Value(payloadKey{}).(*util.Payload)
+	if !ok {
+		return nil
+	}
+	return payload
+}
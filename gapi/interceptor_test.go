@@ -0,0 +1,135 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	mocks "github.com/flukis/simplebank/db/mock"
+	db "github.com/flukis/simplebank/db/sqlc"
+	"github.com/flukis/simplebank/pb"
+	"github.com/flukis/simplebank/util"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// newTestClient serves a Server of store over an in-memory connection and
+// returns a client of it
+func newTestClient(t *testing.T, store db.Store) (pb.SimpleBankClient, *Server) {
+	server, err := NewServer(store, util.Config{
+		TokenSymetricKey:    "12345678901234567890123456789012",
+		AccessTokenDuration: time.Minute,
+	})
+	require.NoError(t, err)
+
+	listener := bufconn.Listen(1024 * 1024)
+	grpcServer := server.GRPCServer()
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.Dial(
+		"bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return pb.NewSimpleBankClient(conn), server
+}
+
+func withAuthorization(t *testing.T, tokenMaker util.JWTMaker, authType string, userID uuid.UUID, role string, duration time.Duration) context.Context {
+	token, payload, err := tokenMaker.CreateToken(userID, "user", role, duration)
+	require.NoError(t, err)
+	require.NotEmpty(t, payload)
+
+	return metadata.AppendToOutgoingContext(context.Background(), authorizationHeader, fmt.Sprintf("%s %s", authType, token))
+}
+
+func TestAuthInterceptor(t *testing.T) {
+	account := randomAccount()
+
+	testCases := []struct {
+		name    string
+		context func(t *testing.T, tokenMaker util.JWTMaker) context.Context
+		build   func(store *mocks.Store)
+		code    codes.Code
+	}{
+		{
+			name: "OK",
+			context: func(t *testing.T, tokenMaker util.JWTMaker) context.Context {
+				return withAuthorization(t, tokenMaker, "Bearer", account.OwnerID, util.RoleCustomer, time.Minute)
+			},
+			build: func(store *mocks.Store) {
+				store.On("GetAccount", mock.Anything, account.ID).
+					Return(account, nil).
+					Once()
+			},
+			code: codes.OK,
+		},
+		{
+			name: "NoAuthorization",
+			context: func(t *testing.T, tokenMaker util.JWTMaker) context.Context {
+				return context.Background()
+			},
+			build: func(store *mocks.Store) {},
+			code:  codes.Unauthenticated,
+		},
+		{
+			name: "UnsupportedAuthorization",
+			context: func(t *testing.T, tokenMaker util.JWTMaker) context.Context {
+				return withAuthorization(t, tokenMaker, "Basic", account.OwnerID, util.RoleCustomer, time.Minute)
+			},
+			build: func(store *mocks.Store) {},
+			code:  codes.Unauthenticated,
+		},
+		{
+			name: "ExpiredToken",
+			context: func(t *testing.T, tokenMaker util.JWTMaker) context.Context {
+				return withAuthorization(t, tokenMaker, "Bearer", account.OwnerID, util.RoleCustomer, -time.Minute)
+			},
+			build: func(store *mocks.Store) {},
+			code:  codes.Unauthenticated,
+		},
+	}
+
+	for i := range testCases {
+		ts := testCases[i]
+
+		t.Run(ts.name, func(t *testing.T) {
+			store := &mocks.Store{}
+			ts.build(store)
+
+			client, server := newTestClient(t, store)
+			_, err := client.GetAccount(ts.context(t, server.tokenMaker), &pb.GetAccountRequest{Account: &pb.GetAccountRequest_Id{Id: account.ID}})
+			require.Equal(t, ts.code, status.Code(err))
+			store.AssertExpectations(t)
+		})
+	}
+}
+
+func randomAccount() db.Account {
+	id := util.GenRandomNum(1, 10000)
+	// ten digits never fail
+	number, _ := util.NewAccountNumber(fmt.Sprintf("%010d", id))
+	return db.Account{
+		ID:          id,
+		Number:      number,
+		OwnerID:     uuid.New(),
+		Balance:     util.GenRandomMoney(),
+		Currency:    util.GenRandomCurrency(),
+		AccountType: db.AccountTypeCurrent,
+		Status:      db.AccountStatusActive,
+	}
+}
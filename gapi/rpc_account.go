@@ -0,0 +1,126 @@
+package gapi
+
+import (
+	"context"
+
+	db "github.com/flukis/simplebank/db/sqlc"
+	"github.com/flukis/simplebank/pb"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func validateCreateAccountRequest(req *pb.CreateAccountRequest) error {
+	return validation.Errors{
+		"owner_id":     validation.Validate(req.GetOwnerId(), validation.Required, is.UUID),
+		"currency":     validation.Validate(req.GetCurrency(), validation.Required, validation.In("USD", "EUR", "IDR")),
+		"account_type": validation.Validate(req.GetAccountType(), validation.In(db.AccountTypeCurrent, db.AccountTypeSavings, db.AccountTypeBusiness)),
+	}.Filter()
+}
+
+func (s *Server) CreateAccount(ctx context.Context, req *pb.CreateAccountRequest) (*pb.CreateAccountResponse, error) {
+	if err := validateCreateAccountRequest(req); err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	ownerID := uuid.MustParse(req.GetOwnerId())
+	payload := authPayload(ctx)
+	if !db.CanOpenAccountFor(payload.UserID, payload.Role, ownerID) {
+		return nil, status.Error(codes.PermissionDenied, errAccountNotOwned.Error())
+	}
+
+	accountType := req.GetAccountType()
+	if accountType == "" {
+		accountType = db.AccountTypeCurrent
+	}
+
+	account, err := s.store.CreateAccount(ctx, db.CreateAccountParams{
+		OwnerID:     ownerID,
+		Currency:    req.GetCurrency(),
+		AccountType: accountType,
+	})
+	if err != nil {
+		return nil, storeError(err)
+	}
+
+	return &pb.CreateAccountResponse{
+		Account: convertAccount(account),
+	}, nil
+}
+
+func validateGetAccountRequest(req *pb.GetAccountRequest) error {
+	return validation.Errors{
+		"account": validation.Validate(req.GetAccount(), validation.Required),
+		"id":      validation.Validate(req.GetId(), validation.Min(int64(1))),
+	}.Filter()
+}
+
+func (s *Server) GetAccount(ctx context.Context, req *pb.GetAccountRequest) (*pb.GetAccountResponse, error) {
+	if err := validateGetAccountRequest(req); err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	account, err := s.getAccount(ctx, req.GetId(), req.GetNumber())
+	if err != nil {
+		return nil, err
+	}
+	if err = s.canViewAccount(ctx, account); err != nil {
+		return nil, err
+	}
+
+	return &pb.GetAccountResponse{
+		Account: convertAccount(account),
+	}, nil
+}
+
+// getAccount returns the account a request names by its id or, when set,
+// by its number, checked and looked up as by the REST API
+func (s *Server) getAccount(ctx context.Context, id int64, number string) (db.Account, error) {
+	var (
+		account db.Account
+		err     error
+	)
+	if number != "" {
+		account, err = db.GetAccountByCheckedNumber(ctx, s.store, number)
+	} else {
+		account, err = s.store.GetAccount(ctx, id)
+	}
+	if err != nil {
+		return account, storeError(err)
+	}
+	return account, nil
+}
+
+func validateListAccountsRequest(req *pb.ListAccountsRequest) error {
+	return validation.Errors{
+		"page":  validation.Validate(req.GetPage(), validation.Required, validation.Min(int32(1))),
+		"limit": validation.Validate(req.GetLimit(), validation.Required, validation.Min(int32(5)), validation.Max(int32(50)), validation.MultipleOf(int32(5))),
+	}.Filter()
+}
+
+// ListAccounts returns a page of the accounts the authenticated user owns or
+// holds
+func (s *Server) ListAccounts(ctx context.Context, req *pb.ListAccountsRequest) (*pb.ListAccountsResponse, error) {
+	if err := validateListAccountsRequest(req); err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	accounts, err := s.store.FetchAccountsByOwner(ctx, db.FetchAccountsByOwnerParams{
+		OwnerID: authPayload(ctx).UserID,
+		Limit:   req.GetLimit(),
+		Offset:  (req.GetPage() - 1) * req.GetLimit(),
+	})
+	if err != nil {
+		return nil, storeError(err)
+	}
+
+	res := &pb.ListAccountsResponse{
+		Accounts: make([]*pb.Account, len(accounts)),
+	}
+	for i, account := range accounts {
+		res.Accounts[i] = convertAccount(account)
+	}
+	return res, nil
+}
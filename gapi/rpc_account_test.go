@@ -0,0 +1,263 @@
+package gapi
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	mocks "github.com/flukis/simplebank/db/mock"
+	db "github.com/flukis/simplebank/db/sqlc"
+	"github.com/flukis/simplebank/pb"
+	"github.com/flukis/simplebank/util"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCreateAccountRPC(t *testing.T) {
+	account := randomAccount()
+
+	testCases := []struct {
+		name   string
+		req    *pb.CreateAccountRequest
+		userID uuid.UUID
+		role   string
+		build  func(store *mocks.Store)
+		code   codes.Code
+	}{
+		{
+			name: "OK",
+			req: &pb.CreateAccountRequest{
+				OwnerId:  account.OwnerID.String(),
+				Currency: account.Currency,
+			},
+			userID: account.OwnerID,
+			role:   util.RoleCustomer,
+			build: func(store *mocks.Store) {
+				store.On("CreateAccount", mock.Anything, db.CreateAccountParams{
+					OwnerID:     account.OwnerID,
+					Currency:    account.Currency,
+					AccountType: db.AccountTypeCurrent,
+				}).
+					Return(account, nil).
+					Once()
+			},
+			code: codes.OK,
+		},
+		{
+			name: "ForAnotherUser",
+			req: &pb.CreateAccountRequest{
+				OwnerId:  account.OwnerID.String(),
+				Currency: account.Currency,
+			},
+			userID: uuid.New(),
+			role:   util.RoleCustomer,
+			build:  func(store *mocks.Store) {},
+			code:   codes.PermissionDenied,
+		},
+		{
+			name: "ByTeller",
+			req: &pb.CreateAccountRequest{
+				OwnerId:     account.OwnerID.String(),
+				Currency:    account.Currency,
+				AccountType: db.AccountTypeSavings,
+			},
+			userID: uuid.New(),
+			role:   util.RoleTeller,
+			build: func(store *mocks.Store) {
+				store.On("CreateAccount", mock.Anything, db.CreateAccountParams{
+					OwnerID:     account.OwnerID,
+					Currency:    account.Currency,
+					AccountType: db.AccountTypeSavings,
+				}).
+					Return(account, nil).
+					Once()
+			},
+			code: codes.OK,
+		},
+		{
+			name: "InvalidCurrency",
+			req: &pb.CreateAccountRequest{
+				OwnerId:  account.OwnerID.String(),
+				Currency: "XYZ",
+			},
+			userID: account.OwnerID,
+			role:   util.RoleCustomer,
+			build:  func(store *mocks.Store) {},
+			code:   codes.InvalidArgument,
+		},
+	}
+
+	for i := range testCases {
+		ts := testCases[i]
+
+		t.Run(ts.name, func(t *testing.T) {
+			store := &mocks.Store{}
+			ts.build(store)
+
+			client, server := newTestClient(t, store)
+			ctx := withAuthorization(t, server.tokenMaker, "Bearer", ts.userID, ts.role, time.Minute)
+			_, err := client.CreateAccount(ctx, ts.req)
+			require.Equal(t, ts.code, status.Code(err))
+			store.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetAccountRPC(t *testing.T) {
+	account := randomAccount()
+	// a single digit off, so the check digits no longer match
+	last := account.Number[len(account.Number)-1]
+	mistyped := account.Number[:len(account.Number)-1] + string('0'+(last-'0'+1)%10)
+
+	testCases := []struct {
+		name   string
+		req    *pb.GetAccountRequest
+		userID uuid.UUID
+		build  func(store *mocks.Store)
+		code   codes.Code
+	}{
+		{
+			name:   "ByID",
+			req:    &pb.GetAccountRequest{Account: &pb.GetAccountRequest_Id{Id: account.ID}},
+			userID: account.OwnerID,
+			build: func(store *mocks.Store) {
+				store.On("GetAccount", mock.Anything, account.ID).
+					Return(account, nil).
+					Once()
+			},
+			code: codes.OK,
+		},
+		{
+			name:   "ByNumber",
+			req:    &pb.GetAccountRequest{Account: &pb.GetAccountRequest_Number{Number: account.Number[:4] + " " + account.Number[4:]}},
+			userID: account.OwnerID,
+			build: func(store *mocks.Store) {
+				store.On("GetAccountByNumber", mock.Anything, account.Number).
+					Return(account, nil).
+					Once()
+			},
+			code: codes.OK,
+		},
+		{
+			name:   "MistypedNumber",
+			req:    &pb.GetAccountRequest{Account: &pb.GetAccountRequest_Number{Number: mistyped}},
+			userID: account.OwnerID,
+			build:  func(store *mocks.Store) {},
+			code:   codes.InvalidArgument,
+		},
+		{
+			name:   "NumberNotFound",
+			req:    &pb.GetAccountRequest{Account: &pb.GetAccountRequest_Number{Number: account.Number}},
+			userID: account.OwnerID,
+			build: func(store *mocks.Store) {
+				store.On("GetAccountByNumber", mock.Anything, account.Number).
+					Return(db.Account{}, sql.ErrNoRows).
+					Once()
+			},
+			code: codes.NotFound,
+		},
+		{
+			name:   "NoAccount",
+			req:    &pb.GetAccountRequest{},
+			userID: account.OwnerID,
+			build:  func(store *mocks.Store) {},
+			code:   codes.InvalidArgument,
+		},
+		{
+			name:   "NotOwned",
+			req:    &pb.GetAccountRequest{Account: &pb.GetAccountRequest_Number{Number: account.Number}},
+			userID: uuid.New(),
+			build: func(store *mocks.Store) {
+				store.On("GetAccountByNumber", mock.Anything, account.Number).
+					Return(account, nil).
+					Once()
+				store.On("GetAccountHolder", mock.Anything, mock.Anything).
+					Return(db.AccountHolder{}, sql.ErrNoRows).
+					Once()
+			},
+			code: codes.PermissionDenied,
+		},
+	}
+
+	for i := range testCases {
+		ts := testCases[i]
+
+		t.Run(ts.name, func(t *testing.T) {
+			store := &mocks.Store{}
+			ts.build(store)
+
+			client, server := newTestClient(t, store)
+			ctx := withAuthorization(t, server.tokenMaker, "Bearer", ts.userID, util.RoleCustomer, time.Minute)
+			res, err := client.GetAccount(ctx, ts.req)
+			require.Equal(t, ts.code, status.Code(err))
+			if err == nil {
+				require.Equal(t, account.ID, res.GetAccount().GetId())
+			}
+			store.AssertExpectations(t)
+		})
+	}
+}
+
+func TestListAccountsRPC(t *testing.T) {
+	owner := uuid.New()
+	accounts := make([]db.Account, 5)
+	for i := range accounts {
+		accounts[i] = randomAccount()
+		accounts[i].OwnerID = owner
+	}
+
+	testCases := []struct {
+		name  string
+		req   *pb.ListAccountsRequest
+		build func(store *mocks.Store)
+		code  codes.Code
+	}{
+		{
+			name: "OK",
+			req:  &pb.ListAccountsRequest{Page: 2, Limit: 5},
+			build: func(store *mocks.Store) {
+				store.On("FetchAccountsByOwner", mock.Anything, db.FetchAccountsByOwnerParams{
+					OwnerID: owner,
+					Limit:   5,
+					Offset:  5,
+				}).
+					Return(accounts, nil).
+					Once()
+			},
+			code: codes.OK,
+		},
+		{
+			name:  "InvalidPage",
+			req:   &pb.ListAccountsRequest{Page: 0, Limit: 5},
+			build: func(store *mocks.Store) {},
+			code:  codes.InvalidArgument,
+		},
+		{
+			name:  "InvalidLimit",
+			req:   &pb.ListAccountsRequest{Page: 1, Limit: 7},
+			build: func(store *mocks.Store) {},
+			code:  codes.InvalidArgument,
+		},
+	}
+
+	for i := range testCases {
+		ts := testCases[i]
+
+		t.Run(ts.name, func(t *testing.T) {
+			store := &mocks.Store{}
+			ts.build(store)
+
+			client, server := newTestClient(t, store)
+			ctx := withAuthorization(t, server.tokenMaker, "Bearer", owner, util.RoleCustomer, time.Minute)
+			res, err := client.ListAccounts(ctx, ts.req)
+			require.Equal(t, ts.code, status.Code(err))
+			if err == nil {
+				require.Len(t, res.GetAccounts(), len(accounts))
+			}
+			store.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+
+	db "github.com/flukis/simplebank/db/sqlc"
+	"github.com/flukis/simplebank/pb"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+func validateCreateTransferRequest(req *pb.CreateTransferRequest) error {
+	return validation.Errors{
+		"from_account":    validation.Validate(req.GetFromAccount(), validation.Required),
+		"from_account_id": validation.Validate(req.GetFromAccountId(), validation.Min(int64(1))),
+		"to_account":      validation.Validate(req.GetToAccount(), validation.Required),
+		"to_account_id":   validation.Validate(req.GetToAccountId(), validation.Min(int64(1))),
+		"currency":        validation.Validate(req.GetCurrency(), validation.Required, validation.In("USD", "EUR", "IDR")),
+		"amount":          validation.Validate(req.GetAmount(), validation.Required, validation.Min(int64(1))),
+	}.Filter()
+}
+
+// CreateTransfer moves money between two accounts of the same currency. The
+// transfer is checked and charged by the store as for the REST API.
+func (s *Server) CreateTransfer(ctx context.Context, req *pb.CreateTransferRequest) (*pb.CreateTransferResponse, error) {
+	if err := validateCreateTransferRequest(req); err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	from, err := s.validAccount(ctx, req.GetFromAccountId(), req.GetFromAccountNumber(), req.GetCurrency())
+	if err != nil {
+		return nil, err
+	}
+	if err = s.canMoveMoney(ctx, from, req.GetAmount()); err != nil {
+		return nil, err
+	}
+	to, err := s.validAccount(ctx, req.GetToAccountId(), req.GetToAccountNumber(), req.GetCurrency())
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.store.TransferTx(ctx, db.TransferTxParams{
+		FromAccountID: from.ID,
+		ToAccountID:   to.ID,
+		Amount:        req.GetAmount(),
+	})
+	if err != nil {
+		return nil, storeError(err)
+	}
+
+	return &pb.CreateTransferResponse{
+		Transfer:    convertTransfer(result.Transfer),
+		FromAccount: convertAccount(result.FromAccount),
+		ToAccount:   convertAccount(result.ToAccount),
+		Fee:         result.Fee.Amount,
+	}, nil
+}
+
+func (s *Server) validAccount(ctx context.Context, id int64, number string, currency string) (db.Account, error) {
+	account, err := s.getAccount(ctx, id, number)
+	if err != nil {
+		return account, err
+	}
+
+	if account.Currency != currency {
+		return account, invalidArgument(fmt.Errorf("account with id %d have mismatch currency, expected %s got %s", account.ID, account.Currency, currency))
+	}
+	return account, nil
+}
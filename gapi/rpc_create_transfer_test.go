@@ -0,0 +1,345 @@
+package gapi
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	mocks "github.com/flukis/simplebank/db/mock"
+	db "github.com/flukis/simplebank/db/sqlc"
+	"github.com/flukis/simplebank/pb"
+	"github.com/flukis/simplebank/util"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCreateTransferRPC(t *testing.T) {
+	from := randomAccount()
+	to := randomAccount()
+	to.Currency = from.Currency
+	holder := uuid.New()
+	amount := int64(10)
+
+	testCases := []struct {
+		name   string
+		req    *pb.CreateTransferRequest
+		userID uuid.UUID
+		role   string
+		build  func(store *mocks.Store)
+		check  func(t *testing.T, res *pb.CreateTransferResponse, err error)
+	}{
+		{
+			name: "OK",
+			req: &pb.CreateTransferRequest{
+				FromAccount: &pb.CreateTransferRequest_FromAccountId{FromAccountId: from.ID},
+				ToAccount:   &pb.CreateTransferRequest_ToAccountId{ToAccountId: to.ID},
+				Currency:    from.Currency,
+				Amount:      amount,
+			},
+			userID: from.OwnerID,
+			build: func(store *mocks.Store) {
+				store.On("GetAccount", mock.Anything, from.ID).
+					Return(from, nil).
+					Once()
+				store.On("GetAccount", mock.Anything, to.ID).
+					Return(to, nil).
+					Once()
+				store.On("TransferTx", mock.Anything, db.TransferTxParams{
+					FromAccountID: from.ID,
+					ToAccountID:   to.ID,
+					Amount:        amount,
+				}).
+					Return(db.TransferTxResult{
+						Transfer: db.Transfer{
+							ID:            1,
+							FromAccountID: from.ID,
+							ToAccountID:   to.ID,
+							Amount:        amount,
+						},
+						FromAccount: from,
+						ToAccount:   to,
+						Fee:         db.TransferFee{Amount: 1},
+					}, nil).
+					Once()
+			},
+			check: func(t *testing.T, res *pb.CreateTransferResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, amount, res.GetTransfer().GetAmount())
+				require.Equal(t, from.ID, res.GetFromAccount().GetId())
+				require.Equal(t, int64(1), res.GetFee())
+			},
+		},
+		{
+			name: "ByNumber",
+			req: &pb.CreateTransferRequest{
+				FromAccount: &pb.CreateTransferRequest_FromAccountNumber{FromAccountNumber: from.Number},
+				ToAccount:   &pb.CreateTransferRequest_ToAccountNumber{ToAccountNumber: to.Number},
+				Currency:    from.Currency,
+				Amount:      amount,
+			},
+			userID: from.OwnerID,
+			build: func(store *mocks.Store) {
+				store.On("GetAccountByNumber", mock.Anything, from.Number).
+					Return(from, nil).
+					Once()
+				store.On("GetAccountByNumber", mock.Anything, to.Number).
+					Return(to, nil).
+					Once()
+				store.On("TransferTx", mock.Anything, db.TransferTxParams{
+					FromAccountID: from.ID,
+					ToAccountID:   to.ID,
+					Amount:        amount,
+				}).
+					Return(db.TransferTxResult{
+						Transfer:    db.Transfer{ID: 1, FromAccountID: from.ID, ToAccountID: to.ID, Amount: amount},
+						FromAccount: from,
+						ToAccount:   to,
+					}, nil).
+					Once()
+			},
+			check: func(t *testing.T, res *pb.CreateTransferResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, to.ID, res.GetTransfer().GetToAccountId())
+			},
+		},
+		{
+			name: "MistypedNumber",
+			req: &pb.CreateTransferRequest{
+				FromAccount: &pb.CreateTransferRequest_FromAccountId{FromAccountId: from.ID},
+				ToAccount:   &pb.CreateTransferRequest_ToAccountNumber{ToAccountNumber: to.Number[:len(to.Number)-1]},
+				Currency:    from.Currency,
+				Amount:      amount,
+			},
+			userID: from.OwnerID,
+			build: func(store *mocks.Store) {
+				store.On("GetAccount", mock.Anything, from.ID).
+					Return(from, nil).
+					Once()
+			},
+			check: func(t *testing.T, res *pb.CreateTransferResponse, err error) {
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+			},
+		},
+		{
+			name: "NoSourceAccount",
+			req: &pb.CreateTransferRequest{
+				ToAccount: &pb.CreateTransferRequest_ToAccountId{ToAccountId: to.ID},
+				Currency:  from.Currency,
+				Amount:    amount,
+			},
+			userID: from.OwnerID,
+			build:  func(store *mocks.Store) {},
+			check: func(t *testing.T, res *pb.CreateTransferResponse, err error) {
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+			},
+		},
+		{
+			name: "InvalidAmount",
+			req: &pb.CreateTransferRequest{
+				FromAccount: &pb.CreateTransferRequest_FromAccountId{FromAccountId: from.ID},
+				ToAccount:   &pb.CreateTransferRequest_ToAccountId{ToAccountId: to.ID},
+				Currency:    from.Currency,
+				Amount:      -1,
+			},
+			userID: from.OwnerID,
+			build:  func(store *mocks.Store) {},
+			check: func(t *testing.T, res *pb.CreateTransferResponse, err error) {
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+			},
+		},
+		{
+			name: "CurrencyMismatch",
+			req: &pb.CreateTransferRequest{
+				FromAccount: &pb.CreateTransferRequest_FromAccountId{FromAccountId: from.ID},
+				ToAccount:   &pb.CreateTransferRequest_ToAccountId{ToAccountId: to.ID},
+				Currency:    otherCurrency(from.Currency),
+				Amount:      amount,
+			},
+			userID: from.OwnerID,
+			build: func(store *mocks.Store) {
+				store.On("GetAccount", mock.Anything, from.ID).
+					Return(from, nil).
+					Once()
+			},
+			check: func(t *testing.T, res *pb.CreateTransferResponse, err error) {
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+			},
+		},
+		{
+			name: "AccountNotFound",
+			req: &pb.CreateTransferRequest{
+				FromAccount: &pb.CreateTransferRequest_FromAccountId{FromAccountId: from.ID},
+				ToAccount:   &pb.CreateTransferRequest_ToAccountId{ToAccountId: to.ID},
+				Currency:    from.Currency,
+				Amount:      amount,
+			},
+			userID: from.OwnerID,
+			build: func(store *mocks.Store) {
+				store.On("GetAccount", mock.Anything, from.ID).
+					Return(db.Account{}, sql.ErrNoRows).
+					Once()
+			},
+			check: func(t *testing.T, res *pb.CreateTransferResponse, err error) {
+				require.Equal(t, codes.NotFound, status.Code(err))
+			},
+		},
+		{
+			name: "NotOwned",
+			req: &pb.CreateTransferRequest{
+				FromAccount: &pb.CreateTransferRequest_FromAccountId{FromAccountId: from.ID},
+				ToAccount:   &pb.CreateTransferRequest_ToAccountId{ToAccountId: to.ID},
+				Currency:    from.Currency,
+				Amount:      amount,
+			},
+			userID: holder,
+			build: func(store *mocks.Store) {
+				store.On("GetAccount", mock.Anything, from.ID).
+					Return(from, nil).
+					Once()
+				store.On("GetAccountHolder", mock.Anything, db.GetAccountHolderParams{
+					AccountID: from.ID,
+					UserID:    holder,
+				}).
+					Return(db.AccountHolder{}, sql.ErrNoRows).
+					Once()
+			},
+			check: func(t *testing.T, res *pb.CreateTransferResponse, err error) {
+				require.Equal(t, codes.PermissionDenied, status.Code(err))
+			},
+		},
+		{
+			name: "Teller",
+			req: &pb.CreateTransferRequest{
+				FromAccount: &pb.CreateTransferRequest_FromAccountId{FromAccountId: from.ID},
+				ToAccount:   &pb.CreateTransferRequest_ToAccountId{ToAccountId: to.ID},
+				Currency:    from.Currency,
+				Amount:      amount,
+			},
+			userID: holder,
+			role:   util.RoleTeller,
+			build: func(store *mocks.Store) {
+				store.On("GetAccount", mock.Anything, from.ID).
+					Return(from, nil).
+					Once()
+				store.On("GetAccountHolder", mock.Anything, db.GetAccountHolderParams{
+					AccountID: from.ID,
+					UserID:    holder,
+				}).
+					Return(db.AccountHolder{}, sql.ErrNoRows).
+					Once()
+			},
+			check: func(t *testing.T, res *pb.CreateTransferResponse, err error) {
+				require.Equal(t, codes.PermissionDenied, status.Code(err))
+			},
+		},
+		{
+			name: "AboveSpendLimit",
+			req: &pb.CreateTransferRequest{
+				FromAccount: &pb.CreateTransferRequest_FromAccountId{FromAccountId: from.ID},
+				ToAccount:   &pb.CreateTransferRequest_ToAccountId{ToAccountId: to.ID},
+				Currency:    from.Currency,
+				Amount:      amount,
+			},
+			userID: holder,
+			build: func(store *mocks.Store) {
+				store.On("GetAccount", mock.Anything, from.ID).
+					Return(from, nil).
+					Once()
+				store.On("GetAccountHolder", mock.Anything, db.GetAccountHolderParams{
+					AccountID: from.ID,
+					UserID:    holder,
+				}).
+					Return(db.AccountHolder{
+						AccountID:   from.ID,
+						UserID:      holder,
+						CanInitiate: true,
+						SpendLimit:  amount - 1,
+					}, nil).
+					Once()
+			},
+			check: func(t *testing.T, res *pb.CreateTransferResponse, err error) {
+				require.Equal(t, codes.PermissionDenied, status.Code(err))
+			},
+		},
+		{
+			name: "AccountFrozen",
+			req: &pb.CreateTransferRequest{
+				FromAccount: &pb.CreateTransferRequest_FromAccountId{FromAccountId: from.ID},
+				ToAccount:   &pb.CreateTransferRequest_ToAccountId{ToAccountId: to.ID},
+				Currency:    from.Currency,
+				Amount:      amount,
+			},
+			userID: from.OwnerID,
+			build: func(store *mocks.Store) {
+				store.On("GetAccount", mock.Anything, from.ID).
+					Return(from, nil).
+					Once()
+				store.On("GetAccount", mock.Anything, to.ID).
+					Return(to, nil).
+					Once()
+				store.On("TransferTx", mock.Anything, mock.Anything).
+					Return(db.TransferTxResult{}, &db.AccountStatusError{AccountID: from.ID, Status: db.AccountStatusFrozen}).
+					Once()
+			},
+			check: func(t *testing.T, res *pb.CreateTransferResponse, err error) {
+				require.Equal(t, codes.FailedPrecondition, status.Code(err))
+			},
+		},
+		{
+			name: "InsufficientFunds",
+			req: &pb.CreateTransferRequest{
+				FromAccount: &pb.CreateTransferRequest_FromAccountId{FromAccountId: from.ID},
+				ToAccount:   &pb.CreateTransferRequest_ToAccountId{ToAccountId: to.ID},
+				Currency:    from.Currency,
+				Amount:      amount,
+			},
+			userID: from.OwnerID,
+			build: func(store *mocks.Store) {
+				store.On("GetAccount", mock.Anything, from.ID).
+					Return(from, nil).
+					Once()
+				store.On("GetAccount", mock.Anything, to.ID).
+					Return(to, nil).
+					Once()
+				store.On("TransferTx", mock.Anything, mock.Anything).
+					Return(db.TransferTxResult{}, &db.InsufficientFundsError{AccountID: from.ID, Balance: 0, Amount: amount}).
+					Once()
+			},
+			check: func(t *testing.T, res *pb.CreateTransferResponse, err error) {
+				require.Equal(t, codes.FailedPrecondition, status.Code(err))
+			},
+		},
+	}
+
+	for i := range testCases {
+		ts := testCases[i]
+
+		t.Run(ts.name, func(t *testing.T) {
+			store := &mocks.Store{}
+			ts.build(store)
+
+			role := ts.role
+			if role == "" {
+				role = util.RoleCustomer
+			}
+
+			client, server := newTestClient(t, store)
+			ctx := withAuthorization(t, server.tokenMaker, "Bearer", ts.userID, role, time.Minute)
+			res, err := client.CreateTransfer(ctx, ts.req)
+			ts.check(t, res, err)
+			store.AssertExpectations(t)
+		})
+	}
+}
+
+func otherCurrency(currency string) string {
+	for _, c := range []string{"USD", "EUR", "IDR"} {
+		if c != currency {
+			return c
+		}
+	}
+	return currency
+}
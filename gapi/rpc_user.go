@@ -0,0 +1,85 @@
+package gapi
+
+import (
+	"context"
+
+	db "github.com/flukis/simplebank/db/sqlc"
+	"github.com/flukis/simplebank/pb"
+	"github.com/flukis/simplebank/util"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func validateCreateUserRequest(req *pb.CreateUserRequest) error {
+	return validation.Errors{
+		"email":     validation.Validate(req.GetEmail(), validation.Required, is.Email),
+		"username":  validation.Validate(req.GetUsername(), validation.Required, validation.Length(8, 32)),
+		"full_name": validation.Validate(req.GetFullName(), validation.Required, validation.Length(8, 32)),
+		"password":  validation.Validate(req.GetPassword(), validation.Required, validation.Length(8, 32)),
+	}.Filter()
+}
+
+func (s *Server) CreateUser(ctx context.Context, req *pb.CreateUserRequest) (*pb.CreateUserResponse, error) {
+	if err := validateCreateUserRequest(req); err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	hashPassword, err := util.GenerateHashFromPassword(req.GetPassword(), s.passwordHashing)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	user, err := s.store.CreateUser(ctx, db.CreateUserParams{
+		Username:       req.GetUsername(),
+		FullName:       req.GetFullName(),
+		Email:          req.GetEmail(),
+		HashedPassword: hashPassword,
+	})
+	if err != nil {
+		return nil, storeError(err)
+	}
+
+	return &pb.CreateUserResponse{
+		User: convertUser(user),
+	}, nil
+}
+
+func validateLoginUserRequest(req *pb.LoginUserRequest) error {
+	return validation.Errors{
+		"username": validation.Validate(req.GetUsername(), validation.Required, validation.Length(5, 32)),
+		"password": validation.Validate(req.GetPassword(), validation.Required, validation.Length(8, 32)),
+	}.Filter()
+}
+
+func (s *Server) LoginUser(ctx context.Context, req *pb.LoginUserRequest) (*pb.LoginUserResponse, error) {
+	if err := validateLoginUserRequest(req); err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	user, err := s.store.GetUserByUsername(ctx, req.GetUsername())
+	if err != nil {
+		return nil, storeError(err)
+	}
+
+	isMatch, err := util.ComparePasswordAndHashPassword(req.GetPassword(), user.HashedPassword, s.passwordHashing)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if !isMatch {
+		return nil, status.Error(codes.Unauthenticated, util.ErrWrongPassword.Error())
+	}
+
+	accessToken, payload, err := s.tokenMaker.CreateToken(user.ID, user.Username, user.Role, s.config.AccessTokenDuration)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.LoginUserResponse{
+		User:                 convertUser(user),
+		AccessToken:          accessToken,
+		AccessTokenExpiresAt: timestamppb.New(payload.ExpiredAt),
+	}, nil
+}
@@ -0,0 +1,42 @@
+package gapi
+
+import (
+	"fmt"
+
+	db "github.com/flukis/simplebank/db/sqlc"
+	"github.com/flukis/simplebank/pb"
+	"github.com/flukis/simplebank/util"
+	"google.golang.org/grpc"
+)
+
+// Server serves the gRPC API from the same store and tokens as api.Server
+type Server struct {
+	pb.UnimplementedSimpleBankServer
+	store           db.Store
+	passwordHashing util.Argon2Param
+	tokenMaker      util.JWTMaker
+	config          util.Config
+}
+
+func NewServer(store db.Store, cfg util.Config) (*Server, error) {
+	tokenMaker, err := util.NewJWTMaker(cfg.TokenSymetricKey)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create token maker: %w", err)
+	}
+
+	server := &Server{
+		store:           store,
+		passwordHashing: util.DefaultArgon2Param,
+		tokenMaker:      *tokenMaker,
+		config:          cfg,
+	}
+	return server, nil
+}
+
+// GRPCServer returns a grpc.Server with s registered behind the auth
+// interceptor
+func (s *Server) GRPCServer() *grpc.Server {
+	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(s.AuthInterceptor))
+	pb.RegisterSimpleBankServer(grpcServer, s)
+	return grpcServer
+}
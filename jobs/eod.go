@@ -0,0 +1,124 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	db "github.com/flukis/simplebank/db/sqlc"
+)
+
+const DateLayout = "2006-01-02"
+
+// idempotencyKeyRetention is how long the responses kept for requests sent
+// with an Idempotency-Key can be replayed
+const idempotencyKeyRetention = 24 * time.Hour
+
+var ErrBusinessDayOpen = errors.New("end of day can only run for a day that has ended")
+
+// EndOfDay collects the loan installments due on a business date, settles
+// the term deposits maturing on it, accrues interest for it and, on the last
+// day of a month, capitalizes the interest accrued during that month. It
+// also drops the idempotency keys older than a day.
+// Running it again for the same date does not post anything twice.
+type EndOfDay struct {
+	store db.Store
+	now   func() time.Time
+}
+
+func NewEndOfDay(store db.Store) *EndOfDay {
+	return &EndOfDay{
+		store: store,
+		now:   time.Now,
+	}
+}
+
+type EndOfDayResult struct {
+	Date        string `json:"date"`
+	Collected   int    `json:"collected"`
+	Overdue     int    `json:"overdue"`
+	Matured     int    `json:"matured"`
+	Accrued     int    `json:"accrued"`
+	Capitalized int    `json:"capitalized"`
+	ExpiredKeys int    `json:"expired_keys"`
+}
+
+func (j *EndOfDay) Run(ctx context.Context, date time.Time) (EndOfDayResult, error) {
+	date = startOfDay(date)
+	result := EndOfDayResult{
+		Date: date.Format(DateLayout),
+	}
+
+	if !date.Before(startOfDay(j.now())) {
+		return result, ErrBusinessDayOpen
+	}
+
+	collection, err := j.store.CollectLoanInstallments(ctx, date)
+	if err != nil {
+		return result, err
+	}
+	result.Collected = len(collection.Collected)
+	result.Overdue = len(collection.Overdue)
+
+	closures, err := j.store.MatureTermDeposits(ctx, date)
+	if err != nil {
+		return result, err
+	}
+	result.Matured = len(closures)
+
+	accruals, err := j.store.AccrueInterest(ctx, date)
+	if err != nil {
+		return result, err
+	}
+	result.Accrued = len(accruals)
+
+	if isMonthEnd(date) {
+		capitalizations, err := j.store.CapitalizeInterest(ctx, date)
+		if err != nil {
+			return result, err
+		}
+		result.Capitalized = len(capitalizations)
+	}
+
+	expired, err := j.store.DeleteIdempotencyKeysBefore(ctx, j.now().Add(-idempotencyKeyRetention))
+	if err != nil {
+		return result, err
+	}
+	result.ExpiredKeys = int(expired)
+
+	return result, nil
+}
+
+// Schedule runs the job shortly after every UTC midnight for the day that
+// just ended, until ctx is done.
+func (j *EndOfDay) Schedule(ctx context.Context) {
+	for {
+		now := j.now().UTC()
+		next := startOfDay(now).AddDate(0, 0, 1).Add(5 * time.Minute)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(next.Sub(now)):
+		}
+
+		date := startOfDay(next).AddDate(0, 0, -1)
+		result, err := j.Run(ctx, date)
+		if err != nil {
+			log.Printf("end of day for %s failed: %v", date.Format(DateLayout), err)
+			continue
+		}
+		log.Printf("end of day for %s: %d installments collected, %d overdue, %d deposits matured, %d accrued, %d capitalized, %d idempotency keys expired",
+			result.Date, result.Collected, result.Overdue, result.Matured, result.Accrued, result.Capitalized, result.ExpiredKeys)
+	}
+}
+
+func startOfDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func isMonthEnd(date time.Time) bool {
+	return date.AddDate(0, 0, 1).Day() == 1
+}
@@ -0,0 +1,98 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mocks "github.com/flukis/simplebank/db/mock"
+	db "github.com/flukis/simplebank/db/sqlc"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndOfDayRun(t *testing.T) {
+	today := time.Date(2023, time.April, 10, 15, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name  string
+		date  time.Time
+		build func(store *mocks.Store)
+		check func(t *testing.T, result EndOfDayResult, err error)
+	}{
+		{
+			name: "MidMonth",
+			date: time.Date(2023, time.March, 15, 0, 0, 0, 0, time.UTC),
+			build: func(store *mocks.Store) {
+				date := time.Date(2023, time.March, 15, 0, 0, 0, 0, time.UTC)
+				store.On("CollectLoanInstallments", mock.Anything, date).
+					Return(db.LoanCollectionResult{
+						Collected: []db.LoanInstallment{{ID: 1}},
+						Overdue:   []db.LoanInstallment{{ID: 2}, {ID: 3}},
+					}, nil).
+					Once()
+				store.On("MatureTermDeposits", mock.Anything, date).
+					Return([]db.TermDepositClosure{{Interest: 10}}, nil).
+					Once()
+				store.On("AccrueInterest", mock.Anything, date).
+					Return([]db.InterestAccrual{{ID: 1}, {ID: 2}}, nil).
+					Once()
+				store.On("DeleteIdempotencyKeysBefore", mock.Anything, today.Add(-idempotencyKeyRetention)).
+					Return(int64(3), nil).
+					Once()
+			},
+			check: func(t *testing.T, result EndOfDayResult, err error) {
+				require.NoError(t, err)
+				require.Equal(t, EndOfDayResult{Date: "2023-03-15", Collected: 1, Overdue: 2, Matured: 1, Accrued: 2, ExpiredKeys: 3}, result)
+			},
+		},
+		{
+			name: "MonthEnd",
+			date: time.Date(2023, time.February, 28, 0, 0, 0, 0, time.UTC),
+			build: func(store *mocks.Store) {
+				monthEnd := time.Date(2023, time.February, 28, 0, 0, 0, 0, time.UTC)
+				store.On("CollectLoanInstallments", mock.Anything, monthEnd).
+					Return(db.LoanCollectionResult{}, nil).
+					Once()
+				store.On("MatureTermDeposits", mock.Anything, monthEnd).
+					Return([]db.TermDepositClosure{}, nil).
+					Once()
+				store.On("AccrueInterest", mock.Anything, monthEnd).
+					Return([]db.InterestAccrual{{ID: 1}}, nil).
+					Once()
+				store.On("CapitalizeInterest", mock.Anything, monthEnd).
+					Return([]db.InterestCapitalization{{ID: 1}}, nil).
+					Once()
+				store.On("DeleteIdempotencyKeysBefore", mock.Anything, today.Add(-idempotencyKeyRetention)).
+					Return(int64(0), nil).
+					Once()
+			},
+			check: func(t *testing.T, result EndOfDayResult, err error) {
+				require.NoError(t, err)
+				require.Equal(t, EndOfDayResult{Date: "2023-02-28", Accrued: 1, Capitalized: 1}, result)
+			},
+		},
+		{
+			name:  "Today",
+			date:  today,
+			build: func(store *mocks.Store) {},
+			check: func(t *testing.T, result EndOfDayResult, err error) {
+				require.ErrorIs(t, err, ErrBusinessDayOpen)
+			},
+		},
+	}
+
+	for _, ts := range testCases {
+		t.Run(ts.name, func(t *testing.T) {
+			store := &mocks.Store{}
+			ts.build(store)
+
+			job := NewEndOfDay(store)
+			job.now = func() time.Time { return today }
+
+			result, err := job.Run(context.Background(), ts.date)
+			ts.check(t, result, err)
+			store.AssertExpectations(t)
+		})
+	}
+}
@@ -1,29 +1,15 @@
 package main
 
 import (
-	"database/sql"
-	"log"
+	"fmt"
+	"os"
 
-	"github.com/flukis/simplebank/api"
-	db "github.com/flukis/simplebank/db/sqlc"
-	"github.com/flukis/simplebank/util"
-	_ "github.com/lib/pq"
+	"github.com/flukis/simplebank/cmd"
 )
 
 func main() {
-	conf, err := util.LoadConfig(".")
-	if err != nil {
-		log.Fatal(err)
+	if err := cmd.NewRootCommand().Execute(); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
 	}
-	dbConn, err := sql.Open(conf.DBDriver, conf.DBSource)
-	if err != nil {
-		log.Fatal("cannot connect to db: ", err)
-	}
-
-	store := db.NewStore(dbConn)
-	server, err := api.NewServer(store, conf)
-	if err != nil {
-		log.Fatal("cannot create server: ", err)
-	}
-	server.Start(conf.ServerAddr)
 }
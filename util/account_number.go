@@ -0,0 +1,81 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Account numbers are IBAN-style: country code, two check digits, bank code
+// and ten digits, e.g. ID92SMPL0123456789. The database generates them with
+// the same scheme, see the generate_account_number migration.
+const (
+	AccountNumberCountry = "ID"
+	AccountNumberBank    = "SMPL"
+
+	accountNumberDigits = 10
+	AccountNumberLength = len(AccountNumberCountry) + 2 + len(AccountNumberBank) + accountNumberDigits
+)
+
+var (
+	ErrAccountNumberFormat = errors.New("account number is not in the format of this bank")
+	ErrAccountNumberCheck  = errors.New("account number check digits don't match")
+)
+
+// NewAccountNumber returns the account number for the ten digit account part
+func NewAccountNumber(account string) (string, error) {
+	if len(account) != accountNumberDigits || !isDigits(account) {
+		return "", ErrAccountNumberFormat
+	}
+
+	bban := AccountNumberBank + account
+	check := 98 - mod97(bban+AccountNumberCountry+"00")
+	return fmt.Sprintf("%s%02d%s", AccountNumberCountry, check, bban), nil
+}
+
+// NormalizeAccountNumber drops the spaces account numbers are usually
+// printed with and upper-cases the letters
+func NormalizeAccountNumber(number string) string {
+	return strings.ToUpper(strings.ReplaceAll(number, " ", ""))
+}
+
+// ValidateAccountNumber checks a normalized account number against the
+// format of this bank and its check digits, without looking it up
+func ValidateAccountNumber(number string) error {
+	if len(number) != AccountNumberLength ||
+		!strings.HasPrefix(number, AccountNumberCountry) ||
+		!isDigits(number[2:4]) ||
+		number[4:4+len(AccountNumberBank)] != AccountNumberBank ||
+		!isDigits(number[4+len(AccountNumberBank):]) {
+		return ErrAccountNumberFormat
+	}
+
+	// the country code and check digits go to the end, as for any IBAN
+	if mod97(number[4:]+number[:4]) != 1 {
+		return ErrAccountNumberCheck
+	}
+	return nil
+}
+
+// mod97 returns s modulo 97, with letters standing for 10 to 35
+func mod97(s string) int {
+	rem := 0
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			rem = (rem*10 + int(r-'0')) % 97
+		case r >= 'A' && r <= 'Z':
+			rem = (rem*100 + int(r-'A') + 10) % 97
+		}
+	}
+	return rem
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
@@ -0,0 +1,36 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMod97(t *testing.T) {
+	// the example IBAN of the standard, rearranged
+	require.Equal(t, 1, mod97("WEST12345698765432GB82"))
+}
+
+func TestAccountNumber(t *testing.T) {
+	number, err := NewAccountNumber("0123456789")
+	require.NoError(t, err)
+	require.Len(t, number, AccountNumberLength)
+	require.Equal(t, "ID", number[:2])
+	require.Equal(t, "SMPL0123456789", number[4:])
+	require.NoError(t, ValidateAccountNumber(number))
+
+	require.Equal(t, number, NormalizeAccountNumber(" "+number[:4]+" "+number[4:8]+" "+number[8:]))
+
+	// a mistyped digit and swapped neighbours are caught by the check digits
+	typo := number[:10] + "9" + number[11:]
+	require.ErrorIs(t, ValidateAccountNumber(typo), ErrAccountNumberCheck)
+	swapped := number[:8] + number[9:10] + number[8:9] + number[10:]
+	require.ErrorIs(t, ValidateAccountNumber(swapped), ErrAccountNumberCheck)
+
+	require.ErrorIs(t, ValidateAccountNumber("GB82WEST12345698765432"), ErrAccountNumberFormat)
+	require.ErrorIs(t, ValidateAccountNumber(number[:len(number)-1]), ErrAccountNumberFormat)
+	require.ErrorIs(t, ValidateAccountNumber("ID00SMPL01234X6789"), ErrAccountNumberFormat)
+
+	_, err = NewAccountNumber("12345")
+	require.ErrorIs(t, err, ErrAccountNumberFormat)
+}
@@ -0,0 +1,149 @@
+package util
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+)
+
+const (
+	AmortizationAnnuity = "annuity"
+	AmortizationFlat    = "flat"
+
+	FrequencyWeekly  = "weekly"
+	FrequencyMonthly = "monthly"
+)
+
+// IsSupportedAmortization reports whether method is a known amortization method
+func IsSupportedAmortization(method string) bool {
+	return method == AmortizationAnnuity || method == AmortizationFlat
+}
+
+// IsSupportedFrequency reports whether frequency is a known repayment frequency
+func IsSupportedFrequency(frequency string) bool {
+	return frequency == FrequencyWeekly || frequency == FrequencyMonthly
+}
+
+// Installment is one line of an amortization schedule
+type Installment struct {
+	Number    int32     `json:"number"`
+	DueDate   time.Time `json:"due_date"`
+	Principal int64     `json:"principal"`
+	Interest  int64     `json:"interest"`
+}
+
+// Amortize splits a loan of principal at an annual rate of rateBps into term
+// installments due every period after start.
+//
+// Annuity installments are level payments, with interest charged on the
+// outstanding principal of each period. Flat installments repay equal parts
+// of the principal and of the interest on the original principal over the
+// whole term. Amounts are rounded half up and the last installment takes the
+// rounding difference, so principals always add up to the loan.
+func Amortize(principal, rateBps int64, term int32, frequency, method string, start time.Time) ([]Installment, error) {
+	if principal <= 0 {
+		return nil, fmt.Errorf("principal must be positive, got %d", principal)
+	}
+	if rateBps < 0 {
+		return nil, fmt.Errorf("rate must not be negative, got %d", rateBps)
+	}
+	if term <= 0 {
+		return nil, fmt.Errorf("term must be positive, got %d", term)
+	}
+
+	var periodsPerYear int64
+	switch frequency {
+	case FrequencyWeekly:
+		periodsPerYear = 52
+	case FrequencyMonthly:
+		periodsPerYear = 12
+	default:
+		return nil, fmt.Errorf("unsupported repayment frequency %q", frequency)
+	}
+
+	// periodic rate
+	rate := big.NewRat(rateBps, 10000*periodsPerYear)
+
+	schedule := make([]Installment, term)
+	for i := range schedule {
+		schedule[i].Number = int32(i + 1)
+		schedule[i].DueDate = dueDate(start, frequency, i+1)
+	}
+
+	switch method {
+	case AmortizationAnnuity:
+		payment := annuityPayment(principal, rate, term)
+		remaining := principal
+		for i := range schedule {
+			interest := roundRat(new(big.Rat).Mul(big.NewRat(remaining, 1), rate))
+			part := payment - interest
+			if i == len(schedule)-1 || part > remaining {
+				part = remaining
+			}
+			schedule[i].Principal = part
+			schedule[i].Interest = interest
+			remaining -= part
+		}
+	case AmortizationFlat:
+		n := int64(term)
+		interest := roundRat(new(big.Rat).Mul(big.NewRat(principal*n, 1), rate))
+		for i := range schedule {
+			schedule[i].Principal = principal / n
+			schedule[i].Interest = interest / n
+		}
+		schedule[term-1].Principal += principal % n
+		schedule[term-1].Interest += interest % n
+	default:
+		return nil, fmt.Errorf("unsupported amortization method %q", method)
+	}
+
+	return schedule, nil
+}
+
+// annuityPayment returns principal * r / (1 - (1+r)^-n), rounded half up
+func annuityPayment(principal int64, rate *big.Rat, term int32) int64 {
+	if rate.Sign() == 0 {
+		return roundRat(big.NewRat(principal, int64(term)))
+	}
+
+	growth := big.NewRat(1, 1)
+	base := new(big.Rat).Add(big.NewRat(1, 1), rate)
+	for i := int32(0); i < term; i++ {
+		growth.Mul(growth, base)
+	}
+
+	// principal * r * g / (g - 1)
+	n := new(big.Rat).Mul(big.NewRat(principal, 1), rate)
+	n.Mul(n, growth)
+	d := new(big.Rat).Sub(growth, big.NewRat(1, 1))
+	return roundRat(n.Quo(n, d))
+}
+
+func roundRat(r *big.Rat) int64 {
+	n := new(big.Int).Mul(r.Num(), big.NewInt(2))
+	n.Add(n, r.Denom())
+	d := new(big.Int).Mul(r.Denom(), big.NewInt(2))
+	return n.Div(n, d).Int64()
+}
+
+// dueDate returns the date n periods after start
+func dueDate(start time.Time, frequency string, n int) time.Time {
+	if frequency == FrequencyWeekly {
+		start = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+		return start.AddDate(0, 0, 7*n)
+	}
+	return AddMonths(start, n)
+}
+
+// AddMonths returns the date n months after t. Dates that fall past the end
+// of a shorter month are moved back to its last day, so Jan 31 plus one
+// month is Feb 28 rather than Mar 3.
+func AddMonths(t time.Time, n int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(n), 1, 0, 0, 0, 0, time.UTC)
+	last := first.AddDate(0, 1, -1).Day()
+	day := t.Day()
+	if day > last {
+		day = last
+	}
+	return time.Date(first.Year(), first.Month(), day, 0, 0, 0, 0, time.UTC)
+}
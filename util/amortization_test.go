@@ -0,0 +1,88 @@
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func sumSchedule(schedule []Installment) (principal, interest int64) {
+	for _, installment := range schedule {
+		principal += installment.Principal
+		interest += installment.Interest
+	}
+	return
+}
+
+func TestAmortizeAnnuity(t *testing.T) {
+	// 12% a year repaid monthly over a year: 1% a month, level payment of 88849
+	schedule, err := Amortize(1000000, 1200, 12, FrequencyMonthly, AmortizationAnnuity, date(2023, time.January, 31))
+	require.NoError(t, err)
+	require.Len(t, schedule, 12)
+
+	require.Equal(t, int64(10000), schedule[0].Interest)
+	require.Equal(t, int64(78849), schedule[0].Principal)
+	for _, installment := range schedule[:11] {
+		require.Equal(t, int64(88849), installment.Principal+installment.Interest)
+	}
+
+	principal, interest := sumSchedule(schedule)
+	require.Equal(t, int64(1000000), principal)
+	require.InDelta(t, 66185, interest, 12)
+
+	require.Equal(t, date(2023, time.February, 28), schedule[0].DueDate)
+	require.Equal(t, date(2023, time.March, 31), schedule[1].DueDate)
+	require.Equal(t, date(2024, time.January, 31), schedule[11].DueDate)
+}
+
+func TestAmortizeZeroRate(t *testing.T) {
+	schedule, err := Amortize(1000, 0, 3, FrequencyWeekly, AmortizationAnnuity, date(2023, time.January, 2))
+	require.NoError(t, err)
+
+	principal, interest := sumSchedule(schedule)
+	require.Equal(t, int64(1000), principal)
+	require.Zero(t, interest)
+	require.Equal(t, int64(333), schedule[0].Principal)
+	require.Equal(t, int64(334), schedule[2].Principal)
+	require.Equal(t, date(2023, time.January, 23), schedule[2].DueDate)
+}
+
+func TestAmortizeFlat(t *testing.T) {
+	// 10% a year on the original principal for 6 months is 5000
+	schedule, err := Amortize(100000, 1000, 6, FrequencyMonthly, AmortizationFlat, date(2023, time.March, 15))
+	require.NoError(t, err)
+	require.Len(t, schedule, 6)
+
+	require.Equal(t, int64(16666), schedule[0].Principal)
+	require.Equal(t, int64(833), schedule[0].Interest)
+	require.Equal(t, int64(16670), schedule[5].Principal)
+	require.Equal(t, int64(835), schedule[5].Interest)
+
+	principal, interest := sumSchedule(schedule)
+	require.Equal(t, int64(100000), principal)
+	require.Equal(t, int64(5000), interest)
+}
+
+func TestAmortizeInvalid(t *testing.T) {
+	start := date(2023, time.January, 1)
+
+	_, err := Amortize(0, 1000, 12, FrequencyMonthly, AmortizationAnnuity, start)
+	require.Error(t, err)
+	_, err = Amortize(1000, -1, 12, FrequencyMonthly, AmortizationAnnuity, start)
+	require.Error(t, err)
+	_, err = Amortize(1000, 1000, 0, FrequencyMonthly, AmortizationAnnuity, start)
+	require.Error(t, err)
+	_, err = Amortize(1000, 1000, 12, "daily", AmortizationAnnuity, start)
+	require.Error(t, err)
+	_, err = Amortize(1000, 1000, 12, FrequencyMonthly, "balloon", start)
+	require.Error(t, err)
+	require.False(t, IsSupportedAmortization("balloon"))
+	require.False(t, IsSupportedFrequency("daily"))
+}
+
+func TestAddMonths(t *testing.T) {
+	require.Equal(t, date(2023, time.February, 28), AddMonths(date(2023, time.January, 31), 1))
+	require.Equal(t, date(2024, time.February, 29), AddMonths(date(2023, time.August, 31), 6))
+	require.Equal(t, date(2024, time.March, 15), AddMonths(date(2023, time.March, 15), 12))
+}
@@ -10,8 +10,23 @@ type Config struct {
 	DBDriver            string        `mapstructure:"DB_DRIVER"`
 	DBSource            string        `mapstructure:"DB_SOURCE"`
 	ServerAddr          string        `mapstructure:"SERVER_ADDR"`
+	GRPCServerAddr      string        `mapstructure:"GRPC_SERVER_ADDR"`
 	TokenSymetricKey    string        `mapstructure:"TOKEN_SYMMETRIC_KEY"`
 	AccessTokenDuration time.Duration `mapstructure:"TOKEN_ACCESS_DURATION"`
+	// how long payments to a new beneficiary are capped, 0 to disable
+	BeneficiaryCoolingOff time.Duration `mapstructure:"BENEFICIARY_COOLING_OFF"`
+	// how long payment requests can be paid when they don't say
+	PaymentRequestExpiry time.Duration `mapstructure:"PAYMENT_REQUEST_EXPIRY"`
+	// whether serve applies the embedded migrations before starting
+	AutoMigrate bool `mapstructure:"AUTO_MIGRATE"`
+	// pool settings, 0 keeps the defaults of pgxpool
+	DBMaxConns          int32         `mapstructure:"DB_MAX_CONNS"`
+	DBMinConns          int32         `mapstructure:"DB_MIN_CONNS"`
+	DBMaxConnLifetime   time.Duration `mapstructure:"DB_MAX_CONN_LIFETIME"`
+	DBMaxConnIdleTime   time.Duration `mapstructure:"DB_MAX_CONN_IDLE_TIME"`
+	DBHealthCheckPeriod time.Duration `mapstructure:"DB_HEALTH_CHECK_PERIOD"`
+	// prepare, describe or none, see db.PoolConfig
+	DBStatementCacheMode string `mapstructure:"DB_STATEMENT_CACHE_MODE"`
 }
 
 func LoadConfig(path string) (config Config, err error) {
@@ -0,0 +1,54 @@
+package util
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	DayCountACT365 = "ACT/365"
+	DayCountACT360 = "ACT/360"
+	DayCount30360  = "30/360"
+)
+
+// IsSupportedDayCount reports whether convention is a known day-count convention
+func IsSupportedDayCount(convention string) bool {
+	switch convention {
+	case DayCountACT365, DayCountACT360, DayCount30360:
+		return true
+	}
+	return false
+}
+
+// DayCount returns the number of days between start and end and the number
+// of days in a year under the given convention. The year fraction of the
+// period is days / basis.
+func DayCount(convention string, start, end time.Time) (days int64, basis int64, err error) {
+	switch convention {
+	case DayCountACT365:
+		return actualDays(start, end), 365, nil
+	case DayCountACT360:
+		return actualDays(start, end), 360, nil
+	case DayCount30360:
+		return days30360(start, end), 360, nil
+	}
+	return 0, 0, fmt.Errorf("unsupported day count convention %q", convention)
+}
+
+func actualDays(start, end time.Time) int64 {
+	s := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+	e := time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, time.UTC)
+	return int64(e.Sub(s).Hours() / 24)
+}
+
+// days30360 counts days as if every month had 30 days (US bond basis)
+func days30360(start, end time.Time) int64 {
+	d1, d2 := start.Day(), end.Day()
+	if d1 == 31 {
+		d1 = 30
+	}
+	if d2 == 31 && d1 == 30 {
+		d2 = 30
+	}
+	return int64(360*(end.Year()-start.Year()) + 30*(int(end.Month())-int(start.Month())) + d2 - d1)
+}
@@ -0,0 +1,55 @@
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestDayCount(t *testing.T) {
+	testCases := []struct {
+		convention string
+		start, end time.Time
+		days       int64
+		basis      int64
+	}{
+		{DayCountACT365, date(2023, time.January, 1), date(2023, time.February, 1), 31, 365},
+		{DayCountACT365, date(2024, time.February, 28), date(2024, time.March, 1), 2, 365},
+		{DayCountACT360, date(2023, time.January, 1), date(2023, time.February, 1), 31, 360},
+		{DayCount30360, date(2023, time.January, 1), date(2023, time.February, 1), 30, 360},
+		{DayCount30360, date(2023, time.January, 31), date(2023, time.February, 1), 1, 360},
+		{DayCount30360, date(2023, time.January, 30), date(2023, time.January, 31), 0, 360},
+		{DayCount30360, date(2023, time.February, 28), date(2023, time.March, 1), 3, 360},
+		{DayCount30360, date(2023, time.January, 15), date(2024, time.January, 15), 360, 360},
+	}
+
+	for _, tc := range testCases {
+		days, basis, err := DayCount(tc.convention, tc.start, tc.end)
+		require.NoError(t, err)
+		require.Equal(t, tc.days, days, "%s %s to %s", tc.convention, tc.start, tc.end)
+		require.Equal(t, tc.basis, basis)
+	}
+
+	_, _, err := DayCount("ACT/ACT", date(2023, time.January, 1), date(2023, time.January, 2))
+	require.Error(t, err)
+	require.False(t, IsSupportedDayCount("ACT/ACT"))
+	require.True(t, IsSupportedDayCount(DayCount30360))
+}
+
+func TestDayCount30360FullMonth(t *testing.T) {
+	// accruing day by day adds up to 30 days for every month
+	for month := time.January; month <= time.December; month++ {
+		var total int64
+		for d := date(2023, month, 1); d.Month() == month; d = d.AddDate(0, 0, 1) {
+			days, _, err := DayCount(DayCount30360, d, d.AddDate(0, 0, 1))
+			require.NoError(t, err)
+			total += days
+		}
+		require.Equal(t, int64(30), total, month.String())
+	}
+}
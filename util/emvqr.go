@@ -0,0 +1,282 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Payment QR codes follow the EMVCo merchant-presented mode: a sequence of
+// fields made of a two digit id, a two digit length and the value, closed by
+// a CRC-16 over everything before it. The payee account goes in the merchant
+// account template 26 under this bank's globally unique id.
+const (
+	QRInitiationStatic  = "11"
+	QRInitiationDynamic = "12"
+
+	// QRGloballyUniqueID names this bank in the merchant account template
+	QRGloballyUniqueID = "ID.SIMPLEBANK"
+	// QRCategoryTransfer is the merchant category code of money transfers
+	QRCategoryTransfer = "4829"
+	QRMerchantCity     = "JAKARTA"
+
+	qrPayloadFormat     = "00"
+	qrInitiation        = "01"
+	qrMerchantAccount   = "26"
+	qrCategory          = "52"
+	qrCurrency          = "53"
+	qrAmount            = "54"
+	qrCountry           = "58"
+	qrMerchantName      = "59"
+	qrCity              = "60"
+	qrAdditionalData    = "62"
+	qrCRC               = "63"
+	qrAccountGUID       = "00"
+	qrAccountNumber     = "01"
+	qrReferenceLabel    = "05"
+	qrMaxMerchantName   = 25
+	qrMaxReferenceLabel = 25
+	qrMaxFieldLength    = 99
+)
+
+var (
+	ErrQRFormat   = errors.New("qr payload is malformed")
+	ErrQRCRC      = errors.New("qr payload crc doesn't match")
+	ErrQRAccount  = errors.New("qr payload doesn't name an account of this bank")
+	ErrQRCurrency = errors.New("qr payload currency is not supported")
+)
+
+// qrCurrencies maps the supported currencies to their ISO 4217 numeric code
+var qrCurrencies = map[string]string{
+	"EUR": "978",
+	"IDR": "360",
+	"USD": "840",
+}
+
+// QRPayment is what a payment QR code asks for. Static codes leave the amount
+// to the payer and may be paid any number of times, dynamic codes carry it.
+type QRPayment struct {
+	Dynamic       bool   `json:"dynamic"`
+	AccountNumber string `json:"account_number"`
+	Currency      string `json:"currency"`
+	// in minor units, zero for static codes
+	Amount       int64  `json:"amount"`
+	MerchantName string `json:"merchant_name"`
+	Reference    string `json:"reference,omitempty"`
+}
+
+// EncodeQRPayment returns the EMVCo payload of p
+func EncodeQRPayment(p QRPayment) (string, error) {
+	currency, ok := qrCurrencies[p.Currency]
+	if !ok {
+		return "", ErrQRCurrency
+	}
+	if p.Dynamic != (p.Amount > 0) {
+		return "", fmt.Errorf("%w: only dynamic codes carry an amount", ErrQRFormat)
+	}
+
+	initiation := QRInitiationStatic
+	if p.Dynamic {
+		initiation = QRInitiationDynamic
+	}
+
+	// the first field too long to encode, if any
+	var err error
+	write := func(b *strings.Builder, id, value string) {
+		if err == nil {
+			err = writeTLV(b, id, value)
+		}
+	}
+
+	var b strings.Builder
+	write(&b, qrPayloadFormat, "01")
+	write(&b, qrInitiation, initiation)
+
+	var account strings.Builder
+	write(&account, qrAccountGUID, QRGloballyUniqueID)
+	write(&account, qrAccountNumber, p.AccountNumber)
+	write(&b, qrMerchantAccount, account.String())
+
+	write(&b, qrCategory, QRCategoryTransfer)
+	write(&b, qrCurrency, currency)
+	if p.Dynamic {
+		write(&b, qrAmount, formatQRAmount(p.Amount))
+	}
+	write(&b, qrCountry, AccountNumberCountry)
+	write(&b, qrMerchantName, truncate(p.MerchantName, qrMaxMerchantName))
+	write(&b, qrCity, QRMerchantCity)
+	if p.Reference != "" {
+		var data strings.Builder
+		write(&data, qrReferenceLabel, truncate(p.Reference, qrMaxReferenceLabel))
+		write(&b, qrAdditionalData, data.String())
+	}
+	if err != nil {
+		return "", err
+	}
+
+	// the crc covers its own id and length
+	b.WriteString(qrCRC + "04")
+	b.WriteString(fmt.Sprintf("%04X", crc16CCITT(b.String())))
+	return b.String(), nil
+}
+
+// DecodeQRPayment parses a scanned EMVCo payload, checking its CRC and that
+// it asks for a payment to an account of this bank
+func DecodeQRPayment(payload string) (QRPayment, error) {
+	var p QRPayment
+
+	payload = strings.TrimSpace(payload)
+	if len(payload) < 8 || payload[len(payload)-8:len(payload)-4] != qrCRC+"04" {
+		return p, ErrQRFormat
+	}
+	crc, err := strconv.ParseUint(payload[len(payload)-4:], 16, 16)
+	if err != nil {
+		return p, ErrQRFormat
+	}
+	if uint16(crc) != crc16CCITT(payload[:len(payload)-4]) {
+		return p, ErrQRCRC
+	}
+
+	fields, err := parseTLV(payload[:len(payload)-8])
+	if err != nil {
+		return p, err
+	}
+	if fields[qrPayloadFormat] != "01" {
+		return p, ErrQRFormat
+	}
+
+	switch fields[qrInitiation] {
+	case QRInitiationStatic:
+	case QRInitiationDynamic:
+		p.Dynamic = true
+	default:
+		return p, ErrQRFormat
+	}
+
+	account, err := parseTLV(fields[qrMerchantAccount])
+	if err != nil {
+		return p, err
+	}
+	if account[qrAccountGUID] != QRGloballyUniqueID {
+		return p, ErrQRAccount
+	}
+	p.AccountNumber = account[qrAccountNumber]
+	if ValidateAccountNumber(p.AccountNumber) != nil {
+		return p, ErrQRAccount
+	}
+
+	p.Currency = qrCurrencyByCode(fields[qrCurrency])
+	if p.Currency == "" {
+		return p, ErrQRCurrency
+	}
+
+	amount, hasAmount := fields[qrAmount]
+	if hasAmount {
+		if p.Amount, err = parseQRAmount(amount); err != nil {
+			return p, err
+		}
+	}
+	if p.Dynamic != hasAmount {
+		return p, fmt.Errorf("%w: only dynamic codes carry an amount", ErrQRFormat)
+	}
+
+	p.MerchantName = fields[qrMerchantName]
+	if data, ok := fields[qrAdditionalData]; ok {
+		additional, err := parseTLV(data)
+		if err != nil {
+			return p, err
+		}
+		p.Reference = additional[qrReferenceLabel]
+	}
+
+	return p, nil
+}
+
+// writeTLV appends a field to b. Its length has two digits, so a value
+// longer than 99 bytes cannot be encoded.
+func writeTLV(b *strings.Builder, id, value string) error {
+	if len(value) > qrMaxFieldLength {
+		return fmt.Errorf("%w: field %s is %d bytes long, at most %d fit", ErrQRFormat, id, len(value), qrMaxFieldLength)
+	}
+	b.WriteString(fmt.Sprintf("%s%02d%s", id, len(value), value))
+	return nil
+}
+
+// parseTLV splits s into its fields by id, later fields win on repeated ids
+func parseTLV(s string) (map[string]string, error) {
+	fields := make(map[string]string)
+	for len(s) > 0 {
+		if len(s) < 4 || !isDigits(s[:4]) {
+			return nil, ErrQRFormat
+		}
+		n, _ := strconv.Atoi(s[2:4])
+		if len(s) < 4+n {
+			return nil, ErrQRFormat
+		}
+		fields[s[:2]] = s[4 : 4+n]
+		s = s[4+n:]
+	}
+	return fields, nil
+}
+
+// formatQRAmount writes minor units as the decimal amount EMVCo expects
+func formatQRAmount(amount int64) string {
+	if amount%100 == 0 {
+		return strconv.FormatInt(amount/100, 10)
+	}
+	return fmt.Sprintf("%d.%02d", amount/100, amount%100)
+}
+
+// parseQRAmount reads a decimal amount of at most two fraction digits into
+// minor units
+func parseQRAmount(s string) (int64, error) {
+	whole, fraction, _ := strings.Cut(s, ".")
+	if !isDigits(whole) || len(fraction) > 2 || (fraction != "" && !isDigits(fraction)) {
+		return 0, ErrQRFormat
+	}
+	fraction += strings.Repeat("0", 2-len(fraction))
+
+	amount, err := strconv.ParseInt(whole+fraction, 10, 64)
+	if err != nil || amount <= 0 {
+		return 0, ErrQRFormat
+	}
+	return amount, nil
+}
+
+func qrCurrencyByCode(code string) string {
+	for currency, numeric := range qrCurrencies {
+		if numeric == code {
+			return currency
+		}
+	}
+	return ""
+}
+
+// truncate keeps the first n characters of s, never splitting one
+func truncate(s string, n int) string {
+	for i := range s {
+		if n == 0 {
+			return s[:i]
+		}
+		n--
+	}
+	return s
+}
+
+// crc16CCITT is the CRC-16/CCITT-FALSE checksum EMVCo uses: polynomial
+// 0x1021, initial value 0xFFFF
+func crc16CCITT(s string) uint16 {
+	crc := uint16(0xFFFF)
+	for i := 0; i < len(s); i++ {
+		crc ^= uint16(s[i]) << 8
+		for bit := 0; bit < 8; bit++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
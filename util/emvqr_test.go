@@ -0,0 +1,131 @@
+package util
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCRC16CCITT(t *testing.T) {
+	// the check value of CRC-16/CCITT-FALSE
+	require.Equal(t, uint16(0x29B1), crc16CCITT("123456789"))
+}
+
+func TestQRPayment(t *testing.T) {
+	number, err := NewAccountNumber("0123456789")
+	require.NoError(t, err)
+
+	static := QRPayment{
+		AccountNumber: number,
+		Currency:      "IDR",
+		MerchantName:  "Jane Doe",
+	}
+	payload, err := EncodeQRPayment(static)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(payload, "000201"+"010211"))
+	require.Contains(t, payload, "5303360")
+	require.NotContains(t, payload, "54")
+
+	decoded, err := DecodeQRPayment(payload)
+	require.NoError(t, err)
+	require.Equal(t, static, decoded)
+
+	dynamic := QRPayment{
+		Dynamic:       true,
+		AccountNumber: number,
+		Currency:      "USD",
+		Amount:        12345,
+		MerchantName:  "A merchant name longer than the field allows",
+		Reference:     "INV-42",
+	}
+	payload, err = EncodeQRPayment(dynamic)
+	require.NoError(t, err)
+	require.Contains(t, payload, "010212")
+	require.Contains(t, payload, "5406123.45")
+
+	decoded, err = DecodeQRPayment(payload)
+	require.NoError(t, err)
+	require.True(t, decoded.Dynamic)
+	require.Equal(t, int64(12345), decoded.Amount)
+	require.Equal(t, "USD", decoded.Currency)
+	require.Equal(t, "INV-42", decoded.Reference)
+	require.Len(t, decoded.MerchantName, qrMaxMerchantName)
+
+	// any change to the payload breaks the crc
+	tampered := strings.Replace(payload, "123.45", "923.45", 1)
+	_, err = DecodeQRPayment(tampered)
+	require.ErrorIs(t, err, ErrQRCRC)
+
+	_, err = DecodeQRPayment(payload[:len(payload)-9])
+	require.ErrorIs(t, err, ErrQRFormat)
+
+	_, err = EncodeQRPayment(QRPayment{AccountNumber: number, Currency: "GBP"})
+	require.ErrorIs(t, err, ErrQRCurrency)
+	_, err = EncodeQRPayment(QRPayment{AccountNumber: number, Currency: "IDR", Amount: 100})
+	require.ErrorIs(t, err, ErrQRFormat)
+}
+
+func TestQRPaymentMultiByteName(t *testing.T) {
+	number, err := NewAccountNumber("0123456789")
+	require.NoError(t, err)
+
+	// 30 characters, the 25th is 2 bytes long
+	name := strings.Repeat("a", 24) + "é" + strings.Repeat("b", 5)
+	payload, err := EncodeQRPayment(QRPayment{
+		AccountNumber: number,
+		Currency:      "EUR",
+		MerchantName:  name,
+	})
+	require.NoError(t, err)
+	require.True(t, utf8.ValidString(payload))
+
+	decoded, err := DecodeQRPayment(payload)
+	require.NoError(t, err)
+	require.Equal(t, strings.Repeat("a", 24)+"é", decoded.MerchantName)
+
+	// 25 characters of 4 bytes don't fit the two digit length
+	_, err = EncodeQRPayment(QRPayment{
+		AccountNumber: number,
+		Currency:      "EUR",
+		MerchantName:  strings.Repeat("😀", 25),
+	})
+	require.ErrorIs(t, err, ErrQRFormat)
+}
+
+func TestTruncate(t *testing.T) {
+	require.Equal(t, "abc", truncate("abc", 5))
+	require.Equal(t, "ab", truncate("abc", 2))
+	require.Equal(t, "añ", truncate("añb", 2))
+	require.Equal(t, "", truncate("ñ", 0))
+}
+
+func TestDecodeQRPaymentOtherBank(t *testing.T) {
+	var b strings.Builder
+	writeTLV(&b, qrPayloadFormat, "01")
+	writeTLV(&b, qrInitiation, QRInitiationStatic)
+	writeTLV(&b, qrMerchantAccount, "0014ID.CO.QRIS.WWW0118ID92SMPL0123456789")
+	writeTLV(&b, qrCurrency, "360")
+	b.WriteString("6304")
+	b.WriteString(fmt.Sprintf("%04X", crc16CCITT(b.String())))
+
+	_, err := DecodeQRPayment(b.String())
+	require.ErrorIs(t, err, ErrQRAccount)
+}
+
+func TestQRAmount(t *testing.T) {
+	require.Equal(t, "10", formatQRAmount(1000))
+	require.Equal(t, "0.05", formatQRAmount(5))
+
+	for s, amount := range map[string]int64{"10": 1000, "10.5": 1050, "0.05": 5} {
+		got, err := parseQRAmount(s)
+		require.NoError(t, err)
+		require.Equal(t, amount, got)
+	}
+	for _, s := range []string{"", "0", "1.234", "1,50", "-1", ".5"} {
+		_, err := parseQRAmount(s)
+		require.ErrorIs(t, err, ErrQRFormat)
+	}
+}
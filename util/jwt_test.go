@@ -5,21 +5,23 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 )
 
 func TestJWTOK(t *testing.T) {
 	username := "Fulan"
+	userID := uuid.New()
 	duration, err := time.ParseDuration("15m")
 	require.NoError(t, err)
 
-	payload, err := NewPayload(username, duration)
+	payload, err := NewPayload(userID, username, RoleCustomer, duration)
 	require.NoError(t, err)
 
 	j, err := NewJWTMaker("12345678901234567890123456789012")
 	require.NoError(t, err)
 
-	token, p, err := j.CreateToken(username, duration)
+	token, p, err := j.CreateToken(userID, username, RoleCustomer, duration)
 	require.NoError(t, err)
 	require.NotEmpty(t, token)
 	require.Equal(t, p.Username, payload.Username)
@@ -30,22 +32,25 @@ func TestJWTOK(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, incomingPayload.Username, p.Username)
 	require.Equal(t, incomingPayload.ID, p.ID)
+	require.Equal(t, userID, incomingPayload.UserID)
+	require.Equal(t, RoleCustomer, incomingPayload.Role)
 	require.WithinDuration(t, incomingPayload.IssuedAt, p.IssuedAt, time.Second)
 	require.WithinDuration(t, incomingPayload.ExpiredAt, p.ExpiredAt, time.Second)
 }
 
 func TestJWTClaimFail(t *testing.T) {
 	username := "Fulan"
+	userID := uuid.New()
 	duration, err := time.ParseDuration("15m")
 	require.NoError(t, err)
 
-	payload, err := NewPayload(username, duration)
+	payload, err := NewPayload(userID, username, RoleCustomer, duration)
 	require.NoError(t, err)
 
 	j, err := NewJWTMaker("12345678901234567890123456789012")
 	require.NoError(t, err)
 
-	token, p, err := j.CreateToken(username, duration)
+	token, p, err := j.CreateToken(userID, username, RoleCustomer, duration)
 	require.NoError(t, err)
 	require.NotEmpty(t, token)
 	require.Equal(t, p.Username, payload.Username)
@@ -61,10 +66,11 @@ func TestJWTClaimFail(t *testing.T) {
 
 func TestSecretKeyLengthError(t *testing.T) {
 	username := "Fulan"
+	userID := uuid.New()
 	duration, err := time.ParseDuration("15m")
 	require.NoError(t, err)
 
-	payload, err := NewPayload(username, duration)
+	payload, err := NewPayload(userID, username, RoleCustomer, duration)
 	require.NoError(t, err)
 	require.NotEmpty(t, payload)
 
@@ -19,6 +19,15 @@ type Argon2Param struct {
 	KeyLength   uint32
 }
 
+// DefaultArgon2Param is what passwords are hashed with
+var DefaultArgon2Param = Argon2Param{
+	Memory:      64 * 1024,
+	Iterations:  3,
+	Parallelism: 2,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
 var (
 	ErrInvalidHash         = errors.New("the encoded hash is not in the correct format")
 	ErrIncompatibleVersion = errors.New("incompatible version of argon2")
@@ -0,0 +1,22 @@
+package util
+
+const (
+	RoleCustomer = "customer"
+	RoleTeller   = "teller"
+	RoleAdmin    = "admin"
+	RoleAuditor  = "auditor"
+)
+
+// IsSupportedRole reports whether role is one of the roles a user can hold
+func IsSupportedRole(role string) bool {
+	switch role {
+	case RoleCustomer, RoleTeller, RoleAdmin, RoleAuditor:
+		return true
+	}
+	return false
+}
+
+// IsStaffRole reports whether role can see accounts of other users
+func IsStaffRole(role string) bool {
+	return role == RoleTeller || role == RoleAdmin || role == RoleAuditor
+}
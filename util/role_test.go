@@ -0,0 +1,22 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsSupportedRole(t *testing.T) {
+	for _, role := range []string{RoleCustomer, RoleTeller, RoleAdmin, RoleAuditor} {
+		require.True(t, IsSupportedRole(role))
+	}
+	require.False(t, IsSupportedRole("root"))
+	require.False(t, IsSupportedRole(""))
+}
+
+func TestIsStaffRole(t *testing.T) {
+	require.False(t, IsStaffRole(RoleCustomer))
+	require.True(t, IsStaffRole(RoleTeller))
+	require.True(t, IsStaffRole(RoleAdmin))
+	require.True(t, IsStaffRole(RoleAuditor))
+}
@@ -0,0 +1,55 @@
+package util
+
+import (
+	"errors"
+	"math/big"
+	"sort"
+)
+
+var (
+	ErrSplitShares = errors.New("shares must be positive and there must be at least one")
+	ErrSplitTotal  = errors.New("the total to split must not be negative")
+)
+
+// SplitAmount divides total minor units in proportion to shares. Each part
+// is rounded down and the units left over go one each to the parts with the
+// largest remainders, earlier parts first on ties, so the parts always add up
+// to total.
+func SplitAmount(total int64, shares []int64) ([]int64, error) {
+	if total < 0 {
+		return nil, ErrSplitTotal
+	}
+	if len(shares) == 0 {
+		return nil, ErrSplitShares
+	}
+	sum := new(big.Int)
+	for _, share := range shares {
+		if share <= 0 {
+			return nil, ErrSplitShares
+		}
+		sum.Add(sum, big.NewInt(share))
+	}
+
+	parts := make([]int64, len(shares))
+	remainders := make([]*big.Int, len(shares))
+	left := total
+	for i, share := range shares {
+		quo, rem := new(big.Int).QuoRem(new(big.Int).Mul(big.NewInt(total), big.NewInt(share)), sum, new(big.Int))
+		parts[i] = quo.Int64()
+		remainders[i] = rem
+		left -= parts[i]
+	}
+
+	order := make([]int, len(shares))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return remainders[order[a]].Cmp(remainders[order[b]]) > 0
+	})
+	for _, i := range order[:left] {
+		parts[i]++
+	}
+
+	return parts, nil
+}
@@ -0,0 +1,43 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitAmount(t *testing.T) {
+	parts, err := SplitAmount(100, []int64{1, 1, 1})
+	require.NoError(t, err)
+	require.Equal(t, []int64{34, 33, 33}, parts)
+
+	// 1000 * 1/6 = 166.67, 1000 * 2/6 = 333.33, 1000 * 3/6 = 500
+	parts, err = SplitAmount(1000, []int64{1, 2, 3})
+	require.NoError(t, err)
+	require.Equal(t, []int64{167, 333, 500}, parts)
+
+	parts, err = SplitAmount(2, []int64{1, 1, 1, 1})
+	require.NoError(t, err)
+	require.Equal(t, []int64{1, 1, 0, 0}, parts)
+
+	for n := int64(1); n <= 7; n++ {
+		shares := make([]int64, n)
+		for i := range shares {
+			shares[i] = int64(i) + 1
+		}
+		total := GenRandomMoney()
+		parts, err := SplitAmount(total, shares)
+		require.NoError(t, err)
+
+		var sum int64
+		for _, part := range parts {
+			sum += part
+		}
+		require.Equal(t, total, sum)
+	}
+
+	_, err = SplitAmount(100, nil)
+	require.ErrorIs(t, err, ErrSplitShares)
+	_, err = SplitAmount(100, []int64{1, 0})
+	require.ErrorIs(t, err, ErrSplitShares)
+}